@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var aliasesCmd = &cobra.Command{
+	Use:   "aliases",
+	Short: "Write channel_aliases pinning channels to their current names",
+	Long: `Print a channel_aliases config block mapping each exported channel's ID
+to its current name, read from the archive. With these aliases in your
+config, a channel renamed in Slack keeps writing to the same files.
+
+Channels are selected by your include/exclude patterns. Aliases already in
+your config are kept as they are. Paste the output into your config, or
+write it to a file with -o and reference it from "extends:".
+
+Examples:
+  slack-export aliases
+  slack-export aliases -o ~/.config/slack-export/aliases.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runAliases,
+}
+
+func init() {
+	aliasesCmd.Flags().StringP("output", "o", "", "Write the aliases to this file instead of stdout")
+	rootCmd.AddCommand(aliasesCmd)
+}
+
+func runAliases(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	archiveDir, err := packArchiveDir(cfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	names, err := export.ArchiveChannelNames(ctx, archiveDir)
+	if err != nil {
+		return err
+	}
+	aliases := channelAliases(names, cfg.Include, cfg.Exclude, cfg.ChannelAliasMap())
+	data, err := yaml.Marshal(struct {
+		ChannelAliases map[string]string `yaml:"channel_aliases"`
+	}{aliases})
+	if err != nil {
+		return err
+	}
+
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(outputPath, data, 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote aliases for %d channel(s) to %s\n", len(aliases), outputPath)
+	return nil
+}
+
+// channelAliases maps the channels in names selected by include/exclude
+// to their current names, keeping existing aliases.
+func channelAliases(names map[string]string, include, exclude []string, existing map[string]string) map[string]string {
+	chans := make([]slack.Channel, 0, len(names))
+	for id, name := range names {
+		chans = append(chans, slack.Channel{ID: id, Name: name})
+	}
+	aliases := make(map[string]string)
+	for _, ch := range channels.FilterChannels(chans, include, exclude) {
+		aliases[ch.ID] = ch.Name
+	}
+	for id, name := range existing {
+		aliases[id] = name
+	}
+	return aliases
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChannelAliases_FiltersAndKeepsExisting(t *testing.T) {
+	names := map[string]string{
+		"C1": "eng-backend",
+		"C2": "random",
+		"C3": "eng-platform",
+	}
+	got := channelAliases(names, []string{"eng-*"}, nil, map[string]string{"C3": "eng-infra"})
+	want := map[string]string{"C1": "eng-backend", "C3": "eng-infra"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("channelAliases() = %v, want %v", got, want)
+	}
+}
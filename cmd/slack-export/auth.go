@@ -0,0 +1,243 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Move slackdump credentials between machines or sign in from a browser",
+	Long: `Slackdump encrypts its credentials with the machine ID, so they only decrypt
+on the machine that created them. Docker and CI containers usually have no
+machine ID at all. These commands re-encrypt credentials for another machine.
+
+In a container, set ` + slack.MachineIDEnv + ` to a secret of your choosing;
+it replaces the machine ID for slack-export.
+
+Without slackdump auth, "slack-export auth browser" can sign in with the
+session of a browser where you are already signed in to Slack.
+
+Examples:
+  slack-export auth browser acme.slack.com                   # from Chrome
+  slack-export auth machine-id                               # on the target
+  slack-export auth export --machine-id <id> -o acme.bin     # on the source
+  slack-export auth import acme.bin                          # on the target`,
+}
+
+var authExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Re-encrypt this machine's credentials for another machine",
+	Long: `Write the workspace's credentials (--workspace, or the current workspace)
+encrypted for the machine whose ID is --machine-id. Anyone with the file and
+that ID can use the credentials, so treat the file like a password.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthExport,
+}
+
+var authImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Install credentials exported for this machine",
+	Long: `Check that a file written by "slack-export auth export" decrypts on this
+machine and save it to the slackdump cache. The workspace is named by
+--workspace or the file name, and becomes current when none is selected.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthImport,
+}
+
+var authBrowserCmd = &cobra.Command{
+	Use:   "browser <workspace>",
+	Short: "Sign in with a browser's Slack session cookie",
+	Long: `Read the Slack session cookie ("d") from a local browser's cookie store,
+exchange it for a token, and save both to the slackdump cache as the
+workspace's credentials, as "slackdump auth" would. The workspace is its
+name or URL, e.g. acme.slack.com. macOS only.
+
+--browser picks the store: chrome (default profile), slack (the desktop
+app), or safari. Chrome and the Slack app encrypt cookies with a keychain
+key, so macOS asks to allow access; Safari needs Full Disk Access for your
+terminal.
+
+The session cookie grants full access to your Slack account, so this asks
+for confirmation first. Pass --yes to agree without a prompt.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthBrowser,
+}
+
+var authMachineIDCmd = &cobra.Command{
+	Use:   "machine-id",
+	Short: "Print the machine ID credentials are encrypted with",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthMachineID,
+}
+
+func init() {
+	authExportCmd.Flags().String("machine-id", "", "Machine ID of the target (required)")
+	authExportCmd.Flags().StringP("output", "o", "", "Write to this file (default: <workspace>.bin)")
+	_ = authExportCmd.MarkFlagRequired("machine-id")
+	authBrowserCmd.Flags().String("browser", string(slack.BrowserChrome), "Cookie store to read: "+browserNames())
+	authBrowserCmd.Flags().Bool("yes", false, "Agree to reading the browser's session cookie without a prompt")
+	authCmd.AddCommand(authExportCmd, authImportCmd, authBrowserCmd, authMachineIDCmd)
+	rootCmd.AddCommand(authCmd)
+}
+
+func runAuthExport(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	machineID, _ := cmd.Flags().GetString("machine-id")
+	workspace, data, err := slack.ExportCredentials(cfg.Workspace, machineID)
+	if err != nil {
+		return credentialError(err)
+	}
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath == "" {
+		outputPath = workspace + ".bin"
+	}
+	if err := os.WriteFile(outputPath, data, 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote credentials for %s to %s\n", workspace, outputPath)
+	fmt.Printf("On the target machine, run: slack-export auth import %s\n", outputPath)
+	return nil
+}
+
+func runAuthImport(_ *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	workspace := importWorkspaceName(workspaceFlag, args[0])
+	path, err := slack.ImportCredentials(workspace, data)
+	if err != nil {
+		var credErr *slack.CredentialError
+		if errors.As(err, &credErr) && credErr.Code != slack.ErrCodeNoMachineID {
+			return fmt.Errorf("%s does not decrypt on this machine; export it again with "+
+				"--machine-id set to the output of \"slack-export auth machine-id\" here", args[0])
+		}
+		return credentialError(err)
+	}
+	fmt.Printf("Imported credentials for %s to %s\n", workspace, path)
+	return nil
+}
+
+func runAuthBrowser(cmd *cobra.Command, args []string) error {
+	workspace, err := slack.WorkspaceName(args[0])
+	if err != nil {
+		return err
+	}
+	name, _ := cmd.Flags().GetString("browser")
+	browser, err := parseBrowser(name)
+	if err != nil {
+		return err
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		agreed, err := confirmBrowserCookie(browser, workspace)
+		if err != nil {
+			return err
+		}
+		if !agreed {
+			return errors.New("cancelled; nothing was read")
+		}
+	}
+
+	cookie, err := slack.ReadBrowserCookie(browser)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	creds, err := slack.CredentialsFromCookie(ctx, workspace, cookie)
+	if err != nil {
+		return err
+	}
+	path, err := slack.SaveCredentials(workspace, creds)
+	if err != nil {
+		return credentialError(err)
+	}
+	fmt.Printf("Saved credentials for %s from %s to %s\n", workspace, browser, path)
+	if current, err := slack.LoadCredentials(); err == nil && current.Workspace != workspace {
+		fmt.Printf("The current workspace is still %s; pass --workspace %s to use these\n", current.Workspace, workspace)
+	}
+	return nil
+}
+
+// confirmBrowserCookie asks before reading the browser's session cookie.
+// Without a terminal it refuses, since consent must be given with --yes.
+func confirmBrowserCookie(browser slack.Browser, workspace string) (bool, error) {
+	if !interactive() {
+		return false, errors.New("reading browser cookies needs your consent; run in a terminal or pass --yes")
+	}
+	fmt.Printf("slack-export will read your Slack session cookie from %s and save\n", browser)
+	fmt.Printf("credentials for %s to the slackdump cache. Anyone who can read\n", workspace)
+	fmt.Println("them can act as you in Slack.")
+	fmt.Println()
+	var agreed bool
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Read the " + string(browser) + " session cookie?").
+				Affirmative("Yes, read it").
+				Negative("No").
+				Value(&agreed),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return false, fmt.Errorf("prompt failed: %w", err)
+	}
+	return agreed, nil
+}
+
+// parseBrowser checks a --browser value.
+func parseBrowser(name string) (slack.Browser, error) {
+	for _, b := range slack.Browsers {
+		if strings.EqualFold(name, string(b)) {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf("unknown browser %q; use %s", name, browserNames())
+}
+
+// browserNames lists the supported --browser values.
+func browserNames() string {
+	names := make([]string, len(slack.Browsers))
+	for i, b := range slack.Browsers {
+		names[i] = string(b)
+	}
+	return strings.Join(names, ", ")
+}
+
+func runAuthMachineID(_ *cobra.Command, _ []string) error {
+	id, err := slack.GetMachineID()
+	if err != nil {
+		return credentialError(err)
+	}
+	fmt.Println(id)
+	return nil
+}
+
+// importWorkspaceName names an imported workspace after --workspace, or
+// the file name without its .bin extension.
+func importWorkspaceName(flag, path string) string {
+	if flag != "" {
+		return flag
+	}
+	return strings.TrimSuffix(filepath.Base(path), ".bin")
+}
+
+// credentialError replaces credential errors with their guidance message.
+func credentialError(err error) error {
+	if credErr := slack.GetCredentialError(err); credErr != nil {
+		return fmt.Errorf("%s", credErr.UserMessage())
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestImportWorkspaceName(t *testing.T) {
+	if got := importWorkspaceName("", "/tmp/creds/acme.bin"); got != "acme" {
+		t.Errorf("importWorkspaceName() = %q, want acme from the file name", got)
+	}
+	if got := importWorkspaceName("globex", "acme.bin"); got != "globex" {
+		t.Errorf("importWorkspaceName() = %q, want the --workspace flag", got)
+	}
+}
+
+func TestCredentialError_UsesGuidance(t *testing.T) {
+	err := credentialError(&slack.CredentialError{Code: slack.ErrCodeNoMachineID, Message: "failed to get machine ID"})
+	if !strings.Contains(err.Error(), slack.MachineIDEnv) {
+		t.Errorf("credentialError() = %v, want guidance naming %s", err, slack.MachineIDEnv)
+	}
+	plain := errors.New("disk full")
+	if got := credentialError(plain); got != plain {
+		t.Errorf("credentialError() = %v, want other errors unchanged", got)
+	}
+}
+
+func TestParseBrowser(t *testing.T) {
+	if b, err := parseBrowser("Safari"); err != nil || b != slack.BrowserSafari {
+		t.Errorf("parseBrowser(Safari) = %q, %v; want safari", b, err)
+	}
+	if _, err := parseBrowser("firefox"); err == nil || !strings.Contains(err.Error(), "chrome, slack, safari") {
+		t.Errorf("parseBrowser(firefox) error = %v, want the supported browsers listed", err)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chrisedwards/slack-export/internal/browse"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Browse exported markdown in the terminal",
+	Long: `Open an interactive viewer over the rendered output directory.
+
+Pick a day, then a channel, to read its markdown. While reading, "/"
+searches the document and n/N step through matches. "g" jumps to a date
+(or the newest export before it) from any screen.
+
+Without --dir, the configured output_dir is browsed; with output targets,
+the first target's directory is used.`,
+	Args: cobra.NoArgs,
+	RunE: runBrowse,
+}
+
+func init() {
+	browseCmd.Flags().String("dir", "", "Output directory to browse (default: configured output_dir)")
+	rootCmd.AddCommand(browseCmd)
+}
+
+func runBrowse(cmd *cobra.Command, _ []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	cfg, err := loadConfig()
+	if dir == "" {
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		dir = cfg.OutputDirs()[0]
+	}
+	language := ""
+	if err == nil {
+		language = export.NewTranslator(cfg.Translation).Language()
+	}
+	days, err := browse.Scan(dir, language)
+	if err != nil {
+		return fmt.Errorf("reading output directory: %w", err)
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("no exported days found in %s", dir)
+	}
+	_, err = tea.NewProgram(browse.New(days), tea.WithAltScreen()).Run()
+	return err
+}
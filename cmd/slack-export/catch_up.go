@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// catchUpOptions reads the sync flags that govern a gap over max_sync_days.
+func catchUpOptions(cmd *cobra.Command, opts export.SyncOptions) (export.SyncOptions, error) {
+	all, _ := cmd.Flags().GetBool("catch-up")
+	chunks, _ := cmd.Flags().GetBool("catch-up-chunks")
+	limit, _ := cmd.Flags().GetInt("catch-up-limit")
+	if all && chunks {
+		return opts, errors.New("--catch-up and --catch-up-chunks cannot be combined")
+	}
+	if limit < 0 {
+		return opts, errors.New("--catch-up-limit must not be negative")
+	}
+	opts.MaxSyncDays = limit
+	switch {
+	case all:
+		opts.CatchUp = export.CatchUpAll
+	case chunks:
+		opts.CatchUp = export.CatchUpChunks
+	}
+	return opts, nil
+}
+
+// syncWithCatchUp runs a sync and, when it stops at max_sync_days on an
+// interactive terminal, asks how to catch up and runs it again.
+func syncWithCatchUp(ctx context.Context, exporter *export.Exporter, now time.Time, opts export.SyncOptions) error {
+	err := exporter.Sync(ctx, now, opts)
+	var gap *export.CatchUpError
+	if !errors.As(err, &gap) || !interactive() {
+		return err
+	}
+	mode, promptErr := promptCatchUp(gap)
+	if promptErr != nil {
+		return promptErr
+	}
+	if mode == "" {
+		return err
+	}
+	opts.CatchUp = mode
+	return exporter.Sync(ctx, now, opts)
+}
+
+func promptCatchUp(gap *export.CatchUpError) (string, error) {
+	var mode string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(fmt.Sprintf("The archive is %d days behind (max_sync_days is %d)", gap.Days, gap.Limit)).
+				Options(
+					huh.NewOption(fmt.Sprintf("Catch up in %d-day chunks", gap.Limit), export.CatchUpChunks),
+					huh.NewOption("Fetch everything now", export.CatchUpAll),
+					huh.NewOption("Cancel", ""),
+				).
+				Value(&mode),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+	return mode, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+func newCatchUpCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("catch-up", false, "")
+	cmd.Flags().Bool("catch-up-chunks", false, "")
+	cmd.Flags().Int("catch-up-limit", 0, "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestCatchUpOptions(t *testing.T) {
+	opts, err := catchUpOptions(newCatchUpCmd(t, "--catch-up-chunks", "--catch-up-limit", "14"), export.SyncOptions{})
+	if err != nil {
+		t.Fatalf("catchUpOptions() error = %v", err)
+	}
+	if opts.CatchUp != export.CatchUpChunks || opts.MaxSyncDays != 14 {
+		t.Errorf("catchUpOptions() = %+v", opts)
+	}
+
+	if _, err := catchUpOptions(newCatchUpCmd(t, "--catch-up", "--catch-up-chunks"), export.SyncOptions{}); err == nil {
+		t.Error("catchUpOptions() accepted --catch-up with --catch-up-chunks")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// nonInteractive is the global --non-interactive flag: never prompt, and
+// take each prompt's default instead.
+var nonInteractive bool
+
+// interactive reports whether the command may prompt on the terminal.
+func interactive() bool {
+	return !nonInteractive && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// addChannelErrorFlags registers --skip-failed-channels.
+func addChannelErrorFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("skip-failed-channels", false, "Skip channels that fail to render and queue them for retry-failed instead of aborting")
+}
+
+// handleChannelErrors sets how the run treats a channel that fails to
+// render. With --skip-failed-channels it is skipped and queued for
+// retry-failed without asking; otherwise a terminal asks whether to retry,
+// skip, or abort. Without a terminal, or with --non-interactive, the run
+// aborts as before.
+func handleChannelErrors(cmd *cobra.Command, exporter *export.Exporter) {
+	if skip, _ := cmd.Flags().GetBool("skip-failed-channels"); skip {
+		exporter.SetChannelErrorHandler(skipChannelError)
+		return
+	}
+	if interactive() {
+		exporter.SetChannelErrorHandler(promptChannelError)
+	}
+}
+
+func skipChannelError(export.ChannelFailure) string {
+	return export.ChannelSkip
+}
+
+func promptChannelError(failure export.ChannelFailure) string {
+	action := export.ChannelRetry
+	title := fmt.Sprintf("Rendering %s failed: %v", failure.Name, failure.Err)
+	if failure.Attempt > 1 {
+		title = fmt.Sprintf("Rendering %s failed again (attempt %d): %v", failure.Name, failure.Attempt, failure.Err)
+	}
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(title).
+				Options(
+					huh.NewOption("Retry this channel", export.ChannelRetry),
+					huh.NewOption("Skip it and continue", export.ChannelSkip),
+					huh.NewOption("Abort the run", export.ChannelAbort),
+				).
+				Value(&action),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return export.ChannelAbort
+	}
+	return action
+}
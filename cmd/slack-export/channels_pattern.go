@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// writePatternReport evaluates --test-pattern patterns against every channel,
+// ignoring the configured filters, and shows how each match relates to them.
+func writePatternReport(w io.Writer, patterns []string, chans []slack.Channel, targets []config.OutputTarget) {
+	matched := map[string]bool{}
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			_, _ = fmt.Fprintf(w, "Pattern %q: invalid glob syntax\n", pattern)
+			continue
+		}
+		count := 0
+		for _, ch := range chans {
+			if channels.MatchPattern(pattern, ch.Name) || channels.MatchPattern(pattern, ch.ID) {
+				matched[ch.ID] = true
+				count++
+			}
+		}
+		if count == 0 {
+			_, _ = fmt.Fprintf(w, "Pattern %q: no matches (%s)\n", pattern, noMatchHint(pattern))
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "Pattern %q: %d channels\n", pattern, count)
+	}
+
+	var matches []slack.Channel
+	for _, ch := range chans {
+		if matched[ch.ID] {
+			matches = append(matches, ch)
+		}
+	}
+	if len(matches) == 0 {
+		return
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+
+	_, _ = fmt.Fprintln(w)
+	selected := 0
+	for _, ch := range matches {
+		status, keep := configStatus(ch, targets)
+		if keep {
+			selected++
+		}
+		_, _ = fmt.Fprintf(w, "%-12s  %-30s  %s\n", ch.ID, ch.Name, status)
+	}
+	_, _ = fmt.Fprintf(w, "\n%d matching channels: %d already selected by config, %d not\n",
+		len(matches), selected, len(matches)-selected)
+}
+
+// configStatus describes whether the configured filters select ch, per target
+// when targets are named. keep reports whether any target selects it.
+func configStatus(ch slack.Channel, targets []config.OutputTarget) (string, bool) {
+	var parts []string
+	keep := false
+	for _, target := range targets {
+		selected, reason := channels.NewFilter(target.Include, target.Exclude).Explain(ch)
+		keep = keep || selected
+		status := "not selected by config"
+		if selected {
+			status = "selected by config"
+		}
+		status += " (" + reason + ")"
+		if target.Name != "" {
+			status = target.Name + ": " + status
+		}
+		parts = append(parts, status)
+	}
+	return strings.Join(parts, "; "), keep
+}
+
+// noMatchHint explains the most likely reason a pattern matched nothing.
+func noMatchHint(pattern string) string {
+	switch {
+	case strings.HasPrefix(pattern, "#"):
+		return `channel names are matched without the leading "#"`
+	case strings.HasPrefix(pattern, "@"):
+		return "DMs are matched as dm_<username>"
+	case !strings.ContainsAny(pattern, "*?["):
+		return "no channel has this exact name or ID; add * to match a prefix"
+	default:
+		return "no channel name or ID matches"
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestWritePatternReport(t *testing.T) {
+	chans := []slack.Channel{
+		{ID: "C1", Name: "eng-backend"},
+		{ID: "C2", Name: "eng-random"},
+		{ID: "C3", Name: "eng-new"},
+		{ID: "C4", Name: "marketing"},
+	}
+	targets := []config.OutputTarget{{Include: []string{"eng-b*", "eng-random"}, Exclude: []string{"eng-random"}}}
+
+	var buf bytes.Buffer
+	writePatternReport(&buf, []string{"ENG-*", "#sales", "[eng"}, chans, targets)
+	out := buf.String()
+
+	for _, want := range []string{
+		`Pattern "ENG-*": 3 channels`,
+		`Pattern "#sales": no matches (channel names are matched without the leading "#")`,
+		`Pattern "[eng": invalid glob syntax`,
+		`selected by config (included by "eng-b*")`,
+		`not selected by config (excluded by "eng-random")`,
+		`not selected by config (no include pattern matches)`,
+		"3 matching channels: 1 already selected by config, 2 not",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "marketing") {
+		t.Errorf("report should only list matching channels:\n%s", out)
+	}
+}
+
+func TestConfigStatus_NamedTargets(t *testing.T) {
+	targets := []config.OutputTarget{
+		{Name: "work", Include: []string{"eng-*"}},
+		{Name: "personal", Include: []string{"dm_*"}},
+	}
+	status, keep := configStatus(slack.Channel{ID: "C1", Name: "eng-backend"}, targets)
+	want := `work: selected by config (included by "eng-*"); personal: not selected by config (no include pattern matches)`
+	if status != want || !keep {
+		t.Errorf("configStatus() = (%q, %v), want (%q, true)", status, keep, want)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// channelsTimeFilters reads --since, --stale, and --older-than. It returns
+// the --since lower bound, or for --stale the cutoff before which a channel's
+// last activity counts as stale.
+func channelsTimeFilters(cmd *cobra.Command, cfg *config.Config, now time.Time) (time.Time, time.Time, error) {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	stale, _ := cmd.Flags().GetBool("stale")
+	if stale {
+		if sinceStr != "" {
+			return time.Time{}, time.Time{}, errors.New("--stale cannot be combined with --since")
+		}
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		age, err := export.ParseFriendlyDuration(olderThan)
+		if err != nil || age <= 0 {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --older-than %q: use a duration like 90d", olderThan)
+		}
+		return time.Time{}, now.Add(-age), nil
+	}
+	since, err := parseSinceDate(sinceStr, cfg.Timezone)
+	return since, time.Time{}, err
+}
+
+// parseSinceDate parses a --since date at midnight in timezone. An empty
+// value returns the zero time, meaning no lower bound.
+func parseSinceDate(value, timezone string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone: %w", err)
+	}
+	since, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since date: %w", err)
+	}
+	return since, nil
+}
+
+// staleChannels returns channels whose last activity is before cutoff (or
+// unknown), least recently active first. Archived channels are skipped since
+// they need no action.
+func staleChannels(chans []slack.Channel, cutoff time.Time) []slack.Channel {
+	var stale []slack.Channel
+	for _, ch := range chans {
+		if !ch.IsArchived && ch.LastMessage.Before(cutoff) {
+			stale = append(stale, ch)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		if !stale[i].LastMessage.Equal(stale[j].LastMessage) {
+			return stale[i].LastMessage.Before(stale[j].LastMessage)
+		}
+		return stale[i].Name < stale[j].Name
+	})
+	return stale
+}
+
+func printStaleChannels(chans []slack.Channel, timezone string) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	for _, ch := range chans {
+		last := "never"
+		if !ch.LastMessage.IsZero() {
+			last = ch.LastMessage.In(loc).Format("2006-01-02")
+		}
+		fmt.Printf("%-12s  %-10s  %s\n", ch.ID, last, ch.Name)
+	}
+	fmt.Printf("\n%d stale channels\n", len(chans))
+}
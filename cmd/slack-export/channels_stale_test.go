@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+func newChannelsFlagCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("since", "", "")
+	cmd.Flags().Bool("stale", false, "")
+	cmd.Flags().String("older-than", "90d", "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestChannelsCmd_StaleFlags(t *testing.T) {
+	if channelsCmd.Flags().Lookup("stale") == nil {
+		t.Error("channels command should have --stale flag")
+	}
+	olderThan := channelsCmd.Flags().Lookup("older-than")
+	if olderThan == nil || olderThan.DefValue != "90d" {
+		t.Errorf("channels --older-than default should be 90d, got %+v", olderThan)
+	}
+}
+
+func TestChannelsTimeFilters(t *testing.T) {
+	cfg := &config.Config{Timezone: "UTC"}
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	since, cutoff, err := channelsTimeFilters(newChannelsFlagCmd(t, "--stale", "--older-than", "30d"), cfg, now)
+	if err != nil {
+		t.Fatalf("channelsTimeFilters() error = %v", err)
+	}
+	if !since.IsZero() || !cutoff.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("stale filters = %v, %v; want zero since and cutoff 30 days back", since, cutoff)
+	}
+
+	since, cutoff, err = channelsTimeFilters(newChannelsFlagCmd(t, "--since", "2026-03-01"), cfg, now)
+	if err != nil {
+		t.Fatalf("channelsTimeFilters() error = %v", err)
+	}
+	if !since.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !cutoff.IsZero() {
+		t.Errorf("since filters = %v, %v", since, cutoff)
+	}
+
+	for _, args := range [][]string{
+		{"--stale", "--since", "2026-03-01"},
+		{"--stale", "--older-than", "soon"},
+		{"--stale", "--older-than", "0d"},
+	} {
+		if _, _, err := channelsTimeFilters(newChannelsFlagCmd(t, args...), cfg, now); err == nil {
+			t.Errorf("channelsTimeFilters(%v) should fail", args)
+		}
+	}
+}
+
+func TestStaleChannels(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	chans := []slack.Channel{
+		{ID: "C1", Name: "active", LastMessage: cutoff.Add(time.Hour)},
+		{ID: "C2", Name: "quiet", LastMessage: cutoff.AddDate(0, -2, 0)},
+		{ID: "C3", Name: "never"},
+		{ID: "C4", Name: "older", LastMessage: cutoff.AddDate(-1, 0, 0)},
+		{ID: "C5", Name: "archived", IsArchived: true},
+	}
+
+	var got []string
+	for _, ch := range staleChannels(chans, cutoff) {
+		got = append(got, ch.Name)
+	}
+	if want := []string{"never", "older", "quiet"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("staleChannels() = %v, want %v", got, want)
+	}
+}
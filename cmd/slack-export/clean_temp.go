@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var cleanTempCmd = &cobra.Command{
+	Use:   "clean-temp",
+	Short: "Remove temp directories left behind by crashed runs",
+	Long: `Remove orphaned slack-export-<run ID> directories from temp_dir (or the system
+temp directory when temp_dir is unset).
+
+Each sync gives slackdump its own temp directory and removes it on exit. A run
+that crashes or is killed leaves its directory behind. The directory of a run
+still in progress is kept, as are directories modified more recently than
+--older-than. Other directories are never touched.
+
+Examples:
+  slack-export clean-temp                   # Remove orphans older than 1 hour
+  slack-export clean-temp --dry-run         # List what would be removed
+  slack-export clean-temp --older-than 24h`,
+	Args: cobra.NoArgs,
+	RunE: runCleanTemp,
+}
+
+func init() {
+	cleanTempCmd.Flags().Duration("older-than", time.Hour, "Only remove directories not modified for this long")
+	cleanTempCmd.Flags().Bool("dry-run", false, "List orphaned directories without removing them")
+	rootCmd.AddCommand(cleanTempCmd)
+}
+
+func runCleanTemp(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	base, err := export.TempBaseDir(cfg)
+	if err != nil {
+		return err
+	}
+
+	live, err := export.LiveRunTempDirs(cfg)
+	if err != nil {
+		return err
+	}
+
+	olderThan, _ := cmd.Flags().GetDuration("older-than")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	dirs, err := export.CleanTempDirs(base, live, olderThan, time.Now(), dryRun)
+	for _, dir := range dirs {
+		if dryRun {
+			fmt.Printf("Would remove %s\n", dir)
+		} else {
+			fmt.Printf("Removed %s\n", dir)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("%d orphaned temp dir(s) in %s\n", len(dirs), base)
+	} else {
+		fmt.Printf("Removed %d orphaned temp dir(s) from %s\n", len(dirs), base)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestCleanTempCmd_Registered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "clean-temp" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("clean-temp command should be registered with root")
+	}
+}
+
+func TestCleanTempCmd_Flags(t *testing.T) {
+	for _, name := range []string{"older-than", "dry-run"} {
+		if cleanTempCmd.Flags().Lookup(name) == nil {
+			t.Errorf("clean-temp command should have --%s flag", name)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// newAuthenticatedClient loads credentials and verifies them with auth.test,
+// which also sets the team ID Edge API calls need. Credential problems exit
+// with the guidance message instead of a wrapped error.
+func newAuthenticatedClient(ctx context.Context, cfg *config.Config) (*slack.EdgeClient, error) {
+	creds, err := slack.LoadWorkspaceCredentials(cfg.Workspace)
+	if err != nil {
+		if credErr := slack.GetCredentialError(err); credErr != nil {
+			fmt.Fprintln(os.Stderr, credErr.UserMessage())
+			os.Exit(1)
+		}
+		return nil, fmt.Errorf("failed to load credentials: %w", err)
+	}
+
+	if err := creds.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid credentials: %w", err)
+	}
+
+	client := slack.NewEdgeClient(creds).WithRequestsPerSecond(cfg.EdgeRPS)
+	if cfg.APIHost != "" {
+		client = client.WithAPIHost(cfg.APIHost)
+	}
+	client = client.WithCredentialRefresher(slack.ReloadWorkspaceCredentials(creds.Workspace)).
+		WithChannelDiscovery(cfg.ChannelDiscovery, func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: Edge API unavailable, finding channels with conversations.list: %v\n", err)
+		}).
+		WithDiscoveryCache(slack.DefaultUserListCacheDir(), func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		})
+	if ttl := cfg.UsersCacheDuration(); ttl > 0 {
+		cache := slack.NewUserListCache(slack.DefaultUserListCacheDir(), ttl)
+		client = client.WithUserListCache(cache, func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		})
+	}
+	if _, err := client.AuthTest(ctx); err != nil {
+		return nil, fmt.Errorf("verifying credentials: %w", err)
+	}
+	return client, nil
+}
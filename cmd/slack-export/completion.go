@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// completionDays is how many recent dates date completion offers.
+const completionDays = 14
+
+func init() {
+	exportCmd.ValidArgsFunction = completeDateArg
+	for _, cmd := range []*cobra.Command{exportCmd, dmCmd} {
+		registerDateFlagCompletion(cmd, "from", "to")
+	}
+	registerDateFlagCompletion(estimateCmd, "from")
+	registerDateFlagCompletion(filesCmd, "since")
+	registerDateFlagCompletion(channelsCmd, "since")
+	_ = filesCmd.RegisterFlagCompletionFunc("channel", completeChannels)
+	dmCmd.ValidArgsFunction = completeDMUsers
+	tailCmd.ValidArgsFunction = completeChannels
+}
+
+func registerDateFlagCompletion(cmd *cobra.Command, flags ...string) {
+	for _, name := range flags {
+		_ = cmd.RegisterFlagCompletionFunc(name, completeDates)
+	}
+}
+
+// completeDateArg completes the optional date argument of export.
+func completeDateArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeDates(cmd, args, toComplete)
+}
+
+func completeDates(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	now := time.Now()
+	if cfg, err := loadConfig(); err == nil {
+		if loc, err := time.LoadLocation(cfg.Timezone); err == nil {
+			now = now.In(loc)
+		}
+	}
+	return recentDates(now, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// recentDates lists the last completionDays dates, newest first, that
+// start with prefix.
+func recentDates(now time.Time, prefix string) []string {
+	var dates []string
+	for i := range completionDays {
+		date := now.AddDate(0, 0, -i).Format("2006-01-02")
+		if strings.HasPrefix(date, prefix) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+func completeChannels(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return matchingNames(cachedChannelNames(), "", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDMUsers completes dm's username from the DMs earlier syncs saw.
+func completeDMUsers(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return matchingNames(cachedChannelNames(), "dm_", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// matchingNames returns the names carrying trimPrefix, with it removed,
+// that start with toComplete.
+func matchingNames(names []string, trimPrefix, toComplete string) []string {
+	var matches []string
+	for _, name := range names {
+		name, ok := strings.CutPrefix(name, trimPrefix)
+		if ok && strings.HasPrefix(name, toComplete) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// cachedChannelNames reads the channel list saved by the last sync. It
+// never contacts Slack, so completion stays fast and works offline; any
+// failure just means no suggestions.
+func cachedChannelNames() []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	workspace := cfg.Workspace
+	if workspace == "" {
+		creds, err := slack.LoadWorkspaceCredentials("")
+		if err != nil {
+			return nil
+		}
+		workspace = creds.Workspace
+		if workspace == "" {
+			workspace = creds.TeamID
+		}
+	}
+	names, err := export.CachedChannelNames(cfg, workspace)
+	if err != nil {
+		return nil
+	}
+	return names
+}
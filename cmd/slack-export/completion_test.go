@@ -0,0 +1,35 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecentDates(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	got := recentDates(now, "")
+	if len(got) != completionDays {
+		t.Fatalf("len = %d, want %d", len(got), completionDays)
+	}
+	if got[0] != "2026-03-02" || got[1] != "2026-03-01" {
+		t.Fatalf("dates = %v, want newest first", got[:2])
+	}
+
+	got = recentDates(now, "2026-03")
+	if !slices.Equal(got, []string{"2026-03-02", "2026-03-01"}) {
+		t.Fatalf("prefix dates = %v", got)
+	}
+}
+
+func TestMatchingNames(t *testing.T) {
+	names := []string{"dm_alice", "dm_bob", "engineering", "general"}
+
+	if got := matchingNames(names, "", "en"); !slices.Equal(got, []string{"engineering"}) {
+		t.Fatalf("channels = %v, want [engineering]", got)
+	}
+	if got := matchingNames(names, "dm_", ""); !slices.Equal(got, []string{"alice", "bob"}) {
+		t.Fatalf("dm users = %v, want [alice bob]", got)
+	}
+}
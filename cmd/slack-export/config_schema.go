@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for the config file",
+	Long: `Print a JSON Schema describing slack-export.yaml, for editor validation
+and completion. The schema is generated from the config struct, so it always
+lists every key this version understands.
+
+Examples:
+  slack-export config schema > ~/.config/slack-export/schema.json
+
+Then point the YAML language server at it from the top of the config file:
+  # yaml-language-server: $schema=./schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return writeConfigSchema(os.Stdout)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func writeConfigSchema(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(config.Schema()); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
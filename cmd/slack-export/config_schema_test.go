@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteConfigSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeConfigSchema(&out); err != nil {
+		t.Fatalf("writeConfigSchema() error = %v", err)
+	}
+
+	var schema struct {
+		Type       string                    `json:"type"`
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v\n%s", err, out.String())
+	}
+	if schema.Type != "object" {
+		t.Errorf("type = %q, want object", schema.Type)
+	}
+	if got := schema.Properties["output_dir"]["default"]; got != "./slack-logs" {
+		t.Errorf("output_dir default = %v, want ./slack-logs", got)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before> <after>",
+	Short: "Compare the rendered output of two dates",
+	Long: `Compare channel lists and message counts between two exported outputs and
+print new channels, vanished channels, and per-channel activity changes.
+
+Each argument is a date (YYYY-MM-DD) under output_dir or a path to a date
+directory. Pass a saved copy of a date directory to see what a re-export changed.
+
+Examples:
+  slack-export diff 2026-01-21 2026-01-22
+  slack-export diff /tmp/before/2026-01-21 2026-01-21`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	diff, err := export.DiffOutput(cfg.OutputDir, args[0], args[1], export.NewTranslator(cfg.Translation).Language())
+	if err != nil {
+		return err
+	}
+	export.WriteOutputDiff(os.Stdout, diff)
+	return nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestDiffCmd_Registered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "diff" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("diff command should be registered with root")
+	}
+}
+
+func TestDiffCmd_RequiresTwoArgs(t *testing.T) {
+	if err := diffCmd.Args(diffCmd, []string{"2026-01-21"}); err == nil {
+		t.Error("diff should require two arguments")
+	}
+	if err := diffCmd.Args(diffCmd, []string{"2026-01-21", "2026-01-22"}); err != nil {
+		t.Errorf("diff should accept two arguments: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/tracing"
+	"github.com/spf13/cobra"
+)
+
+var dmCmd = &cobra.Command{
+	Use:   "dm <username>",
+	Short: "Export a single direct message conversation",
+	Long: `Export one direct message conversation by username, without looking up
+its D-channel ID. The username may be a Slack username, display name, or real
+name. The DM is refreshed in the archive (even if it is not in your include
+patterns) and rendered to dm_<username> files.
+
+Examples:
+  slack-export dm alice --from 2026-01-15                  # From date to today
+  slack-export dm @alice --from 2026-01-15 --to 2026-01-20 # Date range`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDM,
+}
+
+func init() {
+	dmCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	dmCmd.Flags().String("to", "", "End date (YYYY-MM-DD), defaults to today")
+	rootCmd.AddCommand(dmCmd)
+}
+
+func runDM(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	if from == "" {
+		return errors.New("--from is required")
+	}
+	if to == "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w", err)
+		}
+		to = time.Now().In(loc).Format("2006-01-02")
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	exporter, err := export.NewExporter(ctx, cfg, tracing.Middleware)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	trackRun(exporter)
+
+	ctx, endTrace := traceCommand(ctx, cfg, "dm")
+
+	err = exporter.ExportDM(ctx, args[0], from, to)
+	endTrace(err)
+	return err
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestDMCmd_Registered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "dm" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("dm command should be registered with root")
+	}
+}
+
+func TestDMCmd_ArgsAndFlags(t *testing.T) {
+	if err := dmCmd.Args(dmCmd, []string{"alice"}); err != nil {
+		t.Errorf("dm command should accept 1 arg: %v", err)
+	}
+	if err := dmCmd.Args(dmCmd, []string{}); err == nil {
+		t.Error("dm command should require a username")
+	}
+	for _, name := range []string{"from", "to"} {
+		if dmCmd.Flags().Lookup(name) == nil {
+			t.Errorf("dm command should have --%s flag", name)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var emojiCmd = &cobra.Command{
+	Use:   "emoji",
+	Short: "Show custom emoji usage from the archive",
+	Long: `Count how often custom emoji were used in archived messages, thread
+replies, and reactions over a date range, most used first.
+
+Messages and reactions come from the local archive, so run sync first.
+The workspace's custom emoji list is fetched from Slack; --all counts
+standard emoji too and skips that call.
+
+Examples:
+  slack-export emoji --since 2026-01-01
+  slack-export emoji --since 2026-01-01 --to 2026-03-31 --channel 'eng-*'
+  slack-export emoji --since 2026-01-01 --all --limit 50`,
+	Args: cobra.NoArgs,
+	RunE: runEmoji,
+}
+
+func init() {
+	emojiCmd.Flags().String("since", "", "Start date (YYYY-MM-DD)")
+	emojiCmd.Flags().String("to", "", "End date (YYYY-MM-DD), defaults to today")
+	emojiCmd.Flags().StringArray("channel", nil, "Channel name, ID, or glob pattern (repeatable)")
+	emojiCmd.Flags().Bool("all", false, "Count standard emoji as well as custom ones")
+	emojiCmd.Flags().Int("limit", 25, "Number of emoji to show (0 for all)")
+	rootCmd.AddCommand(emojiCmd)
+}
+
+func runEmoji(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	since, _ := cmd.Flags().GetString("since")
+	to, _ := cmd.Flags().GetString("to")
+	if since == "" {
+		return errors.New("--since is required")
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	if to == "" {
+		to = time.Now().In(loc).Format("2006-01-02")
+	}
+	for _, date := range []string{since, to} {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("invalid date %q: use YYYY-MM-DD", date)
+		}
+	}
+	patterns, _ := cmd.Flags().GetStringArray("channel")
+	all, _ := cmd.Flags().GetBool("all")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	creds, err := slack.LoadWorkspaceCredentials(cfg.Workspace)
+	if err != nil {
+		return fmt.Errorf("loading credentials: %w", err)
+	}
+	workspace := creds.Workspace
+	if workspace == "" {
+		workspace = creds.TeamID
+	}
+	archiveDir, err := export.WorkspaceArchiveDir(cfg, workspace)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	opts := export.EmojiUsageOptions{From: since, To: to, Timezone: cfg.Timezone, Channels: patterns}
+	if !all {
+		client, err := newAuthenticatedClient(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if opts.Custom, err = client.ListCustomEmoji(ctx); err != nil {
+			return fmt.Errorf("listing custom emoji: %w", err)
+		}
+	}
+
+	usage, err := export.CountArchiveEmoji(ctx, archiveDir, opts)
+	if err != nil {
+		return err
+	}
+	printEmojiUsage(os.Stdout, usage, limit)
+	return nil
+}
+
+func printEmojiUsage(w io.Writer, usage []export.EmojiUsage, limit int) {
+	total := len(usage)
+	if limit > 0 && len(usage) > limit {
+		usage = usage[:limit]
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "EMOJI\tTOTAL\tMESSAGES\tREACTIONS")
+	for _, u := range usage {
+		_, _ = fmt.Fprintf(tw, ":%s:\t%d\t%d\t%d\n", u.Name, u.Total(), u.Messages, u.Reactions)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d emoji used\n", total)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestPrintEmojiUsage(t *testing.T) {
+	usage := []export.EmojiUsage{
+		{Name: "partyparrot", Messages: 3, Reactions: 4},
+		{Name: "shipit", Reactions: 2},
+		{Name: "blobwave", Messages: 1},
+	}
+	var buf bytes.Buffer
+	printEmojiUsage(&buf, usage, 2)
+	out := buf.String()
+
+	if !strings.Contains(out, ":partyparrot:  7") || !strings.Contains(out, ":shipit:") {
+		t.Errorf("output missing top emoji:\n%s", out)
+	}
+	if strings.Contains(out, "blobwave") {
+		t.Errorf("output should stop at the limit:\n%s", out)
+	}
+	if !strings.Contains(out, "3 emoji used") {
+		t.Errorf("output should count every emoji used:\n%s", out)
+	}
+}
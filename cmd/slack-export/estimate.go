@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Estimate the cost of backfilling history",
+	Long: `Estimate API calls, message volume, runtime, and disk usage for
+archiving history since a date, without archiving anything.
+
+Channels active since --from are found from Slack's counts. Up to --sample
+of them have their newest history page fetched, and the result is
+extrapolated to every channel your include/exclude patterns select. The
+figures are approximations for planning rate limits and storage.
+
+Example:
+  slack-export estimate --from 2024-01-01`,
+	Args: cobra.NoArgs,
+	RunE: runEstimate,
+}
+
+func init() {
+	estimateCmd.Flags().String("from", "", "Start date of the backfill (YYYY-MM-DD)")
+	estimateCmd.Flags().Int("sample", 25, "Maximum number of channels to sample")
+	_ = estimateCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(estimateCmd)
+}
+
+func runEstimate(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	fromStr, _ := cmd.Flags().GetString("from")
+	from, err := parseSinceDate(fromStr, cfg.Timezone)
+	if err != nil {
+		return err
+	}
+	sampleSize, _ := cmd.Flags().GetInt("sample")
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	client, err := newAuthenticatedClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	chans, err := client.GetActiveChannels(ctx, from)
+	if err != nil {
+		return fmt.Errorf("getting channels: %w", err)
+	}
+	chans = export.FilterForTargets(chans, cfg.OutputTargets())
+
+	samples := sampleHistories(ctx, client, export.SampleChannels(chans, sampleSize), from)
+	printEstimate(os.Stdout, fromStr, export.EstimateBackfill(samples, len(chans), from, time.Now()))
+	return nil
+}
+
+// sampleHistories fetches each channel's newest history page. Channels that
+// cannot be read are skipped with a warning so one restricted channel does
+// not block the estimate.
+func sampleHistories(
+	ctx context.Context,
+	client *slack.EdgeClient,
+	chans []slack.Channel,
+	from time.Time,
+) []export.ChannelSample {
+	var samples []export.ChannelSample
+	for _, ch := range chans {
+		sample, err := client.SampleHistory(ctx, ch.ID, from, export.HistoryPageSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", ch.Name, err)
+			continue
+		}
+		samples = append(samples, export.ChannelSample{Channel: ch, Sample: sample})
+	}
+	return samples
+}
+
+func printEstimate(w io.Writer, from string, est export.BackfillEstimate) {
+	_, _ = fmt.Fprintf(w, "Backfill estimate since %s (sampled %d of %d channels)\n", from, est.Sampled, est.Channels)
+	_, _ = fmt.Fprintf(w, "  Messages:  ~%s (+ ~%s thread replies)\n",
+		formatCount(est.Messages), formatCount(est.Replies))
+	_, _ = fmt.Fprintf(w, "  API calls: ~%s\n", formatCount(est.APICalls))
+	_, _ = fmt.Fprintf(w, "  Runtime:   ~%s at Slack's Tier 3 limit (~50 calls/minute)\n",
+		est.Runtime.Round(time.Minute))
+	_, _ = fmt.Fprintf(w, "  Disk:      ~%s archive, ~%s markdown\n",
+		export.FormatBytes(est.ArchiveBytes), export.FormatBytes(est.MarkdownBytes))
+}
+
+// formatCount renders n with thousands separators.
+func formatCount(n int64) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int64]string{0: "0", 999: "999", 1000: "1,000", 1234567: "1,234,567"}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestPrintEstimate(t *testing.T) {
+	var out bytes.Buffer
+	printEstimate(&out, "2024-01-01", export.BackfillEstimate{
+		Channels:      40,
+		Sampled:       25,
+		Messages:      182000,
+		Replies:       41000,
+		APICalls:      6100,
+		Runtime:       122 * time.Minute,
+		ArchiveBytes:  3 << 30,
+		MarkdownBytes: 45 << 20,
+	})
+
+	for _, want := range []string{
+		"since 2024-01-01 (sampled 25 of 40 channels)",
+		"~182,000 (+ ~41,000 thread replies)",
+		"API calls: ~6,100",
+		"~2h2m0s",
+		"~3.0GB archive, ~45.0MB markdown",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+// Process exit codes, so scripts and schedulers can tell failure kinds apart.
+const (
+	exitFailure        = 1
+	exitAuthExpired    = 3
+	exitRateLimited    = 4
+	exitSlackdumpError = 5
+	exitChannelSkipped = 6
+	exitTimedOut       = 7
+)
+
+// exitStatus maps an error to its exit code and an optional hint printed
+// after the error.
+func exitStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, errTimedOut):
+		return exitTimedOut, "The --timeout deadline passed. Raise --timeout or narrow the run."
+	case errors.Is(err, export.ErrAuthExpired):
+		return exitAuthExpired, "Slack rejected your credentials. Re-authenticate with: slackdump workspace wiz"
+	case errors.Is(err, export.ErrRateLimited):
+		return exitRateLimited, "Slack is rate limiting requests. Try again later or lower edge_rps."
+	case errors.Is(err, export.ErrSlackdumpFailed):
+		return exitSlackdumpError, "slackdump failed; see its output above."
+	case errors.Is(err, export.ErrChannelSkipped):
+		return exitChannelSkipped, ""
+	default:
+		return exitFailure, ""
+	}
+}
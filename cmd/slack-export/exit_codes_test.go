@@ -0,0 +1,28 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestExitStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("sync: %w", export.ErrAuthExpired), exitAuthExpired},
+		{fmt.Errorf("sync: %w", export.ErrRateLimited), exitRateLimited},
+		{fmt.Errorf("bootstrapping archive: %w", export.ErrSlackdumpFailed), exitSlackdumpError},
+		{export.ErrChannelSkipped, exitChannelSkipped},
+		{fmt.Errorf("%w after 30m0s: %w", errTimedOut, export.ErrSlackdumpFailed), exitTimedOut},
+		{errors.New("boom"), exitFailure},
+	}
+	for _, tt := range tests {
+		if got, _ := exitStatus(tt.err); got != tt.want {
+			t.Errorf("exitStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "List files shared in Slack channels",
+	Long: `List files shared in channels, independent of the daily export.
+
+Each file shows its channel, name, size, type, uploader, and permalink.
+Without --channel, the channels selected by your include/exclude patterns
+(or output targets) are searched. --download saves each file to
+<dir>/<channel>/<file-id>-<name>, skipping files already downloaded. With
+attachment_processing configured, audio clips and images are also run
+through its commands and their text is saved beside each file as
+<file>.txt.
+
+Examples:
+  slack-export files --since 2026-01-01 --channel 'eng-*'
+  slack-export files --channel general --download ./files`,
+	Args: cobra.NoArgs,
+	RunE: runFiles,
+}
+
+// channelFile is a file together with the channel it was found in.
+type channelFile struct {
+	channel string
+	file    slack.File
+}
+
+func init() {
+	filesCmd.Flags().String("since", "", "Only list files shared since this date (YYYY-MM-DD)")
+	filesCmd.Flags().StringArray("channel", nil, "Channel name or glob pattern (repeatable)")
+	filesCmd.Flags().String("download", "", "Download the listed files into this directory")
+	rootCmd.AddCommand(filesCmd)
+}
+
+func runFiles(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	sinceStr, _ := cmd.Flags().GetString("since")
+	since, err := parseSinceDate(sinceStr, cfg.Timezone)
+	if err != nil {
+		return err
+	}
+	patterns, _ := cmd.Flags().GetStringArray("channel")
+	downloadDir, _ := cmd.Flags().GetString("download")
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	client, err := newAuthenticatedClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	userIndex, err := client.FetchUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching users: %w", err)
+	}
+	resolver := slack.NewUserResolver(userIndex, nil, nil).WithNameStyle(export.ConfiguredNameStyle(cfg))
+	chans, err := client.GetActiveChannelsWithResolver(ctx, since, resolver)
+	if err != nil {
+		return fmt.Errorf("getting channels: %w", err)
+	}
+
+	files, err := collectFiles(ctx, client, selectFileChannels(chans, patterns, cfg), since)
+	if err != nil {
+		return err
+	}
+	printFiles(os.Stdout, files, userIndex)
+	if downloadDir == "" {
+		return nil
+	}
+	return downloadFiles(ctx, client, files, downloadDir, cfg.AttachmentProcessing)
+}
+
+// selectFileChannels applies --channel patterns, falling back to the
+// configured include/exclude patterns and output targets.
+func selectFileChannels(chans []slack.Channel, patterns []string, cfg *config.Config) []slack.Channel {
+	if len(patterns) > 0 {
+		return channels.FilterChannels(chans, patterns, nil)
+	}
+	return export.FilterForTargets(chans, cfg.OutputTargets())
+}
+
+// collectFiles lists each channel's files, newest first. A file shared in
+// several channels is listed once, under the first channel it was found in.
+func collectFiles(
+	ctx context.Context,
+	client *slack.EdgeClient,
+	chans []slack.Channel,
+	since time.Time,
+) ([]channelFile, error) {
+	sort.Slice(chans, func(i, j int) bool { return chans[i].Name < chans[j].Name })
+	seen := make(map[string]bool)
+	var files []channelFile
+	for _, ch := range chans {
+		listed, err := client.ListFiles(ctx, ch.ID, since)
+		if err != nil {
+			return nil, fmt.Errorf("listing files in %s: %w", ch.Name, err)
+		}
+		for _, file := range listed {
+			if !seen[file.ID] {
+				seen[file.ID] = true
+				files = append(files, channelFile{channel: ch.Name, file: file})
+			}
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].file.Created > files[j].file.Created })
+	return files, nil
+}
+
+func printFiles(w io.Writer, files []channelFile, users slack.UserIndex) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHANNEL\tNAME\tSIZE\tTYPE\tUPLOADER\tPERMALINK")
+	for _, cf := range files {
+		fileType := cf.file.PrettyType
+		if fileType == "" {
+			fileType = cf.file.Filetype
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", cf.channel, cf.file.Name, export.FormatBytes(cf.file.Size),
+			fileType, users.Username(cf.file.User), cf.file.Permalink)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d files\n", len(files))
+}
+
+func downloadFiles(
+	ctx context.Context,
+	client *slack.EdgeClient,
+	files []channelFile,
+	dir string,
+	processing config.AttachmentProcessing,
+) error {
+	downloaded, processed := 0, 0
+	for _, cf := range files {
+		path := downloadPath(dir, cf)
+		if _, err := os.Stat(path); err != nil {
+			if err := downloadFile(ctx, client, cf.file, path); err != nil {
+				return fmt.Errorf("downloading %s: %w", cf.file.Name, err)
+			}
+			downloaded++
+		}
+		if processAttachment(ctx, processing, cf, path) {
+			processed++
+		}
+	}
+	fmt.Printf("Downloaded %d file(s) to %s\n", downloaded, dir)
+	if processing.Enabled() {
+		fmt.Printf("Processed %d audio or image file(s) into text\n", processed)
+	}
+	return nil
+}
+
+// processAttachment writes the text of a downloaded audio clip or image,
+// including files downloaded before processing was configured. A failure
+// is a warning so one bad file does not stop the download.
+func processAttachment(ctx context.Context, processing config.AttachmentProcessing, cf channelFile, path string) bool {
+	done, err := export.ProcessAttachment(ctx, processing, cf.file, path, cf.channel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: processing %s: %v\n", cf.file.Name, err)
+	}
+	return done
+}
+
+// downloadPath places a file under its channel, prefixed with the file ID so
+// files sharing a name do not overwrite each other.
+func downloadPath(dir string, cf channelFile) string {
+	name := strings.NewReplacer("/", "_", `\`, "_").Replace(cf.file.Name)
+	if name == "" || name == "." || name == ".." {
+		name = "file"
+	}
+	channel := strings.NewReplacer("/", "_", `\`, "_").Replace(cf.channel)
+	return filepath.Join(dir, channel, cf.file.ID+"-"+name)
+}
+
+// downloadFile writes to a temporary file first so an interrupted download
+// is retried on the next run instead of being skipped as complete.
+func downloadFile(ctx context.Context, client *slack.EdgeClient, file slack.File, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := client.DownloadFile(ctx, file, tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
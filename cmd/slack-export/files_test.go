@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestFilesCmd_Flags(t *testing.T) {
+	for _, name := range []string{"since", "channel", "download"} {
+		if filesCmd.Flags().Lookup(name) == nil {
+			t.Errorf("files command should have --%s flag", name)
+		}
+	}
+}
+
+func TestSelectFileChannels(t *testing.T) {
+	chans := []slack.Channel{{ID: "C1", Name: "eng-api"}, {ID: "C2", Name: "eng-web"}, {ID: "C3", Name: "random"}}
+	cfg := &config.Config{Include: []string{"random"}}
+
+	if got := selectFileChannels(chans, []string{"eng-*"}, cfg); len(got) != 2 {
+		t.Errorf("--channel eng-* selected %d channels, want 2", len(got))
+	}
+	got := selectFileChannels(chans, nil, cfg)
+	if len(got) != 1 || got[0].Name != "random" {
+		t.Errorf("config patterns selected %+v, want random", got)
+	}
+}
+
+func TestPrintFiles(t *testing.T) {
+	users := slack.UserIndex{"U1": {ID: "U1", Name: "alice"}}
+	files := []channelFile{{channel: "eng-api", file: slack.File{
+		ID: "F1", Name: "plan.pdf", Size: 2048, PrettyType: "PDF", User: "U1",
+		Permalink: "https://acme.slack.com/files/U1/F1/plan.pdf",
+	}}}
+
+	var out bytes.Buffer
+	printFiles(&out, files, users)
+
+	wants := []string{"eng-api", "plan.pdf", "2.0KB", "PDF", "alice", "https://acme.slack.com/files/U1/F1/plan.pdf", "1 files"}
+	for _, want := range wants {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDownloadPath(t *testing.T) {
+	got := downloadPath("out", channelFile{channel: "eng-api", file: slack.File{ID: "F1", Name: "../x/plan.pdf"}})
+	if want := filepath.Join("out", "eng-api", "F1-.._x_plan.pdf"); got != want {
+		t.Errorf("downloadPath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// historyRecord is one line of history.jsonl.
+type historyRecord struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args,omitempty"`
+	From       string    `json:"from,omitempty"`
+	To         string    `json:"to,omitempty"`
+	Channels   []string  `json:"channels,omitempty"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// currentRun collects what the running command exported for its history
+// record.
+var currentRun = &historyRecord{}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show past slack-export runs",
+	Long: `Show past runs recorded in history.jsonl under
+$XDG_STATE_HOME/slack-export (default ~/.local/state/slack-export).
+
+Every command except history itself appends one record with its arguments,
+the date range and channels it exported, its outcome, and its duration, so
+you can tell when a gap in the output was introduced.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().Int("limit", 20, "Number of most recent runs to show (0 for all)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, _ []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	records, err := readHistory(historyPath())
+	if err != nil {
+		return err
+	}
+	if limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	printHistory(os.Stdout, records)
+	return nil
+}
+
+// historyPath follows the XDG state directory convention.
+func historyPath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = os.TempDir()
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "slack-export", "history.jsonl")
+}
+
+// trackRun reports the exporter's progress to the console, records the
+// channels and dates it renders in currentRun, and prints a status report on
+// SIGUSR1. It returns the events it installed.
+func trackRun(exporter *export.Exporter) *export.ProgressTracker {
+	tracker := export.NewProgressTracker(historyEvents{ConsoleEvents: export.NewConsoleEvents(), run: currentRun}, time.Now())
+	exporter.SetEvents(tracker)
+	watchStatusSignal(tracker)
+	return tracker
+}
+
+type historyEvents struct {
+	export.ConsoleEvents
+	run *historyRecord
+}
+
+func (h historyEvents) OnChannelDone(ch export.ChannelProgress) {
+	h.ConsoleEvents.OnChannelDone(ch)
+	if len(ch.Dates) == 0 {
+		return
+	}
+	h.run.addRange(ch.Dates[0], ch.Dates[len(ch.Dates)-1])
+	h.run.Channels = append(h.run.Channels, ch.Name)
+}
+
+// addRange widens the record's date range to cover from through to.
+func (r *historyRecord) addRange(from, to string) {
+	if r.From == "" || from < r.From {
+		r.From = from
+	}
+	if to > r.To {
+		r.To = to
+	}
+}
+
+// recordRun appends the finished command to history.jsonl. Failing to
+// record never changes the command's result.
+func recordRun(cmd *cobra.Command, started time.Time, runErr error) {
+	if cmd == nil || cmd == rootCmd || cmd == historyCmd || !cmd.Runnable() || strings.HasPrefix(cmd.Name(), "__") {
+		return
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return
+	}
+	record := *currentRun
+	record.Time = started
+	record.Command = cmd.Name()
+	record.Args = os.Args[1:]
+	record.DurationMS = time.Since(started).Milliseconds()
+	record.Outcome = "ok"
+	if runErr != nil {
+		record.Outcome = "error"
+		record.Error = runErr.Error()
+	}
+	sort.Strings(record.Channels)
+	record.Channels = slices.Compact(record.Channels)
+	if err := appendHistory(historyPath(), record); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record run history: %v\n", err)
+	}
+}
+
+func appendHistory(path string, record historyRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readHistory loads every record, skipping lines that do not parse.
+func readHistory(path string) ([]historyRecord, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading run history: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []historyRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var record historyRecord
+		if json.Unmarshal(scanner.Bytes(), &record) == nil {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
+
+func printHistory(out io.Writer, records []historyRecord) {
+	if len(records) == 0 {
+		_, _ = fmt.Fprintln(out, "No runs recorded yet")
+		return
+	}
+	for _, r := range records {
+		dates := "-"
+		if r.From != "" {
+			dates = r.From + ".." + r.To
+		}
+		line := fmt.Sprintf("%s  %-8s %-5s %8s  %-22s %3d channel(s)",
+			r.Time.Local().Format("2006-01-02 15:04"), r.Command, r.Outcome,
+			(time.Duration(r.DurationMS) * time.Millisecond).Round(time.Second), dates, len(r.Channels))
+		if r.Error != "" {
+			line += "  " + strings.SplitN(r.Error, "\n", 2)[0]
+		}
+		_, _ = fmt.Fprintln(out, line)
+	}
+}
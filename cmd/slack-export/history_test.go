@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestHistoryPath_UsesXDGStateHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+
+	if got, want := historyPath(), filepath.Join(dir, "slack-export", "history.jsonl"); got != want {
+		t.Errorf("historyPath() = %q, want %q", got, want)
+	}
+}
+
+func TestRecordRun_AppendsRecord(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	saved := currentRun
+	t.Cleanup(func() { currentRun = saved })
+	currentRun = &historyRecord{}
+
+	events := historyEvents{ConsoleEvents: export.ConsoleEvents{Out: &bytes.Buffer{}, Err: &bytes.Buffer{}}, run: currentRun}
+	events.OnChannelDone(export.ChannelProgress{Name: "general", Dates: []string{"2026-03-02", "2026-03-04"}})
+	events.OnChannelDone(export.ChannelProgress{Name: "alerts", Dates: []string{"2026-03-01"}})
+	events.OnChannelDone(export.ChannelProgress{Name: "general", Dates: []string{"2026-03-05"}})
+
+	recordRun(syncCmd, time.Now().Add(-time.Second), errors.New("resuming archive: boom"))
+	recordRun(historyCmd, time.Now(), nil)
+
+	records, err := readHistory(historyPath())
+	if err != nil {
+		t.Fatalf("readHistory() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("readHistory() = %d records, want 1 (history itself not recorded)", len(records))
+	}
+	got := records[0]
+	if got.Command != "sync" || got.Outcome != "error" || got.Error != "resuming archive: boom" {
+		t.Errorf("record = %+v", got)
+	}
+	if got.From != "2026-03-01" || got.To != "2026-03-05" {
+		t.Errorf("record range = %s..%s, want 2026-03-01..2026-03-05", got.From, got.To)
+	}
+	if strings.Join(got.Channels, ",") != "alerts,general" {
+		t.Errorf("record channels = %v", got.Channels)
+	}
+	if got.DurationMS < 1000 {
+		t.Errorf("record duration = %dms, want at least 1s", got.DurationMS)
+	}
+}
+
+func TestReadHistory_SkipsBadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	content := `{"command":"sync","outcome":"ok"}` + "\nnot json\n" + `{"command":"render","outcome":"ok"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := readHistory(path)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("readHistory() = %v, %v; want 2 records", records, err)
+	}
+}
+
+func TestPrintHistory(t *testing.T) {
+	var out bytes.Buffer
+	printHistory(&out, []historyRecord{{
+		Time:       time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local),
+		Command:    "sync",
+		From:       "2026-03-01",
+		To:         "2026-03-05",
+		Channels:   []string{"general"},
+		Outcome:    "error",
+		Error:      "resuming archive: boom\ndetails",
+		DurationMS: 61500,
+	}})
+
+	line := out.String()
+	for _, want := range []string{"2026-03-05 09:00", "sync", "error", "1m2s", "2026-03-01..2026-03-05", "1 channel(s)", "resuming archive: boom"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("printHistory() missing %q: %q", want, line)
+		}
+	}
+	if strings.Contains(line, "details") {
+		t.Errorf("printHistory() should show only the first error line: %q", line)
+	}
+}
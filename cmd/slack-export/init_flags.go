@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// runInitNonInteractive sets up slack-export from init's flags without
+// prompting: it checks slackdump and credentials, writes and validates the
+// config, and verifies the connection as the wizard does. Nothing is
+// installed or authenticated; missing pieces are reported instead.
+func runInitNonInteractive(cmd *cobra.Command) error {
+	fmt.Println("Step 1/4: Checking for slackdump...")
+	if path, err := export.FindSlackdump(); err == nil {
+		fmt.Printf("✓ Found slackdump at %s\n\n", path)
+	} else {
+		fmt.Println("⚠ slackdump not found; sync needs it. Install it with:")
+		fmt.Println("  go install github.com/rusq/slackdump/v4/cmd/slackdump@v4.4.1")
+		fmt.Println()
+	}
+
+	fmt.Println("Step 2/4: Checking Slack authentication...")
+	skipAuth, _ := cmd.Flags().GetBool("skip-auth")
+	workspace := ""
+	if skipAuth {
+		fmt.Println("Skipped (--skip-auth)")
+		fmt.Println()
+	} else {
+		creds, err := slack.LoadWorkspaceCredentials(workspaceFlag)
+		if err == nil {
+			err = creds.Validate()
+		}
+		if err != nil {
+			return fmt.Errorf("%w\n\nPass --skip-auth to write the config without credentials", credentialError(err))
+		}
+		workspace = creds.Workspace
+		fmt.Printf("✓ Authenticated to workspace: %s\n\n", workspace)
+	}
+
+	fmt.Println("Step 3/4: Configuring slack-export...")
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.DefaultConfigPath()
+	}
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		if _, err := os.Stat(configPath); err == nil {
+			return fmt.Errorf("config already exists at %s; pass --force to overwrite it", configPath)
+		}
+	}
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	cfg, err := initConfigFromFlags(outputDir, timezone, include, exclude)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Save(configPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Printf("✓ Config saved to %s\n\n", configPath)
+
+	return initStepVerify(cfg, configPath, skipAuth, workspace)
+}
+
+// initConfigFromFlags builds and validates init's config from flag values,
+// filling in the wizard's defaults for those left empty. Validation creates
+// the output directory.
+func initConfigFromFlags(outputDir, timezone string, include, exclude []string) (*config.Config, error) {
+	if outputDir == "" {
+		outputDir = "./slack-logs"
+	}
+	absOutputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output directory: %w", err)
+	}
+	if timezone == "" {
+		timezone = detectTimezone()
+	}
+	if timezone == "" {
+		timezone = "America/New_York"
+	}
+	cfg := newInitConfig(absOutputDir, timezone)
+	cfg.Include = trimPatterns(include)
+	cfg.Exclude = trimPatterns(exclude)
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// trimPatterns drops blank channel patterns and surrounding spaces.
+func trimPatterns(patterns []string) []string {
+	var trimmed []string
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p != "" {
+			trimmed = append(trimmed, p)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+func TestInitConfigFromFlags(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "logs")
+	cfg, err := initConfigFromFlags(outputDir, "Europe/Berlin", []string{"eng-*", " "}, []string{" eng-random "})
+	if err != nil {
+		t.Fatalf("initConfigFromFlags() error = %v", err)
+	}
+	if cfg.OutputDir != outputDir || cfg.Timezone != "Europe/Berlin" {
+		t.Errorf("cfg = %s in %s, want %s in Europe/Berlin", cfg.OutputDir, cfg.Timezone, outputDir)
+	}
+	if strings.Join(cfg.Include, ",") != "eng-*" || strings.Join(cfg.Exclude, ",") != "eng-random" {
+		t.Errorf("patterns = %v / %v, want trimmed flags without blanks", cfg.Include, cfg.Exclude)
+	}
+	if _, err := os.Stat(outputDir); err != nil {
+		t.Errorf("output directory not created: %v", err)
+	}
+	if cfg.Lookback != "7d" || cfg.ArchiveDir == "" {
+		t.Errorf("cfg = %+v, want the wizard's defaults", cfg)
+	}
+
+	if _, err := initConfigFromFlags(outputDir, "Mars/Olympus", nil, nil); err == nil {
+		t.Error("initConfigFromFlags() should reject an unknown timezone")
+	}
+}
+
+func TestRunInitNonInteractive_WritesConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "slack-export.yaml")
+	oldCfg := cfgFile
+	cfgFile = configPath
+	t.Cleanup(func() { cfgFile = oldCfg })
+	setInitFlags(t, map[string]string{
+		"output-dir": filepath.Join(dir, "logs"),
+		"timezone":   "Asia/Tokyo",
+		"include":    "general,eng-*",
+		"skip-auth":  "true",
+	})
+
+	if err := runInitNonInteractive(initCmd); err != nil {
+		t.Fatalf("runInitNonInteractive() error = %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	if cfg.Timezone != "Asia/Tokyo" || strings.Join(cfg.Include, ",") != "general,eng-*" {
+		t.Errorf("saved config = %s %v, want the flags", cfg.Timezone, cfg.Include)
+	}
+
+	err = runInitNonInteractive(initCmd)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("second run error = %v, want a refusal to overwrite without --force", err)
+	}
+	setInitFlags(t, map[string]string{"force": "true"})
+	if err := runInitNonInteractive(initCmd); err != nil {
+		t.Errorf("runInitNonInteractive() with --force error = %v", err)
+	}
+}
+
+// setInitFlags sets init's flags for one test and restores their defaults.
+func setInitFlags(t *testing.T, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		flag := initCmd.Flags().Lookup(name)
+		if err := flag.Value.Set(value); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if s, ok := flag.Value.(interface{ Replace([]string) error }); ok {
+				_ = s.Replace(nil)
+			} else {
+				_ = flag.Value.Set(flag.DefValue)
+			}
+			flag.Changed = false
+		})
+	}
+}
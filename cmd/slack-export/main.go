@@ -6,18 +6,16 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/charmbracelet/huh"
-	"github.com/chrisedwards/slack-export/internal/channels"
 	"github.com/chrisedwards/slack-export/internal/config"
 	"github.com/chrisedwards/slack-export/internal/export"
 	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/chrisedwards/slack-export/internal/tracing"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -39,7 +37,11 @@ var rootCmd = &cobra.Command{
 	Long: `slack-export is a CLI tool that exports Slack channel logs to dated markdown files.
 
 It uses the Slack Edge API for fast channel detection and slackdump for message export.
-Configuration is via YAML file with glob-based channel include/exclude patterns.`,
+Configuration is via YAML file with glob-based channel include/exclude patterns.
+
+Shell completion (dates and cached channel names) is available via
+"slack-export completion bash|zsh|fish|powershell"; see
+"slack-export completion --help" for install instructions.`,
 	Version: fmt.Sprintf("%s (build %s, %s)", Version, Build, BuildTime),
 }
 
@@ -58,7 +60,11 @@ var exportCmd = &cobra.Command{
 Examples:
   slack-export export 2026-01-22               # Export single date
   slack-export export --from 2026-01-15        # From date to today
-  slack-export export --from 2026-01-15 --to 2026-01-20  # Date range`,
+  slack-export export --from 2026-01-15 --to 2026-01-20  # Date range
+  slack-export export --from 2026-01-15 --changed-only   # Skip channels unchanged since last run
+  slack-export export 2026-01-22 --timezone Asia/Tokyo   # Use another timezone for this run
+  slack-export export --from 2026-03-01 --to 2026-03-31 --channels-from-file audit.txt  # Only listed channels
+  slack-export export 2026-01-22 --channel eng-backend --stdout | less  # One channel-day to stdout, no files`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runExport,
 }
@@ -72,7 +78,8 @@ The command scans the output directory for dated folders (YYYY-MM-DD pattern),
 finds the most recent date, and re-exports from that date through today.
 If no previous exports exist, it starts from today.
 
-The last export date is re-exported because it may have been incomplete.`,
+The last export date is re-exported because it may have been incomplete.
+Use --resume after an interrupted sync to also render what it archived.`,
 	RunE: runSync,
 }
 
@@ -96,7 +103,10 @@ Include and exclude patterns from the configuration are applied to the output.
 
 Examples:
   slack-export channels                      # All channels
-  slack-export channels --since 2026-01-20   # Channels with recent activity`,
+  slack-export channels --since 2026-01-20   # Channels with recent activity
+  slack-export channels --stale              # No activity in the last 90 days
+  slack-export channels --stale --older-than 180d
+  slack-export channels --test-pattern "eng-*" # Preview a pattern against all channels`,
 	RunE: runChannels,
 }
 
@@ -109,33 +119,67 @@ Walks through:
   - Installing slackdump (if needed)
   - Authenticating with Slack (if needed)
   - Creating configuration file
-  - Verifying the setup works`,
+  - Verifying the setup works
+
+With --non-interactive, nothing is prompted: settings come from flags, the
+config is written and validated, and missing slackdump or credentials are
+reported instead of installed. For CI and containers:
+
+  slack-export init --non-interactive --output-dir /data/slack-logs \
+    --timezone Europe/Berlin --include "eng-*" --exclude "eng-random" --skip-auth`,
 	RunE: runInit,
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default: ~/.config/slack-export/slack-export.yaml)")
+	rootCmd.PersistentFlags().StringVar(&workspaceFlag, "workspace", "", "slackdump workspace to use (overrides config workspace)")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "hard deadline for the whole command, e.g. 30m (default: none)")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "never prompt; take the default answer instead")
 	rootCmd.AddCommand(configCmd)
 
 	exportCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
 	exportCmd.Flags().String("to", "", "End date (YYYY-MM-DD), defaults to today")
+	exportCmd.Flags().Bool("changed-only", false, "Only render channels with activity since the last changed-only export")
+	exportCmd.Flags().String("timezone", "", "IANA timezone for this run's day boundaries (overrides config timezone)")
+	exportCmd.Flags().String("channels-from-file", "", "File listing channel names, IDs, or patterns (one per line) that replaces include patterns for this run")
+	exportCmd.Flags().String("channel", "", "Channel name or ID to write with --stdout")
+	exportCmd.Flags().Bool("stdout", false, "Write one channel-day to stdout instead of the output directory (requires a date and --channel)")
+	addChannelScopeFlags(exportCmd)
+	addChannelErrorFlags(exportCmd)
 	rootCmd.AddCommand(exportCmd)
 
 	syncCmd.Flags().Bool("full", false, "Run the bounded full archive sweep")
+	syncCmd.Flags().Int("reconcile", 0, "Re-fetch the last N days and log edits/deletions to changes.log")
+	syncCmd.Flags().Bool("catch-up", false, "Sync past max_sync_days in one run")
+	syncCmd.Flags().Bool("catch-up-chunks", false, "Sync past max_sync_days in resumable chunks of that many days")
+	syncCmd.Flags().Int("catch-up-limit", 0, "Override max_sync_days for this run")
+	syncCmd.Flags().String("timezone", "", "IANA timezone for this run's day boundaries (overrides config timezone)")
+	syncCmd.Flags().Bool("resume", false, "Also render what an interrupted sync archived")
+	addChannelScopeFlags(syncCmd)
+	addChannelErrorFlags(syncCmd)
 	rootCmd.AddCommand(syncCmd)
 
 	renderCmd.Flags().Bool("full", false, "Render every date from seed_date through today")
 	rootCmd.AddCommand(renderCmd)
 
 	channelsCmd.Flags().String("since", "", "Only show channels with activity since this date (YYYY-MM-DD)")
+	channelsCmd.Flags().Bool("stale", false, "Only show channels with no activity within --older-than")
+	channelsCmd.Flags().String("older-than", "90d", "Inactivity threshold for --stale (e.g. 90d, 720h)")
+	channelsCmd.Flags().StringArray("test-pattern", nil, "Show which channels a pattern matches, ignoring config filters (repeatable)")
+	addChannelScopeFlags(channelsCmd)
 	rootCmd.AddCommand(channelsCmd)
 
 	initCmd.Flags().Bool("force", false, "Skip config exists warning, still shows form with current values")
+	initCmd.Flags().String("output-dir", "", "Output directory (with --non-interactive; default ./slack-logs)")
+	initCmd.Flags().String("timezone", "", "IANA timezone (with --non-interactive; default: detected, else America/New_York)")
+	initCmd.Flags().StringSlice("include", nil, "Channel include pattern (with --non-interactive; repeatable)")
+	initCmd.Flags().StringSlice("exclude", nil, "Channel exclude pattern (with --non-interactive; repeatable)")
+	initCmd.Flags().Bool("skip-auth", false, "Do not require slackdump credentials (with --non-interactive)")
 	rootCmd.AddCommand(initCmd)
 }
 
 func runConfig(_ *cobra.Command, _ []string) error {
-	cfg, err := config.Load(cfgFile)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -145,9 +189,16 @@ func runConfig(_ *cobra.Command, _ []string) error {
 	fmt.Printf("  Timezone:         %s\n", cfg.Timezone)
 	fmt.Printf("  Include patterns: %s\n", formatPatterns(cfg.Include))
 	fmt.Printf("  Exclude patterns: %s\n", formatPatterns(cfg.Exclude))
+	for _, target := range cfg.Targets {
+		fmt.Printf("  Target %s: %s (include %s, exclude %s)\n",
+			target.Name, target.OutputDir, formatPatterns(target.Include), formatPatterns(target.Exclude))
+	}
 	fmt.Println()
 	if cfg.ConfigFile() != "" {
 		fmt.Printf("Config file: %s\n", cfg.ConfigFile())
+		if cfg.Extends != "" {
+			fmt.Printf("Extends:     %s\n", cfg.Extends)
+		}
 	} else {
 		fmt.Println("Config file: (none - using defaults)")
 	}
@@ -155,6 +206,54 @@ func runConfig(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// applyTimezoneFlag replaces the configured timezone with --timezone for
+// this run. Date manifests record the zone each folder was rendered in.
+func applyTimezoneFlag(cmd *cobra.Command, cfg *config.Config) error {
+	timezone, _ := cmd.Flags().GetString("timezone")
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid --timezone %q: %w", timezone, err)
+	}
+	cfg.Timezone = timezone
+	return nil
+}
+
+// applyChannelsFile replaces the configured include patterns with the
+// channels listed in --channels-from-file for this run.
+func applyChannelsFile(cmd *cobra.Command, cfg *config.Config) error {
+	path, _ := cmd.Flags().GetString("channels-from-file")
+	if path == "" {
+		return nil
+	}
+	patterns, err := config.ReadPatternFile(path)
+	if err != nil {
+		return err
+	}
+	cfg.OverrideInclude(patterns)
+	return nil
+}
+
+// addChannelScopeFlags registers --only-dms and --only-channels, which
+// narrow one run by conversation type without touching the patterns.
+func addChannelScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("only-dms", false, "Limit this run to direct and group messages")
+	cmd.Flags().Bool("only-channels", false, "Limit this run to public and private channels")
+	cmd.MarkFlagsMutuallyExclusive("only-dms", "only-channels")
+}
+
+// channelScope reads --only-dms and --only-channels.
+func channelScope(cmd *cobra.Command) export.ChannelScope {
+	if onlyDMs, _ := cmd.Flags().GetBool("only-dms"); onlyDMs {
+		return export.ScopeDMs
+	}
+	if onlyChannels, _ := cmd.Flags().GetBool("only-channels"); onlyChannels {
+		return export.ScopeChannels
+	}
+	return export.ScopeAll
+}
+
 func formatPatterns(patterns []string) string {
 	if len(patterns) == 0 {
 		return "(none)"
@@ -162,22 +261,40 @@ func formatPatterns(patterns []string) string {
 	return "[" + strings.Join(patterns, ", ") + "]"
 }
 
-func runExport(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(cfgFile)
+func runExport(cmd *cobra.Command, args []string) (err error) {
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := applyTimezoneFlag(cmd, cfg); err != nil {
+		return err
+	}
+	if err := applyChannelsFile(cmd, cfg); err != nil {
+		return err
+	}
 
-	exporter, err := export.NewExporter(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to initialize exporter: %w", err)
+	toStdout, _ := cmd.Flags().GetBool("stdout")
+	channel, _ := cmd.Flags().GetString("channel")
+	if toStdout || channel != "" {
+		return exportToStdout(cmd, cfg, args, channel)
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := commandContext()
 	defer cancel()
+	ctx, endTrace := traceCommand(ctx, cfg, "export")
+	defer func() { endTrace(err) }()
 
+	exporter, err := export.NewExporter(ctx, cfg, tracing.Middleware)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	trackRun(exporter)
+	handleChannelErrors(cmd, exporter)
+
+	changedOnly, _ := cmd.Flags().GetBool("changed-only")
+	opts := export.ExportOptions{ChangedOnly: changedOnly, Scope: channelScope(cmd)}
 	if len(args) == 1 {
-		return exporter.ExportDate(ctx, args[0])
+		return exporter.ExportDate(ctx, args[0], opts)
 	}
 
 	from, _ := cmd.Flags().GetString("from")
@@ -195,36 +312,85 @@ func runExport(cmd *cobra.Command, args []string) error {
 		to = time.Now().In(loc).Format("2006-01-02")
 	}
 
-	return exporter.ExportRange(ctx, from, to)
+	return exporter.ExportRange(ctx, from, to, opts)
+}
+
+// exportToStdout writes one channel-day to stdout for piping. Progress and
+// warnings go to stderr so they never mix with the output.
+func exportToStdout(cmd *cobra.Command, cfg *config.Config, args []string, channel string) error {
+	toStdout, _ := cmd.Flags().GetBool("stdout")
+	from, _ := cmd.Flags().GetString("from")
+	changedOnly, _ := cmd.Flags().GetBool("changed-only")
+	switch {
+	case !toStdout:
+		return errors.New("--channel is only supported with --stdout")
+	case channel == "" || len(args) != 1:
+		return errors.New("--stdout needs a date argument and --channel")
+	case from != "" || changedOnly:
+		return errors.New("--stdout writes a single date and cannot be combined with --from or --changed-only")
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	exporter, err := export.NewExporter(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	exporter.SetEvents(export.ConsoleEvents{Out: os.Stderr, Err: os.Stderr})
+	currentRun.addRange(args[0], args[0])
+	currentRun.Channels = append(currentRun.Channels, channel)
+	return exporter.ExportChannelDay(ctx, os.Stdout, args[0], channel)
 }
 
-func runSync(cmd *cobra.Command, _ []string) error {
-	cfg, err := config.Load(cfgFile)
+func runSync(cmd *cobra.Command, _ []string) (err error) {
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := applyTimezoneFlag(cmd, cfg); err != nil {
+		return err
+	}
 
-	exporter, err := export.NewExporter(cfg)
+	full, _ := cmd.Flags().GetBool("full")
+	reconcile, _ := cmd.Flags().GetInt("reconcile")
+	if full && reconcile > 0 {
+		return errors.New("--full and --reconcile cannot be combined")
+	}
+	resume, _ := cmd.Flags().GetBool("resume")
+	syncOpts, err := catchUpOptions(cmd, export.SyncOptions{
+		Full: full, ReconcileDays: reconcile, Resume: resume, Scope: channelScope(cmd),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to initialize exporter: %w", err)
+		return err
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := commandContext()
 	defer cancel()
+	ctx, endTrace := traceCommand(ctx, cfg, "sync")
+	defer func() { endTrace(err) }()
+
+	exporter, err := export.NewExporter(ctx, cfg, tracing.Middleware)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	finishReport := reportRun(cfg, exporter, trackRun(exporter))
+	handleChannelErrors(cmd, exporter)
 
-	full, _ := cmd.Flags().GetBool("full")
 	syncCtx := ctx
-	if !full {
+	if !full && reconcile == 0 && syncOpts.CatchUp == "" {
 		var timeoutCancel context.CancelFunc
 		syncCtx, timeoutCancel = context.WithTimeout(ctx, dailySyncTimeout)
 		defer timeoutCancel()
 	}
 
-	return exporter.Sync(syncCtx, time.Now(), export.SyncOptions{Full: full})
+	err = syncWithCatchUp(syncCtx, exporter, time.Now(), syncOpts)
+	finishReport(err)
+	return err
 }
 
-func runRender(cmd *cobra.Command, _ []string) error {
-	cfg, err := config.Load(cfgFile)
+func runRender(cmd *cobra.Command, _ []string) (err error) {
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -232,7 +398,7 @@ func runRender(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	creds, err := slack.LoadCredentials()
+	creds, err := slack.LoadWorkspaceCredentials(cfg.Workspace)
 	if err != nil {
 		return fmt.Errorf("loading credentials: %w", err)
 	}
@@ -265,57 +431,43 @@ func runRender(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := commandContext()
 	defer cancel()
+	ctx, endTrace := traceCommand(ctx, cfg, "render")
+	defer func() { endTrace(err) }()
 
-	writes, err := export.RenderArchiveRange(ctx, archiveDir, cfg.OutputDir, from, to, cfg.Timezone)
+	opts := export.RenderOptionsFromConfig(cfg)
+	currentRun.addRange(from, to)
+	writes, err := export.RenderConfiguredRange(ctx, cfg, archiveDir, from, to, opts, nil)
 	if err != nil {
 		return err
 	}
 	fmt.Printf("Rendered %s through %s (%d changed file(s))\n", from, to, writes)
+	fmt.Println(opts.Accounting.Summary())
 	return nil
 }
 
 func runChannels(cmd *cobra.Command, _ []string) error {
-	cfg, err := config.Load(cfgFile)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	creds, err := slack.LoadCredentials()
-	if err != nil {
-		if credErr := slack.GetCredentialError(err); credErr != nil {
-			fmt.Fprintln(os.Stderr, credErr.UserMessage())
-			os.Exit(1)
-		}
-		return fmt.Errorf("failed to load credentials: %w", err)
-	}
-
-	if err := creds.Validate(); err != nil {
-		return fmt.Errorf("invalid credentials: %w", err)
-	}
-
-	client := slack.NewEdgeClient(creds)
-
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := commandContext()
 	defer cancel()
 
-	// AuthTest verifies credentials and sets the TeamID needed for Edge API calls
-	if _, err := client.AuthTest(ctx); err != nil {
-		return fmt.Errorf("verifying credentials: %w", err)
+	client, err := newAuthenticatedClient(ctx, cfg)
+	if err != nil {
+		return err
 	}
 
-	var since time.Time
-	sinceStr, _ := cmd.Flags().GetString("since")
-	if sinceStr != "" {
-		loc, err := time.LoadLocation(cfg.Timezone)
-		if err != nil {
-			return fmt.Errorf("invalid timezone: %w", err)
-		}
-		since, err = time.ParseInLocation("2006-01-02", sinceStr, loc)
-		if err != nil {
-			return fmt.Errorf("invalid since date: %w", err)
-		}
+	since, staleCutoff, err := channelsTimeFilters(cmd, cfg, time.Now())
+	if err != nil {
+		return err
+	}
+	testPatterns, _ := cmd.Flags().GetStringArray("test-pattern")
+	if len(testPatterns) > 0 && (!since.IsZero() || !staleCutoff.IsZero()) {
+		return errors.New("--test-pattern cannot be combined with --since or --stale")
 	}
 
 	userIndex, err := client.FetchUsers(ctx)
@@ -329,7 +481,9 @@ func runChannels(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("loading user cache: %w", err)
 	}
 
-	resolver := slack.NewUserResolver(userIndex, cache, client)
+	resolver := slack.NewUserResolver(userIndex, cache, client).WithTeams(client.TeamID(), client).
+		WithNameStyle(export.ConfiguredNameStyle(cfg)).
+		WithWarnings(func(err error) { fmt.Fprintf(os.Stderr, "Warning: %v\n", err) })
 
 	chans, err := client.GetActiveChannelsWithResolver(ctx, since, resolver)
 	if err != nil {
@@ -341,7 +495,16 @@ func runChannels(cmd *cobra.Command, _ []string) error {
 		fmt.Fprintf(os.Stderr, "Warning: failed to save user cache: %v\n", err)
 	}
 
-	chans = channels.FilterChannels(chans, cfg.Include, cfg.Exclude)
+	if len(testPatterns) > 0 {
+		writePatternReport(os.Stdout, testPatterns, chans, cfg.OutputTargets())
+		return nil
+	}
+
+	chans = channelScope(cmd).FilterChannels(export.FilterForTargets(chans, cfg.OutputTargets()))
+	if !staleCutoff.IsZero() {
+		printStaleChannels(staleChannels(chans, staleCutoff), cfg.Timezone)
+		return nil
+	}
 
 	sort.Slice(chans, func(i, j int) bool {
 		return chans[i].Name < chans[j].Name
@@ -355,9 +518,12 @@ func runChannels(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func runInit(_ *cobra.Command, _ []string) error {
+func runInit(cmd *cobra.Command, _ []string) error {
+	if nonInteractive {
+		return runInitNonInteractive(cmd)
+	}
 	if !term.IsTerminal(int(os.Stdin.Fd())) {
-		return errors.New("init requires an interactive terminal")
+		return errors.New("init requires an interactive terminal; use --non-interactive to set up from flags")
 	}
 
 	// Step 1: Check for slackdump
@@ -501,6 +667,7 @@ func initStepAuth() (bool, string, error) {
 
 	// #nosec G204 -- slackdumpPath comes from FindSlackdump, not untrusted input
 	cmd := exec.Command(slackdumpPath, "auth")
+	cmd.Env = export.SlackdumpEnv("")
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -623,17 +790,7 @@ func initStepConfig() (*config.Config, string, error) {
 	}
 
 	if !useDetectedTZ {
-		timezones := []string{
-			"America/New_York",
-			"America/Chicago",
-			"America/Denver",
-			"America/Los_Angeles",
-			"Europe/London",
-			"Europe/Paris",
-			"Asia/Tokyo",
-			"UTC",
-		}
-
+		timezones := availableTimezones()
 		options := make([]huh.Option[string], len(timezones))
 		for i, tz := range timezones {
 			options[i] = huh.NewOption(tz, tz)
@@ -643,7 +800,10 @@ func initStepConfig() (*config.Config, string, error) {
 			huh.NewGroup(
 				huh.NewSelect[string]().
 					Title("Select timezone").
+					Description("Type / to search").
 					Options(options...).
+					Filtering(true).
+					Height(12).
 					Value(&timezone),
 			),
 		)
@@ -654,13 +814,7 @@ func initStepConfig() (*config.Config, string, error) {
 	}
 
 	// Create and save config
-	cfg := &config.Config{
-		OutputDir:        outputDir,
-		Timezone:         timezone,
-		ArchiveDir:       "~/.local/share/slack-export/archive",
-		Lookback:         "7d",
-		SkipStaleThreads: "21d",
-	}
+	cfg := newInitConfig(outputDir, timezone)
 
 	if err := cfg.Save(configPath); err != nil {
 		return nil, "", fmt.Errorf("failed to save config: %w", err)
@@ -671,6 +825,17 @@ func initStepConfig() (*config.Config, string, error) {
 	return cfg, configPath, nil
 }
 
+// newInitConfig returns the config init writes for outputDir and timezone.
+func newInitConfig(outputDir, timezone string) *config.Config {
+	return &config.Config{
+		OutputDir:        outputDir,
+		Timezone:         timezone,
+		ArchiveDir:       "~/.local/share/slack-export/archive",
+		Lookback:         "7d",
+		SkipStaleThreads: "21d",
+	}
+}
+
 // detectTimezone attempts to detect the system timezone.
 func detectTimezone() string {
 	// Try TZ environment variable first
@@ -717,7 +882,7 @@ func initStepVerify(cfg *config.Config, configPath string, authSkipped bool, wor
 					cache := slack.NewUserCache(slack.DefaultCachePath())
 					_ = cache.Load() // Ignore error - verification only
 
-					resolver := slack.NewUserResolver(userIndex, cache, client)
+					resolver := slack.NewUserResolver(userIndex, cache, client).WithTeams(client.TeamID(), client)
 
 					// Fetch channels
 					chans, err := client.GetActiveChannelsWithResolver(ctx, time.Time{}, resolver)
@@ -780,8 +945,16 @@ func initStepVerify(cfg *config.Config, configPath string, authSkipped bool, wor
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	started := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	err = timeoutError(err)
+	recordRun(cmd, started, err)
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		code, hint := exitStatus(err)
+		if hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+		}
+		os.Exit(code)
 	}
 }
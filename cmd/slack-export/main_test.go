@@ -1,8 +1,13 @@
 package main
 
 import (
+	"io"
 	"os"
 	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
 )
 
 func TestExportCmd_Flags(t *testing.T) {
@@ -16,6 +21,43 @@ func TestExportCmd_Flags(t *testing.T) {
 	if toFlag == nil {
 		t.Error("export command should have --to flag")
 	}
+
+	if exportCmd.Flags().Lookup("channels-from-file") == nil {
+		t.Error("export command should have --channels-from-file flag")
+	}
+
+	for _, name := range []string{"channel", "stdout"} {
+		if exportCmd.Flags().Lookup(name) == nil {
+			t.Errorf("export command should have --%s flag", name)
+		}
+	}
+}
+
+func TestChannelScopeFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{exportCmd, syncCmd, channelsCmd} {
+		for _, name := range []string{"only-dms", "only-channels"} {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("%s command should have --%s flag", cmd.Name(), name)
+			}
+		}
+	}
+
+	cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+	addChannelScopeFlags(cmd)
+	cmd.SetArgs([]string{"--only-dms", "--only-channels"})
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil {
+		t.Error("--only-dms and --only-channels should be mutually exclusive")
+	}
+
+	cmd = &cobra.Command{Use: "test"}
+	addChannelScopeFlags(cmd)
+	if err := cmd.ParseFlags([]string{"--only-dms"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := channelScope(cmd); got != export.ScopeDMs {
+		t.Errorf("channelScope() = %q, want %q", got, export.ScopeDMs)
+	}
 }
 
 func TestExportCmd_Args(t *testing.T) {
@@ -145,6 +187,26 @@ func TestSyncCmd_FullFlag(t *testing.T) {
 	}
 }
 
+func TestSyncCmd_ReconcileFlag(t *testing.T) {
+	reconcileFlag := syncCmd.Flags().Lookup("reconcile")
+	if reconcileFlag == nil {
+		t.Fatal("sync command should have --reconcile flag")
+	}
+	if reconcileFlag.DefValue != "0" {
+		t.Errorf("--reconcile default = %q, want 0", reconcileFlag.DefValue)
+	}
+}
+
+func TestSyncCmd_ResumeFlag(t *testing.T) {
+	resumeFlag := syncCmd.Flags().Lookup("resume")
+	if resumeFlag == nil {
+		t.Fatal("sync command should have --resume flag")
+	}
+	if resumeFlag.DefValue != "false" {
+		t.Errorf("--resume default = %q, want false", resumeFlag.DefValue)
+	}
+}
+
 func TestRenderCmd_Registered(t *testing.T) {
 	found := false
 	for _, cmd := range rootCmd.Commands() {
@@ -266,3 +328,21 @@ func TestDetectTimezone_EmptyTZ(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyTimezoneFlag(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("timezone", "", "")
+	cfg := &config.Config{Timezone: "America/New_York"}
+
+	if err := applyTimezoneFlag(cmd, cfg); err != nil || cfg.Timezone != "America/New_York" {
+		t.Fatalf("unset flag: timezone = %q, err = %v", cfg.Timezone, err)
+	}
+	_ = cmd.Flags().Set("timezone", "Asia/Tokyo")
+	if err := applyTimezoneFlag(cmd, cfg); err != nil || cfg.Timezone != "Asia/Tokyo" {
+		t.Fatalf("set flag: timezone = %q, err = %v", cfg.Timezone, err)
+	}
+	_ = cmd.Flags().Set("timezone", "Mars/Olympus")
+	if err := applyTimezoneFlag(cmd, cfg); err == nil {
+		t.Fatal("invalid timezone should fail")
+	}
+}
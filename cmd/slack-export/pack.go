@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// packPassphraseEnv supplies the pack passphrase for scripted runs.
+const packPassphraseEnv = "SLACK_EXPORT_PACK_PASSPHRASE"
+
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "Bundle exported dates into one file for sharing",
+	Long: `Bundle a date range of the output directory into a single tar.gz that
+another machine can merge with "slack-export unpack". The pack holds a
+manifest with every file's checksum, so damage is caught before unpacking.
+
+--channel limits the pack to matching channel files. --users adds a snapshot
+of the archive's users. --anonymize renders the range afresh from the archive
+with pseudonyms instead of packing the files as they are. --encrypt asks for
+a passphrase (or reads ` + packPassphraseEnv + `) and encrypts the pack.
+
+Examples:
+  slack-export pack --from 2026-01-01 --to 2026-01-31
+  slack-export pack --from 2026-01-05 --channel "eng-*" --users -o eng.tar.gz
+  slack-export pack --from 2026-01-05 --to 2026-01-09 --anonymize --encrypt`,
+	Args: cobra.NoArgs,
+	RunE: runPack,
+}
+
+var unpackCmd = &cobra.Command{
+	Use:   "unpack <file>",
+	Short: "Merge a pack into the output directory",
+	Long: `Verify a pack written by "slack-export pack" and merge its files into the
+output directory. New files are added and identical files left alone. Files
+that differ from the packed copy are kept and listed as conflicts unless
+--overwrite is given. A user snapshot is merged into users.json in the
+output directory.
+
+Encrypted packs ask for the passphrase, or read ` + packPassphraseEnv + `.
+
+Examples:
+  slack-export unpack slack-export-2026-01-01-2026-01-31.tar.gz
+  slack-export unpack eng.tar.gz --output-dir ~/shared/slack --overwrite`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUnpack,
+}
+
+func init() {
+	packCmd.Flags().String("from", "", "First date to pack (YYYY-MM-DD, required)")
+	packCmd.Flags().String("to", "", "Last date to pack (YYYY-MM-DD, default: --from)")
+	packCmd.Flags().StringArray("channel", nil, "Only pack channels matching this pattern (repeatable)")
+	packCmd.Flags().Bool("users", false, "Include a snapshot of the archive's users")
+	packCmd.Flags().Bool("anonymize", false, "Render the range with pseudonyms instead of packing it as is")
+	packCmd.Flags().Bool("encrypt", false, "Encrypt the pack with a passphrase")
+	packCmd.Flags().StringP("output", "o", "", "Pack file to write (default: slack-export-<from>-<to>.tar.gz)")
+	_ = packCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(packCmd)
+
+	unpackCmd.Flags().String("output-dir", "", "Directory to merge into (default: output_dir)")
+	unpackCmd.Flags().Bool("overwrite", false, "Replace existing files that differ from the packed copy")
+	rootCmd.AddCommand(unpackCmd)
+}
+
+func runPack(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	opts := export.PackOptions{}
+	opts.From, _ = cmd.Flags().GetString("from")
+	opts.To, _ = cmd.Flags().GetString("to")
+	if opts.To == "" {
+		opts.To = opts.From
+	}
+	opts.Channels, _ = cmd.Flags().GetStringArray("channel")
+	opts.Users, _ = cmd.Flags().GetBool("users")
+	opts.Anonymize, _ = cmd.Flags().GetBool("anonymize")
+	encrypt, _ := cmd.Flags().GetBool("encrypt")
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath == "" {
+		outputPath = packFileName(opts.From, opts.To, encrypt)
+	}
+
+	archiveDir := ""
+	if opts.Users || opts.Anonymize {
+		if archiveDir, err = packArchiveDir(cfg); err != nil {
+			return err
+		}
+	}
+	if encrypt {
+		if opts.Passphrase, err = packPassphrase(true); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	manifest, err := export.Pack(ctx, f, cfg.OutputDir, archiveDir, export.RenderOptionsFromConfig(cfg), opts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(outputPath)
+		return err
+	}
+	fmt.Printf("Packed %d file(s) from %s through %s into %s\n", len(manifest.Files), opts.From, opts.To, outputPath)
+	return nil
+}
+
+func runUnpack(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	if outputDir == "" {
+		outputDir = cfg.OutputDir
+	}
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	report, err := export.Unpack(f, outputDir, export.UnpackOptions{
+		Passphrase: func() (string, error) { return packPassphrase(false) },
+		Overwrite:  overwrite,
+	})
+	if err != nil {
+		return err
+	}
+	printUnpackReport(os.Stdout, outputDir, report)
+	return nil
+}
+
+func packFileName(from, to string, encrypted bool) string {
+	name := fmt.Sprintf("slack-export-%s-%s.tar.gz", from, to)
+	if encrypted {
+		name += ".enc"
+	}
+	return name
+}
+
+// packArchiveDir returns the configured workspace's archive directory.
+func packArchiveDir(cfg *config.Config) (string, error) {
+	creds, err := slack.LoadWorkspaceCredentials(cfg.Workspace)
+	if err != nil {
+		return "", fmt.Errorf("loading credentials: %w", err)
+	}
+	workspace := creds.Workspace
+	if workspace == "" {
+		workspace = creds.TeamID
+	}
+	return export.WorkspaceArchiveDir(cfg, workspace)
+}
+
+// packPassphrase reads the passphrase from the environment or, on a
+// terminal, asks for it; confirm asks twice.
+func packPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(packPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !interactive() {
+		return "", fmt.Errorf("set %s to use encrypted packs without a terminal", packPassphraseEnv)
+	}
+	var passphrase, again string
+	fields := []huh.Field{
+		huh.NewInput().Title("Pack passphrase").EchoMode(huh.EchoModePassword).Value(&passphrase),
+	}
+	if confirm {
+		fields = append(fields, huh.NewInput().Title("Repeat passphrase").EchoMode(huh.EchoModePassword).Value(&again))
+	}
+	if err := huh.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase must not be empty")
+	}
+	if confirm && again != passphrase {
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+func printUnpackReport(w io.Writer, outputDir string, report *export.UnpackReport) {
+	for _, path := range report.Conflicts {
+		_, _ = fmt.Fprintf(w, "CONFLICT  %s (kept the existing file; use --overwrite to replace it)\n", path)
+	}
+	_, _ = fmt.Fprintf(w, "Unpacked %s through %s into %s: %d added, %d replaced, %d unchanged, %d conflict(s)\n",
+		report.Manifest.From, report.Manifest.To, outputDir,
+		len(report.Added), len(report.Replaced), len(report.Unchanged), len(report.Conflicts))
+	if report.Users > 0 {
+		_, _ = fmt.Fprintf(w, "Merged %d user(s) into users.json\n", report.Users)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestPackFileName(t *testing.T) {
+	if got := packFileName("2026-01-01", "2026-01-31", false); got != "slack-export-2026-01-01-2026-01-31.tar.gz" {
+		t.Errorf("packFileName() = %q", got)
+	}
+	if got := packFileName("2026-01-01", "2026-01-01", true); !strings.HasSuffix(got, ".tar.gz.enc") {
+		t.Errorf("packFileName(encrypted) = %q, want .tar.gz.enc", got)
+	}
+}
+
+func TestPrintUnpackReport(t *testing.T) {
+	var out bytes.Buffer
+	printUnpackReport(&out, "/out", &export.UnpackReport{
+		Manifest:  &export.PackManifest{From: "2026-01-01", To: "2026-01-02"},
+		Added:     []string{"2026-01-01/2026-01-01-general.md"},
+		Conflicts: []string{"2026-01-02/2026-01-02-general.md"},
+		Users:     3,
+	})
+	for _, want := range []string{
+		"CONFLICT  2026-01-02/2026-01-02-general.md",
+		"1 added, 0 replaced, 0 unchanged, 1 conflict(s)",
+		"Merged 3 user(s)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+}
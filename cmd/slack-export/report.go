@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+// reportRun collects a summary of the sync when report is configured, and
+// returns the function that delivers it once the sync has finished. A
+// report that cannot be delivered is a warning; it never fails the sync.
+func reportRun(cfg *config.Config, exporter *export.Exporter, events export.Events) func(error) {
+	if !cfg.Report.Enabled() {
+		return func(error) {}
+	}
+	collector := export.NewReportCollector(events, "sync", time.Now())
+	exporter.SetEvents(collector)
+	return func(runErr error) {
+		if err := export.DeliverReport(cfg.Report, collector.Report(time.Now(), runErr)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to deliver sync report: %v\n", err)
+		}
+	}
+}
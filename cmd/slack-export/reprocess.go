@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var reprocessCmd = &cobra.Command{
+	Use:   "reprocess <date>...",
+	Short: "Render dates again from the raw files kept by keep_raw",
+	Long: `Render dates again from the raw/<date>/<channel>.json files that keep_raw
+writes beside the markdown, so template, filter, and name changes apply to
+days already exported.
+
+Reprocess reads nothing from the archive or Slack: user names come from the
+users cache, and only channel-days with a raw file are rendered. Use render
+instead when the archive is available.
+
+Examples:
+  slack-export reprocess 2026-01-22
+  slack-export reprocess 2026-01-20 2026-01-21 2026-01-22`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReprocess,
+}
+
+func init() {
+	rootCmd.AddCommand(reprocessCmd)
+}
+
+func runReprocess(_ *cobra.Command, dates []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	opts := export.RenderOptionsFromConfig(cfg)
+	writes, reprocessed := 0, 0
+	for _, dir := range cfg.OutputDirs() {
+		n, err := export.ReprocessRaw(ctx, dir, dates, opts)
+		writes += n
+		if errors.Is(err, export.ErrNoRawFiles) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reprocessing %s: %w", dir, err)
+		}
+		reprocessed++
+	}
+	if reprocessed == 0 {
+		return export.ErrNoRawFiles
+	}
+	fmt.Printf("Reprocessed %d date(s) from raw files (%d changed file(s))\n", len(dates), writes)
+	fmt.Println(opts.Accounting.Summary())
+	return nil
+}
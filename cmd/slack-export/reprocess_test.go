@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestReprocessCmd_Registered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "reprocess" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("reprocess command should be registered with root")
+	}
+}
+
+func TestReprocessCmd_RequiresDate(t *testing.T) {
+	if err := reprocessCmd.Args(reprocessCmd, nil); err == nil {
+		t.Error("reprocess should require at least one date")
+	}
+}
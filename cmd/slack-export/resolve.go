@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve <id>...",
+	Short: "Look up the names behind channel and user IDs",
+	Long: `Resolve Slack channel, DM, and user IDs, such as ones seen in logs or old
+exports, to their names. Each ID prints on one line with its type, name,
+and details like archival, creator, topic, or real name.
+
+Users are looked up in the user cache, then with users.info; channels with
+conversations.info, falling back to the names recorded in the archive when
+Slack no longer knows the channel.
+
+Examples:
+  slack-export resolve C03TSU00NK1 U123ABC
+  slack-export resolve D0123456789`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runResolve,
+}
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+}
+
+func runResolve(_ *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	client, err := newAuthenticatedClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	cache := slack.NewUserCache(slack.DefaultCachePath())
+	if err := cache.Load(); err != nil {
+		return fmt.Errorf("loading user cache: %w", err)
+	}
+
+	resolver := &idResolver{
+		lookup: client,
+		users:  cache,
+		loc:    loc,
+		archiveNames: func() map[string]string {
+			archiveDir, err := packArchiveDir(cfg)
+			if err != nil {
+				return nil
+			}
+			names, _ := export.ArchiveChannelNames(ctx, archiveDir)
+			return names
+		},
+	}
+	failed := 0
+	for _, arg := range args {
+		line, err := resolver.resolve(ctx, arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", arg, err)
+			failed++
+			continue
+		}
+		fmt.Println(line)
+	}
+
+	if err := cache.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save user cache: %v\n", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("could not resolve %d of %d ID(s)", failed, len(args))
+	}
+	return nil
+}
+
+// idLookup fetches users and conversations by ID from Slack.
+type idLookup interface {
+	FetchUserInfo(ctx context.Context, userID string) (*slack.User, error)
+	FetchConversationInfo(ctx context.Context, channelID string) (*slack.Conversation, error)
+}
+
+// idResolver formats one line per resolved ID.
+type idResolver struct {
+	lookup idLookup
+	users  *slack.UserCache
+	loc    *time.Location
+	// archiveNames loads the archive's channel names by ID, for channels
+	// Slack no longer returns. It is called at most once.
+	archiveNames func() map[string]string
+	archive      map[string]string
+	archiveRead  bool
+}
+
+func (r *idResolver) resolve(ctx context.Context, arg string) (string, error) {
+	id := strings.ToUpper(strings.TrimSpace(arg))
+	switch {
+	case strings.HasPrefix(id, "U"), strings.HasPrefix(id, "W"):
+		user, err := r.user(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return resolvedLine(id, "user", "@"+user.Name, userDetails(user)), nil
+	case strings.HasPrefix(id, "C"), strings.HasPrefix(id, "G"), strings.HasPrefix(id, "D"):
+		return r.conversation(ctx, id)
+	default:
+		return "", fmt.Errorf("not a user (U, W) or conversation (C, G, D) ID")
+	}
+}
+
+// user returns the cached user, fetching and caching it when missing.
+func (r *idResolver) user(ctx context.Context, id string) (*slack.User, error) {
+	if user := r.users.Get(id); user != nil {
+		return user, nil
+	}
+	user, err := r.lookup.FetchUserInfo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.users.Set(user)
+	return user, nil
+}
+
+func (r *idResolver) conversation(ctx context.Context, id string) (string, error) {
+	conv, err := r.lookup.FetchConversationInfo(ctx, id)
+	if err != nil {
+		if name, ok := r.archivedName(id); ok {
+			return resolvedLine(id, conversationKind(id, slack.Conversation{}), name, []string{"from archive"}), nil
+		}
+		return "", err
+	}
+	kind := conversationKind(id, *conv)
+	name := "#" + conv.Name
+	var details []string
+	switch kind {
+	case "dm":
+		name = conv.User
+		if user, err := r.user(ctx, conv.User); err == nil {
+			name = "@" + user.Name
+		}
+	case "group-dm":
+		name = conv.Name
+	}
+	if conv.IsArchived {
+		details = append(details, "archived")
+	}
+	if conv.Created > 0 {
+		created := "created " + time.Unix(conv.Created, 0).In(r.loc).Format("2006-01-02")
+		if conv.Creator != "" && kind != "dm" {
+			creator := conv.Creator
+			if user, err := r.user(ctx, conv.Creator); err == nil {
+				creator = "@" + user.Name
+			}
+			created += " by " + creator
+		}
+		details = append(details, created)
+	}
+	if conv.NumMembers > 0 {
+		details = append(details, fmt.Sprintf("%d members", conv.NumMembers))
+	}
+	if conv.Topic.Value != "" {
+		details = append(details, "topic: "+conv.Topic.Value)
+	}
+	return resolvedLine(id, kind, name, details), nil
+}
+
+func (r *idResolver) archivedName(id string) (string, bool) {
+	if !r.archiveRead && r.archiveNames != nil {
+		r.archive = r.archiveNames()
+		r.archiveRead = true
+	}
+	name, ok := r.archive[id]
+	return name, ok
+}
+
+// conversationKind names a conversation's type, using the ID prefix when
+// Slack's flags are unavailable.
+func conversationKind(id string, conv slack.Conversation) string {
+	switch {
+	case conv.IsIM || (conv.ID == "" && strings.HasPrefix(id, "D")):
+		return "dm"
+	case conv.IsMpim || strings.HasPrefix(conv.Name, "mpdm-"):
+		return "group-dm"
+	case conv.IsPrivate || conv.IsGroup || (conv.ID == "" && strings.HasPrefix(id, "G")):
+		return "private"
+	default:
+		return "channel"
+	}
+}
+
+func userDetails(user *slack.User) []string {
+	var details []string
+	if user.RealName != "" {
+		details = append(details, user.RealName)
+	}
+	if display := user.Profile.DisplayName; display != "" && display != user.Name {
+		details = append(details, "display name "+display)
+	}
+	if user.TeamID != "" {
+		details = append(details, "team "+user.TeamID)
+	}
+	if user.IsBot {
+		details = append(details, "bot")
+	}
+	if user.Deleted {
+		details = append(details, "deactivated")
+	}
+	return details
+}
+
+func resolvedLine(id, kind, name string, details []string) string {
+	line := fmt.Sprintf("%-12s  %-8s  %s", id, kind, name)
+	if len(details) > 0 {
+		line += "  (" + strings.Join(details, ", ") + ")"
+	}
+	return line
+}
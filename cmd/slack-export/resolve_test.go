@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+type fakeIDLookup struct {
+	users      map[string]*slack.User
+	convs      map[string]*slack.Conversation
+	userCalls  int
+	convErrors int
+}
+
+func (f *fakeIDLookup) FetchUserInfo(_ context.Context, id string) (*slack.User, error) {
+	f.userCalls++
+	if user, ok := f.users[id]; ok {
+		return user, nil
+	}
+	return nil, errors.New("users.info: user_not_found")
+}
+
+func (f *fakeIDLookup) FetchConversationInfo(_ context.Context, id string) (*slack.Conversation, error) {
+	if conv, ok := f.convs[id]; ok {
+		return conv, nil
+	}
+	f.convErrors++
+	return nil, errors.New("conversations.info: channel_not_found")
+}
+
+func TestIDResolver_Resolve(t *testing.T) {
+	created := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC).Unix()
+	general := &slack.Conversation{NumMembers: 42}
+	general.ID, general.Name, general.IsChannel, general.Created, general.Creator = "C1", "general", true, created, "U1"
+	dm := &slack.Conversation{User: "U2"}
+	dm.ID, dm.IsIM = "D1", true
+	lookup := &fakeIDLookup{
+		users: map[string]*slack.User{
+			"U1": {ID: "U1", Name: "alice", RealName: "Alice Smith"},
+			"U2": {ID: "U2", Name: "bob", IsBot: true},
+		},
+		convs: map[string]*slack.Conversation{"C1": general, "D1": dm},
+	}
+	archiveLoads := 0
+	resolver := &idResolver{
+		lookup: lookup,
+		users:  slack.NewUserCache(""),
+		loc:    time.UTC,
+		archiveNames: func() map[string]string {
+			archiveLoads++
+			return map[string]string{"C9": "old-project"}
+		},
+	}
+
+	tests := map[string]string{
+		"u1": "U1            user      @alice  (Alice Smith)",
+		"C1": "C1            channel   #general  (created 2024-03-05 by @alice, 42 members)",
+		"D1": "D1            dm        @bob",
+		"C9": "C9            channel   old-project  (from archive)",
+	}
+	for id, want := range tests {
+		got, err := resolver.resolve(context.Background(), id)
+		if err != nil {
+			t.Fatalf("resolve(%s) error = %v", id, err)
+		}
+		if got != want {
+			t.Errorf("resolve(%s) =\n%q\nwant\n%q", id, got, want)
+		}
+	}
+	if lookup.userCalls != 2 {
+		t.Errorf("users.info calls = %d, want 2 with the cache reused", lookup.userCalls)
+	}
+
+	if _, err := resolver.resolve(context.Background(), "C404"); err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("resolve(C404) error = %v, want channel_not_found", err)
+	}
+	if archiveLoads != 1 {
+		t.Errorf("archive names loaded %d times, want 1", archiveLoads)
+	}
+	if _, err := resolver.resolve(context.Background(), "X1"); err == nil {
+		t.Error("resolve(X1) should reject an unknown ID prefix")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/tracing"
+	"github.com/spf13/cobra"
+)
+
+var retryFailedCmd = &cobra.Command{
+	Use:   "retry-failed",
+	Short: "Render the channel-days skipped after errors again",
+	Long: `When a channel fails to render and is skipped, during export or sync, its
+channel-days are queued in failed-jobs.json in the archive directory with
+the kind of failure (rate_limited, auth_expired, slackdump_failed, or error).
+retry-failed renders just those channel-days again from the archive and
+removes the ones that succeed from the queue.
+
+Examples:
+  slack-export retry-failed           # Render the queued channel-days
+  slack-export retry-failed --list    # Show the queue without rendering
+  slack-export sync --skip-failed-channels && slack-export retry-failed`,
+	Args: cobra.NoArgs,
+	RunE: runRetryFailed,
+}
+
+func init() {
+	retryFailedCmd.Flags().Bool("list", false, "List the queued channel-days without rendering")
+	addChannelErrorFlags(retryFailedCmd)
+	rootCmd.AddCommand(retryFailedCmd)
+}
+
+func runRetryFailed(cmd *cobra.Command, _ []string) (err error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		archiveDir, err := packArchiveDir(cfg)
+		if err != nil {
+			return err
+		}
+		jobs, err := export.LoadFailedJobs(archiveDir)
+		if err != nil {
+			return err
+		}
+		printFailedJobs(jobs)
+		return nil
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+	ctx, endTrace := traceCommand(ctx, cfg, "retry-failed")
+	defer func() { endTrace(err) }()
+
+	exporter, err := export.NewExporter(ctx, cfg, tracing.Middleware)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	trackRun(exporter)
+	handleChannelErrors(cmd, exporter)
+	return exporter.RetryFailed(ctx)
+}
+
+// printFailedJobs lists queued channel-days one per line.
+func printFailedJobs(jobs []export.FailedJob) {
+	for _, job := range jobs {
+		fmt.Printf("%s  %-24s  %-16s  %s\n", job.Date, job.Channel, job.Kind, job.Error)
+	}
+	fmt.Printf("\n%d failed channel-day(s)\n", len(jobs))
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "github.com/chrisedwards/slack-export/internal/export"
+
+// watchStatusSignal is a no-op where SIGUSR1 does not exist.
+func watchStatusSignal(*export.ProgressTracker) {}
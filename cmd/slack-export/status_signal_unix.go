@@ -0,0 +1,25 @@
+//go:build unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+// watchStatusSignal prints the run's progress to stderr whenever the
+// process receives SIGUSR1 (kill -USR1 <pid>).
+func watchStatusSignal(tracker *export.ProgressTracker) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	go func() {
+		for range signals {
+			fmt.Fprint(os.Stderr, export.FormatProgress(tracker.Snapshot(), time.Now()))
+		}
+	}()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var tailCmd = &cobra.Command{
+	Use:   "tail <channel>",
+	Short: "Follow a channel's new messages as they are posted",
+	Long: `Follow a tracked channel, printing each new message with resolved names as
+it is posted, formatted as in the export. Handy during incidents.
+
+New messages are also appended to today's file for the channel in each
+output directory that selects it, so the export stays current; the next
+sync re-renders the file from the archive. --no-append only prints.
+Thread replies are not followed. Stop with Ctrl-C.
+
+Examples:
+  slack-export tail eng-incidents
+  slack-export tail eng-incidents -n 20 --interval 2s --no-append`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTail,
+}
+
+func init() {
+	tailCmd.Flags().IntP("lines", "n", 10, "Recent messages to show before following")
+	tailCmd.Flags().Duration("interval", export.DefaultTailInterval, "How often to poll for new messages")
+	tailCmd.Flags().Bool("no-append", false, "Only print messages; do not append them to today's export file")
+	rootCmd.AddCommand(tailCmd)
+}
+
+func runTail(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	opts := export.TailOptions{Channel: args[0]}
+	opts.Backlog, _ = cmd.Flags().GetInt("lines")
+	opts.Interval, _ = cmd.Flags().GetDuration("interval")
+	opts.NoAppend, _ = cmd.Flags().GetBool("no-append")
+	if opts.Backlog < 0 {
+		return errors.New("--lines must not be negative")
+	}
+	if opts.Interval < export.MinTailInterval {
+		return fmt.Errorf("--interval must be at least %s", export.MinTailInterval)
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	exporter, err := export.NewExporter(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	trackRun(exporter)
+	return exporter.Tail(ctx, os.Stdout, opts)
+}
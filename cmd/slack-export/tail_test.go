@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestTailCmd_Flags(t *testing.T) {
+	if flag := tailCmd.Flags().Lookup("lines"); flag == nil || flag.Shorthand != "n" || flag.DefValue != "10" {
+		t.Error("tail command should have --lines/-n flag defaulting to 10")
+	}
+	for _, name := range []string{"interval", "no-append"} {
+		if tailCmd.Flags().Lookup(name) == nil {
+			t.Errorf("tail command should have --%s flag", name)
+		}
+	}
+	if tailCmd.ValidArgsFunction == nil {
+		t.Error("tail command should complete channel names")
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// timeoutFlag is the global --timeout deadline for a command. Zero means no
+// deadline.
+var timeoutFlag time.Duration
+
+// runCtx is the context of the running command, kept so main can tell a
+// --timeout expiry apart from other failures.
+var runCtx context.Context
+
+// errTimedOut marks a command stopped by --timeout.
+var errTimedOut = errors.New("command timed out")
+
+// commandContext returns the context commands run under: cancelled on
+// SIGINT or SIGTERM and, with --timeout, at the deadline. Slack API calls
+// and slackdump subprocesses all run under it, so the deadline stops them.
+func commandContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if timeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeoutFlag)
+		parentStop := stop
+		stop = func() {
+			cancel()
+			parentStop()
+		}
+	}
+	runCtx = ctx
+	return ctx, stop
+}
+
+// timeoutError marks err as a timeout when the command's --timeout passed,
+// whatever error the interrupted work surfaced.
+func timeoutError(err error) error {
+	if err == nil || runCtx == nil || !errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%w after %s: %w", errTimedOut, timeoutFlag, err)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommandContext_Timeout(t *testing.T) {
+	defer func(saved time.Duration) { timeoutFlag = saved; runCtx = nil }(timeoutFlag)
+
+	timeoutFlag = time.Millisecond
+	ctx, cancel := commandContext()
+	defer cancel()
+	<-ctx.Done()
+
+	err := timeoutError(errors.New("slackdump archive failed: signal: killed"))
+	if !errors.Is(err, errTimedOut) || !strings.Contains(err.Error(), "after 1ms") {
+		t.Errorf("timeoutError() = %v", err)
+	}
+	if timeoutError(nil) != nil {
+		t.Error("timeoutError(nil) should stay nil")
+	}
+}
+
+func TestCommandContext_NoTimeout(t *testing.T) {
+	defer func(saved time.Duration) { timeoutFlag = saved; runCtx = nil }(timeoutFlag)
+
+	timeoutFlag = 0
+	ctx, cancel := commandContext()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("context should have no deadline without --timeout")
+	}
+	cancel()
+
+	boom := errors.New("boom")
+	if err := timeoutError(boom); err != boom {
+		t.Errorf("timeoutError() after cancel = %v, want the original error", err)
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("ctx.Err() = %v, want context.Canceled", ctx.Err())
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var listTimezonesCmd = &cobra.Command{
+	Use:   "list-timezones [query]",
+	Short: "List IANA timezone names, optionally fuzzy-filtered",
+	Long: `List IANA timezone names for the config "timezone" setting and --timezone.
+
+With a query, only matching names are shown: names containing the query come
+first, then names containing its letters in order ("nyork" finds
+America/New_York). Matching ignores case, and spaces match underscores.
+
+Names come from the system zoneinfo database, or a built-in list of common
+zones when it is missing (e.g. scratch containers).
+
+Examples:
+  slack-export list-timezones
+  slack-export list-timezones tokyo
+  slack-export list-timezones "los angeles"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runListTimezones,
+}
+
+func init() {
+	rootCmd.AddCommand(listTimezonesCmd)
+}
+
+func runListTimezones(_ *cobra.Command, args []string) error {
+	zones := availableTimezones()
+	if len(args) == 1 {
+		zones = matchTimezones(zones, args[0])
+		if len(zones) == 0 {
+			return fmt.Errorf("no timezone matches %q", args[0])
+		}
+	}
+	for _, zone := range zones {
+		fmt.Println(zone)
+	}
+	return nil
+}
+
+// zoneinfoDirs are where Go's time package looks for the zoneinfo database
+// on Unix systems.
+var zoneinfoDirs = []string{"/usr/share/zoneinfo", "/usr/share/lib/zoneinfo", "/usr/lib/locale/TZ", "/etc/zoneinfo"}
+
+// commonTimezones is used when no zoneinfo database is installed.
+var commonTimezones = []string{
+	"Africa/Cairo", "Africa/Johannesburg", "Africa/Lagos", "Africa/Nairobi",
+	"America/Anchorage", "America/Argentina/Buenos_Aires", "America/Bogota", "America/Chicago",
+	"America/Denver", "America/Halifax", "America/Los_Angeles", "America/Mexico_City",
+	"America/New_York", "America/Phoenix", "America/Santiago", "America/Sao_Paulo",
+	"America/St_Johns", "America/Toronto", "America/Vancouver",
+	"Asia/Bangkok", "Asia/Dubai", "Asia/Hong_Kong", "Asia/Jakarta", "Asia/Jerusalem",
+	"Asia/Karachi", "Asia/Kolkata", "Asia/Manila", "Asia/Seoul", "Asia/Shanghai",
+	"Asia/Singapore", "Asia/Taipei", "Asia/Tokyo",
+	"Australia/Adelaide", "Australia/Brisbane", "Australia/Melbourne", "Australia/Perth", "Australia/Sydney",
+	"Europe/Amsterdam", "Europe/Athens", "Europe/Berlin", "Europe/Dublin", "Europe/Helsinki",
+	"Europe/Istanbul", "Europe/Lisbon", "Europe/London", "Europe/Madrid", "Europe/Moscow",
+	"Europe/Paris", "Europe/Rome", "Europe/Stockholm", "Europe/Warsaw", "Europe/Zurich",
+	"Pacific/Auckland", "Pacific/Honolulu",
+	"UTC",
+}
+
+// availableTimezones returns the sorted zone names listed in the zoneinfo
+// database's zone.tab (or zone1970.tab), plus UTC. $ZONEINFO is honored
+// when it names a directory.
+func availableTimezones() []string {
+	dirs := zoneinfoDirs
+	if dir := os.Getenv("ZONEINFO"); dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, dir := range dirs {
+		for _, name := range []string{"zone.tab", "zone1970.tab"} {
+			if zones, err := readZoneTab(filepath.Join(dir, name)); err == nil && len(zones) > 0 {
+				return zones
+			}
+		}
+	}
+	return commonTimezones
+}
+
+// readZoneTab reads the zone names from the third column of a zone.tab
+// style file.
+func readZoneTab(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	zones := []string{"UTC"}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if fields := strings.Split(line, "\t"); len(fields) >= 3 {
+			zones = append(zones, fields[2])
+		}
+	}
+	sort.Strings(zones)
+	return zones, scanner.Err()
+}
+
+// matchTimezones returns the zones containing query, then those containing
+// its characters in order, each group keeping the input order.
+func matchTimezones(zones []string, query string) []string {
+	query = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(query), " ", "_"))
+	var exact, fuzzy []string
+	for _, zone := range zones {
+		name := strings.ToLower(zone)
+		switch {
+		case strings.Contains(name, query):
+			exact = append(exact, zone)
+		case isSubsequence(query, name):
+			fuzzy = append(fuzzy, zone)
+		}
+	}
+	return append(exact, fuzzy...)
+}
+
+func isSubsequence(query, s string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+1:]
+	}
+	return true
+}
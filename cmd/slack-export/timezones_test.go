@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMatchTimezones(t *testing.T) {
+	zones := []string{"America/Los_Angeles", "America/New_York", "Asia/Tokyo", "Europe/London", "UTC"}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"tokyo", []string{"Asia/Tokyo"}},
+		{"los angeles", []string{"America/Los_Angeles"}},
+		{"nyork", []string{"America/New_York"}},
+		{"lon", []string{"Europe/London", "America/Los_Angeles"}},
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		if got := matchTimezones(zones, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchTimezones(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestReadZoneTab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "zone.tab")
+	content := "# comment\nJP\t+353916+1394441\tAsia/Tokyo\nUS\t+404251-0740023\tAmerica/New_York\tEastern (most areas)\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readZoneTab(path)
+	if err != nil {
+		t.Fatalf("readZoneTab() error = %v", err)
+	}
+	if want := []string{"America/New_York", "Asia/Tokyo", "UTC"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readZoneTab() = %v, want %v", got, want)
+	}
+}
+
+func TestCommonTimezones_Load(t *testing.T) {
+	for _, zone := range commonTimezones {
+		if _, err := time.LoadLocation(zone); err != nil {
+			t.Errorf("time.LoadLocation(%q) error = %v", zone, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/tracing"
+)
+
+// traceFlushTimeout bounds sending the trace after a run, so an unreachable
+// collector cannot hold up a finished command.
+const traceFlushTimeout = 10 * time.Second
+
+// traceCommand starts the run's root span, named after the command, when
+// tracing is configured in the config file or the OTEL_ environment. The
+// returned function ends the span with the command's error and exports the
+// trace; export failures only warn.
+func traceCommand(ctx context.Context, cfg *config.Config, command string) (context.Context, func(error)) {
+	tracer := tracing.New(tracing.ConfigFromEnv(tracing.Config{
+		Endpoint:       cfg.Tracing.Endpoint,
+		Headers:        cfg.Tracing.Headers,
+		ServiceVersion: Version,
+	}))
+	if tracer == nil {
+		return ctx, func(error) {}
+	}
+	ctx, span := tracing.Start(tracing.WithTracer(ctx, tracer), "slack-export "+command,
+		tracing.String("slack_export.command", command))
+	return ctx, func(runErr error) {
+		span.End(runErr)
+		flushCtx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+		defer cancel()
+		if err := tracer.Flush(flushCtx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to export trace: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+func TestTraceCommand_ExportsRootSpan(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if got, end := traceCommand(ctx, &config.Config{}, "sync"); got != ctx {
+		t.Error("traceCommand() without an endpoint should leave the context alone")
+	} else {
+		end(nil)
+	}
+
+	cfg := &config.Config{Tracing: config.Tracing{Endpoint: server.URL}}
+	_, end := traceCommand(ctx, cfg, "sync")
+	end(errors.New("archive locked"))
+	for _, want := range []string{`"name":"slack-export sync"`, `"message":"archive locked"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("exported trace missing %s:\n%s", want, body)
+		}
+	}
+}
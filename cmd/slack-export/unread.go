@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var unreadCmd = &cobra.Command{
+	Use:   "unread",
+	Short: "Export a digest of messages you have not read yet",
+	Long: `Export a "what I missed" digest: for each tracked conversation with
+messages newer than your Slack read position (last_read), the unread messages
+and their replies, mentions first. Useful for catching up after time away.
+
+The digest is read from the local archive and is separate from the dated
+export folders, so run sync first. Conversations whose newest message is not
+archived yet are listed as a warning.
+
+Examples:
+  slack-export sync && slack-export unread
+  slack-export unread --output catch-up.md --limit 20`,
+	Args: cobra.NoArgs,
+	RunE: runUnread,
+}
+
+func init() {
+	unreadCmd.Flags().StringP("output", "o", "", "Write the digest to this file instead of stdout")
+	unreadCmd.Flags().Int("limit", 50, "Newest unread messages shown per conversation (0 for all)")
+	rootCmd.AddCommand(unreadCmd)
+}
+
+func runUnread(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	outputPath, _ := cmd.Flags().GetString("output")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	exporter, err := export.NewExporter(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	trackRun(exporter)
+
+	var w io.Writer = os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+	return exporter.ExportUnread(ctx, w, time.Now(), export.UnreadOptions{Limit: limit})
+}
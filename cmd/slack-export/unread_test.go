@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestUnreadCmd_Registered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "unread" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("unread command should be registered with root")
+	}
+}
+
+func TestUnreadCmd_Flags(t *testing.T) {
+	if flag := unreadCmd.Flags().Lookup("output"); flag == nil || flag.Shorthand != "o" {
+		t.Error("unread command should have --output/-o flag")
+	}
+	if flag := unreadCmd.Flags().Lookup("limit"); flag == nil || flag.DefValue != "50" {
+		t.Error("unread command should have --limit flag defaulting to 50")
+	}
+}
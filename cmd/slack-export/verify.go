@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check exported files for tampering or corruption",
+	Long: `Check exported files against the SHA256SUMS written in each date folder
+when checksums is enabled in the config.
+
+Modified or missing files are reported and make the command exit non-zero.
+Files added after the checksums were written are listed but not counted as
+failures. Folders without a SHA256SUMS are skipped.
+
+Examples:
+  slack-export verify --checksums`,
+	Args: cobra.NoArgs,
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().Bool("checksums", false, "Compare files with each date folder's SHA256SUMS")
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, _ []string) error {
+	checksums, _ := cmd.Flags().GetBool("checksums")
+	if !checksums {
+		return errors.New("specify what to verify: --checksums")
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	failed := false
+	for _, dir := range cfg.OutputDirs() {
+		report, err := export.VerifyChecksums(dir)
+		if err != nil {
+			return fmt.Errorf("verifying %s: %w", dir, err)
+		}
+		printChecksumReport(os.Stdout, dir, report)
+		failed = failed || !report.OK()
+	}
+	if failed {
+		return errors.New("checksum verification failed")
+	}
+	return nil
+}
+
+func printChecksumReport(w io.Writer, dir string, report *export.ChecksumReport) {
+	for _, path := range report.Modified {
+		_, _ = fmt.Fprintf(w, "MODIFIED  %s\n", path)
+	}
+	for _, path := range report.Missing {
+		_, _ = fmt.Fprintf(w, "MISSING   %s\n", path)
+	}
+	for _, path := range report.Unlisted {
+		_, _ = fmt.Fprintf(w, "UNLISTED  %s\n", path)
+	}
+	_, _ = fmt.Fprintf(w, "%s: %d date folder(s) checked, %d modified, %d missing, %d unlisted\n",
+		dir, report.Folders, len(report.Modified), len(report.Missing), len(report.Unlisted))
+}
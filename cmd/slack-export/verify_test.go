@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestPrintChecksumReport(t *testing.T) {
+	report := &export.ChecksumReport{
+		Folders:  3,
+		Modified: []string{"2026-01-15/2026-01-15-general.md"},
+		Unlisted: []string{"2026-01-15/notes.txt"},
+	}
+	var buf bytes.Buffer
+	printChecksumReport(&buf, "/out", report)
+	out := buf.String()
+
+	for _, want := range []string{
+		"MODIFIED  2026-01-15/2026-01-15-general.md",
+		"UNLISTED  2026-01-15/notes.txt",
+		"/out: 3 date folder(s) checked, 1 modified, 0 missing, 1 unlisted",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunVerify_RequiresMode(t *testing.T) {
+	cmd := verifyCmd
+	if err := cmd.Flags().Set("checksums", "false"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runVerify(cmd, nil); err == nil || !strings.Contains(err.Error(), "--checksums") {
+		t.Errorf("runVerify() without a mode error = %v", err)
+	}
+}
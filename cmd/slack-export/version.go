@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/spf13/cobra"
+)
+
+const (
+	slackExportRepo  = "ChrisEdwards/slack-export"
+	slackdumpRepo    = "rusq/slackdump"
+	installScriptURL = "https://raw.githubusercontent.com/ChrisEdwards/slack-export/main/install.sh"
+
+	// releaseCheckTimeout bounds each GitHub releases query.
+	releaseCheckTimeout = 10 * time.Second
+)
+
+// githubAPIURL is the GitHub API base, replaced in tests.
+var githubAPIURL = "https://api.github.com"
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the slack-export and slackdump versions",
+	Long: `Show this binary's version and the slackdump it uses.
+
+--check also asks GitHub for the latest release of each and prints the
+command that updates an outdated one: "brew upgrade" for Homebrew installs,
+the install script for binaries it installed, or "go install" for a
+slackdump built from source. --json prints the same report as JSON.
+
+Examples:
+  slack-export version
+  slack-export version --check
+  slack-export version --check --json | jq .slackdump.update_available`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	versionCmd.Flags().Bool("check", false, "Check GitHub for newer releases")
+	versionCmd.Flags().Bool("json", false, "Print the report as JSON")
+	rootCmd.AddCommand(versionCmd)
+}
+
+// componentVersion is one binary in a version report. Latest and the
+// fields after it are set by --check.
+type componentVersion struct {
+	Version         string `json:"version"`
+	Path            string `json:"path,omitempty"`
+	Bundled         bool   `json:"bundled,omitempty"`
+	Latest          string `json:"latest,omitempty"`
+	UpdateAvailable bool   `json:"update_available"`
+	UpdateCommand   string `json:"update_command,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+type versionReport struct {
+	SlackExport componentVersion `json:"slack_export"`
+	Build       string           `json:"build"`
+	BuildTime   string           `json:"build_time"`
+	Slackdump   componentVersion `json:"slackdump"`
+	Checked     bool             `json:"checked"`
+}
+
+func runVersion(cmd *cobra.Command, _ []string) error {
+	check, _ := cmd.Flags().GetBool("check")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	report := versionReport{
+		SlackExport: componentVersion{Version: Version},
+		Build:       Build,
+		BuildTime:   BuildTime,
+	}
+	if exe, err := os.Executable(); err == nil {
+		report.SlackExport.Path = exe
+	}
+	install, err := export.LocateSlackdump()
+	report.Slackdump = componentVersion{Path: install.Path, Version: install.Version, Bundled: install.Bundled}
+	if err != nil {
+		report.Slackdump.Error = err.Error()
+	}
+
+	if check {
+		ctx, cancel := commandContext()
+		defer cancel()
+		client := &http.Client{Timeout: releaseCheckTimeout}
+		report.Checked = true
+		checkComponent(ctx, client, &report.SlackExport, slackExportRepo, slackExportUpdateCommand(report.SlackExport.Path))
+		if install.Path != "" {
+			checkComponent(ctx, client, &report.Slackdump, slackdumpRepo, slackdumpUpdateCommand(install))
+		}
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printVersionReport(os.Stdout, report)
+	return nil
+}
+
+// checkComponent records the latest release of repo on c, noting a failed
+// query on c instead of failing the command.
+func checkComponent(ctx context.Context, client *http.Client, c *componentVersion, repo, updateCommand string) {
+	latest, err := latestRelease(ctx, client, repo)
+	if err != nil {
+		c.Error = err.Error()
+		return
+	}
+	c.Latest = latest
+	c.UpdateAvailable = newerRelease(c.Version, latest)
+	c.UpdateCommand = updateCommand
+}
+
+// latestRelease returns the version of repo's latest GitHub release,
+// without a leading "v".
+func latestRelease(ctx context.Context, client *http.Client, repo string) (string, error) {
+	requestURL := fmt.Sprintf("%s/repos/%s/releases/latest", githubAPIURL, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "slack-export/"+Version)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("checking %s releases: %w", repo, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checking %s releases: HTTP %d", repo, resp.StatusCode)
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("decoding %s release: %w", repo, err)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("%s has no published release", repo)
+	}
+	return strings.TrimPrefix(release.TagName, "v"), nil
+}
+
+// newerRelease reports whether latest is a newer version than current.
+// Development builds and unparseable versions never report an update.
+func newerRelease(current, latest string) bool {
+	cmp, err := export.CompareVersions(strings.TrimPrefix(current, "v"), latest)
+	return err == nil && cmp < 0
+}
+
+// comparableVersion reports whether version is an X.Y.Z release number.
+func comparableVersion(version string) bool {
+	_, err := export.CompareVersions(strings.TrimPrefix(version, "v"), "0.0.0")
+	return err == nil
+}
+
+// slackExportUpdateCommand returns the command that updates the slack-export
+// binary at exe.
+func slackExportUpdateCommand(exe string) string {
+	if homebrewPath(exe) {
+		return "brew upgrade slack-export"
+	}
+	return installCommand(filepath.Dir(exe))
+}
+
+// slackdumpUpdateCommand returns the command that updates install.
+func slackdumpUpdateCommand(install export.SlackdumpInstall) string {
+	switch {
+	case homebrewPath(install.Path):
+		return "brew upgrade slackdump"
+	case install.Bundled:
+		return installCommand(filepath.Dir(install.Path))
+	default:
+		return "go install github.com/rusq/slackdump/v4/cmd/slackdump@latest"
+	}
+}
+
+// installCommand runs the install script, which updates slack-export and
+// its bundled slackdump together, into dir.
+func installCommand(dir string) string {
+	command := "curl -fsSL " + installScriptURL + " | "
+	if home, err := os.UserHomeDir(); err == nil && dir == filepath.Join(home, ".local", "bin") {
+		return command + "sh"
+	}
+	return command + "INSTALL_DIR=" + dir + " sh"
+}
+
+// homebrewPath reports whether path lies in a Homebrew prefix.
+func homebrewPath(path string) bool {
+	if path == "" {
+		return false
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	path = filepath.ToSlash(path)
+	if prefix := os.Getenv("HOMEBREW_PREFIX"); prefix != "" && strings.HasPrefix(path, filepath.ToSlash(prefix)+"/") {
+		return true
+	}
+	return strings.Contains(path, "/Cellar/") || strings.Contains(path, "/homebrew/") || strings.Contains(path, "/linuxbrew/")
+}
+
+func printVersionReport(w io.Writer, r versionReport) {
+	_, _ = fmt.Fprintf(w, "slack-export %s (build %s, %s)\n", r.SlackExport.Version, r.Build, r.BuildTime)
+	printComponentCheck(w, r.SlackExport, r.Checked)
+
+	switch s := r.Slackdump; {
+	case s.Path == "":
+		_, _ = fmt.Fprintf(w, "slackdump    not found: %s\n", s.Error)
+		return
+	case s.Version == "":
+		_, _ = fmt.Fprintf(w, "slackdump    unknown version at %s\n", s.Path)
+	default:
+		location := s.Path
+		if s.Bundled {
+			location += " (bundled)"
+		}
+		_, _ = fmt.Fprintf(w, "slackdump    %s at %s\n", s.Version, location)
+	}
+	printComponentCheck(w, r.Slackdump, r.Checked)
+}
+
+func printComponentCheck(w io.Writer, c componentVersion, checked bool) {
+	switch {
+	case !checked:
+	case c.Error != "":
+		_, _ = fmt.Fprintf(w, "  could not check for updates: %s\n", c.Error)
+	case c.UpdateAvailable:
+		_, _ = fmt.Fprintf(w, "  update available: %s\n  update with: %s\n", c.Latest, c.UpdateCommand)
+	case !comparableVersion(c.Version):
+		_, _ = fmt.Fprintf(w, "  latest release %s (this build's version cannot be compared)\n", c.Latest)
+	default:
+		_, _ = fmt.Fprintf(w, "  up to date (latest release %s)\n", c.Latest)
+	}
+}
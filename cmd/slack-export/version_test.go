@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestVersionCmd_Flags(t *testing.T) {
+	for _, name := range []string{"check", "json"} {
+		if versionCmd.Flags().Lookup(name) == nil {
+			t.Errorf("version command should have --%s flag", name)
+		}
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/rusq/slackdump/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name": "v4.5.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	old := githubAPIURL
+	githubAPIURL = server.URL
+	defer func() { githubAPIURL = old }()
+
+	latest, err := latestRelease(context.Background(), server.Client(), slackdumpRepo)
+	if err != nil || latest != "4.5.0" {
+		t.Errorf("latestRelease() = %q, %v; want 4.5.0", latest, err)
+	}
+	if _, err := latestRelease(context.Background(), server.Client(), "nobody/nothing"); err == nil {
+		t.Error("latestRelease() should fail for a repository without releases")
+	}
+}
+
+func TestNewerRelease(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"0.2.5", "0.2.6", true},
+		{"v0.2.5", "0.3.0", true},
+		{"0.2.6", "0.2.6", false},
+		{"0.3.0", "0.2.6", false},
+		{"dev", "0.2.6", false},
+	}
+	for _, tt := range tests {
+		if got := newerRelease(tt.current, tt.latest); got != tt.want {
+			t.Errorf("newerRelease(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateCommands(t *testing.T) {
+	t.Setenv("HOMEBREW_PREFIX", "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if got := slackExportUpdateCommand("/opt/homebrew/bin/slack-export"); got != "brew upgrade slack-export" {
+		t.Errorf("Homebrew slack-export update = %q", got)
+	}
+	if got := slackExportUpdateCommand(filepath.Join(home, ".local", "bin", "slack-export")); !strings.HasSuffix(got, "install.sh | sh") {
+		t.Errorf("default install update = %q", got)
+	}
+	if got := slackExportUpdateCommand("/usr/local/bin/slack-export"); !strings.HasSuffix(got, "| INSTALL_DIR=/usr/local/bin sh") {
+		t.Errorf("custom install dir update = %q", got)
+	}
+
+	for install, want := range map[export.SlackdumpInstall]string{
+		{Path: "/home/linuxbrew/.linuxbrew/bin/slackdump"}:    "brew upgrade slackdump",
+		{Path: "/usr/local/bin/slackdump", Bundled: true}:     "| INSTALL_DIR=/usr/local/bin sh",
+		{Path: filepath.Join(home, "go", "bin", "slackdump")}: "go install github.com/rusq/slackdump/v4/cmd/slackdump@latest",
+	} {
+		if got := slackdumpUpdateCommand(install); !strings.HasSuffix(got, want) {
+			t.Errorf("slackdumpUpdateCommand(%+v) = %q, want suffix %q", install, got, want)
+		}
+	}
+}
+
+func TestPrintVersionReport(t *testing.T) {
+	report := versionReport{
+		SlackExport: componentVersion{Version: "0.2.5", Latest: "0.2.6", UpdateAvailable: true, UpdateCommand: "brew upgrade slack-export"},
+		Build:       "abc123",
+		BuildTime:   "2026-10-01",
+		Slackdump:   componentVersion{Version: "4.4.1", Path: "/usr/local/bin/slackdump", Bundled: true, Error: "HTTP 403"},
+		Checked:     true,
+	}
+	var out bytes.Buffer
+	printVersionReport(&out, report)
+	for _, want := range []string{
+		"slack-export 0.2.5 (build abc123, 2026-10-01)\n  update available: 0.2.6\n  update with: brew upgrade slack-export\n",
+		"slackdump    4.4.1 at /usr/local/bin/slackdump (bundled)\n  could not check for updates: HTTP 403\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+}
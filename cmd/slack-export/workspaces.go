@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// workspaceFlag is the global --workspace override for the config's workspace.
+var workspaceFlag string
+
+var workspacesCmd = &cobra.Command{
+	Use:   "workspaces",
+	Short: "List slackdump workspaces and check their credentials",
+	Long: `List every workspace with credentials in slackdump's cache and check that
+each one decrypts and has a valid token. The current workspace is marked
+with "*" and the workspace selected by --workspace or config "workspace:"
+with ">".`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaces,
+}
+
+func init() {
+	rootCmd.AddCommand(workspacesCmd)
+}
+
+// loadConfig loads the config file and applies global flag overrides.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	if workspaceFlag != "" {
+		cfg.Workspace = workspaceFlag
+	}
+	return cfg, nil
+}
+
+func runWorkspaces(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	statuses, err := slack.CheckWorkspaces()
+	if err != nil {
+		if credErr := slack.GetCredentialError(err); credErr != nil {
+			return fmt.Errorf("%s", credErr.UserMessage())
+		}
+		return err
+	}
+	for _, line := range workspaceLines(statuses, cfg.Workspace) {
+		fmt.Println(line)
+	}
+	fmt.Printf("\n%d workspaces\n", len(statuses))
+	return nil
+}
+
+func workspaceLines(statuses []slack.WorkspaceStatus, selected string) []string {
+	lines := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		marker := " "
+		switch {
+		case status.Name == selected:
+			marker = ">"
+		case status.Current:
+			marker = "*"
+		}
+		state := "ok"
+		if status.Err != nil {
+			state = "invalid: " + status.Err.Error()
+		}
+		lines = append(lines, fmt.Sprintf("%s %-24s %s", marker, status.Name, state))
+	}
+	return lines
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestWorkspaceLines(t *testing.T) {
+	statuses := []slack.WorkspaceStatus{
+		{Name: "acme", Current: true},
+		{Name: "globex"},
+		{Name: "initech", Err: errors.New("token is empty")},
+	}
+
+	lines := workspaceLines(statuses, "globex")
+	if !strings.HasPrefix(lines[0], "* acme") || !strings.HasSuffix(lines[0], "ok") {
+		t.Errorf("current workspace line = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "> globex") {
+		t.Errorf("selected workspace line = %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "invalid: token is empty") {
+		t.Errorf("invalid workspace line = %q", lines[2])
+	}
+}
+
+func TestLoadConfig_WorkspaceFlagOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	oldCfg, oldFlag := cfgFile, workspaceFlag
+	defer func() { cfgFile, workspaceFlag = oldCfg, oldFlag }()
+	cfgFile, workspaceFlag = "", "globex"
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if cfg.Workspace != "globex" {
+		t.Errorf("Workspace = %q, want globex", cfg.Workspace)
+	}
+}
@@ -0,0 +1,147 @@
+// Package browse implements the interactive terminal viewer for rendered
+// markdown exports.
+package browse
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+var (
+	datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	// sharedFolderPattern matches the week and month folders of the weekly
+	// and monthly layouts, whose file names carry each day's date.
+	sharedFolderPattern = regexp.MustCompile(`^\d{4}-(W\d{2}|\d{2})$`)
+	fileDatePattern     = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+)
+
+// Day is one date in an output directory: a YYYY-MM-DD directory, or the
+// files of one date in a week or month directory.
+type Day struct {
+	Date  string
+	Files []File
+}
+
+// File is one rendered channel file for a day.
+type File struct {
+	Channel string
+	Path    string
+}
+
+// Scan lists the date directories in an output directory, newest first. Each
+// day's channel files are ordered by channel; days without any are skipped.
+// language names translated copies, which are not listed; see
+// export.IsRenderedChannelFile.
+func Scan(dir, language string) ([]Day, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var days []Day
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if sharedFolderPattern.MatchString(entry.Name()) {
+			shared, err := scanSharedFolder(filepath.Join(dir, entry.Name()), language)
+			if err != nil {
+				return nil, err
+			}
+			days = append(days, shared...)
+			continue
+		}
+		if !datePattern.MatchString(entry.Name()) {
+			continue
+		}
+		files, err := scanDay(filepath.Join(dir, entry.Name()), entry.Name(), language)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			days = append(days, Day{Date: entry.Name(), Files: files})
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date > days[j].Date })
+	return days, nil
+}
+
+// scanDay lists a day's channel files. os.ReadDir sorts by file name, and
+// every name carries the same date (or none), so the result is sorted by
+// channel.
+func scanDay(dir, date, language string) ([]File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !export.IsRenderedChannelFile(date, name, language) {
+			continue
+		}
+		files = append(files, File{Channel: fileChannel(name, date), Path: filepath.Join(dir, name)})
+	}
+	return files, nil
+}
+
+// scanSharedFolder splits a week or month directory's channel files into
+// days by the date in their names.
+func scanSharedFolder(dir, language string) ([]Day, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string][]File)
+	var dates []string
+	for _, entry := range entries {
+		name := entry.Name()
+		date := fileDatePattern.FindString(name)
+		if entry.IsDir() || !export.IsRenderedChannelFile(filepath.Base(dir), name, language) || date == "" {
+			continue
+		}
+		if byDate[date] == nil {
+			dates = append(dates, date)
+		}
+		byDate[date] = append(byDate[date], File{Channel: fileChannel(name, date), Path: filepath.Join(dir, name)})
+	}
+	days := make([]Day, 0, len(dates))
+	for _, date := range dates {
+		days = append(days, Day{Date: date, Files: byDate[date]})
+	}
+	return days, nil
+}
+
+// fileChannel strips the date and extension from a rendered file name.
+func fileChannel(name, date string) string {
+	channel := strings.TrimSuffix(strings.TrimPrefix(name, date+"-"), ".md")
+	return strings.TrimSuffix(channel, "-"+date)
+}
+
+// jumpIndex returns the index of the newest day on or before date. Dates
+// older than the whole archive select the oldest day.
+func jumpIndex(days []Day, date string) int {
+	for i, day := range days {
+		if day.Date <= date {
+			return i
+		}
+	}
+	return len(days) - 1
+}
+
+// matchLines returns the zero-based lines of content containing query,
+// ignoring case.
+func matchLines(content, query string) []int {
+	query = strings.ToLower(query)
+	var matches []int
+	for i, line := range strings.Split(content, "\n") {
+		if strings.Contains(strings.ToLower(line), query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,186 @@
+package browse
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeExport(t *testing.T, dir, date, channel, content string) {
+	t.Helper()
+	dayDir := filepath.Join(dir, date)
+	if err := os.MkdirAll(dayDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dayDir, date+"-"+channel+".md"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan_ListsDaysNewestFirst(t *testing.T) {
+	dir := t.TempDir()
+	writeExport(t, dir, "2026-07-01", "general", "# general\n")
+	writeExport(t, dir, "2026-07-03", "random", "# random\n")
+	writeExport(t, dir, "2026-07-03", "eng-backend", "# eng\n")
+	if err := os.MkdirAll(filepath.Join(dir, "2026-07-02"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "notes"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	days, err := Scan(dir, "")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if len(days) != 2 || days[0].Date != "2026-07-03" || days[1].Date != "2026-07-01" {
+		t.Fatalf("days = %+v", days)
+	}
+	var channels []string
+	for _, file := range days[0].Files {
+		channels = append(channels, file.Channel)
+	}
+	if !reflect.DeepEqual(channels, []string{"eng-backend", "random"}) {
+		t.Errorf("channels = %v", channels)
+	}
+}
+
+func TestScan_SplitsWeekFoldersIntoDays(t *testing.T) {
+	dir := t.TempDir()
+	week := filepath.Join(dir, "2026-W27")
+	if err := os.MkdirAll(week, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2026-07-01-general.md", "2026-07-03-random.md", "2026-07-03-index.md", "SHA256SUMS"} {
+		if err := os.WriteFile(filepath.Join(week, name), []byte("# x\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	days, err := Scan(dir, "")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(days) != 2 || days[0].Date != "2026-07-03" || days[1].Date != "2026-07-01" {
+		t.Fatalf("days = %+v", days)
+	}
+	var channels []string
+	for _, file := range days[0].Files {
+		channels = append(channels, file.Channel)
+	}
+	if !reflect.DeepEqual(channels, []string{"random"}) {
+		t.Errorf("channels = %v", channels)
+	}
+}
+
+func TestScan_SkipsDayFilesAndTranslations(t *testing.T) {
+	dir := t.TempDir()
+	writeExport(t, dir, "2026-07-01", "general", "# general\n")
+	writeExport(t, dir, "2026-07-01", "general-fr", "# général\n")
+	for _, name := range []string{"index.md", "status.md", "reminders.md", "channel-events.md"} {
+		if err := os.WriteFile(filepath.Join(dir, "2026-07-01", name), []byte("# x\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	days, err := Scan(dir, "fr")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(days) != 1 || len(days[0].Files) != 1 || days[0].Files[0].Channel != "general" {
+		t.Errorf("days = %+v, want only general", days)
+	}
+}
+
+func TestJumpIndex(t *testing.T) {
+	days := []Day{{Date: "2026-07-10"}, {Date: "2026-07-05"}, {Date: "2026-07-01"}}
+	tests := map[string]int{
+		"2026-07-05": 1,
+		"2026-07-07": 1,
+		"2026-08-01": 0,
+		"2026-06-01": 2,
+	}
+	for date, want := range tests {
+		if got := jumpIndex(days, date); got != want {
+			t.Errorf("jumpIndex(%s) = %d, want %d", date, got, want)
+		}
+	}
+}
+
+func TestMatchLines_IgnoresCase(t *testing.T) {
+	content := "# general\nDeploy done\nno match\nredeploy tomorrow"
+	if got := matchLines(content, "DEPLOY"); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("matchLines() = %v", got)
+	}
+}
+
+func press(m tea.Model, keys ...string) tea.Model {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func TestModel_OpensDocumentAndSearches(t *testing.T) {
+	dir := t.TempDir()
+	writeExport(t, dir, "2026-07-03", "general", "# general\n\nalice: hello\nbob: ship it\nalice: shipped")
+	days, err := Scan(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sized, _ := New(days).Update(tea.WindowSizeMsg{Width: 80, Height: 4})
+	m := press(sized, "enter", "enter", "/", "ship", "enter").(Model)
+
+	if m.view != documentView {
+		t.Fatalf("view = %v, want document", m.view)
+	}
+	if !reflect.DeepEqual(m.matches, []int{3, 4}) || m.doc.YOffset != 3 {
+		t.Errorf("matches = %v, offset = %d", m.matches, m.doc.YOffset)
+	}
+	m = press(m, "n").(Model)
+	if m.match != 1 || !strings.Contains(m.status, "match 2/2") {
+		t.Errorf("after n: match = %d, status = %q", m.match, m.status)
+	}
+	m = press(m, "esc").(Model)
+	if m.view != channelView {
+		t.Errorf("esc from document: view = %v, want channel list", m.view)
+	}
+}
+
+func TestModel_JumpToDate(t *testing.T) {
+	days := []Day{
+		{Date: "2026-07-10", Files: []File{{Channel: "general"}}},
+		{Date: "2026-07-05", Files: []File{{Channel: "general"}}},
+	}
+
+	m := press(New(days), "g", "2026-07-07", "enter").(Model)
+
+	if m.view != channelView || m.day != 1 {
+		t.Fatalf("view = %v, day = %d", m.view, m.day)
+	}
+	if !strings.Contains(m.status, "showing 2026-07-05") {
+		t.Errorf("status = %q", m.status)
+	}
+
+	m = press(m, "g", "july", "enter").(Model)
+	if m.day != 1 || !strings.Contains(m.status, "invalid date") {
+		t.Errorf("invalid jump: day = %d, status = %q", m.day, m.status)
+	}
+}
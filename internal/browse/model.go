@@ -0,0 +1,223 @@
+package browse
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type view int
+
+const (
+	dayView view = iota
+	channelView
+	documentView
+)
+
+type prompt int
+
+const (
+	noPrompt prompt = iota
+	searchPrompt
+	jumpPrompt
+)
+
+// chromeLines is the header and footer drawn around every view.
+const chromeLines = 2
+
+// Model is the bubbletea model for browsing an output directory: a list of
+// days, a day's channels, and a scrollable markdown document.
+type Model struct {
+	days   []Day
+	view   view
+	day    int
+	file   int
+	width  int
+	height int
+
+	doc     viewport.Model
+	raw     string
+	prompt  prompt
+	input   textinput.Model
+	query   string
+	matches []int
+	match   int
+	status  string
+}
+
+// New returns a Model over days, which must be non-empty and newest first as
+// returned by Scan.
+func New(days []Day) Model {
+	input := textinput.New()
+	return Model{days: days, doc: viewport.New(80, 20), input: input, width: 80, height: 24}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.doc.Width = msg.Width
+		m.doc.Height = max(msg.Height-chromeLines, 1)
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.prompt != noPrompt {
+			return m.updatePrompt(msg)
+		}
+		switch m.view {
+		case dayView:
+			return m.updateDays(msg)
+		case channelView:
+			return m.updateChannels(msg)
+		default:
+			return m.updateDocument(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateDays(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.day = max(m.day-1, 0)
+	case "down", "j":
+		m.day = min(m.day+1, len(m.days)-1)
+	case "enter", "right", "l":
+		m.view, m.file = channelView, 0
+	case "g":
+		return m.openPrompt(jumpPrompt, "Jump to date (YYYY-MM-DD): ")
+	}
+	return m, nil
+}
+
+func (m Model) updateChannels(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "left", "h":
+		m.view = dayView
+	case "up", "k":
+		m.file = max(m.file-1, 0)
+	case "down", "j":
+		m.file = min(m.file+1, len(m.days[m.day].Files)-1)
+	case "enter", "right", "l":
+		return m.openDocument(), nil
+	case "g":
+		return m.openPrompt(jumpPrompt, "Jump to date (YYYY-MM-DD): ")
+	}
+	return m, nil
+}
+
+func (m Model) updateDocument(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "left", "h":
+		m.view, m.status = channelView, ""
+		return m, nil
+	case "/":
+		return m.openPrompt(searchPrompt, "Search: ")
+	case "n":
+		return m.nextMatch(1), nil
+	case "N":
+		return m.nextMatch(-1), nil
+	case "g":
+		return m.openPrompt(jumpPrompt, "Jump to date (YYYY-MM-DD): ")
+	}
+	var cmd tea.Cmd
+	m.doc, cmd = m.doc.Update(msg)
+	return m, cmd
+}
+
+func (m Model) openPrompt(kind prompt, label string) (tea.Model, tea.Cmd) {
+	m.prompt = kind
+	m.input.Prompt = label
+	m.input.Reset()
+	return m, m.input.Focus()
+}
+
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompt = noPrompt
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		kind, value := m.prompt, m.input.Value()
+		m.prompt = noPrompt
+		m.input.Blur()
+		if kind == jumpPrompt {
+			return m.jump(value), nil
+		}
+		return m.search(value), nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// jump selects the newest day on or before date and shows its channels.
+func (m Model) jump(date string) Model {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		m.status = fmt.Sprintf("invalid date %q", date)
+		return m
+	}
+	m.day = jumpIndex(m.days, date)
+	m.view, m.file = channelView, 0
+	m.status = ""
+	if m.days[m.day].Date != date {
+		m.status = fmt.Sprintf("no export for %s; showing %s", date, m.days[m.day].Date)
+	}
+	return m
+}
+
+func (m Model) openDocument() Model {
+	file := m.days[m.day].Files[m.file]
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		m.status = err.Error()
+		return m
+	}
+	m.raw = string(content)
+	m.view = documentView
+	m.query, m.matches, m.status = "", nil, ""
+	m.doc.SetContent(renderMarkdown(m.raw, ""))
+	m.doc.GotoTop()
+	return m
+}
+
+func (m Model) search(query string) Model {
+	m.query = query
+	m.matches = nil
+	m.status = ""
+	if query != "" {
+		m.matches = matchLines(m.raw, query)
+	}
+	m.doc.SetContent(renderMarkdown(m.raw, query))
+	if query != "" && len(m.matches) == 0 {
+		m.status = fmt.Sprintf("no matches for %q", query)
+		return m
+	}
+	m.match = -1
+	return m.nextMatch(1)
+}
+
+// nextMatch scrolls to the next (step 1) or previous (step -1) match,
+// wrapping around the document.
+func (m Model) nextMatch(step int) Model {
+	if len(m.matches) == 0 {
+		return m
+	}
+	m.match = (m.match + step + len(m.matches)) % len(m.matches)
+	m.doc.SetYOffset(m.matches[m.match])
+	m.status = fmt.Sprintf("match %d/%d for %q", m.match+1, len(m.matches), m.query)
+	return m
+}
@@ -0,0 +1,119 @@
+package browse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	headingStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	quoteStyle    = lipgloss.NewStyle().Faint(true)
+	matchStyle    = lipgloss.NewStyle().Reverse(true)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+const (
+	dayHelp      = "↑/↓ move • enter open • g jump to date • q quit"
+	channelHelp  = "↑/↓ move • enter read • g jump to date • esc back"
+	documentHelp = "↑/↓ scroll • / search • n/N next/prev match • g jump to date • esc back"
+)
+
+func (m Model) View() string {
+	var body, help string
+	switch m.view {
+	case dayView:
+		body, help = m.dayList(), dayHelp
+	case channelView:
+		body, help = m.channelList(), channelHelp
+	default:
+		body, help = m.doc.View(), documentHelp
+	}
+	return titleStyle.Render(m.title()) + "\n" + body + "\n" + m.footer(help)
+}
+
+func (m Model) title() string {
+	switch m.view {
+	case dayView:
+		return fmt.Sprintf("slack-export — %d days", len(m.days))
+	case channelView:
+		return fmt.Sprintf("slack-export — %s", m.days[m.day].Date)
+	default:
+		return fmt.Sprintf("slack-export — %s / %s", m.days[m.day].Date, m.days[m.day].Files[m.file].Channel)
+	}
+}
+
+func (m Model) footer(help string) string {
+	if m.prompt != noPrompt {
+		return m.input.View()
+	}
+	if m.status != "" {
+		return m.status
+	}
+	return helpStyle.Render(help)
+}
+
+func (m Model) dayList() string {
+	labels := make([]string, len(m.days))
+	for i, day := range m.days {
+		labels[i] = fmt.Sprintf("%s  (%d channels)", day.Date, len(day.Files))
+	}
+	return m.list(labels, m.day)
+}
+
+func (m Model) channelList() string {
+	files := m.days[m.day].Files
+	labels := make([]string, len(files))
+	for i, file := range files {
+		labels[i] = file.Channel
+	}
+	return m.list(labels, m.file)
+}
+
+// list renders the window of labels that keeps the cursor visible, padded
+// to the body height so the footer stays on the last line.
+func (m Model) list(labels []string, cursor int) string {
+	height := max(m.height-chromeLines, 1)
+	start := max(cursor-height+1, 0)
+	end := min(start+height, len(labels))
+	lines := make([]string, 0, height)
+	for i := start; i < end; i++ {
+		if i == cursor {
+			lines = append(lines, selectedStyle.Render("> "+labels[i]))
+		} else {
+			lines = append(lines, "  "+labels[i])
+		}
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderMarkdown styles headings and quotes line by line and highlights
+// case-insensitive occurrences of query. Lines map one-to-one to the input
+// so search matches can scroll by line number.
+func renderMarkdown(content, query string) string {
+	var highlight *regexp.Regexp
+	if query != "" {
+		highlight = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if highlight != nil {
+			line = highlight.ReplaceAllStringFunc(line, func(s string) string { return matchStyle.Render(s) })
+		}
+		switch {
+		case strings.HasPrefix(lines[i], "#"):
+			line = headingStyle.Render(line)
+		case strings.HasPrefix(lines[i], ">"):
+			line = quoteStyle.Render(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,6 +3,7 @@
 package channels
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -54,6 +55,31 @@ func (f *Filter) Apply(channels []slack.Channel) []slack.Channel {
 	return result
 }
 
+// Explain reports whether Apply would keep ch and why, naming the pattern
+// that decided it.
+func (f *Filter) Explain(ch slack.Channel) (bool, string) {
+	if pattern, ok := FirstMatch(f.exclude, ch); ok {
+		return false, fmt.Sprintf("excluded by %q", pattern)
+	}
+	if len(f.include) == 0 {
+		return true, "no include patterns"
+	}
+	if pattern, ok := FirstMatch(f.include, ch); ok {
+		return true, fmt.Sprintf("included by %q", pattern)
+	}
+	return false, "no include pattern matches"
+}
+
+// FirstMatch returns the first pattern matching the channel's name or ID.
+func FirstMatch(patterns []string, ch slack.Channel) (string, bool) {
+	for _, pattern := range patterns {
+		if MatchPattern(pattern, ch.Name) || MatchPattern(pattern, ch.ID) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
 // matchesExclude returns true if the channel matches any exclude pattern.
 func (f *Filter) matchesExclude(ch slack.Channel) bool {
 	return MatchAny(f.exclude, ch.Name) || MatchAny(f.exclude, ch.ID)
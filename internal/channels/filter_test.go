@@ -389,3 +389,31 @@ func TestFilterApply(t *testing.T) {
 		}
 	})
 }
+
+func TestFilterExplain(t *testing.T) {
+	tests := []struct {
+		name       string
+		include    []string
+		exclude    []string
+		ch         slack.Channel
+		wantKeep   bool
+		wantReason string
+	}{
+		{"no include patterns", nil, nil, slack.Channel{ID: "C1", Name: "general"}, true, "no include patterns"},
+		{"included by name", []string{"eng-*"}, nil, slack.Channel{ID: "C1", Name: "eng-backend"}, true, `included by "eng-*"`},
+		{"included by ID", []string{"C1"}, nil, slack.Channel{ID: "C1", Name: "eng-backend"}, true, `included by "C1"`},
+		{"exclude wins", []string{"eng-*"}, []string{"*-backend"}, slack.Channel{ID: "C1", Name: "eng-backend"}, false, `excluded by "*-backend"`},
+		{"not included", []string{"eng-*"}, nil, slack.Channel{ID: "C2", Name: "marketing"}, false, "no include pattern matches"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, reason := NewFilter(tt.include, tt.exclude).Explain(tt.ch)
+			if keep != tt.wantKeep || reason != tt.wantReason {
+				t.Errorf("Explain() = (%v, %q), want (%v, %q)", keep, reason, tt.wantKeep, tt.wantReason)
+			}
+			if applied := len(NewFilter(tt.include, tt.exclude).Apply([]slack.Channel{tt.ch})) == 1; applied != keep {
+				t.Errorf("Explain() keep = %v but Apply kept = %v", keep, applied)
+			}
+		})
+	}
+}
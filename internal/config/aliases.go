@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChannelAliasMap returns channel_aliases keyed by upper-case channel ID.
+// Config loading lower-cases map keys, while Slack IDs are upper-case.
+func (c *Config) ChannelAliasMap() map[string]string {
+	if len(c.ChannelAliases) == 0 {
+		return nil
+	}
+	aliases := make(map[string]string, len(c.ChannelAliases))
+	for id, name := range c.ChannelAliases {
+		aliases[strings.ToUpper(strings.TrimSpace(id))] = strings.TrimSpace(name)
+	}
+	return aliases
+}
+
+// validateChannelAliases rejects aliases that cannot be file names and
+// aliases shared by two channels, which would write to the same files.
+func (c *Config) validateChannelAliases() error {
+	owners := make(map[string]string, len(c.ChannelAliases))
+	for id, name := range c.ChannelAliasMap() {
+		if id == "" {
+			return fmt.Errorf("channel_aliases has an empty channel ID for %q", name)
+		}
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("channel_aliases name %q for %s is not a valid file name", name, id)
+		}
+		key := strings.ToLower(name)
+		if other, ok := owners[key]; ok {
+			first, second := min(id, other), max(id, other)
+			return fmt.Errorf("channel_aliases gives %s and %s the same name %q", first, second, name)
+		}
+		owners[key] = id
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestChannelAliasMap_NormalizesIDs(t *testing.T) {
+	cfg := &Config{ChannelAliases: map[string]string{"c0123abc": " eng-backend "}}
+	if got := cfg.ChannelAliasMap()["C0123ABC"]; got != "eng-backend" {
+		t.Errorf("ChannelAliasMap()[C0123ABC] = %q, want eng-backend", got)
+	}
+}
+
+func TestValidate_ChannelAliases(t *testing.T) {
+	for name, tc := range map[string]struct {
+		aliases map[string]string
+		wantErr bool
+	}{
+		"valid":          {map[string]string{"C1": "eng-backend", "C2": "eng-frontend"}, false},
+		"path separator": {map[string]string{"C1": "eng/backend"}, true},
+		"empty name":     {map[string]string{"C1": " "}, true},
+		"duplicate":      {map[string]string{"C1": "eng", "C2": "ENG"}, true},
+	} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", ChannelAliases: tc.aliases}
+		if err := cfg.Validate(); (err != nil) != tc.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", name, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+// AttachmentProcessing runs commands on files saved by "files --download"
+// and writes each command's output beside the file as <file>.txt, so audio
+// clips and images become searchable text.
+type AttachmentProcessing struct {
+	// AudioCommand transcribes audio files and Slack clips.
+	AudioCommand string `yaml:"audio_command,omitempty" mapstructure:"audio_command"`
+	// ImageCommand extracts the text in images, for example with OCR.
+	ImageCommand string `yaml:"image_command,omitempty" mapstructure:"image_command"`
+}
+
+// Enabled reports whether any processing command is configured.
+func (a AttachmentProcessing) Enabled() bool {
+	return a.AudioCommand != "" || a.ImageCommand != ""
+}
+
+// CommandFor returns the command for a file kind ("audio" or "image"), or
+// "" when that kind is not processed.
+func (a AttachmentProcessing) CommandFor(kind string) string {
+	switch kind {
+	case "audio":
+		return a.AudioCommand
+	case "image":
+		return a.ImageCommand
+	}
+	return ""
+}
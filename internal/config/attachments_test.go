@@ -0,0 +1,19 @@
+package config
+
+import "testing"
+
+func TestAttachmentProcessing_CommandFor(t *testing.T) {
+	a := AttachmentProcessing{AudioCommand: "whisper", ImageCommand: "tesseract stdin stdout"}
+	if got := a.CommandFor("audio"); got != "whisper" {
+		t.Errorf("CommandFor(audio) = %q", got)
+	}
+	if got := a.CommandFor("image"); got != "tesseract stdin stdout" {
+		t.Errorf("CommandFor(image) = %q", got)
+	}
+	if got := a.CommandFor(""); got != "" {
+		t.Errorf("CommandFor(\"\") = %q, want empty", got)
+	}
+	if (AttachmentProcessing{}).Enabled() {
+		t.Error("empty AttachmentProcessing is enabled")
+	}
+}
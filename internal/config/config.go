@@ -13,6 +13,10 @@ import (
 
 // Config holds application configuration loaded from YAML.
 type Config struct {
+	// Extends names a base config file, relative to this one, whose
+	// settings apply wherever this file does not set its own.
+	Extends string `yaml:"extends,omitempty" mapstructure:"extends"`
+
 	OutputDir           string   `yaml:"output_dir" mapstructure:"output_dir"`
 	Timezone            string   `yaml:"timezone" mapstructure:"timezone"`
 	Include             []string `yaml:"include" mapstructure:"include"`
@@ -23,6 +27,221 @@ type Config struct {
 	SkipStaleThreads    string   `yaml:"skip_stale_threads" mapstructure:"skip_stale_threads"`
 	SkipCompleteThreads bool     `yaml:"skip_complete_threads" mapstructure:"skip_complete_threads"`
 
+	// ChannelTimezones maps channel name/ID glob patterns to IANA timezones
+	// that override Timezone for matching channels.
+	ChannelTimezones map[string]string `yaml:"channel_timezones,omitempty" mapstructure:"channel_timezones"`
+
+	// ChannelAliases maps channel IDs to the names used for their files,
+	// so renamed channels keep writing to the same files.
+	ChannelAliases map[string]string `yaml:"channel_aliases,omitempty" mapstructure:"channel_aliases"`
+
+	// MaxFileSize and MaxMessagesPerFile split a channel-day into numbered
+	// part files once either limit is exceeded. Empty or zero disables a limit.
+	MaxFileSize        string `yaml:"max_file_size,omitempty" mapstructure:"max_file_size"`
+	MaxMessagesPerFile int    `yaml:"max_messages_per_file,omitempty" mapstructure:"max_messages_per_file"`
+
+	// FilenameDate places the date in channel file names: "prefix"
+	// (default, 2026-01-22-general.md), "suffix" (general-2026-01-22.md),
+	// or "none" (general.md, since the date folder already holds it).
+	FilenameDate string `yaml:"filename_date,omitempty" mapstructure:"filename_date" jsonschema:"enum=prefix|suffix|none"`
+
+	// Layout groups date folders: "daily" (default, 2026-01-22/), "weekly"
+	// (ISO weeks, 2026-W04/), or "monthly" (2026-01/). Weekly and monthly
+	// folders prefix per-day files such as index.md with the date.
+	Layout string `yaml:"layout,omitempty" mapstructure:"layout" jsonschema:"enum=daily|weekly|monthly"`
+
+	// NameStyle chooses the name shown for people in messages and mentions
+	// and used in DM file names: "username", "display", "real", or a
+	// template over {{.Name}}, {{.DisplayName}}, {{.RealName}} and {{.ID}}.
+	// Empty keeps display names in messages and usernames in file names.
+	NameStyle string `yaml:"name_style,omitempty" mapstructure:"name_style"`
+
+	// DeactivatedLabel is added in parentheses after the names of
+	// deactivated users, e.g. "deactivated" renders "alice (deactivated)".
+	// Empty shows their names unmarked.
+	DeactivatedLabel string `yaml:"deactivated_label,omitempty" mapstructure:"deactivated_label"`
+
+	// Sort orders each channel-day's messages: "threads-grouped" (default)
+	// lists top-level messages by time with their same-day replies nested
+	// under the parent, "chronological" lists every message and reply of
+	// the day in timestamp order.
+	Sort string `yaml:"sort,omitempty" mapstructure:"sort" jsonschema:"enum=chronological|threads-grouped"`
+
+	// TempDir is the base for per-run slackdump scratch directories.
+	// Empty uses the system temp directory.
+	TempDir string `yaml:"temp_dir,omitempty" mapstructure:"temp_dir"`
+
+	// TrackChanges records message edits and deletions found on re-render
+	// in a changes.log inside each date folder.
+	TrackChanges bool `yaml:"track_changes,omitempty" mapstructure:"track_changes"`
+
+	// TrackMembership logs channels you joined, left, or saw renamed or
+	// archived between syncs in a membership-changes.md inside each date
+	// folder.
+	TrackMembership bool `yaml:"track_membership,omitempty" mapstructure:"track_membership"`
+
+	// ChannelEvents writes the channels created or archived each day, as
+	// far as userBoot reports them, to a channel-events.md inside each date
+	// folder.
+	ChannelEvents bool `yaml:"channel_events,omitempty" mapstructure:"channel_events"`
+
+	// ReadState records how far you had read each tracked channel when a
+	// sync ran in that day's manifest.json, for compliance records of what
+	// was seen, not just what was said.
+	ReadState bool `yaml:"read_state,omitempty" mapstructure:"read_state"`
+
+	// ExpandCanvases embeds the content of linked Slack canvases and posts
+	// below the linking message.
+	ExpandCanvases bool `yaml:"expand_canvases,omitempty" mapstructure:"expand_canvases"`
+
+	// MaxDailyOutputSize caps the rendered size of one work day across all
+	// channels. OutputSizeAction is "abort" (default) or "warn".
+	MaxDailyOutputSize string `yaml:"max_daily_output_size,omitempty" mapstructure:"max_daily_output_size"`
+	OutputSizeAction   string `yaml:"output_size_action,omitempty" mapstructure:"output_size_action" jsonschema:"enum=abort|warn"`
+
+	// Workspace selects which slackdump workspace's credentials to use.
+	// Empty uses slackdump's current workspace (workspace.txt).
+	Workspace string `yaml:"workspace,omitempty" mapstructure:"workspace"`
+
+	// RenderBlocks renders messages from their rich_text blocks as markdown,
+	// keeping bold, lists, quotes and code blocks, instead of the plain text.
+	RenderBlocks bool `yaml:"render_blocks,omitempty" mapstructure:"render_blocks"`
+
+	// IncludeUnfurls renders link previews and other attachments' title,
+	// text and footer as a blockquote under the message.
+	IncludeUnfurls bool `yaml:"include_unfurls,omitempty" mapstructure:"include_unfurls"`
+
+	// MessageInclude and MessageExclude are regular expressions matched
+	// against each message's text. A message matching any exclude pattern
+	// is dropped; when include patterns are set, so is one matching none.
+	MessageInclude []string `yaml:"message_include,omitempty" mapstructure:"message_include"`
+	MessageExclude []string `yaml:"message_exclude,omitempty" mapstructure:"message_exclude"`
+
+	// TimeFormat, TimeClock and TimeLocale control message timestamps: a Go
+	// layout or strftime format, "12h" or "24h", and the language of month
+	// and weekday names. Empty keeps the default UTC timestamps.
+	TimeFormat string `yaml:"time_format,omitempty" mapstructure:"time_format"`
+	TimeClock  string `yaml:"time_clock,omitempty" mapstructure:"time_clock" jsonschema:"enum=12h|24h"`
+	TimeLocale string `yaml:"time_locale,omitempty" mapstructure:"time_locale"`
+
+	// StatusAudit records presence and status changes seen by each sync in
+	// <date>/status.md: "self" for your own, "all" to add every member's
+	// custom status. Empty disables the audit.
+	StatusAudit string `yaml:"status_audit,omitempty" mapstructure:"status_audit" jsonschema:"enum=self|all"`
+
+	// Reminders writes your pending reminders and scheduled messages to
+	// <date>/reminders.md on each sync.
+	Reminders bool `yaml:"reminders,omitempty" mapstructure:"reminders"`
+
+	// UserProfiles snapshots every member's title and custom profile fields
+	// once a day into user-profiles.json, keeping a dated copy whenever
+	// they change.
+	UserProfiles bool `yaml:"user_profiles,omitempty" mapstructure:"user_profiles"`
+
+	// Templates maps channel name/ID glob patterns to Go text/template
+	// sources that render each message in matching channels. The longest
+	// matching pattern wins; other channels keep the default format.
+	Templates map[string]string `yaml:"templates,omitempty" mapstructure:"templates"`
+
+	// ThreadLookbackDays makes daily syncs revisit threads started up to
+	// this many days ago, so late replies land in today's thread
+	// continuations with their parent as context. Zero keeps lookback and
+	// skip_stale_threads as configured.
+	ThreadLookbackDays int `yaml:"thread_lookback_days,omitempty" mapstructure:"thread_lookback_days"`
+
+	// BackfillNewChannelsDays limits the history fetched for channels a
+	// sync archives for the first time to this many days. Zero fetches
+	// them from the archive's seed date.
+	BackfillNewChannelsDays int `yaml:"backfill_new_channels_days,omitempty" mapstructure:"backfill_new_channels_days"`
+
+	// ParticipatedOnly skips a channel's day unless you wrote one of the
+	// messages rendered for it, including thread replies.
+	ParticipatedOnly bool `yaml:"participated_only,omitempty" mapstructure:"participated_only"`
+
+	// MaxSyncDays stops a daily sync whose archive is further behind than
+	// this many days until the catch-up is confirmed or chunked. Zero
+	// disables the check.
+	MaxSyncDays int `yaml:"max_sync_days,omitempty" mapstructure:"max_sync_days"`
+
+	// SlackdumpArgs are extra -flag or -flag=value arguments appended to
+	// every slackdump archive and resume run, such as -member-only.
+	SlackdumpArgs []string `yaml:"slackdump_args,omitempty" mapstructure:"slackdump_args"`
+
+	// Translation writes translated copies of rendered files; see
+	// Translation.
+	Translation Translation `yaml:"translation,omitempty" mapstructure:"translation"`
+
+	// AttachmentProcessing transcribes or OCRs files downloaded by the
+	// files command; see AttachmentProcessing.
+	AttachmentProcessing AttachmentProcessing `yaml:"attachment_processing,omitempty" mapstructure:"attachment_processing"`
+
+	// Report writes or mails a summary after each sync; see Report.
+	Report Report `yaml:"report,omitempty" mapstructure:"report"`
+
+	// Tracing exports OpenTelemetry spans of each run; see Tracing.
+	Tracing Tracing `yaml:"tracing,omitempty" mapstructure:"tracing"`
+
+	// DateIndex writes an index.md in each date folder listing its files by
+	// channel type with message counts. IndexOrder orders each group:
+	// "alpha" (default), "activity", or "priority" (include pattern order).
+	DateIndex  bool   `yaml:"date_index,omitempty" mapstructure:"date_index"`
+	IndexOrder string `yaml:"index_order,omitempty" mapstructure:"index_order" jsonschema:"enum=alpha|activity|priority"`
+
+	// Mbox writes an mbox copy of each channel-day beside its markdown,
+	// one mail per message, for import into mail clients.
+	Mbox bool `yaml:"mbox,omitempty" mapstructure:"mbox"`
+
+	// KeepRaw also writes each channel-day's messages as slackdump archived
+	// them to raw/<date>/<channel>.json in the output directory, so later
+	// formatters can reprocess them without fetching from Slack again.
+	KeepRaw bool `yaml:"keep_raw,omitempty" mapstructure:"keep_raw"`
+
+	// Avatars downloads the profile photo of everyone who posted in a
+	// rendered channel-day into avatars/ in the output directory.
+	Avatars bool `yaml:"avatars,omitempty" mapstructure:"avatars"`
+
+	// Checksums keeps a SHA256SUMS file in each date folder listing the
+	// SHA-256 of every exported file, for "verify --checksums".
+	Checksums bool `yaml:"checksums,omitempty" mapstructure:"checksums"`
+
+	// VerifyCounts compares this many of each date's busiest exported
+	// channels with Slack's message counts after a render and records the
+	// result in the date's manifest.json. Zero disables verification.
+	VerifyCounts int `yaml:"verify_counts,omitempty" mapstructure:"verify_counts"`
+
+	// VerifyWrites re-reads each rendered file after writing it and fails
+	// the run when its checksum does not match.
+	VerifyWrites bool `yaml:"verify_writes,omitempty" mapstructure:"verify_writes"`
+
+	// Anonymize replaces user names with stable pseudonyms (User-A, ...) and
+	// strips email addresses and phone numbers from rendered output.
+	Anonymize bool `yaml:"anonymize,omitempty" mapstructure:"anonymize"`
+
+	// EdgeRPS paces Slack API requests to this many per second. Zero sends
+	// requests unpaced; rate-limited responses are retried either way.
+	EdgeRPS float64 `yaml:"edge_rps,omitempty" mapstructure:"edge_rps"`
+
+	// APIHost is the domain of the Slack deployment serving the workspace,
+	// e.g. slack-gov.com. Empty uses slack.com, switching to the workspace's
+	// own host when auth.test reports one outside slack.com.
+	APIHost string `yaml:"api_host,omitempty" mapstructure:"api_host"`
+
+	// UsersCacheTTL keeps the workspace's users.list result on disk and
+	// reuses it for this long, e.g. "12h" or "7d", instead of fetching every
+	// member on each run. Empty fetches the list every time.
+	UsersCacheTTL string `yaml:"users_cache_ttl,omitempty" mapstructure:"users_cache_ttl"`
+
+	// ChannelDiscovery selects how active channels are found: "edge" (the
+	// Edge API), "webapi" (conversations.list and conversations.history, for
+	// networks that block the Edge endpoints), or "auto" (default: Edge,
+	// falling back to the Web API when it is unreachable).
+	ChannelDiscovery string `yaml:"channel_discovery,omitempty" mapstructure:"channel_discovery" jsonschema:"enum=edge|webapi|auto"`
+
+	// Targets fans one sync out to several output directories, each with its
+	// own include/exclude patterns. When set, the top-level include, exclude,
+	// and output_dir are ignored for rendering.
+	Targets []OutputTarget `yaml:"targets,omitempty" mapstructure:"targets"`
+
 	configFile string // path to the config file used (if any)
 }
 
@@ -33,17 +252,11 @@ func (c *Config) ConfigFile() string {
 
 // Load reads configuration from YAML file and environment variables.
 // Search order: explicit path > ~/.config/slack-export/slack-export.yaml
+// A file with extends is layered over its base files (see applyExtends).
 // Environment variables with SLACK_EXPORT_ prefix override file values.
 func Load(path string) (*Config, error) {
 	v := viper.New()
-
-	v.SetDefault("output_dir", "./slack-logs")
-	v.SetDefault("timezone", "America/New_York")
-	v.SetDefault("archive_dir", "~/.local/share/slack-export/archive")
-	v.SetDefault("seed_date", "")
-	v.SetDefault("lookback", "7d")
-	v.SetDefault("skip_stale_threads", "21d")
-	v.SetDefault("skip_complete_threads", true)
+	setDefaults(v)
 
 	v.SetEnvPrefix("SLACK_EXPORT")
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
@@ -65,6 +278,10 @@ func Load(path string) (*Config, error) {
 		}
 	}
 
+	if err := applyExtends(v); err != nil {
+		return nil, err
+	}
+
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
@@ -74,12 +291,54 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// Default returns the built-in defaults without reading a config file or the
+// environment, for embedding programs that configure everything in code.
+func Default() *Config {
+	v := viper.New()
+	setDefaults(v)
+	var cfg Config
+	// Decoding the built-in defaults cannot fail.
+	_ = v.Unmarshal(&cfg)
+	return &cfg
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("output_dir", "./slack-logs")
+	v.SetDefault("timezone", "America/New_York")
+	v.SetDefault("archive_dir", "~/.local/share/slack-export/archive")
+	v.SetDefault("seed_date", "")
+	v.SetDefault("lookback", "7d")
+	v.SetDefault("skip_stale_threads", "21d")
+	v.SetDefault("skip_complete_threads", true)
+	v.SetDefault("workspace", "")
+}
+
 // Validate checks that the configuration is valid.
-// It validates the timezone and ensures the output directory exists (creating it if needed).
+// It validates the timezones and ensures the output directory exists (creating it if needed).
 func (c *Config) Validate() error {
 	if _, err := time.LoadLocation(c.Timezone); err != nil {
 		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
 	}
+	for pattern, tz := range c.ChannelTimezones {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid timezone %q for channel pattern %q: %w", tz, pattern, err)
+		}
+	}
+	if err := c.validateChannelAliases(); err != nil {
+		return err
+	}
+	if err := c.validateLimits(); err != nil {
+		return err
+	}
+	if err := c.validateRendering(); err != nil {
+		return err
+	}
+	if err := c.validateExternalTools(); err != nil {
+		return err
+	}
+	if len(c.Targets) > 0 {
+		return c.validateTargets()
+	}
 	if err := os.MkdirAll(c.OutputDir, 0750); err != nil {
 		return fmt.Errorf("cannot create output directory %q: %w", c.OutputDir, err)
 	}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -35,6 +36,18 @@ func TestLoad_Defaults(t *testing.T) {
 	}
 }
 
+func TestDefault_IgnoresEnvironment(t *testing.T) {
+	t.Setenv("SLACK_EXPORT_OUTPUT_DIR", "/from/env")
+
+	cfg := Default()
+	if cfg.OutputDir != "./slack-logs" || cfg.Timezone != "America/New_York" || !cfg.SkipCompleteThreads {
+		t.Errorf("Default() = %+v, want built-in defaults", cfg)
+	}
+	if cfg.ConfigFile() != "" {
+		t.Errorf("ConfigFile() = %q, want empty", cfg.ConfigFile())
+	}
+}
+
 func TestLoad_ExplicitPath(t *testing.T) {
 	dir := t.TempDir()
 	configPath := filepath.Join(dir, "test-config.yaml")
@@ -288,6 +301,99 @@ func TestValidate_InvalidTimezone(t *testing.T) {
 	}
 }
 
+func TestValidate_InvalidChannelTimezone(t *testing.T) {
+	cfg := &Config{
+		OutputDir:        t.TempDir(),
+		Timezone:         "UTC",
+		ChannelTimezones: map[string]string{"tokyo-*": "Asia/Nowhere"},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() expected error for invalid channel timezone, got nil")
+	}
+	if !strings.Contains(err.Error(), "tokyo-*") {
+		t.Errorf("Validate() error = %v, want pattern in message", err)
+	}
+}
+
+func TestLoad_ChannelTimezones(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "test-config.yaml")
+
+	content := `channel_timezones:
+  "tokyo-*": Asia/Tokyo
+  "london-office": Europe/London
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.ChannelTimezones["tokyo-*"] != "Asia/Tokyo" {
+		t.Errorf("ChannelTimezones[tokyo-*] = %q, want Asia/Tokyo", cfg.ChannelTimezones["tokyo-*"])
+	}
+	if cfg.ChannelTimezones["london-office"] != "Europe/London" {
+		t.Errorf("ChannelTimezones[london-office] = %q, want Europe/London", cfg.ChannelTimezones["london-office"])
+	}
+}
+
+func TestValidate_InvalidMaxFileSize(t *testing.T) {
+	cfg := &Config{
+		OutputDir:   t.TempDir(),
+		Timezone:    "UTC",
+		MaxFileSize: "huge",
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() expected error for invalid max_file_size, got nil")
+	}
+	if !strings.Contains(err.Error(), "max_file_size") {
+		t.Errorf("Validate() error = %v, want max_file_size in message", err)
+	}
+}
+
+func TestValidate_NegativeMaxMessagesPerFile(t *testing.T) {
+	cfg := &Config{
+		OutputDir:          t.TempDir(),
+		Timezone:           "UTC",
+		MaxMessagesPerFile: -1,
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("Validate() expected error for negative max_messages_per_file, got nil")
+	}
+}
+
+func TestLoad_SplitLimits(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "test-config.yaml")
+
+	content := `max_file_size: 2MB
+max_messages_per_file: 500
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.MaxFileSize != "2MB" {
+		t.Errorf("MaxFileSize = %q, want 2MB", cfg.MaxFileSize)
+	}
+	if cfg.MaxMessagesPerFile != 500 {
+		t.Errorf("MaxMessagesPerFile = %d, want 500", cfg.MaxMessagesPerFile)
+	}
+}
+
 func TestValidate_CreatesOutputDir(t *testing.T) {
 	dir := t.TempDir()
 	nested := filepath.Join(dir, "a", "b", "c")
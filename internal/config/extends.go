@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// maxExtendsDepth bounds extends chains so a mistake fails clearly instead
+// of recursing through a long chain of files.
+const maxExtendsDepth = 10
+
+// applyExtends layers the files named by extends under the config already
+// read into v. Each file is merged in order from the outermost base to the
+// file itself, so nearer files win; maps merge key by key and lists are
+// replaced whole.
+func applyExtends(v *viper.Viper) error {
+	file := v.ConfigFileUsed()
+	if file == "" || !v.InConfig("extends") {
+		return nil
+	}
+	layers, err := readLayers(file, nil)
+	if err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		if err := v.MergeConfigMap(layer); err != nil {
+			return fmt.Errorf("cannot merge config %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// readLayers reads path and, recursively, the file it extends, returning
+// them base first. chain holds the files already being read, to detect
+// cycles.
+func readLayers(path string, chain []string) ([]map[string]any, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, seen := range chain {
+		if seen == abs {
+			return nil, fmt.Errorf("config extends cycle: %s", strings.Join(append(chain, abs), " -> "))
+		}
+	}
+	if len(chain) >= maxExtendsDepth {
+		return nil, fmt.Errorf("config extends chain deeper than %d files at %s", maxExtendsDepth, abs)
+	}
+	chain = append(chain, abs)
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config: %w", err)
+	}
+	layer := map[string]any{}
+	if err := yaml.Unmarshal(data, &layer); err != nil {
+		return nil, fmt.Errorf("cannot parse config %s: %w", abs, err)
+	}
+
+	base, ok := layer["extends"]
+	if !ok || base == nil || base == "" {
+		return []map[string]any{layer}, nil
+	}
+	basePath, ok := base.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s: extends must be a file path, got %v", abs, base)
+	}
+	basePath, err = resolveExtends(filepath.Dir(abs), basePath)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := readLayers(basePath, chain)
+	if err != nil {
+		return nil, err
+	}
+	return append(layers, layer), nil
+}
+
+// resolveExtends expands a leading ~ and makes a relative extends path
+// relative to the directory of the file naming it.
+func resolveExtends(dir, path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand %q: %w", path, err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path, nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_ExtendsOverlaysBase(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "team", "base.yaml"), `timezone: "Europe/London"
+include: ["eng-*", "team-*"]
+exclude: ["*-random"]
+translation:
+  command: "translate"
+  language: "de"
+`)
+	writeConfigFile(t, filepath.Join(dir, "user.yaml"), `extends: team/base.yaml
+output_dir: "/home/me/slack"
+include: ["eng-*"]
+translation:
+  language: "fr"
+`)
+
+	cfg, err := Load(filepath.Join(dir, "user.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.OutputDir != "/home/me/slack" || cfg.Timezone != "Europe/London" {
+		t.Errorf("OutputDir, Timezone = %q, %q", cfg.OutputDir, cfg.Timezone)
+	}
+	if !slices.Equal(cfg.Include, []string{"eng-*"}) {
+		t.Errorf("Include = %v, want the overlay's list", cfg.Include)
+	}
+	if !slices.Equal(cfg.Exclude, []string{"*-random"}) {
+		t.Errorf("Exclude = %v, want the base's list", cfg.Exclude)
+	}
+	if cfg.Translation.Command != "translate" || cfg.Translation.Language != "fr" {
+		t.Errorf("Translation = %+v, want merged keys", cfg.Translation)
+	}
+	if cfg.Lookback != "7d" {
+		t.Errorf("Lookback = %q, want default", cfg.Lookback)
+	}
+}
+
+func TestLoad_ExtendsChainAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "org.yaml"), "lookback: 14d\ntimezone: Asia/Tokyo\n")
+	writeConfigFile(t, filepath.Join(dir, "team.yaml"), "extends: org.yaml\ntimezone: Europe/Paris\n")
+	writeConfigFile(t, filepath.Join(dir, "user.yaml"), "extends: team.yaml\n")
+	t.Setenv("SLACK_EXPORT_TIMEZONE", "UTC")
+
+	cfg, err := Load(filepath.Join(dir, "user.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Lookback != "14d" || cfg.Timezone != "UTC" {
+		t.Errorf("Lookback, Timezone = %q, %q", cfg.Lookback, cfg.Timezone)
+	}
+}
+
+func TestLoad_ExtendsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "a.yaml"), "extends: b.yaml\n")
+	writeConfigFile(t, filepath.Join(dir, "b.yaml"), "extends: a.yaml\n")
+	writeConfigFile(t, filepath.Join(dir, "missing.yaml"), "extends: nowhere.yaml\n")
+
+	if _, err := Load(filepath.Join(dir, "a.yaml")); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("cycle error = %v", err)
+	}
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err == nil || !strings.Contains(err.Error(), "nowhere.yaml") {
+		t.Errorf("missing base error = %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadPatternFile reads channel names, IDs, or glob patterns from path, one
+// per line. Blank lines and lines starting with # are skipped.
+func ReadPatternFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read channel list: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read channel list: %w", err)
+	}
+	if len(patterns) == 0 {
+		return nil, errors.New("channel list " + path + " has no channels")
+	}
+	return patterns, nil
+}
+
+// OverrideInclude replaces the include patterns for one run, including each
+// target's, so every output only receives the listed channels. Exclude
+// patterns still apply.
+func (c *Config) OverrideInclude(patterns []string) {
+	c.Include = patterns
+	for i := range c.Targets {
+		c.Targets[i].Include = patterns
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadPatternFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "channels.txt")
+	content := "# March audit\neng-backend\n\n  C0123ABC  \nsales-*\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+
+	got, err := ReadPatternFile(path)
+	if err != nil {
+		t.Fatalf("ReadPatternFile() error = %v", err)
+	}
+	want := []string{"eng-backend", "C0123ABC", "sales-*"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadPatternFile() = %v, want %v", got, want)
+	}
+}
+
+func TestReadPatternFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("# nothing yet\n\n"), 0600); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+
+	if _, err := ReadPatternFile(empty); err == nil || !strings.Contains(err.Error(), "has no channels") {
+		t.Errorf("empty list error = %v", err)
+	}
+	if _, err := ReadPatternFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("missing file should return an error")
+	}
+}
+
+func TestOverrideInclude(t *testing.T) {
+	cfg := &Config{
+		Include: []string{"*"},
+		Exclude: []string{"random"},
+		Targets: []OutputTarget{
+			{Name: "work", Include: []string{"eng-*"}, Exclude: []string{"eng-x"}},
+			{Name: "personal", Include: []string{"dm_*"}},
+		},
+	}
+
+	cfg.OverrideInclude([]string{"eng-backend", "C0123ABC"})
+
+	want := []string{"eng-backend", "C0123ABC"}
+	if !reflect.DeepEqual(cfg.Include, want) {
+		t.Errorf("Include = %v, want %v", cfg.Include, want)
+	}
+	for _, target := range cfg.Targets {
+		if !reflect.DeepEqual(target.Include, want) {
+			t.Errorf("target %s Include = %v, want %v", target.Name, target.Include, want)
+		}
+	}
+	if !reflect.DeepEqual(cfg.Exclude, []string{"random"}) || !reflect.DeepEqual(cfg.Targets[0].Exclude, []string{"eng-x"}) {
+		t.Error("OverrideInclude should leave exclude patterns unchanged")
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+)
+
+// Report configures a summary of each sync, written to a file and/or sent
+// by mail so unattended runs can be checked without reading logs.
+type Report struct {
+	// Format is "markdown" (default) or "html".
+	Format string `yaml:"format,omitempty" mapstructure:"format" jsonschema:"enum=markdown|html"`
+	// Path, when set, is overwritten with the latest report.
+	Path string `yaml:"path,omitempty" mapstructure:"path"`
+	// TopChannels is how many of the most active channels are listed.
+	// Zero lists five.
+	TopChannels int `yaml:"top_channels,omitempty" mapstructure:"top_channels"`
+	// SMTP sends the report by mail when Host is set.
+	SMTP ReportSMTP `yaml:"smtp,omitempty" mapstructure:"smtp"`
+}
+
+// ReportSMTP is the mail server a report is sent through. The connection
+// is upgraded with STARTTLS when the server offers it.
+type ReportSMTP struct {
+	Host string `yaml:"host,omitempty" mapstructure:"host"`
+	// Port defaults to 587.
+	Port     int    `yaml:"port,omitempty" mapstructure:"port"`
+	Username string `yaml:"username,omitempty" mapstructure:"username"`
+	// Password may be left empty and supplied in SLACK_EXPORT_SMTP_PASSWORD.
+	Password string   `yaml:"password,omitempty" mapstructure:"password"`
+	From     string   `yaml:"from,omitempty" mapstructure:"from"`
+	To       []string `yaml:"to,omitempty" mapstructure:"to"`
+}
+
+// Enabled reports whether a report is written or sent.
+func (r Report) Enabled() bool {
+	return r.Path != "" || r.SMTP.Host != ""
+}
+
+// ReportFormat returns Format, or "markdown" when it is unset.
+func (r Report) ReportFormat() string {
+	if r.Format == "" {
+		return "markdown"
+	}
+	return r.Format
+}
+
+// TopChannelCount returns TopChannels, or 5 when it is unset.
+func (r Report) TopChannelCount() int {
+	if r.TopChannels == 0 {
+		return 5
+	}
+	return r.TopChannels
+}
+
+// SMTPPort returns Port, or 587 when it is unset.
+func (s ReportSMTP) SMTPPort() int {
+	if s.Port == 0 {
+		return 587
+	}
+	return s.Port
+}
+
+func (c *Config) validateReport() error {
+	r := c.Report
+	switch r.ReportFormat() {
+	case "markdown", "html":
+	default:
+		return fmt.Errorf("report: format must be markdown or html, got %q", r.Format)
+	}
+	if r.TopChannels < 0 {
+		return errors.New("report: top_channels must not be negative")
+	}
+	s := r.SMTP
+	if s.Host == "" {
+		return nil
+	}
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("report: smtp port %d is out of range", s.Port)
+	}
+	if _, err := mail.ParseAddress(s.From); err != nil {
+		return fmt.Errorf("report: smtp from %q is not a mail address", s.From)
+	}
+	if len(s.To) == 0 {
+		return errors.New("report: smtp needs at least one to address")
+	}
+	for _, to := range s.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("report: smtp to %q is not a mail address", to)
+		}
+	}
+	return nil
+}
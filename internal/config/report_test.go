@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestValidate_Report(t *testing.T) {
+	smtp := ReportSMTP{Host: "smtp.example.com", From: "slack-export@example.com", To: []string{"team@example.com"}}
+	tests := []struct {
+		name    string
+		cfg     Report
+		wantErr bool
+	}{
+		{"disabled", Report{}, false},
+		{"file", Report{Path: "/tmp/report.html", Format: "html"}, false},
+		{"smtp", Report{SMTP: smtp}, false},
+		{"bad format", Report{Path: "/tmp/report.txt", Format: "text"}, true},
+		{"no recipients", Report{SMTP: ReportSMTP{Host: "smtp.example.com", From: "a@example.com"}}, true},
+		{"bad from", Report{SMTP: ReportSMTP{Host: "smtp.example.com", From: "nobody", To: smtp.To}}, true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", Report: tt.cfg}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
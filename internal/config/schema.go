@@ -0,0 +1,64 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Schema returns a JSON Schema describing the YAML config file, for editor
+// validation and completion. It is derived from Config by reflection so it
+// cannot drift: property names come from the yaml tags, types from the Go
+// field types, and the built-in defaults fill in "default". A
+// jsonschema:"enum=a|b" tag limits a string field to the listed values.
+func Schema() map[string]any {
+	schema := typeSchema(reflect.TypeOf(Config{}), reflect.ValueOf(*Default()))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "slack-export configuration"
+	return schema
+}
+
+// typeSchema describes t. For structs, non-zero fields of value (which may
+// be invalid) become defaults.
+func typeSchema(t reflect.Type, value reflect.Value) map[string]any {
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			var fieldValue reflect.Value
+			if value.IsValid() {
+				fieldValue = value.Field(i)
+			}
+			prop := typeSchema(field.Type, fieldValue)
+			if values, ok := strings.CutPrefix(field.Tag.Get("jsonschema"), "enum="); ok {
+				prop["enum"] = strings.Split(values, "|")
+			}
+			if fieldValue.IsValid() && !fieldValue.IsZero() && field.Type.Kind() != reflect.Struct {
+				prop["default"] = fieldValue.Interface()
+			}
+			properties[name] = prop
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), reflect.Value{})}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), reflect.Value{})}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
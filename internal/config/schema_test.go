@@ -0,0 +1,54 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSchema_CoversEveryConfigKey(t *testing.T) {
+	properties := Schema()["properties"].(map[string]any)
+
+	typ := reflect.TypeOf(Config{})
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if _, ok := properties[name]; !ok {
+			t.Errorf("schema is missing %s (field %s)", name, field.Name)
+		}
+	}
+}
+
+func TestSchema_TypesDefaultsAndEnums(t *testing.T) {
+	properties := Schema()["properties"].(map[string]any)
+	prop := func(name string) map[string]any { return properties[name].(map[string]any) }
+
+	if got := prop("timezone")["default"]; got != "America/New_York" {
+		t.Errorf("timezone default = %v", got)
+	}
+	if got := prop("skip_complete_threads"); got["type"] != "boolean" || got["default"] != true {
+		t.Errorf("skip_complete_threads = %v", got)
+	}
+	if got := prop("edge_rps")["type"]; got != "number" {
+		t.Errorf("edge_rps type = %v, want number", got)
+	}
+	if got := prop("index_order")["enum"]; !slices.Equal(got.([]string), []string{"alpha", "activity", "priority"}) {
+		t.Errorf("index_order enum = %v", got)
+	}
+	if got := prop("channel_timezones")["additionalProperties"].(map[string]any)["type"]; got != "string" {
+		t.Errorf("channel_timezones values = %v, want string", got)
+	}
+
+	target := prop("targets")["items"].(map[string]any)["properties"].(map[string]any)
+	if _, ok := target["output_dir"]; !ok {
+		t.Errorf("targets items missing output_dir: %v", target)
+	}
+	translation := prop("translation")["properties"].(map[string]any)
+	if _, ok := translation["language"]; !ok {
+		t.Errorf("translation missing language: %v", translation)
+	}
+}
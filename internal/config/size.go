@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseByteSize parses a size such as "512KB", "2MB", or "1048576" into bytes.
+// Units are binary (1KB = 1024 bytes) and case-insensitive. An empty string is 0.
+func ParseByteSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, nil
+	}
+	multiplier := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("expected a non-negative size like 2MB")
+	}
+	return n * multiplier, nil
+}
+
+func (c *Config) validateLimits() error {
+	if _, err := ParseByteSize(c.MaxFileSize); err != nil {
+		return fmt.Errorf("invalid max_file_size %q: %w", c.MaxFileSize, err)
+	}
+	if _, err := ParseByteSize(c.MaxDailyOutputSize); err != nil {
+		return fmt.Errorf("invalid max_daily_output_size %q: %w", c.MaxDailyOutputSize, err)
+	}
+	switch c.OutputSizeAction {
+	case "", "warn", "abort":
+	default:
+		return fmt.Errorf("output_size_action must be warn or abort, got %q", c.OutputSizeAction)
+	}
+	switch c.StatusAudit {
+	case "", "self", "all":
+	default:
+		return fmt.Errorf("status_audit must be self or all, got %q", c.StatusAudit)
+	}
+	if c.MaxMessagesPerFile < 0 {
+		return fmt.Errorf("max_messages_per_file must not be negative, got %d", c.MaxMessagesPerFile)
+	}
+	if c.ThreadLookbackDays < 0 {
+		return fmt.Errorf("thread_lookback_days must not be negative, got %d", c.ThreadLookbackDays)
+	}
+	if c.BackfillNewChannelsDays < 0 {
+		return fmt.Errorf("backfill_new_channels_days must not be negative, got %d", c.BackfillNewChannelsDays)
+	}
+	if c.VerifyCounts < 0 {
+		return fmt.Errorf("verify_counts must not be negative, got %d", c.VerifyCounts)
+	}
+	if c.MaxSyncDays < 0 {
+		return fmt.Errorf("max_sync_days must not be negative, got %d", c.MaxSyncDays)
+	}
+	if c.EdgeRPS < 0 {
+		return fmt.Errorf("edge_rps must not be negative, got %g", c.EdgeRPS)
+	}
+	if _, err := parseCacheTTL(c.UsersCacheTTL); err != nil {
+		return fmt.Errorf("invalid users_cache_ttl %q: %w", c.UsersCacheTTL, err)
+	}
+	switch c.ChannelDiscovery {
+	case "", "edge", "webapi", "auto":
+	default:
+		return fmt.Errorf("channel_discovery must be edge, webapi, or auto, got %q", c.ChannelDiscovery)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"", 0},
+		{"0", 0},
+		{"1024", 1024},
+		{"512B", 512},
+		{"4KB", 4096},
+		{"2mb", 2 << 20},
+		{" 1 GB ", 1 << 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseByteSize(tt.input)
+			if err != nil {
+				t.Fatalf("ParseByteSize(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseByteSize_Invalid(t *testing.T) {
+	for _, input := range []string{"MB", "-1KB", "2TB", "big"} {
+		if _, err := ParseByteSize(input); err == nil {
+			t.Errorf("ParseByteSize(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestValidate_OutputSizeGuard(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    string
+		action  string
+		wantErr bool
+	}{
+		{"unset", "", "", false},
+		{"abort", "50MB", "abort", false},
+		{"warn", "50MB", "warn", false},
+		{"bad size", "lots", "", true},
+		{"bad action", "50MB", "explode", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				OutputDir:          t.TempDir(),
+				Timezone:           "UTC",
+				MaxDailyOutputSize: tt.size,
+				OutputSizeAction:   tt.action,
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_StatusAudit(t *testing.T) {
+	for value, wantErr := range map[string]bool{"": false, "self": false, "all": false, "everyone": true} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", StatusAudit: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(status_audit=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_ChannelDiscovery(t *testing.T) {
+	for value, wantErr := range map[string]bool{"": false, "edge": false, "webapi": false, "auto": false, "rtm": true} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", ChannelDiscovery: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(channel_discovery=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_NegativeThreadLookbackDays(t *testing.T) {
+	cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", ThreadLookbackDays: -1}
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("Validate() expected error for negative thread_lookback_days, got nil")
+	}
+}
+
+func TestValidate_NegativeBackfillNewChannelsDays(t *testing.T) {
+	cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", BackfillNewChannelsDays: -1}
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("Validate() expected error for negative backfill_new_channels_days, got nil")
+	}
+}
+
+func TestValidate_NegativeMaxSyncDays(t *testing.T) {
+	cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", MaxSyncDays: -1}
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("Validate() expected error for negative max_sync_days, got nil")
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// managedSlackdumpFlags are flags slack-export sets itself. Overriding them
+// would archive to another place or workspace, drop archive rows, or break
+// the coverage and lookback bookkeeping.
+var managedSlackdumpFlags = map[string]bool{
+	"o":                  true,
+	"output":             true,
+	"base":               true,
+	"workspace":          true,
+	"dedupe":             true,
+	"time-from":          true,
+	"time-to":            true,
+	"lookback":           true,
+	"skip-stale-threads": true,
+	"threads":            true,
+}
+
+// validateSlackdumpArgs requires each extra argument to be a single
+// -flag or -flag=value, so no entry can add channels or paths as
+// positional arguments, and rejects the flags slack-export manages.
+func (c *Config) validateSlackdumpArgs() error {
+	for _, arg := range c.SlackdumpArgs {
+		if !strings.HasPrefix(arg, "-") || strings.TrimLeft(arg, "-") == "" {
+			return fmt.Errorf("slackdump_args entry %q must be a flag; write values as -flag=value", arg)
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if managedSlackdumpFlags[name] {
+			return fmt.Errorf("slackdump_args must not set -%s; slack-export manages it", name)
+		}
+	}
+	return nil
+}
+
+// validateExternalTools checks the options passed on to other programs.
+func (c *Config) validateExternalTools() error {
+	if err := c.validateSlackdumpArgs(); err != nil {
+		return err
+	}
+	if err := c.validateAPIHost(); err != nil {
+		return err
+	}
+	if err := c.validateTranslation(); err != nil {
+		return err
+	}
+	if err := c.validateTracing(); err != nil {
+		return err
+	}
+	return c.validateReport()
+}
+
+// validateAPIHost accepts a host name, optionally written as an https URL.
+func (c *Config) validateAPIHost() error {
+	if c.APIHost == "" {
+		return nil
+	}
+	host := c.APIHost
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil || u.Scheme != "https" || strings.Trim(u.Path, "/") != "" {
+			return fmt.Errorf("api_host must be a host name such as slack-gov.com, got %q", c.APIHost)
+		}
+		host = u.Host
+	}
+	if !strings.Contains(host, ".") || strings.ContainsAny(host, " /?#@") {
+		return fmt.Errorf("api_host must be a host name such as slack-gov.com, got %q", c.APIHost)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import "testing"
+
+func TestValidate_SlackdumpArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"-member-only", "-api-config=limits.toml"}, false},
+		{[]string{"--files=true"}, false},
+		{[]string{"-o", "/tmp/elsewhere"}, true},
+		{[]string{"-workspace=other"}, true},
+		{[]string{"--dedupe"}, true},
+		{[]string{"C0123456789"}, true},
+		{[]string{"--"}, true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", SlackdumpArgs: tt.args}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(slackdump_args=%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_APIHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		wantErr bool
+	}{
+		{"", false},
+		{"slack-gov.com", false},
+		{"https://slack-gov.com/", false},
+		{"http://slack-gov.com", true},
+		{"https://slack-gov.com/api", true},
+		{"localhost", true},
+		{"slack gov.com", true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", APIHost: tt.host}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(api_host=%q) error = %v, wantErr %v", tt.host, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// OutputTarget is a named output directory with its own channel filters.
+// OutputDir may be a template using {{.Section}}, the channel's Slack
+// sidebar section, to split the target into one directory per section.
+type OutputTarget struct {
+	Name      string   `yaml:"name" mapstructure:"name"`
+	Include   []string `yaml:"include,omitempty" mapstructure:"include"`
+	Exclude   []string `yaml:"exclude,omitempty" mapstructure:"exclude"`
+	OutputDir string   `yaml:"output_dir" mapstructure:"output_dir"`
+}
+
+// UsesSections reports whether OutputDir is a section template.
+func (t OutputTarget) UsesSections() bool {
+	return strings.Contains(t.OutputDir, "{{")
+}
+
+// SectionDir returns the output directory for channels in the named
+// sidebar section. Path separators in the name are replaced.
+func (t OutputTarget) SectionDir(section string) (string, error) {
+	if !t.UsesSections() {
+		return t.OutputDir, nil
+	}
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.OutputDir)
+	if err != nil {
+		return "", err
+	}
+	var dir strings.Builder
+	if err := tmpl.Execute(&dir, map[string]string{"Section": sectionDirName(section)}); err != nil {
+		return "", err
+	}
+	return dir.String(), nil
+}
+
+// RootDir returns the directory holding every section directory of a
+// templated OutputDir: the part before the first template action, cut back
+// to a whole directory. Other targets return OutputDir.
+func (t OutputTarget) RootDir() string {
+	if !t.UsesSections() {
+		return t.OutputDir
+	}
+	return filepath.Dir(t.OutputDir[:strings.Index(t.OutputDir, "{{")] + "x")
+}
+
+func sectionDirName(section string) string {
+	name := strings.TrimSpace(strings.NewReplacer("/", "_", `\`, "_").Replace(section))
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+// UsesSections reports whether any output target is split by sidebar
+// section, which needs the user's sections fetched on each sync.
+func (c *Config) UsesSections() bool {
+	for _, target := range c.Targets {
+		if target.UsesSections() {
+			return true
+		}
+	}
+	return false
+}
+
+// OutputTargets returns the configured targets, or a single unnamed target
+// built from the top-level include, exclude, and output_dir when none are set.
+func (c *Config) OutputTargets() []OutputTarget {
+	if len(c.Targets) > 0 {
+		return c.Targets
+	}
+	return []OutputTarget{{Include: c.Include, Exclude: c.Exclude, OutputDir: c.OutputDir}}
+}
+
+// OutputDirs returns every directory rendered files are written to. A
+// target split by section contributes its RootDir.
+func (c *Config) OutputDirs() []string {
+	targets := c.OutputTargets()
+	dirs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		dirs = append(dirs, target.RootDir())
+	}
+	return dirs
+}
+
+func (c *Config) validateTargets() error {
+	seen := make(map[string]bool, len(c.Targets))
+	for i, target := range c.Targets {
+		name := strings.TrimSpace(target.Name)
+		if name == "" {
+			return fmt.Errorf("targets[%d]: name is required", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("targets: duplicate name %q", name)
+		}
+		seen[name] = true
+		if strings.TrimSpace(target.OutputDir) == "" {
+			return fmt.Errorf("target %q: output_dir is required", name)
+		}
+		if _, err := target.SectionDir("Channels"); err != nil {
+			return fmt.Errorf("target %q: invalid output_dir template: %w", name, err)
+		}
+		if err := os.MkdirAll(target.RootDir(), 0750); err != nil {
+			return fmt.Errorf("target %q: cannot create output directory %q: %w", name, target.OutputDir, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad_Targets(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "test-config.yaml")
+
+	content := `targets:
+  - name: work
+    include: ["eng-*"]
+    exclude: ["eng-random"]
+    output_dir: /tmp/work
+  - name: personal
+    include: ["dm_*"]
+    output_dir: /tmp/personal
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []OutputTarget{
+		{Name: "work", Include: []string{"eng-*"}, Exclude: []string{"eng-random"}, OutputDir: "/tmp/work"},
+		{Name: "personal", Include: []string{"dm_*"}, OutputDir: "/tmp/personal"},
+	}
+	if !reflect.DeepEqual(cfg.Targets, want) {
+		t.Errorf("Targets = %+v, want %+v", cfg.Targets, want)
+	}
+	if got := cfg.OutputDirs(); !reflect.DeepEqual(got, []string{"/tmp/work", "/tmp/personal"}) {
+		t.Errorf("OutputDirs() = %v", got)
+	}
+}
+
+func TestOutputTargets_DefaultsToTopLevelFilters(t *testing.T) {
+	cfg := &Config{Include: []string{"eng-*"}, Exclude: []string{"eng-x"}, OutputDir: "./out"}
+
+	want := []OutputTarget{{Include: []string{"eng-*"}, Exclude: []string{"eng-x"}, OutputDir: "./out"}}
+	if got := cfg.OutputTargets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutputTargets() = %+v, want %+v", got, want)
+	}
+}
+
+func TestValidate_Targets(t *testing.T) {
+	base := t.TempDir()
+	tests := []struct {
+		name    string
+		targets []OutputTarget
+		wantErr string
+	}{
+		{"valid", []OutputTarget{{Name: "a", OutputDir: filepath.Join(base, "a")}}, ""},
+		{"missing name", []OutputTarget{{OutputDir: base}}, "name is required"},
+		{"duplicate name", []OutputTarget{{Name: "a", OutputDir: base}, {Name: "a", OutputDir: base}}, "duplicate"},
+		{"missing output dir", []OutputTarget{{Name: "a"}}, "output_dir is required"},
+		{"section template", []OutputTarget{{Name: "a", OutputDir: filepath.Join(base, "a", "{{.Section}}")}}, ""},
+		{"bad template", []OutputTarget{{Name: "a", OutputDir: filepath.Join(base, "{{.Sektion}}")}}, "invalid output_dir template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Timezone: "UTC", Targets: tt.targets}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if _, err := os.Stat(filepath.Join(base, "a")); err != nil {
+					t.Errorf("target output dir not created: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutputTarget_SectionDirs(t *testing.T) {
+	target := OutputTarget{Name: "work", OutputDir: "/slack/work-{{.Section}}/files"}
+	if !target.UsesSections() {
+		t.Fatal("UsesSections() = false for a templated output_dir")
+	}
+	got, err := target.SectionDir("Eng / Platform")
+	if err != nil || got != "/slack/work-Eng _ Platform/files" {
+		t.Errorf("SectionDir() = %q, %v", got, err)
+	}
+	if got := target.RootDir(); got != "/slack" {
+		t.Errorf("RootDir() = %q, want /slack", got)
+	}
+	if got := (OutputTarget{OutputDir: "/slack/{{.Section}}"}).RootDir(); got != "/slack" {
+		t.Errorf("RootDir() = %q, want /slack", got)
+	}
+
+	cfg := &Config{Targets: []OutputTarget{target, {Name: "home", OutputDir: "/home"}}}
+	if dirs := cfg.OutputDirs(); !reflect.DeepEqual(dirs, []string{"/slack", "/home"}) {
+		t.Errorf("OutputDirs() = %v", dirs)
+	}
+	if !cfg.UsesSections() {
+		t.Error("Config.UsesSections() = false")
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateSample holds every field a message template may use, so templates
+// with typos fail validation instead of rendering.
+var templateSample = map[string]any{
+	"Sender":    "alice",
+	"User":      "U0123456789",
+	"Time":      "02/01/2006 15:04:05 Z",
+	"Timestamp": time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+	"Text":      "hello",
+	"Reply":     false,
+	"Channel":   "general",
+	"Date":      "2006-01-02",
+}
+
+// nameStyleSample holds every field a name_style template may use.
+var nameStyleSample = map[string]any{
+	"ID":          "U0123456789",
+	"Name":        "alice",
+	"DisplayName": "Ali",
+	"RealName":    "Alice Wong",
+}
+
+// ParseTemplate parses a message template. Unknown fields are errors.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+func (c *Config) validateTemplates() error {
+	for pattern, text := range c.Templates {
+		tmpl, err := ParseTemplate(pattern, text)
+		if err == nil {
+			err = tmpl.Execute(io.Discard, templateSample)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid template for channel pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateNameStyle() error {
+	switch c.NameStyle {
+	case "", "username", "display", "real":
+		return nil
+	}
+	if !strings.Contains(c.NameStyle, "{{") {
+		return fmt.Errorf("name_style must be username, display, real, or a template, got %q", c.NameStyle)
+	}
+	tmpl, err := template.New("name_style").Option("missingkey=error").Parse(c.NameStyle)
+	if err == nil {
+		err = tmpl.Execute(io.Discard, nameStyleSample)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid name_style template: %w", err)
+	}
+	return nil
+}
+
+// validateRendering checks the options that shape rendered messages.
+func (c *Config) validateRendering() error {
+	if err := c.validateTimeFormat(); err != nil {
+		return err
+	}
+	switch c.IndexOrder {
+	case "", "alpha", "activity", "priority":
+	default:
+		return fmt.Errorf("index_order must be alpha, activity, or priority, got %q", c.IndexOrder)
+	}
+	switch c.FilenameDate {
+	case "", "prefix", "suffix", "none":
+	default:
+		return fmt.Errorf("filename_date must be prefix, suffix, or none, got %q", c.FilenameDate)
+	}
+	switch c.Layout {
+	case "", "daily":
+	case "weekly", "monthly":
+		if c.FilenameDate == "none" {
+			return fmt.Errorf("layout %s holds several days per folder and needs dated file names; filename_date cannot be none", c.Layout)
+		}
+	default:
+		return fmt.Errorf("layout must be daily, weekly, or monthly, got %q", c.Layout)
+	}
+	switch c.Sort {
+	case "", "chronological", "threads-grouped":
+	default:
+		return fmt.Errorf("sort must be chronological or threads-grouped, got %q", c.Sort)
+	}
+	if err := c.validateNameStyle(); err != nil {
+		return err
+	}
+	if _, err := CompilePatterns("message_include", c.MessageInclude); err != nil {
+		return err
+	}
+	if _, err := CompilePatterns("message_exclude", c.MessageExclude); err != nil {
+		return err
+	}
+	return c.validateTemplates()
+}
+
+// CompilePatterns compiles the regular expressions of a pattern list
+// option, naming the option and pattern in errors.
+func CompilePatterns(option string, patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", option, pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
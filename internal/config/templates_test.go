@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate_Templates(t *testing.T) {
+	cfg := &Config{Timezone: "UTC", OutputDir: t.TempDir(), Templates: map[string]string{
+		"alerts-*": `{{.Timestamp.Format "15:04"}} {{.Sender}}{{if .Reply}} ↳{{end}}: {{.Text}}`,
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, text := range []string{`{{.Sender`, `{{.Author}}: {{.Text}}`} {
+		cfg.Templates = map[string]string{"alerts-*": text}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() should reject template %q", text)
+		}
+	}
+}
+
+func TestValidate_FilenameDate(t *testing.T) {
+	for value, wantErr := range map[string]bool{"": false, "prefix": false, "suffix": false, "none": false, "middle": true} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", FilenameDate: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(filename_date=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_Layout(t *testing.T) {
+	for value, wantErr := range map[string]bool{"": false, "daily": false, "weekly": false, "monthly": false, "yearly": true} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", Layout: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(layout=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+	cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", Layout: "weekly", FilenameDate: "none"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject layout weekly with filename_date none")
+	}
+}
+
+func TestValidate_Sort(t *testing.T) {
+	for value, wantErr := range map[string]bool{"": false, "chronological": false, "threads-grouped": false, "newest": true} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", Sort: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(sort=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_NameStyle(t *testing.T) {
+	for value, wantErr := range map[string]bool{
+		"": false, "username": false, "display": false, "real": false,
+		"{{.RealName}} ({{.Name}})": false, "nickname": true, "{{.Nickname}}": true,
+	} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", NameStyle: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(name_style=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_IndexOrder(t *testing.T) {
+	for value, wantErr := range map[string]bool{"": false, "alpha": false, "activity": false, "priority": false, "size": true} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", IndexOrder: value}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(index_order=%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestValidate_MessagePatterns(t *testing.T) {
+	cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", MessageExclude: []string{`^!standup`}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	cfg.MessageInclude = []string{"deploy(", "release"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "message_include") {
+		t.Errorf("Validate() error = %v, want invalid message_include pattern", err)
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTimeLayout is the message timestamp layout used when time_format is
+// empty.
+const DefaultTimeLayout = "02/01/2006 15:04:05 Z0700"
+
+// TimeLocales lists the time_locale values whose month and weekday names
+// are translated. "en" keeps Go's English names.
+var TimeLocales = []string{"de", "en", "es", "fr", "it", "nl", "pt"}
+
+var strftimeLayouts = map[byte]string{
+	'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'e': "_2", 'j': "002",
+	'H': "15", 'I': "03", 'l': "3", 'M': "04", 'S': "05", 'p': "PM",
+	'b': "Jan", 'B': "January", 'a': "Mon", 'A': "Monday",
+	'Z': "MST", 'z': "-0700", 'F': "2006-01-02", 'T': "15:04:05", 'R': "15:04", '%': "%",
+}
+
+// CustomTimestamps reports whether any timestamp option is set. Custom
+// timestamps are shown in the channel's timezone instead of UTC.
+func (c *Config) CustomTimestamps() bool {
+	return c.TimeFormat != "" || c.TimeClock != "" || c.TimeLocale != ""
+}
+
+// ParseTimeLayout turns a time_format (a Go layout, or strftime when it
+// contains "%") and a time_clock of "12h" or "24h" into a Go layout. An
+// empty format is DefaultTimeLayout and an empty clock keeps its hours.
+func ParseTimeLayout(format, clock string) (string, error) {
+	layout := format
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+	if strings.Contains(layout, "%") {
+		var err error
+		if layout, err = strftimeLayout(layout); err != nil {
+			return "", err
+		}
+	}
+	switch clock {
+	case "":
+		return layout, nil
+	case "12h":
+		return twelveHourLayout(layout), nil
+	case "24h":
+		return twentyFourHourLayout(layout), nil
+	default:
+		return "", fmt.Errorf("time_clock must be 12h or 24h, got %q", clock)
+	}
+}
+
+func strftimeLayout(format string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		if i+1 == len(format) {
+			return "", fmt.Errorf("time_format %q ends with a bare %%", format)
+		}
+		i++
+		layout, ok := strftimeLayouts[format[i]]
+		if !ok {
+			return "", fmt.Errorf("time_format %q: unsupported directive %%%c", format, format[i])
+		}
+		b.WriteString(layout)
+	}
+	return b.String(), nil
+}
+
+// twelveHourLayout swaps 24-hour hours for 12-hour ones and adds an AM/PM
+// marker after the time of day unless the layout already has one.
+func twelveHourLayout(layout string) string {
+	if !strings.Contains(layout, "15") {
+		return layout
+	}
+	layout = strings.Replace(layout, "15", "3", 1)
+	if strings.Contains(layout, "PM") || strings.Contains(layout, "pm") {
+		return layout
+	}
+	end := len(layout)
+	if start := strings.Index(layout, "3:04"); start >= 0 {
+		end = start + len("3:04")
+		if strings.HasPrefix(layout[end:], ":05") {
+			end += len(":05")
+		}
+	}
+	return layout[:end] + " PM" + layout[end:]
+}
+
+func twentyFourHourLayout(layout string) string {
+	if !strings.Contains(layout, "15") {
+		if strings.Contains(layout, "03") {
+			layout = strings.Replace(layout, "03", "15", 1)
+		} else {
+			layout = strings.Replace(layout, "3", "15", 1)
+		}
+	}
+	for _, marker := range []string{" PM", " pm", "PM", "pm"} {
+		layout = strings.Replace(layout, marker, "", 1)
+	}
+	return layout
+}
+
+func (c *Config) validateTimeFormat() error {
+	if _, err := ParseTimeLayout(c.TimeFormat, c.TimeClock); err != nil {
+		return err
+	}
+	if c.TimeLocale == "" {
+		return nil
+	}
+	for _, locale := range TimeLocales {
+		if c.TimeLocale == locale {
+			return nil
+		}
+	}
+	return fmt.Errorf("time_locale must be one of %s, got %q", strings.Join(TimeLocales, ", "), c.TimeLocale)
+}
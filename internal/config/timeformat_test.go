@@ -0,0 +1,52 @@
+package config
+
+import "testing"
+
+func TestParseTimeLayout(t *testing.T) {
+	tests := []struct {
+		format, clock, want string
+	}{
+		{"", "", DefaultTimeLayout},
+		{"2006-01-02 15:04", "", "2006-01-02 15:04"},
+		{"%Y-%m-%d %H:%M:%S %Z", "", "2006-01-02 15:04:05 MST"},
+		{"%a %e %b %I:%M %p", "", "Mon _2 Jan 03:04 PM"},
+		{"100%% %F", "", "100% 2006-01-02"},
+		{"", "12h", "02/01/2006 3:04:05 PM Z0700"},
+		{"2006-01-02 15:04", "12h", "2006-01-02 3:04 PM"},
+		{"%d.%m.%Y %I:%M %p", "24h", "02.01.2006 15:04"},
+		{"Jan 2 3:04pm", "24h", "Jan 2 15:04"},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeLayout(tt.format, tt.clock)
+		if err != nil {
+			t.Errorf("ParseTimeLayout(%q, %q) error = %v", tt.format, tt.clock, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeLayout(%q, %q) = %q, want %q", tt.format, tt.clock, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeLayout_Invalid(t *testing.T) {
+	for _, tt := range []struct{ format, clock string }{
+		{"%Y-%Q", ""},
+		{"%Y %", ""},
+		{"", "am/pm"},
+	} {
+		if _, err := ParseTimeLayout(tt.format, tt.clock); err == nil {
+			t.Errorf("ParseTimeLayout(%q, %q) should fail", tt.format, tt.clock)
+		}
+	}
+}
+
+func TestValidate_TimeLocale(t *testing.T) {
+	cfg := &Config{Timezone: "UTC", OutputDir: t.TempDir(), TimeLocale: "de"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	cfg.TimeLocale = "klingon"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject an unknown time_locale")
+	}
+}
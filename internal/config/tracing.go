@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Tracing sends OpenTelemetry spans for each export stage to a collector
+// over OTLP/HTTP, so pipeline runs show where time goes.
+type Tracing struct {
+	// Endpoint is the collector's OTLP/HTTP URL, e.g. http://localhost:4318.
+	// Empty falls back to OTEL_EXPORTER_OTLP_ENDPOINT; with neither set no
+	// spans are recorded.
+	Endpoint string `yaml:"endpoint,omitempty" mapstructure:"endpoint"`
+	// Headers are sent with each export, e.g. a vendor API key. Empty falls
+	// back to OTEL_EXPORTER_OTLP_HEADERS.
+	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
+}
+
+func (c *Config) validateTracing() error {
+	if c.Tracing.Endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(c.Tracing.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("tracing: endpoint must be an http or https URL, got %q", c.Tracing.Endpoint)
+	}
+	return nil
+}
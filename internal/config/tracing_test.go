@@ -0,0 +1,18 @@
+package config
+
+import "testing"
+
+func TestValidate_TracingEndpoint(t *testing.T) {
+	for endpoint, wantErr := range map[string]bool{
+		"":                                   false,
+		"http://localhost:4318":              false,
+		"https://otlp.example.com/v1/traces": false,
+		"localhost:4318":                     true,
+		"grpc://localhost:4317":              true,
+	} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", Tracing: Tracing{Endpoint: endpoint}}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(tracing.endpoint=%q) error = %v, wantErr %v", endpoint, err, wantErr)
+		}
+	}
+}
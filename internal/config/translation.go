@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// Translation configures an optional pass that sends each rendered file to
+// a translation provider and writes the result beside the original as
+// <file>-<language>.md. Exactly one of Command and URL selects the provider.
+type Translation struct {
+	// Command is run through sh -c with the markdown on stdin and must
+	// print the translation on stdout.
+	Command string `yaml:"command,omitempty" mapstructure:"command"`
+	// URL receives the markdown as a POST body and must answer with the
+	// translation.
+	URL string `yaml:"url,omitempty" mapstructure:"url"`
+	// Language is the target language code used in file names. Default "en".
+	Language string `yaml:"language,omitempty" mapstructure:"language"`
+	// Channels limits translation to channels matching these patterns.
+	// Empty translates every channel.
+	Channels []string `yaml:"channels,omitempty" mapstructure:"channels"`
+}
+
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// Enabled reports whether a translation provider is configured.
+func (t Translation) Enabled() bool {
+	return t.Command != "" || t.URL != ""
+}
+
+// TargetLanguage returns Language, or "en" when it is unset.
+func (t Translation) TargetLanguage() string {
+	if t.Language == "" {
+		return "en"
+	}
+	return t.Language
+}
+
+func (c *Config) validateTranslation() error {
+	t := c.Translation
+	if !t.Enabled() {
+		return nil
+	}
+	if t.Command != "" && t.URL != "" {
+		return errors.New("translation: set either command or url, not both")
+	}
+	if t.URL != "" {
+		u, err := url.Parse(t.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("translation: url must be an http or https URL, got %q", t.URL)
+		}
+	}
+	if !languageCodePattern.MatchString(t.TargetLanguage()) {
+		return fmt.Errorf("translation: invalid language code %q", t.Language)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import "testing"
+
+func TestValidate_Translation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Translation
+		wantErr bool
+	}{
+		{"disabled", Translation{}, false},
+		{"command", Translation{Command: "trans -b :en"}, false},
+		{"url", Translation{URL: "https://translate.example.com/md", Language: "pt-BR"}, false},
+		{"both", Translation{Command: "cat", URL: "https://translate.example.com"}, true},
+		{"bad url", Translation{URL: "translate.example.com"}, true},
+		{"bad language", Translation{Command: "cat", Language: "../en"}, true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", Translation: tt.cfg}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTranslation_TargetLanguage(t *testing.T) {
+	if got := (Translation{}).TargetLanguage(); got != "en" {
+		t.Errorf("TargetLanguage() = %q, want en", got)
+	}
+	if got := (Translation{Language: "ja"}).TargetLanguage(); got != "ja" {
+		t.Errorf("TargetLanguage() = %q, want ja", got)
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UsersCacheDuration returns users_cache_ttl as a duration; zero disables
+// the user list cache. Call it on a validated config.
+func (c *Config) UsersCacheDuration() time.Duration {
+	ttl, _ := parseCacheTTL(c.UsersCacheTTL)
+	return ttl
+}
+
+// parseCacheTTL parses a Go duration such as "12h" or a day count such as
+// "7d". Empty is zero.
+func parseCacheTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	var ttl time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		ttl = time.Duration(n) * 24 * time.Hour
+	} else {
+		ttl, err = time.ParseDuration(value)
+	}
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("expected a non-negative duration like 12h or 7d")
+	}
+	return ttl, nil
+}
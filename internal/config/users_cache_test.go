@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsersCacheDuration(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"":     0,
+		"12h":  12 * time.Hour,
+		"90m":  90 * time.Minute,
+		"7d":   7 * 24 * time.Hour,
+		" 1d ": 24 * time.Hour,
+	} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", UsersCacheTTL: value}
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate(users_cache_ttl %q) error = %v", value, err)
+		}
+		if got := cfg.UsersCacheDuration(); got != want {
+			t.Errorf("UsersCacheDuration(%q) = %v, want %v", value, got, want)
+		}
+	}
+	for _, value := range []string{"soon", "-1h", "-2d", "d"} {
+		cfg := &Config{OutputDir: t.TempDir(), Timezone: "UTC", UsersCacheTTL: value}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(users_cache_ttl %q) accepted an invalid value", value)
+		}
+	}
+}
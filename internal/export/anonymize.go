@@ -0,0 +1,192 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	rslack "github.com/rusq/slack"
+)
+
+const pseudonymsFilename = ".slack-export-pseudonyms.json"
+
+var (
+	slackMailtoPattern = regexp.MustCompile(`<mailto:[^>]*>`)
+	slackTelPattern    = regexp.MustCompile(`<tel:[^>]*>`)
+	emailPattern       = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern       = regexp.MustCompile(`\+\d[\d\s.()-]{6,}\d|\(?\b\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`)
+)
+
+// pseudonymEntry maps one Slack user to a stable pseudonym. The real name is
+// kept so the mapping file can translate an anonymized export back.
+type pseudonymEntry struct {
+	Pseudonym string `json:"pseudonym"`
+	Name      string `json:"name,omitempty"`
+}
+
+type pseudonymsData struct {
+	Users map[string]pseudonymEntry `json:"users"`
+}
+
+// pseudonymMap assigns User-A, User-B, ... in first-seen order and persists
+// the assignments so later exports reuse them.
+type pseudonymMap struct {
+	path  string
+	users map[string]pseudonymEntry
+	dirty bool
+}
+
+func loadPseudonyms(archiveDir string) (*pseudonymMap, error) {
+	m := &pseudonymMap{
+		path:  filepath.Join(archiveDir, pseudonymsFilename),
+		users: make(map[string]pseudonymEntry),
+	}
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading pseudonym map: %w", err)
+	}
+	var stored pseudonymsData
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("parsing pseudonym map: %w", err)
+	}
+	for id, entry := range stored.Users {
+		m.users[id] = entry
+	}
+	return m, nil
+}
+
+// pseudonym returns the stable pseudonym for userID, assigning the next free
+// label on first sight.
+func (m *pseudonymMap) pseudonym(userID, realName string) string {
+	if entry, ok := m.users[userID]; ok {
+		return entry.Pseudonym
+	}
+	entry := pseudonymEntry{Pseudonym: "User-" + pseudonymLabel(len(m.users)), Name: realName}
+	m.users[userID] = entry
+	m.dirty = true
+	return entry.Pseudonym
+}
+
+// save writes newly assigned pseudonyms. A nil map saves nothing.
+func (m *pseudonymMap) save() error {
+	if m == nil || !m.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(pseudonymsData{Users: m.users}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(m.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing pseudonym map: %w", err)
+	}
+	m.dirty = false
+	return nil
+}
+
+// pseudonymLabel returns spreadsheet-style labels: A..Z, AA, AB, ...
+func pseudonymLabel(n int) string {
+	label := ""
+	for n++; n > 0; n = (n - 1) / 26 {
+		label = string(rune('A'+(n-1)%26)) + label
+	}
+	return label
+}
+
+// attachPseudonyms loads the archive's pseudonym map when anonymizing.
+func attachPseudonyms(opts RenderOptions, archiveDir string) (RenderOptions, error) {
+	if !opts.Anonymize {
+		return opts, nil
+	}
+	pseudonyms, err := loadPseudonyms(archiveDir)
+	if err != nil {
+		return opts, err
+	}
+	opts.pseudonyms = pseudonyms
+	return opts, nil
+}
+
+// scrubContactInfo replaces email addresses and phone numbers in message text.
+func scrubContactInfo(text string) string {
+	text = slackMailtoPattern.ReplaceAllString(text, "[email]")
+	text = slackTelPattern.ReplaceAllString(text, "[phone]")
+	text = emailPattern.ReplaceAllString(text, "[email]")
+	return phonePattern.ReplaceAllString(text, "[phone]")
+}
+
+func newRenderLookup(users userLookup, opts RenderOptions) renderLookup {
+	return renderLookup{
+		users:      users,
+		usergroups: opts.Usergroups,
+		canvases:   opts.Canvases,
+		pseudonyms: opts.pseudonyms,
+		blocks:     opts.RenderBlocks,
+		unfurls:    opts.IncludeUnfurls,
+		times:      newTimestampFormat(opts),
+		templates:  compileTemplates(opts.Templates),
+
+		threadLookback: opts.ThreadLookbackDays,
+		filenameDate:   opts.FilenameDate,
+		layout:         opts.Layout,
+		aliases:        opts.ChannelAliases,
+		names:          opts.NameStyle,
+		missingUsers:   opts.Users,
+		deactivated:    opts.DeactivatedLabel,
+		chronological:  opts.Chronological,
+		trackChanges:   opts.TrackChanges,
+	}
+}
+
+// userName returns the display name for a user, or its pseudonym.
+func (l renderLookup) userName(userID string) string {
+	if l.pseudonyms == nil || userID == "" {
+		return l.displayName(userID)
+	}
+	return l.pseudonyms.pseudonym(userID, l.displayName(userID))
+}
+
+// userRef returns the ID shown in message headers, which is the pseudonym
+// when anonymizing so IDs cannot be looked up in Slack.
+func (l renderLookup) userRef(userID string) string {
+	if l.pseudonyms == nil || userID == "" {
+		return userID
+	}
+	return l.userName(userID)
+}
+
+func (l renderLookup) sender(msg rslack.Message) string {
+	if msg.User == "" && msg.Username != "" {
+		return msg.Username
+	}
+	return l.userName(msg.User)
+}
+
+// channelFileName names DM files after the pseudonym instead of the person
+// when anonymizing; group DMs fall back to their channel ID. Otherwise a
+// channel_aliases entry wins over the Slack name.
+func (l renderLookup) channelFileName(names channelNameResolver, ch rslack.Channel) string {
+	if l.pseudonyms != nil {
+		switch {
+		case ch.IsMpIM:
+			return "mpdm_" + ch.ID
+		case ch.IsIM || strings.HasPrefix(ch.ID, "D"):
+			if ch.User == "" {
+				return "dm_" + ch.ID
+			}
+			return "dm_" + l.userName(ch.User)
+		}
+	}
+	if alias := l.aliases[strings.ToUpper(ch.ID)]; alias != "" {
+		return alias
+	}
+	return names.fileName(ch)
+}
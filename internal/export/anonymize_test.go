@@ -0,0 +1,130 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestPseudonymLabel(t *testing.T) {
+	tests := map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for n, want := range tests {
+		if got := pseudonymLabel(n); got != want {
+			t.Errorf("pseudonymLabel(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestPseudonymMap_StableAcrossRuns(t *testing.T) {
+	dir := t.TempDir()
+	first, err := loadPseudonyms(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := first.pseudonym("U2", "Bob"); got != "User-A" {
+		t.Errorf("first pseudonym = %q, want User-A", got)
+	}
+	if got := first.pseudonym("U1", "Alice"); got != "User-B" {
+		t.Errorf("second pseudonym = %q, want User-B", got)
+	}
+	if err := first.save(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, pseudonymsFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mapping file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	second, err := loadPseudonyms(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := second.pseudonym("U1", "Alice"); got != "User-B" {
+		t.Errorf("reloaded pseudonym = %q, want User-B", got)
+	}
+	if got := second.pseudonym("U3", "Carol"); got != "User-C" {
+		t.Errorf("new pseudonym = %q, want User-C", got)
+	}
+}
+
+func TestScrubContactInfo(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"mail <mailto:bob@acme.com|bob@acme.com>", "mail [email]"},
+		{"write bob.smith+x@acme.co.uk today", "write [email] today"},
+		{"call <tel:+15551234567|555-123-4567>", "call [phone]"},
+		{"call (555) 123-4567 or 555.123.4567", "call [phone] or [phone]"},
+		{"intl +44 20 7946 0958 ok", "intl [phone] ok"},
+		{"release 2026-01-21 build 12345", "release 2026-01-21 build 12345"},
+	}
+	for _, tt := range tests {
+		if got := scrubContactInfo(tt.in); got != tt.want {
+			t.Errorf("scrubContactInfo(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteMessage_Anonymized(t *testing.T) {
+	pseudonyms, err := loadPseudonyms(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := userLookup{
+		"U1": {ID: "U1", Name: "alice", RealName: "Alice Smith"},
+		"U2": {ID: "U2", Name: "bob", RealName: "Bob Jones"},
+	}
+	lookup := renderLookup{users: users, pseudonyms: pseudonyms}
+	msg := rslack.Message{Msg: rslack.Msg{
+		User:      "U1",
+		Timestamp: "1768050000.000100",
+		Text:      "ping <@U2>, mail alice@acme.com",
+	}}
+
+	var out bytes.Buffer
+	writeMessage(&out, msg, "", lookup)
+
+	got := out.String()
+	for _, leaked := range []string{"Alice", "Bob", "U1", "U2", "alice@acme.com"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("output leaks %q:\n%s", leaked, got)
+		}
+	}
+	if !strings.HasPrefix(got, "> User-A [User-A] @ ") || !strings.Contains(got, "ping User-B, mail [email]") {
+		t.Errorf("unexpected anonymized output:\n%s", got)
+	}
+}
+
+func TestChannelFileName_AnonymizedDM(t *testing.T) {
+	pseudonyms, err := loadPseudonyms(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := renderLookup{users: userLookup{}, pseudonyms: pseudonyms}
+	names := channelNameResolver{"D1": "dm_alice", "G1": "mpdm-alice--bob-1", "C1": "general"}
+
+	dm := rslack.Channel{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{
+		ID: "D1", IsIM: true, User: "U1",
+	}}}
+	if got := lookup.channelFileName(names, dm); got != "dm_User-A" {
+		t.Errorf("DM file name = %q, want dm_User-A", got)
+	}
+	mpdm := rslack.Channel{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{
+		ID: "G1", IsMpIM: true,
+	}}}
+	if got := lookup.channelFileName(names, mpdm); got != "mpdm_G1" {
+		t.Errorf("group DM file name = %q, want mpdm_G1", got)
+	}
+	public := rslack.Channel{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1"}}}
+	if got := lookup.channelFileName(names, public); got != "general" {
+		t.Errorf("channel file name = %q, want general", got)
+	}
+}
@@ -42,18 +42,12 @@ func archiveCoverageStart(archiveDir string) (time.Time, error) {
 	return parseArchiveTimestamp(raw.String)
 }
 
-func writtenResumeRenderTargets(archiveDir, timezone string) ([]renderTarget, error) {
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		return nil, fmt.Errorf("loading timezone: %w", err)
-	}
-	db, err := sql.Open("sqlite", filepath.Join(archiveDir, source.DefaultDBFile))
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = db.Close() }()
-
-	rows, err := db.Query(`
+func writtenResumeRenderTargets(
+	archiveDir string,
+	opts RenderOptions,
+	channelNames map[string]string,
+) ([]renderTarget, error) {
+	return messageRenderTargets(archiveDir, opts, channelNames, `
 		WITH latest_resume AS (
 			SELECT ID
 			FROM SESSION
@@ -72,6 +66,46 @@ func writtenResumeRenderTargets(archiveDir, timezone string) ([]renderTarget, er
 		  AND TRIM(M.TS) <> ''
 		ORDER BY M.CHANNEL_ID, M.TS
 	`)
+}
+
+// sessionRenderTargets returns the channel-dates of messages written by
+// archive sessions after afterSession, finished or not.
+func sessionRenderTargets(
+	archiveDir string,
+	afterSession int64,
+	opts RenderOptions,
+	channelNames map[string]string,
+) ([]renderTarget, error) {
+	return messageRenderTargets(archiveDir, opts, channelNames, `
+		SELECT DISTINCT M.CHANNEL_ID, M.TS
+		FROM MESSAGE M
+		JOIN CHUNK C ON C.ID = M.CHUNK_ID
+		WHERE C.SESSION_ID > ?
+		  AND M.CHANNEL_ID IS NOT NULL
+		  AND TRIM(M.CHANNEL_ID) <> ''
+		  AND M.TS IS NOT NULL
+		  AND TRIM(M.TS) <> ''
+		ORDER BY M.CHANNEL_ID, M.TS
+	`, afterSession)
+}
+
+// messageRenderTargets maps the (channel ID, ts) rows of query to the
+// channel-dates they render into, in row order without duplicates.
+func messageRenderTargets(
+	archiveDir string,
+	opts RenderOptions,
+	channelNames map[string]string,
+	query string,
+	args ...any,
+) ([]renderTarget, error) {
+	locations := make(map[string]*time.Location)
+	db, err := sql.Open("sqlite", filepath.Join(archiveDir, source.DefaultDBFile))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +118,14 @@ func writtenResumeRenderTargets(archiveDir, timezone string) ([]renderTarget, er
 		if err := rows.Scan(&channelID, &ts); err != nil {
 			return nil, err
 		}
+		loc, ok := locations[channelID]
+		if !ok {
+			loc, err = time.LoadLocation(opts.timezoneFor(channelID, opts.channelName(channelNames, channelID)))
+			if err != nil {
+				return nil, fmt.Errorf("loading timezone: %w", err)
+			}
+			locations[channelID] = loc
+		}
 		date, err := workdayDateForSlackTS(ts, loc)
 		if err != nil {
 			return nil, err
@@ -100,6 +142,21 @@ func writtenResumeRenderTargets(archiveDir, timezone string) ([]renderTarget, er
 	return targets, nil
 }
 
+// latestSessionID returns the newest archive session's ID, or 0 when the
+// archive has none.
+func latestSessionID(archiveDir string) (int64, error) {
+	db, err := sql.Open("sqlite", filepath.Join(archiveDir, source.DefaultDBFile))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = db.Close() }()
+	var id int64
+	if err := db.QueryRow(`SELECT COALESCE(MAX(ID), 0) FROM SESSION`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("reading archive sessions: %w", err)
+	}
+	return id, nil
+}
+
 func workdayDateForSlackTS(ts string, loc *time.Location) (string, error) {
 	posted, err := slack.ParseSlackTS(ts)
 	if err != nil {
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic replaces path with data so readers, and the next run after
+// a crash, see either the old or the new content but never a truncated file.
+// The data is written to a temporary file in the same directory, synced,
+// and renamed over path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// syncDir persists a rename in dir. Not every platform can sync a
+// directory, so failures are ignored.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
+
+// verifyWrite re-reads path and compares its checksum with content.
+func verifyWrite(path string, content []byte) error {
+	written, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("verifying %s: %w", path, err)
+	}
+	want, got := sha256.Sum256(content), sha256.Sum256(written)
+	if !bytes.Equal(want[:], got[:]) {
+		return fmt.Errorf("verifying %s: checksum mismatch after write", path)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileAtomic_ReplacesWithoutLeavingTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2026-07-03-general.md")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(path, []byte("new content"), 0600); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "new content" {
+		t.Fatalf("content = %q, err = %v", data, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, err = %v", info.Mode(), err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the target file", len(entries))
+	}
+}
+
+func TestWriteFileIfChanged_Verify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2026-07-03", "2026-07-03-general.md")
+
+	written, err := writeFileIfChanged(path, []byte("hello"), true)
+	if err != nil || !written {
+		t.Fatalf("writeFileIfChanged() = %v, %v", written, err)
+	}
+	written, err = writeFileIfChanged(path, []byte("hello"), true)
+	if err != nil || written {
+		t.Errorf("unchanged content: written = %v, err = %v", written, err)
+	}
+}
+
+func TestVerifyWrite_DetectsMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.md")
+	if err := os.WriteFile(path, []byte("truncat"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyWrite(path, []byte("truncated")); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("verifyWrite() error = %v, want checksum mismatch", err)
+	}
+	if err := verifyWrite(path, []byte("truncat")); err != nil {
+		t.Errorf("verifyWrite() error = %v", err)
+	}
+}
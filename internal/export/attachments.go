@@ -0,0 +1,68 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// AttachmentTextPath is where the processed text of a downloaded file at
+// path is written.
+func AttachmentTextPath(path string) string {
+	return path + ".txt"
+}
+
+// ProcessAttachment runs the command configured for the file's kind with the
+// downloaded copy at path on stdin, and writes its stdout to
+// AttachmentTextPath(path). It reports false without running anything when
+// the kind has no command or the text was already written.
+func ProcessAttachment(
+	ctx context.Context,
+	cfg config.AttachmentProcessing,
+	file slack.File,
+	path string,
+	channel string,
+) (bool, error) {
+	command := cfg.CommandFor(file.Kind())
+	if command == "" {
+		return false, nil
+	}
+	textPath := AttachmentTextPath(path)
+	if _, err := os.Stat(textPath); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = in.Close() }()
+	// #nosec G204 -- the command comes from the user's own configuration
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = in
+	cmd.Env = append(os.Environ(),
+		"SLACK_EXPORT_FILE="+path,
+		"SLACK_EXPORT_FILE_KIND="+file.Kind(),
+		"SLACK_EXPORT_FILETYPE="+file.Filetype,
+		"SLACK_EXPORT_MIMETYPE="+file.Mimetype,
+		"SLACK_EXPORT_CHANNEL="+channel)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("%s command failed: %w: %s", file.Kind(), err, strings.TrimSpace(stderr.String()))
+	}
+	if err := writeFileAtomic(textPath, out, 0600); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestProcessAttachment(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "F1-clip.m4a")
+	if err := os.WriteFile(path, []byte("spoken words"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.AttachmentProcessing{
+		AudioCommand: `printf '%s %s: ' "$SLACK_EXPORT_CHANNEL" "$SLACK_EXPORT_FILE_KIND"; cat`,
+	}
+	clip := slack.File{ID: "F1", Mimetype: "audio/mp4", Subtype: "slack_audio"}
+
+	done, err := ProcessAttachment(context.Background(), cfg, clip, path, "general")
+	if err != nil || !done {
+		t.Fatalf("ProcessAttachment() = %v, %v, want true", done, err)
+	}
+	data, err := os.ReadFile(AttachmentTextPath(path))
+	if err != nil || string(data) != "general audio: spoken words" {
+		t.Errorf("text = %q, %v", data, err)
+	}
+
+	if done, err := ProcessAttachment(context.Background(), cfg, clip, path, "general"); done || err != nil {
+		t.Errorf("second ProcessAttachment() = %v, %v, want the existing text kept", done, err)
+	}
+	image := slack.File{ID: "F2", Mimetype: "image/png"}
+	if done, err := ProcessAttachment(context.Background(), cfg, image, path, "general"); done || err != nil {
+		t.Errorf("ProcessAttachment(image without command) = %v, %v, want skipped", done, err)
+	}
+}
+
+func TestProcessAttachment_CommandFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "F1-shot.png")
+	if err := os.WriteFile(path, []byte("png"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.AttachmentProcessing{ImageCommand: "echo no ocr >&2; exit 3"}
+	_, err := ProcessAttachment(context.Background(), cfg, slack.File{Mimetype: "image/png"}, path, "general")
+	if err == nil {
+		t.Fatal("ProcessAttachment() error = nil, want the command failure")
+	}
+	if _, statErr := os.Stat(AttachmentTextPath(path)); !os.IsNotExist(statErr) {
+		t.Error("a failed command left a text file behind")
+	}
+}
@@ -0,0 +1,179 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	rslack "github.com/rusq/slack"
+)
+
+const (
+	// avatarDir is the output subdirectory holding profile photos.
+	avatarDir = "avatars"
+
+	// avatarTimeout bounds one photo download.
+	avatarTimeout = 30 * time.Second
+
+	// maxAvatarBytes caps a downloaded photo; Slack's 192px images are a
+	// few KB.
+	maxAvatarBytes = 5 << 20
+)
+
+// Avatars downloads the profile photo of everyone who posted in a rendered
+// channel-day into avatars/<user ID>-<image hash>.<ext> in the output
+// directory. A photo already on disk under its hash is not fetched again. A
+// nil *Avatars records nothing.
+type Avatars struct {
+	client *http.Client
+	// pending maps output dir to the users seen under it, by ID.
+	pending map[string]map[string]rslack.User
+}
+
+// avatarsFromConfig returns a downloader when avatars is enabled.
+func avatarsFromConfig(cfg *config.Config) *Avatars {
+	if !cfg.Avatars {
+		return nil
+	}
+	return NewAvatars(&http.Client{Timeout: avatarTimeout})
+}
+
+// NewAvatars returns a downloader fetching photos with client.
+func NewAvatars(client *http.Client) *Avatars {
+	return &Avatars{client: client, pending: make(map[string]map[string]rslack.User)}
+}
+
+// record notes the authors of messages written under outputDir. Users the
+// archive doesn't describe have no photo and are skipped.
+func (a *Avatars) record(outputDir string, messages []rslack.Message, users userLookup) {
+	if a == nil {
+		return
+	}
+	if a.pending[outputDir] == nil {
+		a.pending[outputDir] = make(map[string]rslack.User)
+	}
+	for _, msg := range messages {
+		if user, ok := users[msg.User]; ok {
+			a.pending[outputDir][user.ID] = user
+		}
+	}
+}
+
+// flush downloads the photos of the users recorded under outputDir that are
+// missing or changed. Failures are passed to warn so the export itself is
+// never held back by a photo.
+func (a *Avatars) flush(ctx context.Context, outputDir string, warn func(error)) {
+	if a == nil {
+		return
+	}
+	users := a.pending[outputDir]
+	delete(a.pending, outputDir)
+	ids := make([]string, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := a.download(ctx, filepath.Join(outputDir, avatarDir), users[id]); err != nil {
+			warn(fmt.Errorf("downloading avatar of %s: %w", id, err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// download saves user's photo unless the file for its current hash exists,
+// then removes the user's photos under older hashes.
+func (a *Avatars) download(ctx context.Context, dir string, user rslack.User) error {
+	imageURL := avatarURL(user.Profile)
+	if imageURL == "" {
+		return nil
+	}
+	name := avatarFileName(user.ID, user.Profile.AvatarHash, imageURL)
+	target := filepath.Join(dir, name)
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAvatarBytes+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxAvatarBytes {
+		return fmt.Errorf("image larger than %d MB", maxAvatarBytes>>20)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("creating avatar directory: %w", err)
+	}
+	if err := writeFileAtomic(target, data, 0600); err != nil {
+		return err
+	}
+	return removeStaleAvatars(dir, user.ID, name)
+}
+
+// avatarURL picks the 192px photo, falling back to smaller sizes.
+func avatarURL(profile rslack.UserProfile) string {
+	for _, candidate := range []string{profile.Image192, profile.Image72, profile.Image48} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// avatarFileName is <user ID>-<hash><ext>. Slack's avatar_hash names the
+// photo; Gravatar defaults without one are named by their URL's SHA-256.
+func avatarFileName(userID, hash, imageURL string) string {
+	if hash == "" {
+		sum := sha256.Sum256([]byte(imageURL))
+		hash = hex.EncodeToString(sum[:6])
+	}
+	ext := ".jpg"
+	if parsed, err := url.Parse(imageURL); err == nil {
+		switch e := strings.ToLower(path.Ext(parsed.Path)); e {
+		case ".png", ".gif", ".jpeg", ".jpg", ".webp":
+			ext = e
+		}
+	}
+	return userID + "-" + hash + ext
+}
+
+func removeStaleAvatars(dir, userID, keep string) error {
+	stale, err := filepath.Glob(filepath.Join(dir, userID+"-*"))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if filepath.Base(path) == keep {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestRenderSourceRange_DownloadsAvatarsOnce(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("png:" + r.URL.Path))
+	}))
+	defer server.Close()
+
+	alice := rslack.User{ID: "U1", Name: "alice"}
+	alice.Profile.Image192 = server.URL + "/alice-192.png"
+	alice.Profile.AvatarHash = "abc123"
+	bob := rslack.User{ID: "U2", Name: "bob"}
+	bob.Profile.Image192 = server.URL + "/bob-192.png"
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users: []rslack.User{alice, bob},
+		messages: map[string][]rslack.Message{"C123": {
+			{Msg: rslack.Msg{User: "U1", Text: "hello", Timestamp: "1768485600.000100"}},
+		}},
+	}
+	outputDir := t.TempDir()
+	render := func() {
+		t.Helper()
+		opts := RenderOptions{Timezone: "UTC", Avatars: NewAvatars(server.Client())}
+		if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15", opts, nil, nil); err != nil {
+			t.Fatalf("renderSourceRange() error = %v", err)
+		}
+	}
+
+	render()
+	data, err := os.ReadFile(filepath.Join(outputDir, "avatars", "U1-abc123.png"))
+	if err != nil {
+		t.Fatalf("reading avatar: %v", err)
+	}
+	if string(data) != "png:/alice-192.png" {
+		t.Errorf("avatar = %q", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(outputDir, "avatars")); len(entries) != 1 {
+		t.Errorf("avatars = %d file(s), want only the author's", len(entries))
+	}
+
+	render()
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests after a second render = %d, want 1", got)
+	}
+
+	src.users[0].Profile.AvatarHash = "def456"
+	render()
+	if _, err := os.Stat(filepath.Join(outputDir, "avatars", "U1-def456.png")); err != nil {
+		t.Errorf("changed photo not downloaded: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "avatars", "U1-abc123.png")); !os.IsNotExist(err) {
+		t.Errorf("old photo should be removed, stat err = %v", err)
+	}
+}
+
+func TestAvatarFileName(t *testing.T) {
+	if got := avatarFileName("U1", "abc123", "https://avatars.slack-edge.com/2026/abc_192.jpg"); got != "U1-abc123.jpg" {
+		t.Errorf("avatarFileName() = %q, want U1-abc123.jpg", got)
+	}
+	gravatar := avatarFileName("U2", "", "https://secure.gravatar.com/avatar/0f1e.jpg?s=192&d=https%3A%2F%2Fa.slack-edge.com%2Fdf10d%2Fimg%2Favatars%2Fava_0001-192.png")
+	if !strings.HasPrefix(gravatar, "U2-") || !strings.HasSuffix(gravatar, ".jpg") || len(gravatar) != len("U2-")+12+len(".jpg") {
+		t.Errorf("avatarFileName() without hash = %q, want U2-<12 hex>.jpg", gravatar)
+	}
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+// blocksMarkdown converts a message's rich_text blocks to markdown, keeping
+// the bold, lists, quotes and code blocks that the plain text loses. It
+// reports false when the message has no blocks or carries non-rich_text
+// blocks (bot layouts), so those keep rendering from the plain text.
+func blocksMarkdown(msg rslack.Message, lookup renderLookup) (string, bool) {
+	blocks := msg.Blocks.BlockSet
+	if len(blocks) == 0 {
+		return "", false
+	}
+	var parts []string
+	for _, block := range blocks {
+		richText, ok := block.(*rslack.RichTextBlock)
+		if !ok {
+			return "", false
+		}
+		for _, element := range richText.Elements {
+			if part := richTextElementMarkdown(element, lookup); part != "" {
+				parts = append(parts, part)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, "\n"), true
+}
+
+func richTextElementMarkdown(element rslack.RichTextElement, lookup renderLookup) string {
+	switch e := element.(type) {
+	case *rslack.RichTextSection:
+		return strings.TrimSuffix(inlineMarkdown(e.Elements, lookup), "\n")
+	case *rslack.RichTextList:
+		return richTextListMarkdown(e, lookup)
+	case *rslack.RichTextQuote:
+		return prefixLines(strings.TrimSuffix(inlineMarkdown(e.Elements, lookup), "\n"), "> ")
+	case *rslack.RichTextPreformatted:
+		return "```\n" + strings.TrimSuffix(preformattedText(e.Elements, lookup), "\n") + "\n```"
+	default:
+		return ""
+	}
+}
+
+func richTextListMarkdown(list *rslack.RichTextList, lookup renderLookup) string {
+	indent := strings.Repeat("  ", list.Indent)
+	var lines []string
+	for i, item := range list.Elements {
+		marker := "- "
+		if list.Style == rslack.RTEListOrdered {
+			marker = fmt.Sprintf("%d. ", list.Offset+i+1)
+		}
+		text := richTextElementMarkdown(item, lookup)
+		lines = append(lines, indent+marker+strings.ReplaceAll(text, "\n", "\n"+indent+"  "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func inlineMarkdown(elements []rslack.RichTextSectionElement, lookup renderLookup) string {
+	var b strings.Builder
+	for _, element := range elements {
+		if text, ok := element.(*rslack.RichTextSectionTextElement); ok {
+			b.WriteString(styledText(text.Text, text.Style))
+			continue
+		}
+		b.WriteString(inlineEntity(element, lookup))
+	}
+	return b.String()
+}
+
+// preformattedText renders a code block's content verbatim, ignoring styles
+// that markdown cannot express inside a fence.
+func preformattedText(elements []rslack.RichTextSectionElement, lookup renderLookup) string {
+	var b strings.Builder
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *rslack.RichTextSectionTextElement:
+			b.WriteString(e.Text)
+		case *rslack.RichTextSectionLinkElement:
+			b.WriteString(e.URL)
+		default:
+			b.WriteString(inlineEntity(element, lookup))
+		}
+	}
+	return b.String()
+}
+
+// inlineEntity renders mentions, links and other non-text section elements.
+func inlineEntity(element rslack.RichTextSectionElement, lookup renderLookup) string {
+	switch e := element.(type) {
+	case *rslack.RichTextSectionLinkElement:
+		return linkMarkdown(e)
+	case *rslack.RichTextSectionUserElement:
+		return lookup.userName(e.UserID)
+	case *rslack.RichTextSectionUserGroupElement:
+		return resolveSubteamMentions("<!subteam^"+e.UsergroupID+">", lookup.usergroups)
+	case *rslack.RichTextSectionChannelElement:
+		return "#" + e.ChannelID
+	case *rslack.RichTextSectionBroadcastElement:
+		return "@" + e.Range
+	case *rslack.RichTextSectionEmojiElement:
+		return ":" + e.Name + ":"
+	case *rslack.RichTextSectionDateElement:
+		return dateMarkdown(e)
+	case *rslack.RichTextSectionTeamElement:
+		return e.TeamID
+	case *rslack.RichTextSectionColorElement:
+		return e.Value
+	default:
+		return ""
+	}
+}
+
+func linkMarkdown(link *rslack.RichTextSectionLinkElement) string {
+	if link.Text == "" || link.Text == link.URL {
+		return styledText(link.URL, link.Style)
+	}
+	return styledText("["+link.Text+"]("+link.URL+")", link.Style)
+}
+
+func dateMarkdown(date *rslack.RichTextSectionDateElement) string {
+	if date.Fallback != nil && *date.Fallback != "" {
+		return *date.Fallback
+	}
+	return time.Unix(int64(date.Timestamp), 0).UTC().Format("2006-01-02 15:04 MST")
+}
+
+// styledText wraps text in markdown emphasis. Surrounding whitespace stays
+// outside the markers because "** bold **" does not render as bold.
+func styledText(text string, style *rslack.RichTextSectionTextStyle) string {
+	core := strings.TrimSpace(text)
+	if style == nil || core == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, core)]
+	trail := text[len(lead)+len(core):]
+	if style.Code {
+		core = "`" + core + "`"
+	}
+	if style.Strike {
+		core = "~~" + core + "~~"
+	}
+	if style.Italic {
+		core = "_" + core + "_"
+	}
+	if style.Bold {
+		core = "**" + core + "**"
+	}
+	return lead + core + trail
+}
+
+func prefixLines(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
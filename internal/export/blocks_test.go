@@ -0,0 +1,77 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+const richTextMessageJSON = `{
+  "type": "message", "user": "U1", "ts": "1768050000.000100",
+  "text": "*Deploy* plan",
+  "blocks": [{"type": "rich_text", "block_id": "b1", "elements": [
+    {"type": "rich_text_section", "elements": [
+      {"type": "text", "text": "Deploy ", "style": {"bold": true}},
+      {"type": "text", "text": "plan for "},
+      {"type": "user", "user_id": "U2"},
+      {"type": "text", "text": " see "},
+      {"type": "link", "url": "https://example.com/run", "text": "runbook"},
+      {"type": "text", "text": "\n"}
+    ]},
+    {"type": "rich_text_list", "style": "ordered", "indent": 0, "elements": [
+      {"type": "rich_text_section", "elements": [{"type": "text", "text": "build"}]},
+      {"type": "rich_text_section", "elements": [{"type": "text", "text": "ship", "style": {"code": true}}]}
+    ]},
+    {"type": "rich_text_list", "style": "bullet", "indent": 1, "elements": [
+      {"type": "rich_text_section", "elements": [{"type": "text", "text": "old", "style": {"strike": true}}]}
+    ]},
+    {"type": "rich_text_quote", "elements": [{"type": "text", "text": "quoted\nmore"}]},
+    {"type": "rich_text_preformatted", "elements": [{"type": "text", "text": "make deploy\n"}]}
+  ]}]
+}`
+
+func TestBlocksMarkdown_RichText(t *testing.T) {
+	var msg rslack.Message
+	if err := json.Unmarshal([]byte(richTextMessageJSON), &msg); err != nil {
+		t.Fatal(err)
+	}
+	lookup := renderLookup{users: userLookup{"U2": {ID: "U2", Name: "bob"}}}
+
+	got, ok := blocksMarkdown(msg, lookup)
+	if !ok {
+		t.Fatal("blocksMarkdown() reported no rich text")
+	}
+	want := "**Deploy** plan for bob see [runbook](https://example.com/run)\n" +
+		"1. build\n2. `ship`\n" +
+		"  - ~~old~~\n" +
+		"> quoted\n> more\n" +
+		"```\nmake deploy\n```"
+	if got != want {
+		t.Errorf("blocksMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBlocksMarkdown_FallsBackWithoutRichText(t *testing.T) {
+	var msg rslack.Message
+	raw := `{"type":"message","text":"alert","blocks":[{"type":"section","text":{"type":"mrkdwn","text":"alert"}}]}`
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := blocksMarkdown(msg, renderLookup{}); ok {
+		t.Error("section blocks should fall back to plain text")
+	}
+	if _, ok := blocksMarkdown(rslack.Message{}, renderLookup{}); ok {
+		t.Error("message without blocks should fall back to plain text")
+	}
+}
+
+func TestStyledText_KeepsWhitespaceOutsideMarkers(t *testing.T) {
+	style := &rslack.RichTextSectionTextStyle{Bold: true, Italic: true}
+	if got := styledText(" both ", style); got != " **_both_** " {
+		t.Errorf("styledText() = %q", got)
+	}
+	if got := styledText("   ", style); got != "   " {
+		t.Errorf("styledText(blank) = %q", got)
+	}
+}
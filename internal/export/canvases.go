@@ -0,0 +1,129 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+var canvasLinkPattern = regexp.MustCompile(`https://[A-Za-z0-9.-]+\.slack\.com/(?:docs|files)/[A-Z0-9]+/(F[A-Z0-9]+)`)
+
+// CanvasSource supplies canvas and post content for links found in messages.
+type CanvasSource interface {
+	Canvas(fileID string) (*slack.Canvas, bool)
+}
+
+// cachedCanvases serves canvases from the on-disk cache only, so offline
+// renders embed whatever the last sync fetched.
+type cachedCanvases struct {
+	cache *slack.CanvasCache
+}
+
+func (c cachedCanvases) Canvas(fileID string) (*slack.Canvas, bool) {
+	canvas, ok, err := c.cache.Get(fileID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to read cached canvas %s: %v\n", fileID, err)
+		return nil, false
+	}
+	return canvas, ok
+}
+
+func cachedCanvasSource(cfg *config.Config) CanvasSource {
+	if !cfg.ExpandCanvases {
+		return nil
+	}
+	return cachedCanvases{cache: slack.NewCanvasCache(slack.DefaultCanvasCacheDir())}
+}
+
+// fetchingCanvases fetches each linked canvas once per run so edits made since
+// the last sync are picked up, falling back to the cache when a fetch fails.
+type fetchingCanvases struct {
+	ctx     context.Context
+	client  *slack.EdgeClient
+	cache   *slack.CanvasCache
+	fetched map[string]*slack.Canvas
+}
+
+func (c *fetchingCanvases) Canvas(fileID string) (*slack.Canvas, bool) {
+	if canvas, seen := c.fetched[fileID]; seen {
+		return canvas, canvas != nil
+	}
+	canvas, err := c.client.FetchCanvas(c.ctx, fileID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch canvas %s: %v\n", fileID, err)
+		canvas, _ = cachedCanvases{cache: c.cache}.Canvas(fileID)
+	} else if err := c.cache.Put(canvas); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache canvas %s: %v\n", fileID, err)
+	}
+	c.fetched[fileID] = canvas
+	return canvas, canvas != nil
+}
+
+// renderOptions returns the configured render options, fetching linked
+// canvases live when expand_canvases is enabled.
+func (e *Exporter) renderOptions(ctx context.Context) RenderOptions {
+	opts := renderOptionsFromConfig(e.cfg, e.events())
+	if e.cfg.ExpandCanvases {
+		opts.Canvases = &fetchingCanvases{
+			ctx:     ctx,
+			client:  e.edgeClient,
+			cache:   slack.NewCanvasCache(slack.DefaultCanvasCacheDir()),
+			fetched: make(map[string]*slack.Canvas),
+		}
+	}
+	opts.Users = newFetchingUsers(ctx, e.edgeClient)
+	if e.channelErrors != nil {
+		opts.OnChannelError = e.channelErrors
+		opts.skipped = newSkippedChannels()
+	}
+	if e.cfg.ParticipatedOnly {
+		opts.ParticipantID = e.creds.UserID
+	}
+	if e.cfg.VerifyCounts > 0 {
+		opts.written = newWrittenCounts()
+	}
+	return opts
+}
+
+// linkedCanvasIDs returns the distinct canvas/post file IDs linked in text.
+func linkedCanvasIDs(text string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range canvasLinkPattern.FindAllStringSubmatch(text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+	return ids
+}
+
+// writeCanvasEmbeds appends the content of each linked canvas below the
+// message, indented so it cannot be mistaken for a message header.
+func writeCanvasEmbeds(out *bytes.Buffer, prefix, text string, canvases CanvasSource) {
+	if canvases == nil {
+		return
+	}
+	for _, id := range linkedCanvasIDs(text) {
+		canvas, ok := canvases.Canvas(id)
+		if !ok {
+			continue
+		}
+		title := canvas.Title
+		if title == "" {
+			title = id
+		}
+		fmt.Fprintf(out, "%s[canvas: %s (%s)]\n", prefix, title, id)
+		for _, line := range strings.Split(canvas.Content, "\n") {
+			out.WriteString(strings.TrimRight(prefix+"    "+line, " "))
+			out.WriteByte('\n')
+		}
+		fmt.Fprintf(out, "%s[end canvas]\n", prefix)
+	}
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+type stubCanvases map[string]*slack.Canvas
+
+func (s stubCanvases) Canvas(fileID string) (*slack.Canvas, bool) {
+	canvas, ok := s[fileID]
+	return canvas, ok
+}
+
+func TestLinkedCanvasIDs(t *testing.T) {
+	text := "see <https://acme.slack.com/docs/T012AB/F0CANVAS|Runbook> and " +
+		"https://acme.slack.com/files/U01ABC/F0POST/notes plus " +
+		"<https://acme.slack.com/docs/T012AB/F0CANVAS> and https://example.com/docs/T1/F0NOPE"
+
+	got := linkedCanvasIDs(text)
+	if want := []string{"F0CANVAS", "F0POST"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("linkedCanvasIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteMessage_EmbedsLinkedCanvas(t *testing.T) {
+	msg := rslack.Message{Msg: rslack.Msg{
+		User:      "U1",
+		Timestamp: "1768050000.000100",
+		Text:      "<https://acme.slack.com/docs/T1/F0CANVAS|Runbook> <https://acme.slack.com/docs/T1/F0GONE>",
+	}}
+	lookup := renderLookup{canvases: stubCanvases{
+		"F0CANVAS": {ID: "F0CANVAS", Title: "Runbook", Content: "# Deploys\n\nRun make"},
+	}}
+
+	var out bytes.Buffer
+	writeMessage(&out, msg, "|   ", lookup)
+
+	want := "|   [canvas: Runbook (F0CANVAS)]\n|       # Deploys\n|\n|       Run make\n|   [end canvas]\n"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("missing canvas embed:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "F0GONE)") {
+		t.Errorf("uncached canvas should not be embedded:\n%s", out.String())
+	}
+	if got := parseRenderedMessages(out.String()); len(got) != 1 {
+		t.Errorf("embed should not create extra rendered messages, got %d", len(got))
+	}
+}
+
+func TestFetchingCanvases_FetchesOncePerRunAndFallsBackToCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": false, "error": "file_not_found"}`))
+	}))
+	defer server.Close()
+
+	cache := slack.NewCanvasCache(t.TempDir())
+	if err := cache.Put(&slack.Canvas{ID: "F0OLD", Title: "Old", Content: "cached"}); err != nil {
+		t.Fatal(err)
+	}
+	source := &fetchingCanvases{
+		ctx:     context.Background(),
+		client:  slack.NewEdgeClient(&slack.Credentials{Token: "xoxc-test"}).WithSlackAPIURL(server.URL),
+		cache:   cache,
+		fetched: make(map[string]*slack.Canvas),
+	}
+
+	canvas, ok := source.Canvas("F0OLD")
+	if !ok || canvas.Content != "cached" {
+		t.Fatalf("Canvas() = %+v, %v; want cached fallback", canvas, ok)
+	}
+	if _, ok := source.Canvas("F0MISSING"); ok {
+		t.Error("Canvas() should miss when fetch fails and nothing is cached")
+	}
+	source.Canvas("F0OLD")
+	source.Canvas("F0MISSING")
+	if requests != 2 {
+		t.Errorf("made %d files.info requests, want 2 (one per canvas)", requests)
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/rusq/slackdump/v4/source"
+)
+
+// Catch-up modes for a daily sync whose archive is further behind than
+// max_sync_days.
+const (
+	// CatchUpAll fetches the whole gap in one resume.
+	CatchUpAll = "all"
+	// CatchUpChunks fetches the gap in max_sync_days windows. Each window's
+	// checkpoints are saved, so an interrupted catch-up resumes from there.
+	CatchUpChunks = "chunks"
+)
+
+// CatchUpError reports a daily sync refused because the archive is more
+// than Limit days behind.
+type CatchUpError struct {
+	Days  int
+	Limit int
+}
+
+func (e *CatchUpError) Error() string {
+	return fmt.Sprintf(
+		"archive is %d days behind, over max_sync_days (%d); rerun with --catch-up to fetch everything "+
+			"or --catch-up-chunks to fetch it in %d-day chunks", e.Days, e.Limit, e.Limit)
+}
+
+// resumeWithCatchUp runs the daily resume, first checking how long ago the
+// archive last saw activity in a tracked channel. Gaps over max_sync_days
+// fail with a CatchUpError unless syncOpts.CatchUp says how to proceed.
+func (e *Exporter) resumeWithCatchUp(
+	ctx context.Context,
+	archiveDir string,
+	tracked []slack.Channel,
+	now time.Time,
+	opts ResumeOptions,
+	syncOpts SyncOptions,
+) (resumeResult, error) {
+	limit := e.catchUpLimit(syncOpts)
+	if limit <= 0 {
+		return e.resumeArchive(ctx, archiveDir, tracked, now, opts)
+	}
+	latest, err := archiveCheckpoints(ctx, archiveDir)
+	if err != nil {
+		return resumeResult{}, fmt.Errorf("reading archive checkpoints: %w", err)
+	}
+	since := lastTrackedActivity(latest, tracked)
+	days := int(now.Sub(since).Hours() / 24)
+	if since.IsZero() || days <= limit {
+		return e.resumeArchive(ctx, archiveDir, tracked, now, opts)
+	}
+	if syncOpts.CatchUp != CatchUpChunks {
+		return resumeResult{}, &CatchUpError{Days: days, Limit: limit}
+	}
+	e.stagef("Archive is %d days behind; catching up in %d-day chunks", days, limit)
+	return e.resumeInChunks(ctx, archiveDir, tracked, latest, since, now, opts, limit)
+}
+
+// catchUpLimit is the day limit that applies to this sync, or zero when the
+// sync is a sweep or reconcile or the catch-up was already confirmed.
+func (e *Exporter) catchUpLimit(syncOpts SyncOptions) int {
+	if syncOpts.Full || syncOpts.ReconcileDays > 0 || syncOpts.CatchUp == CatchUpAll {
+		return 0
+	}
+	if syncOpts.MaxSyncDays > 0 {
+		return syncOpts.MaxSyncDays
+	}
+	return e.cfg.MaxSyncDays
+}
+
+func (e *Exporter) resumeInChunks(
+	ctx context.Context,
+	archiveDir string,
+	tracked []slack.Channel,
+	latest map[string]time.Time,
+	since, now time.Time,
+	opts ResumeOptions,
+	days int,
+) (resumeResult, error) {
+	result := resumeResult{}
+	seen := make(map[renderTarget]bool)
+	step := time.Duration(days) * 24 * time.Hour
+	for from := since; from.Before(now); from = from.Add(step) {
+		to := from.Add(step)
+		if to.After(now) {
+			to = now
+		}
+		e.stagef("Catching up %s through %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		args := chunkResumeArgs(tracked, latest, from, to, from.Equal(since))
+		if err := ResumeArchive(ctx, e.slackdump, archiveDir, args, opts); err != nil {
+			return result, fmt.Errorf("resuming archive for %s: %w", from.Format("2006-01-02"), err)
+		}
+		targets, err := writtenResumeRenderTargets(archiveDir, RenderOptionsFromConfig(e.cfg), channelNameMap(tracked))
+		if err != nil {
+			return result, fmt.Errorf("loading written resume render targets: %w", err)
+		}
+		for _, target := range targets {
+			if !seen[target] {
+				seen[target] = true
+				result.renderTargets = append(result.renderTargets, target)
+			}
+		}
+	}
+	return result, nil
+}
+
+// chunkResumeArgs bounds every tracked channel to [from, to) and excludes
+// the archive's other checkpoints. The first chunk starts each channel at
+// its own checkpoint so quieter channels are not skipped past.
+func chunkResumeArgs(tracked []slack.Channel, latest map[string]time.Time, from, to time.Time, first bool) []string {
+	trackedSet := channelIDSet(channelIDs(tracked))
+	args := make([]string, 0, len(latest)+len(tracked))
+	for key := range latest {
+		if !trackedSet[strings.SplitN(key, ":", 2)[0]] {
+			args = append(args, "^"+key)
+		}
+	}
+	for _, ch := range tracked {
+		start := from
+		if checkpoint, ok := latest[ch.ID]; ok && first && checkpoint.Before(from) {
+			start = checkpoint
+		}
+		args = append(args, ch.ID+","+start.UTC().Format(slackdumpTimeFormat)+","+to.UTC().Format(slackdumpTimeFormat))
+	}
+	return args
+}
+
+// lastTrackedActivity is the newest channel or thread checkpoint of any
+// tracked channel, which is roughly when the archive was last refreshed.
+func lastTrackedActivity(latest map[string]time.Time, tracked []slack.Channel) time.Time {
+	trackedSet := channelIDSet(channelIDs(tracked))
+	var newest time.Time
+	for key, ts := range latest {
+		if trackedSet[strings.SplitN(key, ":", 2)[0]] && ts.After(newest) {
+			newest = ts
+		}
+	}
+	return newest
+}
+
+// archiveCheckpoints reads the archive's checkpoints keyed by channel ID or
+// channel:thread link.
+func archiveCheckpoints(ctx context.Context, archiveDir string) (map[string]time.Time, error) {
+	src, err := source.Load(ctx, archiveDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = src.Close() }()
+
+	latest, err := src.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]time.Time, len(latest))
+	for link, ts := range latest {
+		result[fmt.Sprint(link)] = ts
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestLastTrackedActivity(t *testing.T) {
+	tracked := []slack.Channel{{ID: "C1"}, {ID: "C2"}}
+	latest := map[string]time.Time{
+		"C1":          time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		"C2:1700.000": time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		"C9":          time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	got := lastTrackedActivity(latest, tracked)
+	if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("lastTrackedActivity() = %v, want %v (untracked C9 ignored)", got, want)
+	}
+}
+
+func TestChunkResumeArgs(t *testing.T) {
+	tracked := []slack.Channel{{ID: "C1"}, {ID: "C2"}}
+	latest := map[string]time.Time{
+		"C1": time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		"C9": time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+	}
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+
+	first := chunkResumeArgs(tracked, latest, from, to, true)
+	sort.Strings(first)
+	want := []string{
+		"C1,2026-01-05T00:00:00,2026-03-03T00:00:00",
+		"C2,2026-02-01T00:00:00,2026-03-03T00:00:00",
+		"^C9",
+	}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("chunkResumeArgs(first) = %v, want %v", first, want)
+	}
+
+	later := chunkResumeArgs(tracked, latest, from, to, false)
+	sort.Strings(later)
+	if later[0] != "C1,2026-02-01T00:00:00,2026-03-03T00:00:00" {
+		t.Errorf("chunkResumeArgs(later) = %v, want chunk start for C1", later)
+	}
+}
+
+func TestCatchUpLimit(t *testing.T) {
+	e := &Exporter{cfg: &config.Config{MaxSyncDays: 30}}
+	tests := []struct {
+		name string
+		opts SyncOptions
+		want int
+	}{
+		{"configured", SyncOptions{}, 30},
+		{"flag override", SyncOptions{MaxSyncDays: 90}, 90},
+		{"confirmed", SyncOptions{CatchUp: CatchUpAll}, 0},
+		{"chunks keep limit", SyncOptions{CatchUp: CatchUpChunks}, 30},
+		{"full sweep", SyncOptions{Full: true}, 0},
+		{"reconcile", SyncOptions{ReconcileDays: 7}, 0},
+	}
+	for _, tt := range tests {
+		if got := e.catchUpLimit(tt.opts); got != tt.want {
+			t.Errorf("%s: catchUpLimit() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCatchUpError(t *testing.T) {
+	var err error = &CatchUpError{Days: 120, Limit: 30}
+	var gap *CatchUpError
+	if !errors.As(classifyError(err), &gap) || gap.Days != 120 {
+		t.Fatalf("errors.As(CatchUpError) failed for %v", err)
+	}
+	if !strings.Contains(err.Error(), "120 days behind") || !strings.Contains(err.Error(), "30-day chunks") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,298 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+const changesLogFilename = "changes.log"
+
+// renderedHeaderPattern matches a message header written without a
+// template, in any time_format.
+var renderedHeaderPattern = regexp.MustCompile(
+	`^((?:\|   )?)> (.*) \[([^\]]*)\] @ (.+?)(?: \(also sent to channel\))?:$`,
+)
+
+// renderedMessage is one message block parsed back out of a rendered file.
+type renderedMessage struct {
+	key    string
+	header string
+	body   string
+}
+
+type messageChange struct {
+	kind string
+	old  renderedMessage
+	new  renderedMessage
+}
+
+// trackedMessage is one message written into a channel-day, keyed by its
+// Slack timestamp from the archive. Header is the message's untemplated
+// header line; only Text is compared.
+type trackedMessage struct {
+	TS     string `json:"ts"`
+	Header string `json:"header"`
+	Text   string `json:"text"`
+}
+
+// messageTracker collects the messages written into one channel-day for
+// track_changes. A nil *messageTracker records nothing.
+type messageTracker struct {
+	written []trackedMessage
+}
+
+func (t *messageTracker) record(msg rslack.Message, data MessageData) {
+	if t == nil {
+		return
+	}
+	t.written = append(t.written, trackedMessage{
+		TS:     msg.Timestamp,
+		Header: fmt.Sprintf("> %s [%s] @ %s:", data.Sender, data.User, data.Time),
+		Text:   strings.TrimRight(data.Text, "\n "),
+	})
+}
+
+// take returns the messages recorded since the last call, for the unit
+// that holds them.
+func (t *messageTracker) take() []trackedMessage {
+	if t == nil {
+		return nil
+	}
+	written := t.written
+	t.written = nil
+	return written
+}
+
+// trackedMessages returns the messages recorded in units, or nil when they
+// were rendered without a tracker.
+func trackedMessages(units []renderedUnit) []trackedMessage {
+	var tracked []trackedMessage
+	for _, unit := range units {
+		tracked = append(tracked, unit.tracked...)
+	}
+	return tracked
+}
+
+// trackedMessagesPath is the hidden file beside a channel-day that keeps
+// the messages of its last render for track_changes.
+func trackedMessagesPath(dir, base string) string {
+	return filepath.Join(dir, "."+base+".messages.json")
+}
+
+// loadTrackedMessages returns the messages saved by the channel-day's last
+// tracked render, and false when there is none.
+func loadTrackedMessages(dir, base string) ([]trackedMessage, bool, error) {
+	data, err := os.ReadFile(trackedMessagesPath(dir, base))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading tracked messages: %w", err)
+	}
+	var tracked []trackedMessage
+	if err := json.Unmarshal(data, &tracked); err != nil {
+		return nil, false, fmt.Errorf("parsing %s: %w", trackedMessagesPath(dir, base), err)
+	}
+	return tracked, true, nil
+}
+
+func saveTrackedMessages(dir, base string, tracked []trackedMessage) error {
+	data, err := json.Marshal(tracked)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(trackedMessagesPath(dir, base), data, 0600); err != nil {
+		return fmt.Errorf("writing tracked messages: %w", err)
+	}
+	return nil
+}
+
+// recordChannelDateChanges appends the channel-day's edits and deletions to
+// the date's changes.log. Messages are matched by their archive timestamps
+// against those saved by the last tracked render, so any time_format or
+// template works. Without a saved render, or for units rendered without a
+// tracker, the previous file's headers are parsed instead.
+func recordChannelDateChanges(dir, base, name, content string, current []trackedMessage, now time.Time) error {
+	previous, saved, err := loadTrackedMessages(dir, base)
+	if err != nil {
+		return err
+	}
+	var changes []messageChange
+	if saved && current != nil {
+		changes = diffMessages(keyTrackedMessages(previous), keyTrackedMessages(current))
+	} else {
+		rendered, err := readRenderedChannelDate(dir, base)
+		if err != nil {
+			return err
+		}
+		if rendered != "" {
+			changes = diffRenderedMessages(rendered, content)
+		}
+	}
+	if err := appendChanges(dir, name, changes, now); err != nil {
+		return err
+	}
+	if current != nil {
+		return saveTrackedMessages(dir, base, current)
+	}
+	return nil
+}
+
+// keyTrackedMessages keys tracked messages by timestamp, numbering repeats.
+func keyTrackedMessages(tracked []trackedMessage) []renderedMessage {
+	seen := make(map[string]int)
+	messages := make([]renderedMessage, 0, len(tracked))
+	for _, msg := range tracked {
+		seen[msg.TS]++
+		messages = append(messages, renderedMessage{
+			key:    fmt.Sprintf("%s#%d", msg.TS, seen[msg.TS]),
+			header: msg.Header,
+			body:   msg.Text,
+		})
+	}
+	return messages
+}
+
+// appendChanges writes changes to the date's changes.log.
+func appendChanges(dir, name string, changes []messageChange, now time.Time) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var out strings.Builder
+	stamp := now.UTC().Format(time.RFC3339)
+	for _, change := range changes {
+		fmt.Fprintf(&out, "%s %s %s: %s\n", stamp, change.kind, name, change.old.header)
+		writeChangeLines(&out, "- ", change.old.body)
+		if change.kind == "edited" {
+			writeChangeLines(&out, "+ ", change.new.body)
+		}
+		out.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, changesLogFilename), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening changes log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(out.String()); err != nil {
+		return fmt.Errorf("writing changes log: %w", err)
+	}
+	return nil
+}
+
+// readRenderedChannelDate returns the current single-file or split content
+// for a channel-day, or "" when nothing has been rendered yet.
+func readRenderedChannelDate(dir, base string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, base+".md"))
+	if err == nil {
+		return string(data), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	var out strings.Builder
+	for part := 1; ; part++ {
+		data, err := os.ReadFile(partPath(dir, base, part))
+		if errors.Is(err, os.ErrNotExist) {
+			return out.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		out.Write(data)
+	}
+}
+
+// diffRenderedMessages reports messages whose text changed ("edited") or
+// that no longer appear ("deleted"). New messages are not changes.
+func diffRenderedMessages(previous, current string) []messageChange {
+	return diffMessages(parseRenderedMessages(previous), parseRenderedMessages(current))
+}
+
+// diffMessages compares messages by key; see diffRenderedMessages.
+func diffMessages(previous, current []renderedMessage) []messageChange {
+	currentByKey := make(map[string]renderedMessage)
+	for _, msg := range current {
+		currentByKey[msg.key] = msg
+	}
+	var changes []messageChange
+	for _, old := range previous {
+		updated, ok := currentByKey[old.key]
+		switch {
+		case !ok:
+			changes = append(changes, messageChange{kind: "deleted", old: old})
+		case updated.body != old.body:
+			changes = append(changes, messageChange{kind: "edited", old: old, new: updated})
+		}
+	}
+	return changes
+}
+
+// parseRenderedMessages splits rendered markdown into message blocks keyed by
+// thread prefix, sender ID, and timestamp. [context] lines are skipped since
+// they repeat a message owned by an earlier day.
+func parseRenderedMessages(content string) []renderedMessage {
+	var messages []renderedMessage
+	seen := make(map[string]int)
+	var current *renderedMessage
+	var body []string
+	flush := func() {
+		if current != nil {
+			current.body = strings.TrimRight(strings.Join(body, "\n"), "\n ")
+			messages = append(messages, *current)
+		}
+		current, body = nil, nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if m := renderedHeaderPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			sender := m[3]
+			if sender == "" {
+				sender = m[2]
+			}
+			key := m[1] + sender + "@" + m[4]
+			seen[key]++
+			key = fmt.Sprintf("%s#%d", key, seen[key])
+			current = &renderedMessage{key: key, header: strings.TrimPrefix(line, m[1])}
+			continue
+		}
+		if isRenderedSectionBoundary(line) {
+			flush()
+			continue
+		}
+		if current != nil {
+			body = append(body, line)
+		}
+	}
+	flush()
+	return messages
+}
+
+func isRenderedSectionBoundary(line string) bool {
+	for _, prefix := range []string{"---", "## ", "### ", "[context] ", "(continued "} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeChangeLines(out *strings.Builder, marker, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		out.WriteString(marker)
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+}
@@ -0,0 +1,205 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+const renderedBefore = `> alice [U1] @ 01/07/2026 08:00:00 -0500:
+hello world
+
+> bob [U2] @ 01/07/2026 08:05:00 -0500:
+going away
+
+|   > alice [U1] @ 01/07/2026 08:06:00 -0500:
+|   reply
+
+`
+
+const renderedAfter = `> alice [U1] @ 01/07/2026 08:00:00 -0500:
+hello there
+
+|   > alice [U1] @ 01/07/2026 08:06:00 -0500:
+|   reply
+
+> carol [U3] @ 01/07/2026 09:00:00 -0500:
+new message
+
+`
+
+func TestParseRenderedMessages_SkipsContextAndSections(t *testing.T) {
+	content := renderedAfter + `---
+
+## Thread continuations
+### Thread started 2026-06-30 (see 2026-06-30/2026-06-30-general.md)
+[context] > dave [U4] @ 30/06/2026 10:00:00 -0500:
+[context] original
+
+|   > dave [U4] @ 01/07/2026 10:00:00 Z:
+|   late reply
+
+`
+	got := parseRenderedMessages(content)
+	if len(got) != 4 {
+		t.Fatalf("parsed %d messages, want 4: %+v", len(got), got)
+	}
+	last := got[3]
+	if last.header != "> dave [U4] @ 01/07/2026 10:00:00 Z:" || last.body != "|   late reply" {
+		t.Errorf("continuation reply parsed as %+v", last)
+	}
+}
+
+func TestDiffRenderedMessages_ReportsEditsAndDeletions(t *testing.T) {
+	changes := diffRenderedMessages(renderedBefore, renderedAfter)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(changes), changes)
+	}
+	if changes[0].kind != "edited" || changes[0].new.body != "hello there" {
+		t.Errorf("first change = %+v, want edit to hello there", changes[0])
+	}
+	if changes[1].kind != "deleted" || changes[1].old.body != "going away" {
+		t.Errorf("second change = %+v, want deletion of bob's message", changes[1])
+	}
+}
+
+func TestDiffRenderedMessages_IgnoresRenamedSender(t *testing.T) {
+	renamed := strings.Replace(renderedBefore, "> alice [U1]", "> alice.smith [U1]", 1)
+	if changes := diffRenderedMessages(renderedBefore, renamed); len(changes) != 0 {
+		t.Errorf("rename should not be a change, got %+v", changes)
+	}
+}
+
+func TestDiffRenderedMessages_MarksBroadcastRepliesWithoutChange(t *testing.T) {
+	marked := strings.Replace(renderedBefore, "|   > alice [U1] @ 01/07/2026 08:06:00 -0500:",
+		"|   > alice [U1] @ 01/07/2026 08:06:00 -0500 (also sent to channel):", 1)
+	if changes := diffRenderedMessages(renderedBefore, marked); len(changes) != 0 {
+		t.Errorf("broadcast marker should not be a change, got %+v", changes)
+	}
+}
+
+func TestWriteChannelDate_TrackChangesAppendsChangesLog(t *testing.T) {
+	outputDir := t.TempDir()
+	opts := RenderOptions{TrackChanges: true}
+	before := []renderedUnit{{text: renderedBefore, messages: 3}}
+	after := []renderedUnit{{text: renderedAfter, messages: 3}}
+
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", before, opts); err != nil {
+		t.Fatalf("first writeChannelDate() error = %v", err)
+	}
+	logPath := filepath.Join(outputDir, "2026-07-01", changesLogFilename)
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Fatalf("first render should not create changes.log, stat err = %v", err)
+	}
+
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", after, opts); err != nil {
+		t.Fatalf("second writeChannelDate() error = %v", err)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading changes.log: %v", err)
+	}
+	for _, want := range []string{
+		"edited general: > alice [U1] @ 01/07/2026 08:00:00 -0500:",
+		"- hello world\n+ hello there",
+		"deleted general: > bob [U2] @ 01/07/2026 08:05:00 -0500:",
+		"- going away",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("changes.log missing %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "carol") {
+		t.Errorf("new messages should not be logged:\n%s", data)
+	}
+}
+
+func TestRecordChannelDateChanges_ReadsSplitParts(t *testing.T) {
+	dir := t.TempDir()
+	base := "2026-07-01-general"
+	if err := os.WriteFile(partPath(dir, base, 1), []byte(renderedBefore), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 7, 2, 12, 0, 0, 0, time.UTC)
+	if err := recordChannelDateChanges(dir, base, "general", renderedAfter, nil, now); err != nil {
+		t.Fatalf("recordChannelDateChanges() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, changesLogFilename))
+	if err != nil {
+		t.Fatalf("reading changes.log: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "2026-07-02T12:00:00Z edited general:") {
+		t.Errorf("changes.log = %q, want timestamped edit entry", data)
+	}
+}
+
+func TestRenderSourceRange_TrackChangesMatchesArchiveTimestamps(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{
+			GroupConversation: rslack.GroupConversation{
+				Conversation: rslack.Conversation{ID: "C123"},
+				Name:         "alerts",
+			},
+		}},
+		users: []rslack.User{{ID: "U1", Name: "alice"}},
+		messages: map[string][]rslack.Message{
+			"C123": {
+				{Msg: rslack.Msg{Type: "message", User: "U1", Text: "disk full", Timestamp: "1783094460.000000"}},
+				{Msg: rslack.Msg{Type: "message", User: "U1", Text: "disk ok", Timestamp: "1783094520.000000"}},
+			},
+		},
+	}
+	opts := RenderOptions{
+		Timezone:     "UTC",
+		TrackChanges: true,
+		TimeLayout:   "15:04",
+		Templates:    map[string]string{"*": `{{.Sender}}: {{.Text}}`},
+	}
+	outputDir := t.TempDir()
+	if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-07-03", "2026-07-03", opts, nil, nil); err != nil {
+		t.Fatalf("first renderSourceRange() error = %v", err)
+	}
+	dateDir := filepath.Join(outputDir, "2026-07-03")
+	if _, err := os.Stat(trackedMessagesPath(dateDir, "2026-07-03-alerts")); err != nil {
+		t.Fatalf("tracked messages not saved: %v", err)
+	}
+
+	src.messages["C123"] = []rslack.Message{
+		{Msg: rslack.Msg{Type: "message", User: "U1", Text: "disk nearly full", Timestamp: "1783094460.000000"}},
+	}
+	if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-07-03", "2026-07-03", opts, nil, nil); err != nil {
+		t.Fatalf("second renderSourceRange() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dateDir, changesLogFilename))
+	if err != nil {
+		t.Fatalf("reading changes.log: %v", err)
+	}
+	for _, want := range []string{
+		"edited alerts: > alice [U1] @ 16:01:",
+		"- disk full\n+ disk nearly full",
+		"deleted alerts: > alice [U1] @ 16:02:",
+		"- disk ok",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("changes.log missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestWriteChannelDate_SavesTrackedMessagesOnlyWhenTracking(t *testing.T) {
+	outputDir := t.TempDir()
+	units := []renderedUnit{{text: renderedBefore, messages: 3, tracked: []trackedMessage{{TS: "1", Text: "hello world"}}}}
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", units, RenderOptions{}); err != nil {
+		t.Fatalf("writeChannelDate() error = %v", err)
+	}
+	path := trackedMessagesPath(filepath.Join(outputDir, "2026-07-01"), "2026-07-01-general")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("tracked messages saved without track_changes, stat err = %v", err)
+	}
+}
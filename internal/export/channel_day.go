@@ -0,0 +1,99 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportChannelDay writes one channel-day from the archive to w, formatted
+// as its file would be but never split into parts, and writes no files.
+// channel is a channel's file name (e.g. dm_alice) or ID.
+func (e *Exporter) ExportChannelDay(ctx context.Context, w io.Writer, date, channel string) (err error) {
+	defer func() { err = classifyError(err) }()
+	if _, _, err := GetDateBounds(date, e.cfg.Timezone); err != nil {
+		return err
+	}
+	archiveDir, err := e.ArchiveDir()
+	if err != nil {
+		return err
+	}
+	if !archiveExists(archiveDir) {
+		return fmt.Errorf("archive does not exist at %s; run slack-export sync first", archiveDir)
+	}
+	if err := e.preflightDates(ctx, date, date, time.Now()); err != nil {
+		return err
+	}
+
+	renderOpts, err := attachPseudonyms(e.renderOptions(ctx), archiveDir)
+	if err != nil {
+		return err
+	}
+	channelNames, err := loadChannelNames(archiveDir)
+	if err != nil {
+		return fmt.Errorf("loading channel names: %w", err)
+	}
+	src, err := LoadArchiveSource(ctx, archiveDir)
+	if err != nil {
+		return fmt.Errorf("loading archive source: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	content, err := renderChannelDay(ctx, src, renderOpts, channelNames, date, channel)
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		e.warnf("%s has no messages on %s", channel, date)
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		return err
+	}
+	return renderOpts.pseudonyms.save()
+}
+
+// renderChannelDay renders the channel whose file name or ID is channel
+// for one date, returning "" when it has no messages that day.
+func renderChannelDay(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	opts RenderOptions,
+	channelNames channelNameResolver,
+	date string,
+	channel string,
+) (string, error) {
+	src = opts.MessageFilter.source(opts.Dedup.source(src))
+	channels, err := src.Channels(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading channels: %w", err)
+	}
+	users, err := loadUsers(ctx, src)
+	if err != nil {
+		return "", err
+	}
+	lookup := newRenderLookup(users, opts)
+	for _, ch := range channels {
+		name := lookup.channelFileName(channelNames, ch)
+		if ch.ID != channel && !strings.EqualFold(name, channel) {
+			continue
+		}
+		messages, err := loadChannelMessages(ctx, src, ch.ID)
+		if err != nil {
+			return "", fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
+		}
+		req := RenderRequest{
+			Date:        date,
+			Timezone:    opts.timezoneFor(ch.ID, name),
+			ChannelID:   ch.ID,
+			ChannelName: name,
+		}
+		units, err := renderChannelDateUnits(ctx, src, req, lookup, messages, make(threadMessageCache))
+		if err != nil {
+			return "", fmt.Errorf("rendering %s %s: %w", date, ch.ID, err)
+		}
+		return joinRenderedUnits(units), nil
+	}
+	return "", withKind(ErrChannelSkipped, fmt.Errorf("channel %q is not in the archive", channel))
+}
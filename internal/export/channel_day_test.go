@@ -0,0 +1,48 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestRenderChannelDay(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{
+			{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1"}, Name: "eng-backend"}},
+			{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C2"}, Name: "random"}},
+		},
+		users: []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{
+			"C1": {
+				{Msg: rslack.Msg{Type: "message", User: "U1", Text: "Deploy done", Timestamp: "1768485600.000100"}},
+				{Msg: rslack.Msg{Type: "message", User: "U1", Text: "Next day", Timestamp: "1768572000.000100"}},
+			},
+			"C2": {{Msg: rslack.Msg{Type: "message", User: "U1", Text: "Lunch?", Timestamp: "1768485600.000200"}}},
+		},
+	}
+	opts := RenderOptions{Timezone: "UTC"}
+
+	for _, channel := range []string{"eng-backend", "ENG-BACKEND", "C1"} {
+		content, err := renderChannelDay(context.Background(), src, opts, nil, "2026-01-15", channel)
+		if err != nil {
+			t.Fatalf("renderChannelDay(%q) error = %v", channel, err)
+		}
+		if !strings.Contains(content, "Deploy done") || strings.Contains(content, "Next day") ||
+			strings.Contains(content, "Lunch?") {
+			t.Errorf("renderChannelDay(%q) =\n%s", channel, content)
+		}
+	}
+
+	content, err := renderChannelDay(context.Background(), src, opts, nil, "2026-01-14", "eng-backend")
+	if err != nil || content != "" {
+		t.Errorf("empty day = %q, %v, want no output", content, err)
+	}
+	_, err = renderChannelDay(context.Background(), src, opts, nil, "2026-01-15", "missing")
+	if !errors.Is(err, ErrChannelSkipped) {
+		t.Errorf("unknown channel error = %v, want ErrChannelSkipped", err)
+	}
+}
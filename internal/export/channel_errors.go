@@ -0,0 +1,128 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+// Answers a ChannelErrorHandler gives for a channel that failed to render.
+const (
+	ChannelRetry = "retry"
+	ChannelSkip  = "skip"
+	ChannelAbort = "abort"
+)
+
+// ChannelFailure describes a channel that failed to render. Err is
+// classified, so errors.Is matches ErrRateLimited and the other kinds.
+type ChannelFailure struct {
+	ChannelProgress
+	Err error
+	// Attempt counts renders of this channel so far, starting at 1.
+	Attempt int
+}
+
+// ChannelErrorHandler decides whether a failed channel is rendered again,
+// skipped so the rest of the run continues, or ends the run.
+type ChannelErrorHandler func(ChannelFailure) string
+
+// SetChannelErrorHandler sets how the Exporter handles a channel that fails
+// to render. Nil, the default, ends the run with the channel's error.
+func (e *Exporter) SetChannelErrorHandler(handler ChannelErrorHandler) {
+	e.channelErrors = handler
+}
+
+// skippedChannels collects the channels skipped after errors during a run
+// and the channel-days they left unrendered.
+type skippedChannels struct {
+	names map[string]string
+	jobs  []FailedJob
+}
+
+func newSkippedChannels() *skippedChannels {
+	return &skippedChannels{names: make(map[string]string)}
+}
+
+func (s *skippedChannels) add(id, name string, dates []string, err error) {
+	if s == nil {
+		return
+	}
+	s.names[id] = name
+	now := time.Now().UTC()
+	for _, date := range dates {
+		s.jobs = append(s.jobs, FailedJob{
+			ChannelID: id,
+			Channel:   name,
+			Date:      date,
+			Kind:      failureKind(err),
+			Error:     err.Error(),
+			FailedAt:  now,
+		})
+	}
+}
+
+// failedJobs returns the skipped channel-days, or nil when none.
+func (s *skippedChannels) failedJobs() []FailedJob {
+	if s == nil {
+		return nil
+	}
+	return s.jobs
+}
+
+func (s *skippedChannels) has(id string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.names[id]
+	return ok
+}
+
+// err reports the skipped channels as ErrChannelSkipped, or nil when none
+// were skipped.
+func (s *skippedChannels) err() error {
+	if s == nil || len(s.names) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(s.names))
+	for _, name := range s.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return withKind(ErrChannelSkipped, fmt.Errorf("skipped %d channel(s) after errors: %s; run slack-export retry-failed once fixed",
+		len(names), strings.Join(names, ", ")))
+}
+
+// renderChannel runs render for ch's dates and, when it fails, asks
+// OnChannelError whether to retry, skip, or abort. A skipped channel
+// returns no error, and its dates are queued as failed jobs. Cancellation and expired credentials always abort,
+// since every other channel would fail the same way.
+func (o RenderOptions) renderChannel(ctx context.Context, ch rslack.Channel, name string, dates []string, render func() (int, error)) (int, error) {
+	writes := 0
+	for attempt := 1; ; attempt++ {
+		n, err := render()
+		writes += n
+		if err == nil {
+			return writes, nil
+		}
+		err = classifyError(err)
+		if o.OnChannelError == nil || ctx.Err() != nil || errors.Is(err, ErrAuthExpired) {
+			return writes, err
+		}
+		failure := ChannelFailure{ChannelProgress: ChannelProgress{ID: ch.ID, Name: name}, Err: err, Attempt: attempt}
+		switch o.OnChannelError(failure) {
+		case ChannelRetry:
+			continue
+		case ChannelSkip:
+			o.warn(fmt.Errorf("skipped %s: %w", name, err))
+			o.skipped.add(ch.ID, name, dates, err)
+			return writes, nil
+		default:
+			return writes, err
+		}
+	}
+}
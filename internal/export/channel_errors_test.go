@@ -0,0 +1,102 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+// flakySource fails AllMessages for failChannel the first failures times.
+type flakySource struct {
+	memoryArchiveSource
+	failChannel string
+	failures    *int
+}
+
+func (s flakySource) AllMessages(ctx context.Context, channelID string) (iter.Seq2[rslack.Message, error], error) {
+	if channelID == s.failChannel && *s.failures > 0 {
+		*s.failures--
+		return nil, errors.New("database is locked")
+	}
+	return s.memoryArchiveSource.AllMessages(ctx, channelID)
+}
+
+func channelErrorsFixture(failures int) flakySource {
+	return flakySource{
+		memoryArchiveSource: memoryArchiveSource{
+			channels: []rslack.Channel{
+				{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1"}, Name: "broken"}},
+				{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C2"}, Name: "general"}},
+			},
+			users: []rslack.User{{ID: "U1", Name: "alice"}},
+			messages: map[string][]rslack.Message{
+				"C1": {{Msg: rslack.Msg{User: "U1", Text: "one", Timestamp: "1768485600.000100"}}},
+				"C2": {{Msg: rslack.Msg{User: "U1", Text: "two", Timestamp: "1768485600.000200"}}},
+			},
+		},
+		failChannel: "C1",
+		failures:    &failures,
+	}
+}
+
+func TestRenderSourceRange_ChannelErrorActions(t *testing.T) {
+	for _, tc := range []struct {
+		action      string
+		wantErr     bool
+		wantBroken  bool
+		wantGeneral bool
+	}{
+		{ChannelRetry, false, true, true},
+		{ChannelSkip, false, false, true},
+		{ChannelAbort, true, false, false},
+	} {
+		outputDir := t.TempDir()
+		var asked []ChannelFailure
+		opts := RenderOptions{
+			Timezone: "UTC",
+			OnChannelError: func(f ChannelFailure) string {
+				asked = append(asked, f)
+				return tc.action
+			},
+			events:  ConsoleEvents{Out: io.Discard, Err: io.Discard},
+			skipped: newSkippedChannels(),
+		}
+		_, err := renderSourceRange(context.Background(), channelErrorsFixture(1), outputDir, "2026-01-15", "2026-01-15", opts, nil, nil)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tc.action, err, tc.wantErr)
+		}
+		if len(asked) != 1 || asked[0].Name != "broken" || asked[0].Attempt != 1 {
+			t.Errorf("%s: handler asked %+v, want one failure for broken", tc.action, asked)
+		}
+		for name, want := range map[string]bool{"broken": tc.wantBroken, "general": tc.wantGeneral} {
+			_, statErr := os.Stat(filepath.Join(outputDir, "2026-01-15", "2026-01-15-"+name+".md"))
+			if (statErr == nil) != want {
+				t.Errorf("%s: %s written = %v, want %v", tc.action, name, statErr == nil, want)
+			}
+		}
+		if skipErr := opts.skipped.err(); (skipErr != nil) != (tc.action == ChannelSkip) ||
+			(skipErr != nil && !errors.Is(skipErr, ErrChannelSkipped)) {
+			t.Errorf("%s: skipped error = %v", tc.action, skipErr)
+		}
+	}
+}
+
+func TestRenderChannel_NoHandlerOrCancelledAborts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	opts := RenderOptions{OnChannelError: func(ChannelFailure) string { called = true; return ChannelRetry }}
+	failing := func() (int, error) { return 0, errors.New("boom") }
+	if _, err := opts.renderChannel(ctx, rslack.Channel{}, "general", nil, failing); err == nil || called {
+		t.Errorf("cancelled render: err = %v, handler called = %v", err, called)
+	}
+	if _, err := (RenderOptions{}).renderChannel(context.Background(), rslack.Channel{}, "general", nil, failing); err == nil {
+		t.Error("render without a handler did not return the channel error")
+	}
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+const channelEventsFile = "channel-events.md"
+
+// channelEvent is one line of channel-events.md.
+type channelEvent struct {
+	at   time.Time
+	text string
+}
+
+// recordChannelEvents writes <date>/channel-events.md for each day of the
+// sync's render window, listing the channels userBoot reports as created or
+// archived that day, when channel_events is enabled. The files are rebuilt
+// from userBoot each sync, so days that fall out of the window keep what was
+// last written. Failures only warn.
+func (e *Exporter) recordChannelEvents(ctx context.Context, now time.Time) {
+	if !e.cfg.ChannelEvents {
+		return
+	}
+	boot, err := e.edgeClient.ClientUserBoot(ctx)
+	if err != nil {
+		e.warnf("failed to collect channel events: %v", err)
+		return
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		e.warnf("failed to record channel events: %v", err)
+		return
+	}
+	from, to, err := e.renderWindow(now)
+	if err != nil {
+		e.warnf("failed to record channel events: %v", err)
+		return
+	}
+	events := channelEvents(boot.Channels, newFetchingUsers(ctx, e.edgeClient), loc)
+	for _, dir := range e.cfg.OutputDirs() {
+		if err := writeChannelEvents(dir, e.cfg.Layout, from, to, events); err != nil {
+			e.warnf("failed to write channel events: %v", err)
+		}
+	}
+}
+
+// channelEvents groups channel creations and archivals by work date.
+// Archived channels are dated by their last update, which for an archived
+// channel is the archival. Direct messages are left out.
+func channelEvents(channels []slack.UserBootChannel, users UserSource, loc *time.Location) map[string][]channelEvent {
+	events := make(map[string][]channelEvent)
+	add := func(at time.Time, text string) {
+		date := workDate(at.In(loc))
+		events[date] = append(events[date], channelEvent{at: at.In(loc), text: text})
+	}
+	for _, ch := range channels {
+		if ch.IsIM || ch.IsMpim {
+			continue
+		}
+		if ch.Created > 0 {
+			text := fmt.Sprintf("Created %s", channelEventName(ch))
+			if creator := channelCreatorName(ch.Creator, users); creator != "" {
+				text += " by " + creator
+			}
+			add(time.Unix(ch.Created, 0), text)
+		}
+		if ch.IsArchived && ch.Updated > 0 {
+			add(slackUpdatedTime(ch.Updated), fmt.Sprintf("Archived %s", channelEventName(ch)))
+		}
+	}
+	for _, day := range events {
+		sort.SliceStable(day, func(i, j int) bool {
+			if !day[i].at.Equal(day[j].at) {
+				return day[i].at.Before(day[j].at)
+			}
+			return day[i].text < day[j].text
+		})
+	}
+	return events
+}
+
+func channelEventName(ch slack.UserBootChannel) string {
+	name := "#" + ch.Name
+	if ch.IsPrivate || ch.IsGroup {
+		name += " (private)"
+	}
+	return name
+}
+
+// channelCreatorName names the creator by username when known, falling
+// back to the user ID.
+func channelCreatorName(id string, users UserSource) string {
+	if id == "" {
+		return ""
+	}
+	if users != nil {
+		if user, ok := users.User(id); ok && user.Name != "" {
+			return "@" + user.Name
+		}
+	}
+	return id
+}
+
+// slackUpdatedTime converts a conversation's updated field, which Slack
+// reports in milliseconds, tolerating second precision.
+func slackUpdatedTime(updated int64) time.Time {
+	if updated > 1e11 {
+		return time.UnixMilli(updated)
+	}
+	return time.Unix(updated, 0)
+}
+
+// writeChannelEvents rewrites channel-events.md for every date from through
+// to that has events.
+func writeChannelEvents(dir, layout, from, to string, events map[string][]channelEvent) error {
+	for date, day := range events {
+		if date < from || date > to {
+			continue
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Channel events %s\n\n", date)
+		for _, event := range day {
+			fmt.Fprintf(&b, "- %s %s\n", event.at.Format("15:04"), event.text)
+		}
+		path := filepath.Join(dateDir(dir, date, layout), dayFileName(date, layout, channelEventsFile))
+		if _, err := writeFileIfChanged(path, []byte(b.String()), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+type stubUsers map[string]*slack.User
+
+func (s stubUsers) User(id string) (*slack.User, bool) {
+	user, ok := s[id]
+	return user, ok
+}
+
+func TestChannelEvents_GroupsCreationsAndArchivalsByWorkDate(t *testing.T) {
+	created := time.Date(2026, 7, 3, 14, 5, 0, 0, time.UTC)
+	archived := time.Date(2026, 7, 3, 16, 30, 0, 0, time.UTC)
+	events := channelEvents([]slack.UserBootChannel{
+		{ID: "C1", Name: "launch", Created: created.Unix(), Creator: "U1"},
+		{ID: "C2", Name: "old", IsArchived: true, Created: 1700000000, Updated: archived.UnixMilli()},
+		{ID: "G1", Name: "secret", IsPrivate: true, Created: created.Add(-13 * time.Hour).Unix(), Creator: "U9"},
+		{ID: "D1", Name: "alice", IsIM: true, Created: created.Unix()},
+	}, stubUsers{"U1": {ID: "U1", Name: "alice"}}, time.UTC)
+
+	day := events["2026-07-03"]
+	if len(day) != 2 {
+		t.Fatalf("events on 2026-07-03 = %+v, want 2", day)
+	}
+	if day[0].text != "Created #launch by @alice" || day[1].text != "Archived #old" {
+		t.Errorf("events = %+v", day)
+	}
+	if got := events["2026-07-02"]; len(got) != 1 || got[0].text != "Created #secret (private) by U9" {
+		t.Errorf("events before the 3am boundary = %+v, want #secret on 2026-07-02", got)
+	}
+}
+
+func TestWriteChannelEvents_OnlyWritesWindow(t *testing.T) {
+	outputDir := t.TempDir()
+	at := time.Date(2026, 7, 3, 9, 30, 0, 0, time.UTC)
+	events := map[string][]channelEvent{
+		"2026-07-03": {{at: at, text: "Created #launch"}},
+		"2026-06-01": {{at: at.AddDate(0, -1, -2), text: "Created #ancient"}},
+	}
+	if err := writeChannelEvents(outputDir, "", "2026-07-01", "2026-07-03", events); err != nil {
+		t.Fatalf("writeChannelEvents() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-07-03", channelEventsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Channel events 2026-07-03\n\n- 09:30 Created #launch\n"; string(data) != want {
+		t.Errorf("channel-events.md =\n%s\nwant\n%s", data, want)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-06-01")); !os.IsNotExist(err) {
+		t.Errorf("dates outside the window should not be written, stat err = %v", err)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/chrisedwards/slack-export/internal/config"
 	appslack "github.com/chrisedwards/slack-export/internal/slack"
 	rslack "github.com/rusq/slack"
 )
@@ -29,7 +33,8 @@ func RenderSourceRangeWithChannelNames(
 	timezone string,
 	channelNames map[string]string,
 ) (int, error) {
-	return renderSourceRange(ctx, src, outputDir, from, to, timezone, channelNameResolver(channelNames), nil)
+	opts := RenderOptions{Timezone: timezone}
+	return renderSourceRange(ctx, src, outputDir, from, to, opts, channelNameResolver(channelNames), nil)
 }
 
 func (r channelNameResolver) fileName(ch rslack.Channel) string {
@@ -48,8 +53,17 @@ func (r channelNameResolver) fileName(ch rslack.Channel) string {
 	return ch.ID
 }
 
+// saveChannelNames records file names for the given channels. Names already
+// stored for other channels are kept so conversations archived outside the
+// tracked set (such as a DM exported with the dm command) keep their names.
 func saveChannelNames(archiveDir string, chans []appslack.Channel) error {
-	names := make(map[string]string, len(chans))
+	names, err := loadChannelNames(archiveDir)
+	if err != nil {
+		return err
+	}
+	if names == nil {
+		names = make(map[string]string, len(chans))
+	}
 	for _, ch := range chans {
 		id := strings.TrimSpace(ch.ID)
 		name := strings.TrimSpace(ch.Name)
@@ -66,7 +80,7 @@ func saveChannelNames(archiveDir string, chans []appslack.Channel) error {
 		return err
 	}
 	data = append(data, '\n')
-	return os.WriteFile(channelNamesPath(archiveDir), data, 0600)
+	return writeFileAtomic(channelNamesPath(archiveDir), data, 0600)
 }
 
 func loadChannelNames(archiveDir string) (map[string]string, error) {
@@ -87,3 +101,46 @@ func loadChannelNames(archiveDir string) (map[string]string, error) {
 func channelNamesPath(archiveDir string) string {
 	return filepath.Join(archiveDir, channelNamesFilename)
 }
+
+// CachedChannelNames returns the sorted file names recorded for the
+// workspace's archive by earlier syncs, without contacting Slack. It is
+// used for shell completion.
+func CachedChannelNames(cfg *config.Config, workspace string) ([]string, error) {
+	archiveDir, err := WorkspaceArchiveDir(cfg, workspace)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := loadChannelNames(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(stored))
+	for _, name := range stored {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return slices.Compact(names), nil
+}
+
+// ArchiveChannelNames returns the current file name of every channel in the
+// archive by channel ID, ignoring channel_aliases.
+func ArchiveChannelNames(ctx context.Context, archiveDir string) (map[string]string, error) {
+	stored, err := loadChannelNames(archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading channel names: %w", err)
+	}
+	src, err := LoadArchiveSource(ctx, archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading archive source: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+	chans, err := src.Channels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading channels: %w", err)
+	}
+	names := make(map[string]string, len(chans))
+	for _, ch := range chans {
+		names[ch.ID] = channelNameResolver(stored).fileName(ch)
+	}
+	return names, nil
+}
@@ -1,8 +1,13 @@
 package export
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/chrisedwards/slack-export/internal/config"
 	appslack "github.com/chrisedwards/slack-export/internal/slack"
 	rslack "github.com/rusq/slack"
 )
@@ -31,6 +36,25 @@ func TestSaveAndLoadChannelNames(t *testing.T) {
 	}
 }
 
+func TestSaveChannelNames_KeepsNamesForOtherChannels(t *testing.T) {
+	archiveDir := t.TempDir()
+
+	if err := saveChannelNames(archiveDir, []appslack.Channel{{ID: "D999", Name: "dm_alice"}}); err != nil {
+		t.Fatalf("saveChannelNames() error = %v", err)
+	}
+	if err := saveChannelNames(archiveDir, []appslack.Channel{{ID: "C123", Name: "engineering"}}); err != nil {
+		t.Fatalf("saveChannelNames() error = %v", err)
+	}
+
+	got, err := loadChannelNames(archiveDir)
+	if err != nil {
+		t.Fatalf("loadChannelNames() error = %v", err)
+	}
+	if got["D999"] != "dm_alice" || got["C123"] != "engineering" {
+		t.Fatalf("names = %v, want both channels", got)
+	}
+}
+
 func TestChannelNameResolverFallsBackToArchiveName(t *testing.T) {
 	ch := rslack.Channel{
 		GroupConversation: rslack.GroupConversation{
@@ -44,3 +68,52 @@ func TestChannelNameResolverFallsBackToArchiveName(t *testing.T) {
 		t.Fatalf("fileName() = %q, want archive-name", got)
 	}
 }
+
+func TestCachedChannelNames(t *testing.T) {
+	cfg := &config.Config{ArchiveDir: t.TempDir()}
+	archiveDir, err := WorkspaceArchiveDir(cfg, "acme")
+	if err != nil {
+		t.Fatalf("WorkspaceArchiveDir() error = %v", err)
+	}
+	err = saveChannelNames(archiveDir, []appslack.Channel{
+		{ID: "C2", Name: "general"},
+		{ID: "C1", Name: "engineering"},
+		{ID: "D1", Name: "dm_alice"},
+	})
+	if err != nil {
+		t.Fatalf("saveChannelNames() error = %v", err)
+	}
+
+	got, err := CachedChannelNames(cfg, "acme")
+	if err != nil {
+		t.Fatalf("CachedChannelNames() error = %v", err)
+	}
+	want := []string{"dm_alice", "engineering", "general"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("names = %v, want %v", got, want)
+	}
+}
+
+func TestRenderSourceRange_ChannelAliases(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "eng-backend-renamed",
+		}}},
+		users: []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{"C123": {
+			{Msg: rslack.Msg{User: "U1", Text: "hello", Timestamp: "1768485600.000100"}},
+		}},
+	}
+	outputDir := t.TempDir()
+	opts := RenderOptions{Timezone: "UTC", ChannelAliases: map[string]string{"C123": "eng-backend"}}
+	if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15", opts, nil, nil); err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-01-15", "2026-01-15-eng-backend.md")); err != nil {
+		t.Errorf("aliased file not written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-01-15", "2026-01-15-eng-backend-renamed.md")); !os.IsNotExist(err) {
+		t.Errorf("file written under the Slack name")
+	}
+}
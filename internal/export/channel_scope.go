@@ -0,0 +1,66 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+// ChannelScope narrows one run to a kind of conversation on top of the
+// configured include and exclude patterns.
+type ChannelScope string
+
+const (
+	// ScopeAll keeps every conversation the patterns select.
+	ScopeAll ChannelScope = ""
+	// ScopeDMs keeps direct and group messages (--only-dms).
+	ScopeDMs ChannelScope = "dms"
+	// ScopeChannels keeps public and private channels (--only-channels).
+	ScopeChannels ChannelScope = "channels"
+)
+
+func (s ChannelScope) keeps(direct bool) bool {
+	switch s {
+	case ScopeDMs:
+		return direct
+	case ScopeChannels:
+		return !direct
+	default:
+		return true
+	}
+}
+
+// FilterChannels returns the channels in scope, in input order.
+func (s ChannelScope) FilterChannels(chans []slack.Channel) []slack.Channel {
+	if s == ScopeAll {
+		return chans
+	}
+	var kept []slack.Channel
+	for _, ch := range chans {
+		if s.keeps(ch.IsIM || ch.IsMPIM || isDirectID(ch.ID, ch.Name)) {
+			kept = append(kept, ch)
+		}
+	}
+	return kept
+}
+
+// filterArchive returns the archive channels in scope, in input order.
+func (s ChannelScope) filterArchive(chans []rslack.Channel) []rslack.Channel {
+	if s == ScopeAll {
+		return chans
+	}
+	var kept []rslack.Channel
+	for _, ch := range chans {
+		if s.keeps(ch.IsIM || ch.IsMpIM || isDirectID(ch.ID, ch.Name)) {
+			kept = append(kept, ch)
+		}
+	}
+	return kept
+}
+
+// isDirectID recognizes direct and group messages whose type flags are
+// missing: DM IDs start with D and group DMs are named mpdm-….
+func isDirectID(id, name string) bool {
+	return strings.HasPrefix(id, "D") || strings.HasPrefix(name, "mpdm-")
+}
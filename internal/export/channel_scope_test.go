@@ -0,0 +1,63 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+func TestChannelScope_FilterChannels(t *testing.T) {
+	chans := []slack.Channel{
+		{ID: "C1", Name: "general"},
+		{ID: "D1", Name: "dm_alice", IsIM: true},
+		{ID: "G1", Name: "mpdm-alice--bob-1"},
+		{ID: "G2", Name: "secret", IsPrivate: true},
+	}
+	ids := func(chans []slack.Channel) []string {
+		var out []string
+		for _, ch := range chans {
+			out = append(out, ch.ID)
+		}
+		return out
+	}
+	if got := ids(ScopeDMs.FilterChannels(chans)); !reflect.DeepEqual(got, []string{"D1", "G1"}) {
+		t.Errorf("ScopeDMs = %v", got)
+	}
+	if got := ids(ScopeChannels.FilterChannels(chans)); !reflect.DeepEqual(got, []string{"C1", "G2"}) {
+		t.Errorf("ScopeChannels = %v", got)
+	}
+	if got := ScopeAll.FilterChannels(chans); len(got) != len(chans) {
+		t.Errorf("ScopeAll kept %d of %d", len(got), len(chans))
+	}
+}
+
+func TestRenderSourceRange_OnlyDMs(t *testing.T) {
+	channel := rslack.Channel{}
+	channel.ID, channel.Name = "C1", "general"
+	dm := rslack.Channel{}
+	dm.ID, dm.Name, dm.IsIM = "D1", "dm_alice", true
+	msg := rslack.Message{Msg: rslack.Msg{User: "U1", Text: "hi", Timestamp: "1768485600.000100"}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{channel, dm},
+		users:    []rslack.User{{ID: "U1", Name: "alice"}},
+		messages: map[string][]rslack.Message{"C1": {msg}, "D1": {msg}},
+	}
+	outputDir := t.TempDir()
+	opts := RenderOptions{Timezone: "UTC", Scope: ScopeDMs}
+	names := channelNameResolver{"D1": "dm_alice"}
+	if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15", opts, names, nil); err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(outputDir, "2026-01-15"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "2026-01-15-dm_alice.md" {
+		t.Errorf("rendered %v, want only the DM", entries)
+	}
+}
@@ -0,0 +1,235 @@
+package export
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+// checksumFile lists the SHA-256 of every file in a date folder in the
+// format sha256sum -c reads.
+const checksumFile = "SHA256SUMS"
+
+// dateManifestPattern matches manifest.json and the date-prefixed manifests
+// of weekly and monthly folders.
+var dateManifestPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}-)?manifest\.json$`)
+
+// dateChecksums collects the date folders written during a render so their
+// SHA256SUMS can be rewritten once the folder is complete. Weekly and
+// monthly folders get one SHA256SUMS for all their days. A nil
+// *dateChecksums records nothing.
+type dateChecksums struct {
+	// pending maps output dir to the dates written under it.
+	pending map[string]map[string]bool
+}
+
+// checksumsFromConfig returns a collector when checksums is enabled.
+func checksumsFromConfig(cfg *config.Config) *dateChecksums {
+	if !cfg.Checksums {
+		return nil
+	}
+	return newDateChecksums()
+}
+
+func newDateChecksums() *dateChecksums {
+	return &dateChecksums{pending: make(map[string]map[string]bool)}
+}
+
+func (c *dateChecksums) record(outputDir, date string) {
+	if c == nil {
+		return
+	}
+	if c.pending[outputDir] == nil {
+		c.pending[outputDir] = make(map[string]bool)
+	}
+	c.pending[outputDir][date] = true
+}
+
+// flush rewrites SHA256SUMS in each date folder recorded under outputDir.
+func (c *dateChecksums) flush(outputDir, layout string) error {
+	if c == nil {
+		return nil
+	}
+	folders := make(map[string]bool)
+	for date := range c.pending[outputDir] {
+		folders[dateFolder(date, layout)] = true
+	}
+	for folder := range folders {
+		if err := writeDateChecksums(filepath.Join(outputDir, folder)); err != nil {
+			return fmt.Errorf("writing checksums for %s: %w", folder, err)
+		}
+	}
+	delete(c.pending, outputDir)
+	return nil
+}
+
+// writeDateChecksums hashes every file in dir, sorted by path.
+func writeDateChecksums(dir string) error {
+	sums, err := hashDateFolder(dir)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(sums))
+	for path := range sums {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var out strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&out, "%s  %s\n", sums[path], path)
+	}
+	_, err = writeFileIfChanged(filepath.Join(dir, checksumFile), []byte(out.String()), false)
+	return err
+}
+
+// hashDateFolder returns the SHA-256 of each file under dir by slash-separated
+// relative path. SHA256SUMS itself, date manifests (rewritten by later count
+// checks), and hidden files such as in-progress writes are skipped.
+func hashDateFolder(dir string) (map[string]string, error) {
+	sums := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == checksumFile || dateManifestPattern.MatchString(rel) {
+			return nil
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		sums[rel] = sum
+		return nil
+	})
+	return sums, err
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ChecksumReport is the result of checking date folders against their
+// SHA256SUMS. Paths are relative to the output directory.
+type ChecksumReport struct {
+	Folders int
+	// Modified files no longer match their recorded checksum, Missing files
+	// are listed but gone, and Unlisted files were added after the
+	// checksums were written.
+	Modified []string
+	Missing  []string
+	Unlisted []string
+}
+
+// OK reports whether every listed file is present and unchanged.
+func (r *ChecksumReport) OK() bool {
+	return len(r.Modified) == 0 && len(r.Missing) == 0
+}
+
+// VerifyChecksums checks every date folder under outputDir that has a
+// SHA256SUMS file.
+func VerifyChecksums(outputDir string) (*ChecksumReport, error) {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	report := &ChecksumReport{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !exportFolderPattern.MatchString(entry.Name()) {
+			continue
+		}
+		dir := filepath.Join(outputDir, entry.Name())
+		recorded, err := readChecksumFile(filepath.Join(dir, checksumFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		actual, err := hashDateFolder(dir)
+		if err != nil {
+			return nil, err
+		}
+		report.Folders++
+		report.add(entry.Name(), recorded, actual)
+	}
+	return report, nil
+}
+
+func (r *ChecksumReport) add(date string, recorded, actual map[string]string) {
+	for path, sum := range recorded {
+		got, ok := actual[path]
+		switch {
+		case !ok:
+			r.Missing = append(r.Missing, date+"/"+path)
+		case got != sum:
+			r.Modified = append(r.Modified, date+"/"+path)
+		}
+	}
+	for path := range actual {
+		if _, ok := recorded[path]; !ok {
+			r.Unlisted = append(r.Unlisted, date+"/"+path)
+		}
+	}
+	sort.Strings(r.Modified)
+	sort.Strings(r.Missing)
+	sort.Strings(r.Unlisted)
+}
+
+// readChecksumFile parses "<hex>  <path>" lines, also accepting the "*"
+// binary-mode marker sha256sum may write.
+func readChecksumFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		sum, name, ok := strings.Cut(line, " ")
+		if !ok || len(sum) != sha256.Size*2 {
+			return nil, fmt.Errorf("malformed line in %s: %q", path, line)
+		}
+		name = strings.TrimPrefix(strings.TrimPrefix(name, " "), "*")
+		sums[name] = sum
+	}
+	return sums, scanner.Err()
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDateChecksums_FlushWritesSHA256SUMS(t *testing.T) {
+	outputDir := t.TempDir()
+	dir := filepath.Join(outputDir, "2026-01-15")
+	writeTestFile(t, filepath.Join(dir, "2026-01-15-general.md"), "hello\n")
+	writeTestFile(t, filepath.Join(dir, "translations", "es", "2026-01-15-general.md"), "hola\n")
+	writeTestFile(t, filepath.Join(dir, dateManifestFile), "{}\n")
+	writeTestFile(t, filepath.Join(dir, ".tmp-write"), "partial")
+
+	checksums := newDateChecksums()
+	checksums.record(outputDir, "2026-01-15")
+	if err := checksums.flush(outputDir, ""); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, checksumFile))
+	if err != nil {
+		t.Fatalf("reading %s: %v", checksumFile, err)
+	}
+	want := "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03  2026-01-15-general.md\n" +
+		"133ee989293f92736301280c6f14c89d521200c17dcdcecca30cd20705332d44  translations/es/2026-01-15-general.md\n"
+	if string(data) != want {
+		t.Errorf("%s =\n%s\nwant\n%s", checksumFile, data, want)
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	outputDir := t.TempDir()
+	dir := filepath.Join(outputDir, "2026-01-15")
+	writeTestFile(t, filepath.Join(dir, "2026-01-15-general.md"), "hello\n")
+	writeTestFile(t, filepath.Join(dir, "2026-01-15-random.md"), "hi\n")
+	writeTestFile(t, filepath.Join(outputDir, "2026-01-16", "2026-01-16-general.md"), "no sums\n")
+	if err := writeDateChecksums(dir); err != nil {
+		t.Fatalf("writeDateChecksums() error = %v", err)
+	}
+
+	report, err := VerifyChecksums(outputDir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums() error = %v", err)
+	}
+	if !report.OK() || report.Folders != 1 || len(report.Unlisted) != 0 {
+		t.Errorf("clean report = %+v", report)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "2026-01-15-general.md"), "edited\n")
+	if err := os.Remove(filepath.Join(dir, "2026-01-15-random.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "added later\n")
+
+	report, err = VerifyChecksums(outputDir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums() error = %v", err)
+	}
+	if report.OK() {
+		t.Error("tampered folder should fail verification")
+	}
+	want := &ChecksumReport{
+		Folders:  1,
+		Modified: []string{"2026-01-15/2026-01-15-general.md"},
+		Missing:  []string{"2026-01-15/2026-01-15-random.md"},
+		Unlisted: []string{"2026-01-15/notes.txt"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("VerifyChecksums() = %+v, want %+v", report, want)
+	}
+}
@@ -0,0 +1,217 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+	"github.com/chrisedwards/slack-export/internal/config"
+	rslack "github.com/rusq/slack"
+)
+
+// Orderings for channels listed in a date index.
+const (
+	IndexOrderAlpha    = "alpha"
+	IndexOrderActivity = "activity"
+	IndexOrderPriority = "priority"
+)
+
+// Channel kinds, in the order their sections appear in index.md.
+var indexKinds = []string{"Channels", "Private channels", "Group messages", "Direct messages"}
+
+const (
+	dateIndexFile     = "index.md"
+	dateIndexDataFile = ".index.json"
+)
+
+// indexEntry is one channel-day listed in a date's index.md.
+type indexEntry struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Messages int    `json:"messages"`
+	Files    int    `json:"files"`
+}
+
+// DateIndex collects the channel-days written during a render and keeps an
+// index.md in each touched date folder. Entries are also stored in a hidden
+// .index.json so channel-days that a later run does not re-render stay
+// listed. A nil *DateIndex records nothing.
+type DateIndex struct {
+	order    string
+	priority []string
+	pending  map[string]map[string]map[string]indexEntry
+}
+
+// NewDateIndex creates an index writer. priority holds the include patterns
+// used by the "priority" order; earlier patterns list first.
+func NewDateIndex(order string, priority []string) *DateIndex {
+	return &DateIndex{order: order, priority: priority, pending: make(map[string]map[string]map[string]indexEntry)}
+}
+
+// dateIndexFromConfig returns nil unless date_index is enabled. The
+// priority order follows the top-level include patterns, then each target's.
+func dateIndexFromConfig(cfg *config.Config) *DateIndex {
+	if !cfg.DateIndex {
+		return nil
+	}
+	priority := append([]string(nil), cfg.Include...)
+	for _, target := range cfg.Targets {
+		priority = append(priority, target.Include...)
+	}
+	return NewDateIndex(cfg.IndexOrder, priority)
+}
+
+// channelKind groups a channel for the index.
+func channelKind(ch rslack.Channel) string {
+	switch {
+	case ch.IsMpIM:
+		return indexKinds[2]
+	case ch.IsIM || strings.HasPrefix(ch.ID, "D"):
+		return indexKinds[3]
+	case ch.IsPrivate || ch.IsGroup:
+		return indexKinds[1]
+	default:
+		return indexKinds[0]
+	}
+}
+
+// record notes a rendered channel-day.
+func (x *DateIndex) record(outputDir, date, name, kind string, units []renderedUnit, opts RenderOptions) {
+	if x == nil {
+		return
+	}
+	messages := unitMessages(units)
+	files := len(splitRenderedUnits(units, opts.MaxFileSize, opts.MaxMessagesPerFile))
+	if x.pending[outputDir] == nil {
+		x.pending[outputDir] = make(map[string]map[string]indexEntry)
+	}
+	if x.pending[outputDir][date] == nil {
+		x.pending[outputDir][date] = make(map[string]indexEntry)
+	}
+	base := channelFileBase(date, name, opts.FilenameDate, opts.Layout)
+	x.pending[outputDir][date][base] = indexEntry{Name: name, Kind: kind, Messages: messages, Files: max(files, 1)}
+}
+
+// flush rewrites index.md for every date recorded under outputDir.
+func (x *DateIndex) flush(outputDir, layout string) error {
+	if x == nil {
+		return nil
+	}
+	for date, entries := range x.pending[outputDir] {
+		if err := x.writeDate(dateDir(outputDir, date, layout), date, layout, entries); err != nil {
+			return fmt.Errorf("writing index for %s: %w", date, err)
+		}
+	}
+	delete(x.pending, outputDir)
+	return nil
+}
+
+func (x *DateIndex) writeDate(dir, date, layout string, recorded map[string]indexEntry) error {
+	dataFile := filepath.Join(dir, dayFileName(date, layout, dateIndexDataFile))
+	entries, err := loadIndexEntries(dataFile)
+	if err != nil {
+		return err
+	}
+	for base, entry := range recorded {
+		entries[base] = entry
+	}
+	for base, entry := range entries {
+		if _, err := os.Stat(filepath.Join(dir, indexFileName(base, entry))); err != nil {
+			delete(entries, base)
+		}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(dataFile, data, 0600); err != nil {
+		return err
+	}
+	indexFile := filepath.Join(dir, dayFileName(date, layout, dateIndexFile))
+	_, err = writeFileIfChanged(indexFile, []byte(x.markdown(date, entries)), false)
+	return err
+}
+
+func loadIndexEntries(path string) (map[string]indexEntry, error) {
+	entries := make(map[string]indexEntry)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+	return entries, nil
+}
+
+// indexFileName is the file an entry links to: the day file, or its first
+// part when the day was split.
+func indexFileName(base string, entry indexEntry) string {
+	if entry.Files > 1 {
+		return base + "-part1.md"
+	}
+	return base + ".md"
+}
+
+func (x *DateIndex) markdown(date string, entries map[string]indexEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", date)
+	for _, kind := range indexKinds {
+		var bases []string
+		for base, entry := range entries {
+			if entry.Kind == kind {
+				bases = append(bases, base)
+			}
+		}
+		if len(bases) == 0 {
+			continue
+		}
+		x.sortBases(bases, entries)
+		fmt.Fprintf(&b, "\n## %s\n\n", kind)
+		for _, base := range bases {
+			entry := entries[base]
+			fmt.Fprintf(&b, "- [%s](%s) — %d message(s)", entry.Name, indexFileName(base, entry), entry.Messages)
+			if entry.Files > 1 {
+				fmt.Fprintf(&b, " in %d parts", entry.Files)
+			}
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+func (x *DateIndex) sortBases(bases []string, entries map[string]indexEntry) {
+	sort.Slice(bases, func(i, j int) bool {
+		a, b := entries[bases[i]], entries[bases[j]]
+		switch x.order {
+		case IndexOrderActivity:
+			if a.Messages != b.Messages {
+				return a.Messages > b.Messages
+			}
+		case IndexOrderPriority:
+			if pa, pb := x.priorityOf(a.Name), x.priorityOf(b.Name); pa != pb {
+				return pa < pb
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// priorityOf is the index of the first include pattern matching name, or
+// len(priority) when none does.
+func (x *DateIndex) priorityOf(name string) int {
+	for i, pattern := range x.priority {
+		if channels.MatchPattern(pattern, name) {
+			return i
+		}
+	}
+	return len(x.priority)
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func writeIndexedDay(t *testing.T, x *DateIndex, outputDir, name, kind string, messages int) {
+	t.Helper()
+	units := make([]renderedUnit, messages)
+	for i := range units {
+		units[i] = renderedUnit{text: "msg\n", messages: 1}
+	}
+	opts := RenderOptions{Index: x}
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-03-02", name, units, opts); err != nil {
+		t.Fatal(err)
+	}
+	x.record(outputDir, "2026-03-02", name, kind, units, opts)
+}
+
+func readIndex(t *testing.T, outputDir string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-03-02", "index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestDateIndex_GroupsByKindAlphabetically(t *testing.T) {
+	outputDir := t.TempDir()
+	x := NewDateIndex("", nil)
+	writeIndexedDay(t, x, outputDir, "random", "Channels", 1)
+	writeIndexedDay(t, x, outputDir, "dm_alice", "Direct messages", 2)
+	writeIndexedDay(t, x, outputDir, "general", "Channels", 3)
+	if err := x.flush(outputDir, ""); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	want := "# 2026-03-02\n\n" +
+		"## Channels\n\n" +
+		"- [general](2026-03-02-general.md) — 3 message(s)\n" +
+		"- [random](2026-03-02-random.md) — 1 message(s)\n\n" +
+		"## Direct messages\n\n" +
+		"- [dm_alice](2026-03-02-dm_alice.md) — 2 message(s)\n"
+	if got := readIndex(t, outputDir); got != want {
+		t.Errorf("index.md =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDateIndex_Orders(t *testing.T) {
+	tests := []struct {
+		order    string
+		priority []string
+		want     []string
+	}{
+		{IndexOrderActivity, nil, []string{"busy", "alpha", "zeta"}},
+		{IndexOrderPriority, []string{"zeta", "b*"}, []string{"zeta", "busy", "alpha"}},
+	}
+	for _, tt := range tests {
+		outputDir := t.TempDir()
+		x := NewDateIndex(tt.order, tt.priority)
+		writeIndexedDay(t, x, outputDir, "alpha", "Channels", 1)
+		writeIndexedDay(t, x, outputDir, "busy", "Channels", 5)
+		writeIndexedDay(t, x, outputDir, "zeta", "Channels", 1)
+		if err := x.flush(outputDir, ""); err != nil {
+			t.Fatal(err)
+		}
+		index := readIndex(t, outputDir)
+		last := -1
+		for _, name := range tt.want {
+			pos := strings.Index(index, "["+name+"]")
+			if pos < last {
+				t.Errorf("%s order: %s out of place in\n%s", tt.order, name, index)
+			}
+			last = pos
+		}
+	}
+}
+
+func TestDateIndex_KeepsEarlierRunsAndPrunesMissingFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	first := NewDateIndex("", nil)
+	writeIndexedDay(t, first, outputDir, "general", "Channels", 2)
+	writeIndexedDay(t, first, outputDir, "gone", "Channels", 1)
+	if err := first.flush(outputDir, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(outputDir, "2026-03-02", "2026-03-02-gone.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	second := NewDateIndex("", nil)
+	writeIndexedDay(t, second, outputDir, "random", "Channels", 1)
+	if err := second.flush(outputDir, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	index := readIndex(t, outputDir)
+	if !strings.Contains(index, "[general]") || !strings.Contains(index, "[random]") || strings.Contains(index, "[gone]") {
+		t.Errorf("index.md =\n%s", index)
+	}
+}
+
+func TestDateIndex_LinksFirstPart(t *testing.T) {
+	outputDir := t.TempDir()
+	x := NewDateIndex("", nil)
+	units := []renderedUnit{{text: "a\n", messages: 1}, {text: "b\n", messages: 1}}
+	opts := RenderOptions{MaxMessagesPerFile: 1, Index: x}
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-03-02", "general", units, opts); err != nil {
+		t.Fatal(err)
+	}
+	x.record(outputDir, "2026-03-02", "general", "Channels", units, opts)
+	if err := x.flush(outputDir, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if index := readIndex(t, outputDir); !strings.Contains(index, "(2026-03-02-general-part1.md) — 2 message(s) in 2 parts") {
+		t.Errorf("index.md =\n%s", index)
+	}
+}
+
+func TestChannelKind(t *testing.T) {
+	tests := map[string]rslack.Channel{
+		"Channels":         {GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1"}}},
+		"Private channels": {GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C2", IsPrivate: true}}},
+		"Group messages":   {GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "G1", IsMpIM: true}}},
+		"Direct messages":  {GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "D1"}}},
+	}
+	for want, ch := range tests {
+		if got := channelKind(ch); got != want {
+			t.Errorf("channelKind(%s) = %q, want %q", ch.ID, got, want)
+		}
+	}
+}
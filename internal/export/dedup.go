@@ -0,0 +1,181 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/pkg/slackts"
+	rslack "github.com/rusq/slack"
+)
+
+// Deduplicator drops messages the archive holds more than once, as happens
+// when a day imported from a slackdump export zip is also exported live.
+// Copies match by timestamp, or by sender and text when an imported copy's
+// timestamp lost its microseconds. A nil *Deduplicator keeps every message.
+type Deduplicator struct {
+	// dropped maps channel ID to the keys of the copies dropped so far, so
+	// a channel loaded by several renders counts each duplicate once.
+	dropped  map[string]map[string]bool
+	reported int
+}
+
+// NewDeduplicator returns an empty deduplicator.
+func NewDeduplicator() *Deduplicator {
+	return &Deduplicator{dropped: make(map[string]map[string]bool)}
+}
+
+// source wraps src so each channel's and thread's messages are listed once.
+func (d *Deduplicator) source(src ArchiveMessageSource) ArchiveMessageSource {
+	if d == nil {
+		return src
+	}
+	return dedupSource{ArchiveMessageSource: src, dedup: d}
+}
+
+type dedupSource struct {
+	ArchiveMessageSource
+	dedup *Deduplicator
+}
+
+func (s dedupSource) AllMessages(ctx context.Context, channelID string) (iter.Seq2[rslack.Message, error], error) {
+	seq, err := s.ArchiveMessageSource.AllMessages(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return s.dedup.filter(channelID, seq), nil
+}
+
+func (s dedupSource) AllThreadMessages(
+	ctx context.Context,
+	channelID string,
+	threadTS string,
+) (iter.Seq2[rslack.Message, error], error) {
+	seq, err := s.ArchiveMessageSource.AllThreadMessages(ctx, channelID, threadTS)
+	if err != nil {
+		return nil, err
+	}
+	return s.dedup.filter(channelID, seq), nil
+}
+
+// filter reads the whole sequence, since the copy kept may come after the
+// one it replaces, then yields the survivors in their original order.
+func (d *Deduplicator) filter(channelID string, seq iter.Seq2[rslack.Message, error]) iter.Seq2[rslack.Message, error] {
+	return func(yield func(rslack.Message, error) bool) {
+		var messages []rslack.Message
+		for msg, err := range seq {
+			if err != nil {
+				yield(rslack.Message{}, err)
+				return
+			}
+			messages = append(messages, msg)
+		}
+		for _, msg := range d.dedupe(channelID, messages) {
+			if !yield(msg, nil) {
+				return
+			}
+		}
+	}
+}
+
+// dedupe keeps one copy of each message: the most recently edited, or the
+// first listed. Whole-second timestamps are not unique, since imports that
+// truncate them collide, so those copies must also share sender and text. A
+// copy with a whole-second timestamp gives way to one with the same sender
+// and text whose timestamp has the microseconds.
+func (d *Deduplicator) dedupe(channelID string, messages []rslack.Message) []rslack.Message {
+	byKey := make(map[string]int, len(messages))
+	var kept []rslack.Message
+	for _, msg := range messages {
+		key := msg.Timestamp
+		if wholeSecond(key) {
+			content, _ := contentKey(msg)
+			key += "\x00" + content
+		}
+		i, ok := byKey[key]
+		if !ok {
+			byKey[key] = len(kept)
+			kept = append(kept, msg)
+			continue
+		}
+		if editedAfter(msg, kept[i]) {
+			kept[i] = msg
+		}
+		d.drop(channelID, key)
+	}
+
+	precise := make(map[string]bool)
+	for _, msg := range kept {
+		if key, ok := contentKey(msg); ok && !wholeSecond(msg.Timestamp) {
+			precise[key] = true
+		}
+	}
+	out := kept[:0]
+	for _, msg := range kept {
+		if key, ok := contentKey(msg); ok && wholeSecond(msg.Timestamp) && precise[key] {
+			d.drop(channelID, msg.Timestamp+"\x00"+key)
+			continue
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+func (d *Deduplicator) drop(channelID, key string) {
+	keys, ok := d.dropped[channelID]
+	if !ok {
+		keys = make(map[string]bool)
+		d.dropped[channelID] = keys
+	}
+	keys[key] = true
+}
+
+// Dropped returns the number of duplicate messages dropped so far.
+func (d *Deduplicator) Dropped() int {
+	if d == nil {
+		return 0
+	}
+	total := 0
+	for _, keys := range d.dropped {
+		total += len(keys)
+	}
+	return total
+}
+
+// report announces the duplicates dropped since the last report, so a
+// render to several output directories reports them once.
+func (d *Deduplicator) report(stage func(string)) {
+	total := d.Dropped()
+	if d == nil || total == d.reported {
+		return
+	}
+	stage(fmt.Sprintf("Dropped %d duplicate message(s) in %d channel(s)", total-d.reported, len(d.dropped)))
+	d.reported = total
+}
+
+// contentKey identifies a message by sender, text, and whole second.
+// Messages without text cannot be matched by content.
+func contentKey(msg rslack.Message) (string, bool) {
+	if strings.TrimSpace(msg.Text) == "" {
+		return "", false
+	}
+	sender := msg.User
+	if sender == "" {
+		sender = msg.BotID
+	}
+	seconds, _, _ := strings.Cut(msg.Timestamp, ".")
+	return sender + "\x00" + seconds + "\x00" + msg.Text, true
+}
+
+func wholeSecond(ts string) bool {
+	_, frac, _ := strings.Cut(ts, ".")
+	return strings.Trim(frac, "0") == ""
+}
+
+func editedAfter(msg, than rslack.Message) bool {
+	if msg.Edited == nil {
+		return false
+	}
+	return than.Edited == nil || slackts.Less(than.Edited.Timestamp, msg.Edited.Timestamp)
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestDeduplicator_Dedupe(t *testing.T) {
+	message := func(ts, user, text string) rslack.Message {
+		return rslack.Message{Msg: rslack.Msg{Type: "message", User: user, Text: text, Timestamp: ts}}
+	}
+	original := message("1768485600.000100", "U1", "Deploying now")
+	edited := original
+	edited.Text = "Deploying now (edited)"
+	edited.Edited = &rslack.Edited{User: "U1", Timestamp: "1768485700.000000"}
+	imported := message("1768486600.000000", "U1", "Release is out")
+	live := message("1768486600.123400", "U1", "Release is out")
+	other := message("1768486600.000000", "U2", "Release is out")
+
+	dedup := NewDeduplicator()
+	got := dedup.dedupe("C1", []rslack.Message{original, imported, edited, live, other, original})
+	var texts []string
+	for _, msg := range got {
+		texts = append(texts, msg.Timestamp+" "+msg.Text)
+	}
+	want := []string{
+		"1768485600.000100 Deploying now (edited)",
+		"1768486600.123400 Release is out",
+		"1768486600.000000 Release is out",
+	}
+	if strings.Join(texts, "\n") != strings.Join(want, "\n") {
+		t.Errorf("dedupe() =\n%s\nwant\n%s", strings.Join(texts, "\n"), strings.Join(want, "\n"))
+	}
+	if dedup.Dropped() != 2 {
+		t.Errorf("Dropped() = %d, want 2", dedup.Dropped())
+	}
+	if (*Deduplicator)(nil).Dropped() != 0 {
+		t.Error("nil Deduplicator should report no duplicates")
+	}
+}
+
+func TestRenderSourceRange_DropsDuplicatesAndReportsOnce(t *testing.T) {
+	msg := rslack.Message{Msg: rslack.Msg{Type: "message", User: "U1", Text: "Hello", Timestamp: "1768485600.000100"}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users:    []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{"C123": {msg, msg}},
+	}
+	events := &recordingEvents{}
+	opts := RenderOptions{Timezone: "UTC", Dedup: NewDeduplicator(), events: events}
+
+	for range 2 {
+		outputDir := t.TempDir()
+		if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15", opts, nil, nil); err != nil {
+			t.Fatalf("renderSourceRange() error = %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(outputDir, "2026-01-15", "2026-01-15-engineering.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(string(data), "Hello"); n != 1 {
+			t.Errorf("rendered file lists the message %d times, want once:\n%s", n, data)
+		}
+	}
+	if len(events.stages) != 1 || events.stages[0] != "Dropped 1 duplicate message(s) in 1 channel(s)" {
+		t.Errorf("stages = %q, want one duplicate report", events.stages)
+	}
+}
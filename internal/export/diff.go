@@ -0,0 +1,174 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	renderedFilePrefixPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}-`)
+	renderedPartSuffixPattern = regexp.MustCompile(`-part\d+$`)
+)
+
+// ChannelDelta is one channel's message count on each side of an OutputDiff.
+type ChannelDelta struct {
+	Name   string
+	Before int
+	After  int
+}
+
+// OutputDiff summarizes how the rendered output changed between two dates or
+// between two copies of the same date.
+type OutputDiff struct {
+	Before    string
+	After     string
+	Added     []ChannelDelta
+	Removed   []ChannelDelta
+	Changed   []ChannelDelta
+	Unchanged int
+}
+
+// DiffOutput compares the rendered channel files of two outputs. Each side is
+// either a YYYY-MM-DD date under outputDir or a path to a date directory, so a
+// saved copy can be compared with the same date after a re-export. A date in
+// a weekly or monthly folder compares only that date's files. Copies
+// translated into language are not counted as channels.
+func DiffOutput(outputDir, before, after, language string) (*OutputDiff, error) {
+	beforeDir, beforeDate, err := resolveOutputDateDir(outputDir, before)
+	if err != nil {
+		return nil, err
+	}
+	afterDir, afterDate, err := resolveOutputDateDir(outputDir, after)
+	if err != nil {
+		return nil, err
+	}
+	beforeCounts, err := countRenderedChannels(beforeDir, beforeDate, language)
+	if err != nil {
+		return nil, err
+	}
+	afterCounts, err := countRenderedChannels(afterDir, afterDate, language)
+	if err != nil {
+		return nil, err
+	}
+	diff := diffChannelCounts(beforeCounts, afterCounts)
+	diff.Before, diff.After = before, after
+	return diff, nil
+}
+
+// resolveOutputDateDir returns the directory holding arg and, when that is
+// a weekly or monthly folder, the date whose files to compare.
+func resolveOutputDateDir(outputDir, arg string) (string, string, error) {
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		return arg, "", nil
+	}
+	if _, err := time.Parse("2006-01-02", arg); err != nil {
+		return "", "", fmt.Errorf("%q is neither a date (YYYY-MM-DD) nor a directory", arg)
+	}
+	for _, layout := range []string{LayoutDaily, LayoutWeekly, LayoutMonthly} {
+		dir := dateDir(outputDir, arg, layout)
+		_, err := os.Stat(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if layout == LayoutDaily {
+			return dir, "", nil
+		}
+		return dir, arg, nil
+	}
+	return "", "", fmt.Errorf("no export found for %s in %s", arg, outputDir)
+}
+
+// countRenderedChannels returns the message count for each channel rendered
+// in dir, limited to files named with date when it is set. Split part files
+// are folded into their channel; see IsRenderedChannelFile for language.
+func countRenderedChannels(dir, date, language string) (map[string]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		if entry.IsDir() || !IsRenderedChannelFile(filepath.Base(dir), entry.Name(), language) {
+			continue
+		}
+		if date != "" && !strings.Contains(entry.Name(), date) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", entry.Name(), err)
+		}
+		name := renderedChannelName(entry.Name())
+		counts[name] += len(parseRenderedMessages(string(data)))
+	}
+	return counts, nil
+}
+
+func renderedChannelName(filename string) string {
+	name := strings.TrimSuffix(filename, ".md")
+	name = renderedFilePrefixPattern.ReplaceAllString(name, "")
+	name = renderedPartSuffixPattern.ReplaceAllString(name, "")
+	return renderedFileSuffixPattern.ReplaceAllString(name, "")
+}
+
+func diffChannelCounts(before, after map[string]int) *OutputDiff {
+	diff := &OutputDiff{}
+	for name, count := range before {
+		afterCount, ok := after[name]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, ChannelDelta{Name: name, Before: count})
+		case afterCount != count:
+			diff.Changed = append(diff.Changed, ChannelDelta{Name: name, Before: count, After: afterCount})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for name, count := range after {
+		if _, ok := before[name]; !ok {
+			diff.Added = append(diff.Added, ChannelDelta{Name: name, After: count})
+		}
+	}
+	for _, deltas := range [][]ChannelDelta{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(deltas, func(i, j int) bool { return deltas[i].Name < deltas[j].Name })
+	}
+	return diff
+}
+
+// WriteOutputDiff writes a human-readable summary of diff to w.
+func WriteOutputDiff(w io.Writer, diff *OutputDiff) {
+	fmt.Fprintf(w, "Comparing %s -> %s\n", diff.Before, diff.After)
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) == 0 {
+		fmt.Fprintf(w, "No differences (%d channel(s) unchanged)\n", diff.Unchanged)
+		return
+	}
+	if len(diff.Added) > 0 {
+		fmt.Fprintf(w, "\nNew channels (%d):\n", len(diff.Added))
+		for _, d := range diff.Added {
+			fmt.Fprintf(w, "  + %s (%d messages)\n", d.Name, d.After)
+		}
+	}
+	if len(diff.Removed) > 0 {
+		fmt.Fprintf(w, "\nVanished channels (%d):\n", len(diff.Removed))
+		for _, d := range diff.Removed {
+			fmt.Fprintf(w, "  - %s (%d messages)\n", d.Name, d.Before)
+		}
+	}
+	if len(diff.Changed) > 0 {
+		fmt.Fprintf(w, "\nActivity changes (%d):\n", len(diff.Changed))
+		for _, d := range diff.Changed {
+			fmt.Fprintf(w, "  ~ %s: %d -> %d (%+d)\n", d.Name, d.Before, d.After, d.After-d.Before)
+		}
+	}
+	fmt.Fprintf(w, "\n%d channel(s) unchanged\n", diff.Unchanged)
+}
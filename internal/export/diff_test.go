@@ -0,0 +1,122 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRenderedFixture(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffOutput_ComparesDates(t *testing.T) {
+	out := t.TempDir()
+	day1 := filepath.Join(out, "2026-01-21")
+	day2 := filepath.Join(out, "2026-01-22")
+	writeRenderedFixture(t, day1, "2026-01-21-general.md", renderedBefore)
+	writeRenderedFixture(t, day1, "2026-01-21-random.md", renderedAfter)
+	writeRenderedFixture(t, day1, "2026-01-21-old.md", renderedBefore)
+	writeRenderedFixture(t, day2, "2026-01-22-general.md", renderedAfter)
+	writeRenderedFixture(t, day2, "2026-01-22-random.md", renderedAfter)
+	writeRenderedFixture(t, day2, "2026-01-22-dm_alice-part1.md", renderedBefore)
+	writeRenderedFixture(t, day2, "2026-01-22-dm_alice-part2.md", renderedAfter)
+	writeRenderedFixture(t, day2, "changes.log", "ignored")
+	writeRenderedFixture(t, day2, "index.md", renderedAfter)
+	writeRenderedFixture(t, day2, "status.md", renderedAfter)
+	writeRenderedFixture(t, day2, "2026-01-22-general-fr.md", renderedAfter)
+
+	diff, err := DiffOutput(out, "2026-01-21", "2026-01-22", "fr")
+	if err != nil {
+		t.Fatalf("DiffOutput() error = %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != (ChannelDelta{Name: "dm_alice", After: 6}) {
+		t.Errorf("Added = %+v, want dm_alice with 6 messages", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != (ChannelDelta{Name: "old", Before: 3}) {
+		t.Errorf("Removed = %+v, want old with 3 messages", diff.Removed)
+	}
+	if len(diff.Changed) != 0 || diff.Unchanged != 2 {
+		t.Errorf("Changed = %+v, Unchanged = %d; want none changed, 2 unchanged", diff.Changed, diff.Unchanged)
+	}
+}
+
+func TestDiffOutput_ComparesDatesInWeekFolder(t *testing.T) {
+	out := t.TempDir()
+	week := filepath.Join(out, "2026-W04")
+	writeRenderedFixture(t, week, "2026-01-21-general.md", renderedBefore)
+	writeRenderedFixture(t, week, "2026-01-22-general.md", renderedAfter)
+	writeRenderedFixture(t, week, "2026-01-22-random.md", renderedAfter)
+
+	diff, err := DiffOutput(out, "2026-01-21", "2026-01-22", "")
+	if err != nil {
+		t.Fatalf("DiffOutput() error = %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Name != "random" || diff.Unchanged != 1 {
+		t.Errorf("diff = %+v, want random added and general unchanged", diff)
+	}
+}
+
+func TestDiffOutput_ComparesDirectoryAgainstDate(t *testing.T) {
+	out := t.TempDir()
+	saved := filepath.Join(t.TempDir(), "2026-01-21")
+	writeRenderedFixture(t, saved, "2026-01-21-general.md", renderedBefore)
+	writeRenderedFixture(t, filepath.Join(out, "2026-01-21"), "2026-01-21-general.md", renderedBefore+renderedAfter)
+
+	diff, err := DiffOutput(out, saved, "2026-01-21", "")
+	if err != nil {
+		t.Fatalf("DiffOutput() error = %v", err)
+	}
+	want := ChannelDelta{Name: "general", Before: 3, After: 6}
+	if len(diff.Changed) != 1 || diff.Changed[0] != want {
+		t.Errorf("Changed = %+v, want %+v", diff.Changed, want)
+	}
+}
+
+func TestDiffOutput_RejectsUnknownSide(t *testing.T) {
+	out := t.TempDir()
+	if _, err := DiffOutput(out, "yesterday", "2026-01-22", ""); err == nil {
+		t.Error("DiffOutput() should reject a non-date, non-directory argument")
+	}
+	if _, err := DiffOutput(out, "2026-01-21", "2026-01-22", ""); err == nil || !strings.Contains(err.Error(), "no export found") {
+		t.Errorf("DiffOutput() error = %v, want missing export error", err)
+	}
+}
+
+func TestWriteOutputDiff(t *testing.T) {
+	var buf bytes.Buffer
+	WriteOutputDiff(&buf, &OutputDiff{
+		Before:    "2026-01-21",
+		After:     "2026-01-22",
+		Added:     []ChannelDelta{{Name: "new", After: 4}},
+		Removed:   []ChannelDelta{{Name: "gone", Before: 2}},
+		Changed:   []ChannelDelta{{Name: "general", Before: 10, After: 7}},
+		Unchanged: 3,
+	})
+	got := buf.String()
+	for _, want := range []string{
+		"Comparing 2026-01-21 -> 2026-01-22",
+		"  + new (4 messages)",
+		"  - gone (2 messages)",
+		"  ~ general: 10 -> 7 (-3)",
+		"3 channel(s) unchanged",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	buf.Reset()
+	WriteOutputDiff(&buf, &OutputDiff{Before: "a", After: "b", Unchanged: 2})
+	if !strings.Contains(buf.String(), "No differences (2 channel(s) unchanged)") {
+		t.Errorf("unexpected no-diff output: %s", buf.String())
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package export
+
+// freeDiskBytes is not implemented on this platform; the preflight is skipped.
+func freeDiskBytes(string) (uint64, bool, error) {
+	return 0, false, nil
+}
+
+// freeDiskInodes is not implemented on this platform; the preflight is skipped.
+func freeDiskInodes(string) (uint64, bool, error) {
+	return 0, false, nil
+}
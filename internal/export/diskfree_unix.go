@@ -0,0 +1,29 @@
+//go:build unix
+
+package export
+
+import "syscall"
+
+// freeDiskBytes reports the bytes available to unprivileged users on the
+// volume holding path.
+func freeDiskBytes(path string) (uint64, bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, false, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), true, nil // #nosec G115 -- block size is positive
+}
+
+// freeDiskInodes reports the free inodes on the volume holding path.
+// Filesystems that allocate inodes dynamically report none and are not
+// measurable.
+func freeDiskInodes(path string) (uint64, bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, false, err
+	}
+	if stat.Files == 0 {
+		return 0, false, nil
+	}
+	return uint64(stat.Ffree), true, nil // #nosec G115 -- free inode count is non-negative
+}
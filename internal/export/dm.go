@@ -0,0 +1,202 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/chrisedwards/slack-export/internal/tracing"
+	"github.com/rusq/slackdump/v4/source"
+)
+
+// ExportDM refreshes and renders a single direct message conversation for an
+// inclusive date range. The username is resolved to a DM channel, so callers
+// never need the D-channel ID, and files are named dm_<username>.
+func (e *Exporter) ExportDM(ctx context.Context, username, from, to string) (err error) {
+	defer func() { err = classifyError(err) }()
+	if _, err := datesInRange(from, to, e.cfg.Timezone); err != nil {
+		return err
+	}
+	archiveDir, err := e.ArchiveDir()
+	if err != nil {
+		return err
+	}
+	if !archiveExists(archiveDir) {
+		return fmt.Errorf("archive does not exist at %s; run slack-export sync first", archiveDir)
+	}
+	if err := e.preflightDates(ctx, from, to, time.Now()); err != nil {
+		return err
+	}
+
+	dm, err := e.resolveDM(ctx, archiveDir, username)
+	if err != nil {
+		return err
+	}
+	fromStart, _, err := GetDateBounds(from, e.cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("calculating from date bounds: %w", err)
+	}
+	archiveCtx, span := tracing.Start(ctx, "archive",
+		tracing.String("slack_export.archive_mode", "resume"), tracing.String("slack_export.channel.id", dm.ID))
+	err = e.refreshDM(archiveCtx, archiveDir, dm, fromStart)
+	span.End(err)
+	if err != nil {
+		return err
+	}
+
+	writes, err := tracedRender(ctx, from, to, func(ctx context.Context) (int, error) {
+		return e.renderDM(ctx, archiveDir, dm, from, to)
+	})
+	if err != nil {
+		return err
+	}
+	e.stagef("Rendered %s for %s through %s (%d changed file(s))", dm.Name, from, to, writes)
+	return nil
+}
+
+// renderDM writes the DM into every output target whose filters select it,
+// or into the top-level output_dir when none do.
+func (e *Exporter) renderDM(ctx context.Context, archiveDir string, dm slack.Channel, from, to string) (int, error) {
+	selections, err := outputSelections(e.cfg, archiveDir, []string{dm.ID})
+	if err != nil {
+		return 0, err
+	}
+	if len(selections) == 0 {
+		selections = []outputSelection{{outputDir: e.cfg.OutputDir, ids: []string{dm.ID}}}
+	}
+	opts := e.renderOptions(ctx)
+	writes := 0
+	for _, sel := range selections {
+		n, err := RenderArchiveRangeForChannels(ctx, archiveDir, sel.outputDir, from, to, opts, sel.ids)
+		writes += n
+		if err != nil {
+			return writes, err
+		}
+	}
+	return writes, nil
+}
+
+// resolveDM finds the DM with username. A DM already in the archive is
+// reused; otherwise Slack is asked, and conversations.open, which creates
+// the DM, is only the last resort.
+func (e *Exporter) resolveDM(ctx context.Context, archiveDir, username string) (slack.Channel, error) {
+	users, err := e.edgeClient.FetchUsers(ctx)
+	if err != nil {
+		return slack.Channel{}, fmt.Errorf("fetching users: %w", err)
+	}
+	user, ok := users.FindByName(username)
+	if !ok && e.cfg.UsersCacheDuration() > 0 {
+		// The cached list may predate the user joining.
+		if users, err = e.edgeClient.RefreshUsers(ctx); err != nil {
+			return slack.Channel{}, fmt.Errorf("fetching users: %w", err)
+		}
+		user, ok = users.FindByName(username)
+	}
+	if !ok {
+		return slack.Channel{}, withKind(ErrChannelSkipped, fmt.Errorf("no user named %q found in workspace", username))
+	}
+	channelID, err := archivedDMChannel(ctx, archiveDir, user.ID)
+	if err != nil {
+		e.warnf("looking up DM with %s in archive: %v", username, err)
+	}
+	if channelID == "" {
+		if channelID, err = e.edgeClient.FindDMChannel(ctx, user.ID); err != nil {
+			return slack.Channel{}, withKind(ErrChannelSkipped, fmt.Errorf("finding DM with %s: %w", username, err))
+		}
+	}
+	name := ConfiguredNameStyle(e.cfg).FileName(user.NameFields())
+	if name == "" {
+		name = user.ID
+	}
+	return slack.Channel{ID: channelID, Name: "dm_" + name, IsIM: true}, nil
+}
+
+// archivedDMChannel returns the ID of the archived DM with userID, or ""
+// when the archive has none.
+func archivedDMChannel(ctx context.Context, archiveDir, userID string) (string, error) {
+	if !archiveExists(archiveDir) {
+		return "", nil
+	}
+	src, err := LoadArchiveSource(ctx, archiveDir)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = src.Close() }()
+	return dmChannelForUser(ctx, src, userID)
+}
+
+func dmChannelForUser(ctx context.Context, src ArchiveMessageSource, userID string) (string, error) {
+	channels, err := src.Channels(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, ch := range channels {
+		if ch.IsIM && ch.User == userID {
+			return ch.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// refreshDM resumes the archive for the DM alone. A DM that has never been
+// archived is fetched from the start of the requested range.
+func (e *Exporter) refreshDM(ctx context.Context, archiveDir string, dm slack.Channel, fromStart time.Time) error {
+	lock, acquired, err := acquireArchiveLock(archiveDir)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errors.New("archive refresh already in progress")
+	}
+	defer func() { _ = lock.Release() }()
+
+	args, err := singleChannelResumeArgs(ctx, archiveDir, dm.ID, fromStart)
+	if err != nil {
+		return err
+	}
+	tempDir, cleanupTemp, err := e.prepareRunTempDir(archiveDir, newRunID(time.Now()))
+	if err != nil {
+		return err
+	}
+	defer cleanupTemp()
+
+	e.stagef("Refreshing %s (%s) in archive", dm.Name, dm.ID)
+	if err := ResumeArchive(ctx, e.slackdump, archiveDir, args, withThreadLookback(ResumeOptions{
+		Lookback:            e.cfg.Lookback,
+		SkipStaleThreads:    e.cfg.SkipStaleThreads,
+		SkipCompleteThreads: e.cfg.SkipCompleteThreads,
+		TempDir:             tempDir,
+		Workspace:           e.cfg.Workspace,
+		ExtraArgs:           e.cfg.SlackdumpArgs,
+	}, e.cfg.ThreadLookbackDays)); err != nil {
+		return fmt.Errorf("resuming archive: %w", err)
+	}
+	if err := saveChannelNames(archiveDir, []slack.Channel{dm}); err != nil {
+		return fmt.Errorf("saving channel names: %w", err)
+	}
+	return nil
+}
+
+func singleChannelResumeArgs(ctx context.Context, archiveDir, channelID string, fromStart time.Time) ([]string, error) {
+	src, err := source.Load(ctx, archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading archive checkpoints: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	latest, err := src.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive checkpoints: %w", err)
+	}
+	checkpoints := make(map[string]time.Time, len(latest))
+	for link, ts := range latest {
+		if key := fmt.Sprint(link); !strings.Contains(key, ":") {
+			checkpoints[key] = ts
+		}
+	}
+	tracked := []slack.Channel{{ID: channelID}}
+	return scopedResumeArgsFromLatest(tracked, latest, checkpoints, []string{channelID}, fromStart), nil
+}
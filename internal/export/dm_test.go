@@ -0,0 +1,119 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+func TestExportDM_InvalidDate(t *testing.T) {
+	e := &Exporter{cfg: &config.Config{Timezone: "America/New_York"}}
+
+	err := e.ExportDM(context.Background(), "alice", "not-a-date", "2026-01-22")
+	if err == nil || !strings.Contains(err.Error(), "parsing from date") {
+		t.Fatalf("ExportDM() error = %v, want from date parse error", err)
+	}
+}
+
+func TestExportDM_MissingArchive(t *testing.T) {
+	e := &Exporter{
+		cfg:   &config.Config{ArchiveDir: t.TempDir(), Timezone: "America/New_York"},
+		creds: &slack.Credentials{Workspace: "test"},
+	}
+
+	err := e.ExportDM(context.Background(), "alice", "2026-01-22", "2026-01-22")
+	if err == nil || !strings.Contains(err.Error(), "run slack-export sync first") {
+		t.Fatalf("ExportDM() error = %v, want missing archive error", err)
+	}
+}
+
+func TestResolveDM_NamesChannelAfterUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/users.list":
+			_, _ = w.Write([]byte(`{"ok": true, "members": [
+				{"id": "U1", "name": "Alice.Smith", "profile": {"display_name": "alice"}}
+			]}`))
+		case "/api/client.userBoot":
+			_, _ = w.Write([]byte(`{"ok": true, "ims": [{"id": "D123", "user": "U1"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := slack.NewEdgeClient(&slack.Credentials{Token: "xoxc-test"}).
+		WithSlackAPIURL(server.URL).
+		WithWorkspaceURL(server.URL + "/")
+	e := &Exporter{cfg: &config.Config{}, edgeClient: client}
+
+	dm, err := e.resolveDM(context.Background(), t.TempDir(), "@alice")
+	if err != nil {
+		t.Fatalf("resolveDM() error = %v", err)
+	}
+	if dm.ID != "D123" || dm.Name != "dm_alice.smith" || !dm.IsIM {
+		t.Errorf("resolveDM() = %+v, want D123 named dm_alice.smith", dm)
+	}
+
+	if _, err := e.resolveDM(context.Background(), t.TempDir(), "nobody"); err == nil {
+		t.Error("resolveDM() should fail for an unknown user")
+	}
+}
+
+func TestResolveDM_FindsDeletedUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/users.list":
+			_, _ = w.Write([]byte(`{"ok": true, "members": [{"id": "U1", "name": "alice", "deleted": true}]}`))
+		case "/api/client.userBoot":
+			_, _ = w.Write([]byte(`{"ok": true, "ims": [{"id": "D123", "user": "U1"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := slack.NewEdgeClient(&slack.Credentials{Token: "xoxc-test"}).
+		WithSlackAPIURL(server.URL).
+		WithWorkspaceURL(server.URL + "/")
+	e := &Exporter{cfg: &config.Config{}, edgeClient: client}
+
+	dm, err := e.resolveDM(context.Background(), t.TempDir(), "alice")
+	if err != nil {
+		t.Fatalf("resolveDM() error = %v", err)
+	}
+	if dm.ID != "D123" {
+		t.Errorf("resolveDM() = %+v, want D123", dm)
+	}
+}
+
+func TestDMChannelForUser(t *testing.T) {
+	im := func(id, user string) rslack.Channel {
+		return rslack.Channel{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{
+			ID: id, IsIM: true, User: user,
+		}}}
+	}
+	src := memoryArchiveSource{channels: []rslack.Channel{
+		{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1", User: "U1"}}},
+		im("D1", "U1"),
+		im("D2", "U2"),
+	}}
+
+	for user, want := range map[string]string{"U1": "D1", "U2": "D2", "U3": ""} {
+		got, err := dmChannelForUser(context.Background(), src, user)
+		if err != nil {
+			t.Fatalf("dmChannelForUser(%s) error = %v", user, err)
+		}
+		if got != want {
+			t.Errorf("dmChannelForUser(%s) = %q, want %q", user, got, want)
+		}
+	}
+}
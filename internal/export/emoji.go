@@ -0,0 +1,179 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"regexp"
+	"sort"
+	"strings"
+
+	rslack "github.com/rusq/slack"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+)
+
+// EmojiUsage counts one emoji's appearances in message text and as
+// reactions. Reactions counts people reacting, not reacted messages.
+type EmojiUsage struct {
+	Name      string
+	Messages  int
+	Reactions int
+}
+
+// Total is the emoji's combined usage.
+func (u EmojiUsage) Total() int {
+	return u.Messages + u.Reactions
+}
+
+// EmojiUsageOptions selects the messages CountArchiveEmoji aggregates.
+type EmojiUsageOptions struct {
+	// From and To are inclusive work dates (YYYY-MM-DD) in Timezone.
+	From     string
+	To       string
+	Timezone string
+	// Channels are name or ID patterns; empty counts every archived channel.
+	Channels []string
+	// Custom, when set, limits counting to these emoji names.
+	Custom map[string]bool
+}
+
+// emojiPattern matches :name: shortcodes in message text.
+var emojiPattern = regexp.MustCompile(`:([a-z0-9_+'-]+):`)
+
+// CountArchiveEmoji aggregates emoji usage in archived messages, thread
+// replies, and reactions, most used first.
+func CountArchiveEmoji(ctx context.Context, archiveDir string, opts EmojiUsageOptions) ([]EmojiUsage, error) {
+	src, err := LoadArchiveSource(ctx, archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+	names, err := loadChannelNames(archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading channel names: %w", err)
+	}
+	return countEmojiUsage(ctx, src, names, opts)
+}
+
+func countEmojiUsage(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	names map[string]string,
+	opts EmojiUsageOptions,
+) ([]EmojiUsage, error) {
+	chans, err := src.Channels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading channels: %w", err)
+	}
+	counts := make(map[string]*EmojiUsage)
+	for _, ch := range chans {
+		if !emojiChannelSelected(ch, names, opts.Channels) {
+			continue
+		}
+		messages, err := loadChannelMessages(ctx, src, ch.ID)
+		if err != nil {
+			return nil, fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
+		}
+		for _, msg := range messages {
+			countMessageEmoji(counts, msg, opts)
+			if !isThreadParent(msg) || !threadOverlapsRange(msg, opts) {
+				continue
+			}
+			replies, err := collectMessages(ctx, func() (iter.Seq2[rslack.Message, error], error) {
+				return src.AllThreadMessages(ctx, ch.ID, msg.Timestamp)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("loading thread %s in %s: %w", msg.Timestamp, ch.ID, err)
+			}
+			for _, reply := range replies {
+				// Broadcast replies are already among the channel messages.
+				if reply.Timestamp != msg.Timestamp && reply.SubType != rslack.MsgSubTypeThreadBroadcast {
+					countMessageEmoji(counts, reply, opts)
+				}
+			}
+		}
+	}
+
+	usage := make([]EmojiUsage, 0, len(counts))
+	for _, u := range counts {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Total() != usage[j].Total() {
+			return usage[i].Total() > usage[j].Total()
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	return usage, nil
+}
+
+// emojiChannelSelected matches patterns against the channel's stored file
+// name as well as its archive name and ID.
+func emojiChannelSelected(ch rslack.Channel, names map[string]string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	return channels.MatchAny(patterns, ch.ID) || channels.MatchAny(patterns, ch.Name) ||
+		(names[ch.ID] != "" && channels.MatchAny(patterns, names[ch.ID]))
+}
+
+// threadOverlapsRange skips loading threads that started after the range or
+// whose last reply came before it.
+func threadOverlapsRange(parent rslack.Message, opts EmojiUsageOptions) bool {
+	if date, err := messageWorkDate(parent, opts.Timezone); err == nil && date > opts.To {
+		return false
+	}
+	if parent.LatestReply == "" {
+		return true
+	}
+	latest := rslack.Message{Msg: rslack.Msg{Timestamp: parent.LatestReply}}
+	date, err := messageWorkDate(latest, opts.Timezone)
+	return err != nil || date >= opts.From
+}
+
+// countMessageEmoji adds a message's text emoji and reactions when it falls
+// within the range.
+func countMessageEmoji(counts map[string]*EmojiUsage, msg rslack.Message, opts EmojiUsageOptions) {
+	date, err := messageWorkDate(msg, opts.Timezone)
+	if err != nil || date < opts.From || date > opts.To {
+		return
+	}
+	for _, match := range emojiPattern.FindAllStringSubmatch(msg.Text, -1) {
+		if u := emojiCounter(counts, match[1], opts.Custom); u != nil {
+			u.Messages++
+		}
+	}
+	for _, reaction := range msg.Reactions {
+		if u := emojiCounter(counts, reaction.Name, opts.Custom); u != nil {
+			u.Reactions += reaction.Count
+		}
+	}
+}
+
+// emojiCounter returns the counter for name with any skin tone stripped, or
+// nil when the name is not counted.
+func emojiCounter(counts map[string]*EmojiUsage, name string, custom map[string]bool) *EmojiUsage {
+	name, _, _ = strings.Cut(name, "::")
+	if !isEmojiName(name) || (custom != nil && !custom[name]) {
+		return nil
+	}
+	u, ok := counts[name]
+	if !ok {
+		u = &EmojiUsage{Name: name}
+		counts[name] = u
+	}
+	return u
+}
+
+// isEmojiName rejects skin tone modifiers and all-digit matches such as the
+// ":30:" in "10:30:45", keeping Slack's :100: and :1234:.
+func isEmojiName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "skin-tone-") {
+		return false
+	}
+	if strings.Trim(name, "0123456789") == "" {
+		return name == "100" || name == "1234"
+	}
+	return true
+}
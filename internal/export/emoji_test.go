@@ -0,0 +1,60 @@
+package export
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestCountEmojiUsage(t *testing.T) {
+	// 2026-01-14, 2026-01-15, and 2026-01-16 12:00 UTC.
+	const before, day, after = "1768392000.000100", "1768478400.000100", "1768564800.000100"
+	parent := rslack.Message{Msg: rslack.Msg{
+		Timestamp: before, ThreadTimestamp: before, ReplyCount: 1, LatestReply: day, Text: ":partyparrot:",
+	}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{
+			{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1"}, Name: "eng"}},
+			{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C2"}, Name: "random"}},
+		},
+		messages: map[string][]rslack.Message{
+			"C1": {
+				parent,
+				{Msg: rslack.Msg{Timestamp: day, Text: "shipped :partyparrot: :partyparrot: at 10:30:45 :wave::skin-tone-3:",
+					Reactions: []rslack.ItemReaction{{Name: "thumbsup::skin-tone-2", Count: 2}, {Name: "partyparrot", Count: 3}}}},
+				{Msg: rslack.Msg{Timestamp: after, Text: ":partyparrot:"}},
+			},
+			"C2": {{Msg: rslack.Msg{Timestamp: day, Text: ":100:"}}},
+		},
+		threads: map[string][]rslack.Message{
+			"C1:" + before: {parent, {Msg: rslack.Msg{Timestamp: day, ThreadTimestamp: before, Text: "me too :partyparrot:"}}},
+		},
+	}
+	opts := EmojiUsageOptions{From: "2026-01-15", To: "2026-01-15", Timezone: "UTC"}
+
+	got, err := countEmojiUsage(context.Background(), src, nil, opts)
+	if err != nil {
+		t.Fatalf("countEmojiUsage() error = %v", err)
+	}
+	want := []EmojiUsage{
+		{Name: "partyparrot", Messages: 3, Reactions: 3},
+		{Name: "thumbsup", Reactions: 2},
+		{Name: "100", Messages: 1},
+		{Name: "wave", Messages: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countEmojiUsage() = %+v, want %+v", got, want)
+	}
+
+	opts.Custom = map[string]bool{"partyparrot": true}
+	opts.Channels = []string{"random"}
+	got, err = countEmojiUsage(context.Background(), src, nil, opts)
+	if err != nil {
+		t.Fatalf("countEmojiUsage() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("custom emoji in #random = %+v, want none", got)
+	}
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// Failure kinds returned (wrapped) by export operations. Match them with
+// errors.Is; the error text still describes the specific failure.
+var (
+	// ErrSlackdumpFailed means the slackdump subprocess failed.
+	ErrSlackdumpFailed = errors.New("slackdump failed")
+	// ErrChannelSkipped means a requested channel or DM could not be
+	// resolved and was not exported.
+	ErrChannelSkipped = errors.New("channel skipped")
+	// ErrRateLimited means Slack kept rate limiting after retries.
+	ErrRateLimited = errors.New("rate limited by Slack")
+	// ErrAuthExpired means Slack rejected the credentials.
+	ErrAuthExpired = errors.New("slack credentials expired or revoked")
+)
+
+// kindError tags err with a failure kind without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func withKind(kind, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// classifyError tags Slack API failures with ErrRateLimited or ErrAuthExpired.
+func classifyError(err error) error {
+	switch {
+	case slack.IsAuthFailure(err):
+		return withKind(ErrAuthExpired, err)
+	case slack.IsRateLimited(err):
+		return withKind(ErrRateLimited, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func slackErrorFor(t *testing.T, status int, body string) error {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client := slack.NewEdgeClient(&slack.Credentials{Token: "xoxc-test"}).WithSlackAPIURL(server.URL)
+	_, err := client.AuthTest(context.Background())
+	if err == nil {
+		t.Fatal("AuthTest() should fail")
+	}
+	return err
+}
+
+func TestClassifyError(t *testing.T) {
+	authErr := classifyError(fmt.Errorf("verifying credentials: %w", slackErrorFor(t, http.StatusOK,
+		`{"ok": false, "error": "invalid_auth"}`)))
+	if !errors.Is(authErr, ErrAuthExpired) || errors.Is(authErr, ErrRateLimited) {
+		t.Errorf("invalid_auth should classify as ErrAuthExpired: %v", authErr)
+	}
+	if authErr.Error() != "verifying credentials: auth.test: invalid_auth" {
+		t.Errorf("classification should keep the message, got %q", authErr.Error())
+	}
+
+	limited := classifyError(slackErrorFor(t, http.StatusOK, `{"ok": false, "error": "ratelimited"}`))
+	if !errors.Is(limited, ErrRateLimited) {
+		t.Errorf("ratelimited should classify as ErrRateLimited: %v", limited)
+	}
+
+	plain := errors.New("disk full")
+	if got := classifyError(plain); got != plain {
+		t.Errorf("unrelated errors should pass through, got %v", got)
+	}
+}
+
+func TestRunSlackdump_FailureIsSlackdumpFailed(t *testing.T) {
+	err := runSlackdump(context.Background(), "/nonexistent/slackdump", nil, "", "slackdump archive failed")
+	if !errors.Is(err, ErrSlackdumpFailed) {
+		t.Errorf("runSlackdump() error = %v, want ErrSlackdumpFailed", err)
+	}
+}
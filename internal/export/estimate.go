@@ -0,0 +1,93 @@
+package export
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// Backfill estimate assumptions. Page sizes mirror the per_request limits in
+// slackdump_sweep_api.toml; conversations.history and conversations.replies
+// are Slack Tier 3 methods, allowed about 50 calls per minute. Byte sizes
+// are typical for the archive database and rendered markdown.
+const (
+	HistoryPageSize         = 100
+	repliesPageSize         = 200
+	tier3CallsPerMinute     = 50
+	archiveBytesPerMessage  = 2048
+	markdownBytesPerMessage = 256
+)
+
+// ChannelSample is the newest history page sampled from one channel.
+type ChannelSample struct {
+	Channel slack.Channel
+	Sample  slack.HistorySample
+}
+
+// BackfillEstimate is the projected cost of archiving history since a date.
+type BackfillEstimate struct {
+	Channels      int
+	Sampled       int
+	Messages      int64
+	Replies       int64
+	APICalls      int64
+	Runtime       time.Duration
+	ArchiveBytes  int64
+	MarkdownBytes int64
+}
+
+// SampleChannels picks up to n channels spread evenly over the name-sorted
+// list, so repeated estimates sample the same channels.
+func SampleChannels(chans []slack.Channel, n int) []slack.Channel {
+	sorted := append([]slack.Channel(nil), chans...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	if n <= 0 || len(sorted) <= n {
+		return sorted
+	}
+	picked := make([]slack.Channel, 0, n)
+	for i := 0; i < n; i++ {
+		picked = append(picked, sorted[i*len(sorted)/n])
+	}
+	return picked
+}
+
+// EstimateBackfill extrapolates the sampled channels to all channels active
+// since from. A sampled page that does not reach back to from is scaled by
+// the time it covers.
+func EstimateBackfill(samples []ChannelSample, channels int, from, now time.Time) BackfillEstimate {
+	est := BackfillEstimate{Channels: channels, Sampled: len(samples)}
+	if len(samples) == 0 {
+		return est
+	}
+	var messages, replies, calls float64
+	for _, s := range samples {
+		scale := sampleScale(s.Sample, from, now)
+		m := float64(s.Sample.Messages) * scale
+		r := float64(s.Sample.Replies) * scale
+		messages += m
+		replies += r
+		calls += math.Max(1, math.Ceil(m/HistoryPageSize)) + float64(s.Sample.Threads)*scale + r/repliesPageSize
+	}
+	factor := float64(channels) / float64(len(samples))
+	est.Messages = int64(messages * factor)
+	est.Replies = int64(replies * factor)
+	est.APICalls = int64(math.Ceil(calls * factor))
+	est.Runtime = time.Duration(float64(est.APICalls) / tier3CallsPerMinute * float64(time.Minute))
+	total := est.Messages + est.Replies
+	est.ArchiveBytes = total * archiveBytesPerMessage
+	est.MarkdownBytes = total * markdownBytesPerMessage
+	return est
+}
+
+func sampleScale(sample slack.HistorySample, from, now time.Time) float64 {
+	if !sample.HasMore || sample.Oldest.IsZero() || !sample.Oldest.After(from) {
+		return 1
+	}
+	covered := now.Sub(sample.Oldest)
+	if covered <= 0 {
+		return 1
+	}
+	return float64(now.Sub(from)) / float64(covered)
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestSampleChannels_SpreadsOverSortedNames(t *testing.T) {
+	var chans []slack.Channel
+	for _, name := range []string{"f", "a", "d", "c", "e", "b"} {
+		chans = append(chans, slack.Channel{ID: "C" + name, Name: name})
+	}
+
+	picked := SampleChannels(chans, 3)
+
+	var names []string
+	for _, ch := range picked {
+		names = append(names, ch.Name)
+	}
+	if len(names) != 3 || names[0] != "a" || names[1] != "c" || names[2] != "e" {
+		t.Errorf("SampleChannels() = %v", names)
+	}
+	if got := SampleChannels(chans, 10); len(got) != 6 {
+		t.Errorf("SampleChannels(10) returned %d channels, want all 6", len(got))
+	}
+}
+
+func TestEstimateBackfill_ScalesPartialPagesAndExtrapolates(t *testing.T) {
+	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	from := now.AddDate(0, 0, -100)
+	samples := []ChannelSample{
+		// A busy channel whose 100 messages cover only the last 10 days.
+		{Sample: slack.HistorySample{Messages: 100, Threads: 10, Replies: 40, HasMore: true,
+			Oldest: now.AddDate(0, 0, -10)}},
+		// A quiet channel fully covered by its page.
+		{Sample: slack.HistorySample{Messages: 20}},
+	}
+
+	est := EstimateBackfill(samples, 4, from, now)
+
+	// (1000 + 20) messages over 2 samples, doubled for 4 channels.
+	if est.Messages != 2040 || est.Replies != 800 {
+		t.Errorf("messages = %d, replies = %d", est.Messages, est.Replies)
+	}
+	// Busy: 10 history pages + 100 threads + 2 reply pages; quiet: 1 page.
+	if est.APICalls != 226 {
+		t.Errorf("APICalls = %d, want 226", est.APICalls)
+	}
+	if est.Runtime.Round(time.Second) != 271*time.Second {
+		t.Errorf("Runtime = %v", est.Runtime)
+	}
+	if est.ArchiveBytes != 2840*archiveBytesPerMessage {
+		t.Errorf("ArchiveBytes = %d", est.ArchiveBytes)
+	}
+}
+
+func TestEstimateBackfill_NoSamples(t *testing.T) {
+	est := EstimateBackfill(nil, 5, time.Time{}, time.Now())
+	if est.Channels != 5 || est.Messages != 0 || est.APICalls != 0 {
+		t.Errorf("EstimateBackfill() = %+v", est)
+	}
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Events receives progress from an Exporter. The CLI prints it to the
+// terminal; library users and interactive front ends can observe the same
+// stream instead. Methods are called synchronously from the exporting
+// goroutine, so they must return quickly.
+type Events interface {
+	// OnStage reports a human-readable step such as "Archive already current".
+	OnStage(message string)
+	// OnChannelStart reports that a channel is about to be rendered.
+	OnChannelStart(ch ChannelProgress)
+	// OnChannelDone reports that a channel finished rendering.
+	OnChannelDone(ch ChannelProgress)
+	// OnError reports a non-fatal problem. Fatal errors are returned instead.
+	OnError(err error)
+}
+
+// ChannelProgress identifies a channel in channel events.
+type ChannelProgress struct {
+	ID   string
+	Name string
+	// Files is the number of changed files written, Messages the
+	// top-level messages in the channel-days rendered, and Dates the work
+	// days rendered; all are set on OnChannelDone.
+	Files    int
+	Messages int
+	Dates    []string
+}
+
+// ConsoleEvents writes stages to Out and warnings to Err, one line each.
+// Channel events are not printed.
+type ConsoleEvents struct {
+	Out io.Writer
+	Err io.Writer
+}
+
+// NewConsoleEvents returns the terminal output used by the CLI.
+func NewConsoleEvents() ConsoleEvents {
+	return ConsoleEvents{Out: os.Stdout, Err: os.Stderr}
+}
+
+func (c ConsoleEvents) OnStage(message string) {
+	_, _ = fmt.Fprintln(c.Out, message)
+}
+
+func (c ConsoleEvents) OnChannelStart(ChannelProgress) {}
+
+func (c ConsoleEvents) OnChannelDone(ChannelProgress) {}
+
+func (c ConsoleEvents) OnError(err error) {
+	_, _ = fmt.Fprintf(c.Err, "Warning: %v\n", err)
+}
+
+// SetEvents replaces the Exporter's progress output. Passing nil restores
+// the console output.
+func (e *Exporter) SetEvents(events Events) {
+	e.observer = events
+}
+
+func (e *Exporter) events() Events {
+	if e.observer == nil {
+		return NewConsoleEvents()
+	}
+	return e.observer
+}
+
+func (e *Exporter) stagef(format string, args ...any) {
+	e.events().OnStage(fmt.Sprintf(format, args...))
+}
+
+func (e *Exporter) warnf(format string, args ...any) {
+	e.events().OnError(fmt.Errorf(format, args...))
+}
+
+// channelStarted and channelDone tolerate options built without an
+// Exporter, such as those from RenderOptionsFromConfig.
+func (o RenderOptions) channelStarted(id, name string) {
+	if o.events != nil {
+		o.events.OnChannelStart(ChannelProgress{ID: id, Name: name})
+	}
+}
+
+func (o RenderOptions) channelDone(id, name string, files, messages int, dates []string) {
+	if o.events != nil {
+		o.events.OnChannelDone(ChannelProgress{ID: id, Name: name, Files: files, Messages: messages, Dates: dates})
+	}
+}
+
+// stage reports a render step, falling back to the console when the
+// options were built without an Exporter.
+func (o RenderOptions) stage(message string) {
+	if o.events != nil {
+		o.events.OnStage(message)
+		return
+	}
+	NewConsoleEvents().OnStage(message)
+}
+
+// warn reports a non-fatal render problem, falling back to the console
+// when the options were built without an Exporter.
+func (o RenderOptions) warn(err error) {
+	if o.events != nil {
+		o.events.OnError(err)
+		return
+	}
+	NewConsoleEvents().OnError(err)
+}
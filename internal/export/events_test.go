@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+type recordingEvents struct {
+	stages  []string
+	started []ChannelProgress
+	done    []ChannelProgress
+	errs    []error
+}
+
+func (r *recordingEvents) OnStage(message string)            { r.stages = append(r.stages, message) }
+func (r *recordingEvents) OnChannelStart(ch ChannelProgress) { r.started = append(r.started, ch) }
+func (r *recordingEvents) OnChannelDone(ch ChannelProgress)  { r.done = append(r.done, ch) }
+func (r *recordingEvents) OnError(err error)                 { r.errs = append(r.errs, err) }
+
+func TestConsoleEvents_PrintsStagesAndWarnings(t *testing.T) {
+	var out, errOut bytes.Buffer
+	events := ConsoleEvents{Out: &out, Err: &errOut}
+
+	events.OnStage("Archive already current")
+	events.OnChannelStart(ChannelProgress{ID: "C1", Name: "general"})
+	events.OnChannelDone(ChannelProgress{ID: "C1", Name: "general", Files: 1})
+	events.OnError(errors.New("failed to save user cache: disk full"))
+
+	if got := out.String(); got != "Archive already current\n" {
+		t.Errorf("stdout = %q", got)
+	}
+	if got := errOut.String(); got != "Warning: failed to save user cache: disk full\n" {
+		t.Errorf("stderr = %q", got)
+	}
+}
+
+func TestExporterEvents_RoutesStagesAndWarnings(t *testing.T) {
+	rec := &recordingEvents{}
+	e := &Exporter{}
+	e.SetEvents(rec)
+
+	e.stagef("Resuming archive with %d scoped entity arg(s)", 2)
+	e.warnf("counts scoping failed: %v", errors.New("timeout"))
+
+	if len(rec.stages) != 1 || rec.stages[0] != "Resuming archive with 2 scoped entity arg(s)" {
+		t.Errorf("stages = %q", rec.stages)
+	}
+	if len(rec.errs) != 1 || rec.errs[0].Error() != "counts scoping failed: timeout" {
+		t.Errorf("errs = %v", rec.errs)
+	}
+}
+
+func TestRenderSourceRange_ReportsChannelProgress(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{
+			GroupConversation: rslack.GroupConversation{
+				Conversation: rslack.Conversation{ID: "C1"},
+				Name:         "general",
+			},
+		}},
+		users: []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{"C1": {{Msg: rslack.Msg{
+			Type:      "message",
+			User:      "U1",
+			Text:      "Hello",
+			Timestamp: "1783094460.000000",
+		}}}},
+	}
+	rec := &recordingEvents{}
+
+	_, err := renderSourceRange(context.Background(), src, t.TempDir(), "2026-07-03", "2026-07-03",
+		RenderOptions{Timezone: "America/Chicago", events: rec}, nil, nil)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+
+	want := ChannelProgress{ID: "C1", Name: "general"}
+	if len(rec.started) != 1 || !reflect.DeepEqual(rec.started[0], want) {
+		t.Errorf("started = %+v", rec.started)
+	}
+	want.Files = 1
+	want.Messages = 1
+	want.Dates = []string{"2026-07-03"}
+	if len(rec.done) != 1 || !reflect.DeepEqual(rec.done[0], want) {
+		t.Errorf("done = %+v", rec.done)
+	}
+}
@@ -0,0 +1,221 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rusq/slackdump/v4/source"
+)
+
+const exportStateFilename = ".slack-export-export-state.json"
+
+// ExportOptions controls optional export behavior.
+type ExportOptions struct {
+	// ChangedOnly renders, for each output directory and date, only the
+	// channels whose counts API latest timestamp is newer than the watermark
+	// recorded when a changed-only export last rendered that channel-day.
+	ChangedOnly bool
+	// Scope limits the run to direct messages or to channels.
+	Scope ChannelScope
+}
+
+// exportWatermarks maps an output directory, then a date, then a channel ID
+// to the archive checkpoint the channel-day was last rendered from.
+type exportWatermarks map[string]map[string]map[string]time.Time
+
+// set records id as rendered into outputDir on date from checkpoint.
+func (w exportWatermarks) set(outputDir, date, id string, checkpoint time.Time) {
+	if w[outputDir] == nil {
+		w[outputDir] = make(map[string]map[string]time.Time)
+	}
+	if w[outputDir][date] == nil {
+		w[outputDir][date] = make(map[string]time.Time)
+	}
+	w[outputDir][date][id] = checkpoint
+}
+
+type exportStateData struct {
+	// Rendered replaces the per-channel "watermarks" of earlier versions,
+	// which were shared by every date range and output directory.
+	Rendered exportWatermarks `json:"rendered"`
+	// ChannelDayBytes is the rendered size of an average channel-day in
+	// past runs, used by the output space preflight.
+	ChannelDayBytes int64 `json:"channel_day_bytes,omitempty"`
+}
+
+func (e *Exporter) exportChangedRange(ctx context.Context, archiveDir, from, to string, scope ChannelScope) error {
+	counts, err := e.edgeClient.ClientCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching channel counts: %w", err)
+	}
+	watermarks, err := loadExportWatermarks(archiveDir)
+	if err != nil {
+		return fmt.Errorf("loading export watermarks: %w", err)
+	}
+	archiveLatest, err := archiveChannelLatest(ctx, archiveDir)
+	if err != nil {
+		return fmt.Errorf("reading archive checkpoints: %w", err)
+	}
+
+	dates, err := datesInRange(from, to, e.cfg.Timezone)
+	if err != nil {
+		return err
+	}
+	countLatest := countsLatestByID(counts)
+	var archived []string
+	for id := range countLatest {
+		if _, ok := archiveLatest[id]; ok {
+			archived = append(archived, id)
+		}
+	}
+	if len(archived) == 0 {
+		e.stagef("No channels changed since last export; skipped %s through %s", from, to)
+		return nil
+	}
+	sort.Strings(archived)
+	selections, err := outputSelections(e.cfg, archiveDir, archived)
+	if err != nil {
+		return err
+	}
+
+	opts := e.renderOptions(ctx)
+	opts.Scope = scope
+	writes := 0
+	changedIDs := make(map[string]bool)
+	for _, sel := range selections {
+		changed := changedChannelIDs(countLatest, watermarks[sel.outputDir], dates, sel.ids)
+		if len(changed) == 0 {
+			continue
+		}
+		n, err := RenderArchiveRangeForChannels(ctx, archiveDir, sel.outputDir, from, to, opts, changed)
+		writes += n
+		if err != nil {
+			return err
+		}
+		// Advance to the archive checkpoint rather than the counts timestamp
+		// so a channel whose newest messages are not archived yet stays
+		// pending. Skipped channels stay pending too.
+		for _, id := range changed {
+			changedIDs[id] = true
+			if opts.skipped.has(id) {
+				continue
+			}
+			for _, date := range dates {
+				watermarks.set(sel.outputDir, date, id, archiveLatest[id])
+			}
+		}
+	}
+	if len(changedIDs) == 0 {
+		e.stagef("No channels changed since last export; skipped %s through %s", from, to)
+		return nil
+	}
+	e.recordOutputSize(archiveDir, opts)
+	if err := saveExportWatermarks(archiveDir, watermarks); err != nil {
+		return fmt.Errorf("saving export watermarks: %w", err)
+	}
+	e.stagef("Rendered %s through %s for %d changed channel(s) (%d changed file(s))",
+		from, to, len(changedIDs), writes)
+	return e.finishSkipped(archiveDir, opts.skipped)
+}
+
+// changedChannelIDs returns the channels in ids whose counts latest timestamp
+// is newer than their watermark on any of dates in one output directory.
+// Channel-days without a watermark are changed.
+func changedChannelIDs(countLatest map[string]time.Time, watermarks map[string]map[string]time.Time, dates, ids []string) []string {
+	var changed []string
+	for _, id := range ids {
+		latest := countLatest[id]
+		for _, date := range dates {
+			if watermark, ok := watermarks[date][id]; !ok || latest.After(watermark) {
+				changed = append(changed, id)
+				break
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func archiveChannelLatest(ctx context.Context, archiveDir string) (map[string]time.Time, error) {
+	src, err := source.Load(ctx, archiveDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = src.Close() }()
+
+	latest, err := src.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return channelLatestFromCheckpoints(latest), nil
+}
+
+// checkpointLink has the shape of slackdump's checkpoint key,
+// structures.SlackLink, which its internal package keeps from being named.
+type checkpointLink = struct {
+	Channel  string
+	ThreadTS string
+}
+
+// channelLatestFromCheckpoints folds channel and thread checkpoints into the
+// newest timestamp per channel, matching how counts reports latest activity.
+func channelLatestFromCheckpoints[K ~checkpointLink](latest map[K]time.Time) map[string]time.Time {
+	result := make(map[string]time.Time, len(latest))
+	for link, ts := range latest {
+		channelID := checkpointLink(link).Channel
+		if ts.After(result[channelID]) {
+			result[channelID] = ts
+		}
+	}
+	return result
+}
+
+func loadExportWatermarks(archiveDir string) (exportWatermarks, error) {
+	state, err := loadExportState(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+	return state.Rendered, nil
+}
+
+func saveExportWatermarks(archiveDir string, watermarks exportWatermarks) error {
+	state, err := loadExportState(archiveDir)
+	if err != nil {
+		return err
+	}
+	state.Rendered = watermarks
+	return saveExportState(archiveDir, state)
+}
+
+func loadExportState(archiveDir string) (exportStateData, error) {
+	state := exportStateData{Rendered: exportWatermarks{}}
+	data, err := os.ReadFile(filepath.Join(archiveDir, exportStateFilename))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, err
+	}
+	if state.Rendered == nil {
+		state.Rendered = exportWatermarks{}
+	}
+	return state, nil
+}
+
+func saveExportState(archiveDir string, state exportStateData) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return writeFileAtomic(filepath.Join(archiveDir, exportStateFilename), data, 0600)
+}
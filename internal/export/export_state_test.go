@@ -0,0 +1,123 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChangedChannelIDs_SkipsChannelsAtOrBelowWatermark(t *testing.T) {
+	base := time.Date(2026, 7, 4, 12, 0, 0, 0, time.UTC)
+	countLatest := map[string]time.Time{
+		"C_MOVED":     base.Add(time.Minute),
+		"C_UNCHANGED": base,
+		"C_NEW":       base,
+	}
+	watermarks := exportWatermarks{}
+	for _, id := range []string{"C_MOVED", "C_UNCHANGED"} {
+		watermarks.set("out", "2026-07-04", id, base)
+	}
+
+	got := changedChannelIDs(countLatest, watermarks["out"], []string{"2026-07-04"}, []string{"C_MOVED", "C_NEW", "C_UNCHANGED"})
+	want := []string{"C_MOVED", "C_NEW"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("changedChannelIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestChangedChannelIDs_WatermarksArePerDateAndOutputDir(t *testing.T) {
+	base := time.Date(2026, 7, 4, 12, 0, 0, 0, time.UTC)
+	countLatest := map[string]time.Time{"C1": base}
+	watermarks := exportWatermarks{}
+	watermarks.set("out", "2026-07-04", "C1", base)
+
+	ids := []string{"C1"}
+	if got := changedChannelIDs(countLatest, watermarks["out"], []string{"2026-07-04"}, ids); len(got) != 0 {
+		t.Errorf("rendered date = %v, want unchanged", got)
+	}
+	if got := changedChannelIDs(countLatest, watermarks["out"], []string{"2026-07-03", "2026-07-04"}, ids); len(got) != 1 {
+		t.Errorf("range with an unrendered date = %v, want C1", got)
+	}
+	if got := changedChannelIDs(countLatest, watermarks["mirror"], []string{"2026-07-04"}, ids); len(got) != 1 {
+		t.Errorf("new output dir = %v, want C1", got)
+	}
+}
+
+// testCheckpointLink has the shape of slackdump's checkpoint key.
+type testCheckpointLink struct {
+	Channel  string
+	ThreadTS string
+}
+
+func TestChannelLatestFromCheckpoints_FoldsThreadCheckpoints(t *testing.T) {
+	base := time.Date(2026, 7, 4, 12, 0, 0, 0, time.UTC)
+	latest := map[testCheckpointLink]time.Time{
+		{Channel: "C001"}:                      base,
+		{Channel: "C001", ThreadTS: "111.111"}: base.Add(time.Hour),
+		{Channel: "C002"}:                      base.Add(time.Minute),
+		{Channel: "C002", ThreadTS: "222.222"}: base,
+	}
+
+	got := channelLatestFromCheckpoints(latest)
+	if !got["C001"].Equal(base.Add(time.Hour)) {
+		t.Errorf("C001 latest = %v, want thread checkpoint %v", got["C001"], base.Add(time.Hour))
+	}
+	if !got["C002"].Equal(base.Add(time.Minute)) {
+		t.Errorf("C002 latest = %v, want channel checkpoint %v", got["C002"], base.Add(time.Minute))
+	}
+	if len(got) != 2 {
+		t.Errorf("channelLatestFromCheckpoints() returned %d channels, want 2", len(got))
+	}
+}
+
+func TestExportWatermarks_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	want := time.Date(2026, 7, 4, 12, 30, 0, 123000000, time.UTC)
+
+	watermarks := exportWatermarks{}
+	watermarks.set("/out", "2026-07-04", "C001", want)
+	if err := saveExportWatermarks(dir, watermarks); err != nil {
+		t.Fatalf("saveExportWatermarks() error = %v", err)
+	}
+	got, err := loadExportWatermarks(dir)
+	if err != nil {
+		t.Fatalf("loadExportWatermarks() error = %v", err)
+	}
+	if !got["/out"]["2026-07-04"]["C001"].Equal(want) {
+		t.Errorf("watermark = %v, want %v", got["/out"]["2026-07-04"]["C001"], want)
+	}
+}
+
+func TestLoadExportWatermarks_IgnoresSharedWatermarks(t *testing.T) {
+	dir := t.TempDir()
+	state := `{"watermarks": {"C001": "2026-07-04T12:00:00Z"}, "channel_day_bytes": 10}`
+	if err := os.WriteFile(filepath.Join(dir, exportStateFilename), []byte(state), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadExportWatermarks(dir)
+	if err != nil || len(got) != 0 {
+		t.Errorf("loadExportWatermarks() = %v, %v; want none, so everything renders once", got, err)
+	}
+}
+
+func TestLoadExportWatermarks_MissingFileReturnsEmptyMap(t *testing.T) {
+	got, err := loadExportWatermarks(t.TempDir())
+	if err != nil {
+		t.Fatalf("loadExportWatermarks() error = %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Fatalf("loadExportWatermarks() = %v, want empty non-nil map", got)
+	}
+}
+
+func TestLoadExportWatermarks_InvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, exportStateFilename), []byte("{"), 0600); err != nil {
+		t.Fatalf("writing state file: %v", err)
+	}
+	if _, err := loadExportWatermarks(dir); err == nil {
+		t.Fatal("loadExportWatermarks() should fail on invalid JSON")
+	}
+}
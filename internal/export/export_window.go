@@ -15,7 +15,7 @@ func (e *Exporter) renderWindow(now time.Time) (string, string, error) {
 	if err != nil {
 		return "", "", fmt.Errorf("loading timezone: %w", err)
 	}
-	lookback, err := parseFriendlyDuration(e.cfg.Lookback)
+	lookback, err := ParseFriendlyDuration(e.cfg.Lookback)
 	if err != nil {
 		return "", "", fmt.Errorf("parsing lookback: %w", err)
 	}
@@ -32,10 +32,18 @@ func (e *Exporter) seedDate(now time.Time) (string, error) {
 		}
 		return e.cfg.SeedDate, nil
 	}
-	if date, err := findEarliestExportDate(e.cfg.OutputDir); err != nil {
-		return "", err
-	} else if date != "" {
-		return date, nil
+	earliest := ""
+	for _, dir := range e.cfg.OutputDirs() {
+		date, err := findEarliestExportDate(dir)
+		if err != nil {
+			return "", err
+		}
+		if date != "" && (earliest == "" || date < earliest) {
+			earliest = date
+		}
+	}
+	if earliest != "" {
+		return earliest, nil
 	}
 	loc, err := time.LoadLocation(e.cfg.Timezone)
 	if err != nil {
@@ -76,6 +84,9 @@ func sanitizePathPart(value string) string {
 
 var exportDateDirPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
 
+// findEarliestExportDate returns the first date exported under dir in any
+// layout: a daily folder's name, or the earliest date in the file names of
+// a weekly or monthly folder.
 func findEarliestExportDate(dir string) (string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -86,17 +97,42 @@ func findEarliestExportDate(dir string) (string, error) {
 	}
 	var earliest string
 	for _, entry := range entries {
-		if !entry.IsDir() || !exportDateDirPattern.MatchString(entry.Name()) {
+		if !entry.IsDir() || !exportFolderPattern.MatchString(entry.Name()) {
 			continue
 		}
-		if earliest == "" || entry.Name() < earliest {
-			earliest = entry.Name()
+		dates, err := exportFolderDates(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		for _, date := range dates {
+			if earliest == "" || date < earliest {
+				earliest = date
+			}
 		}
 	}
 	return earliest, nil
 }
 
-func parseFriendlyDuration(value string) (time.Duration, error) {
+// exportFolderDates returns the dates held by one date folder.
+func exportFolderDates(folder string) ([]string, error) {
+	name := filepath.Base(folder)
+	if exportDateDirPattern.MatchString(name) {
+		return []string{name}, nil
+	}
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return folderDates(name, names), nil
+}
+
+// ParseFriendlyDuration parses "90d" style day counts as well as Go
+// durations such as "36h". An empty string is zero.
+func ParseFriendlyDuration(value string) (time.Duration, error) {
 	if value == "" {
 		return 0, nil
 	}
@@ -5,14 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/chrisedwards/slack-export/internal/channels"
 	"github.com/chrisedwards/slack-export/internal/config"
 	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/chrisedwards/slack-export/internal/tracing"
+	"github.com/chrisedwards/slack-export/pkg/slackts"
 	"github.com/rusq/slackdump/v4/source"
 )
 
@@ -22,15 +22,34 @@ type Exporter struct {
 	edgeClient *slack.EdgeClient
 	slackdump  string
 	creds      *slack.Credentials
+	observer   Events
+	// channelErrors is asked what to do when a channel fails to render.
+	channelErrors ChannelErrorHandler
 }
 
 type SyncOptions struct {
 	Full bool
+	// ReconcileDays re-fetches every tracked channel for this many days and
+	// records edits and deletions in changes.log while re-rendering.
+	ReconcileDays int
+	// MaxSyncDays overrides max_sync_days for this sync; zero keeps the
+	// configured limit. CatchUp is CatchUpAll or CatchUpChunks to proceed
+	// past the limit instead of returning a CatchUpError.
+	MaxSyncDays int
+	CatchUp     string
+	// Resume re-renders what an interrupted sync archived, found through
+	// the run state it left in the archive directory.
+	Resume bool
+	// Scope limits the sync, archive refresh included, to direct messages
+	// or to channels.
+	Scope ChannelScope
 }
 
 // NewExporter creates an Exporter with Slack credentials, Edge API, and slackdump.
-func NewExporter(cfg *config.Config) (*Exporter, error) {
-	creds, err := slack.LoadCredentials()
+// middleware wraps every Slack API request, nearest the wire, so it also sees
+// retried attempts and refreshed credentials. ctx bounds the credential check.
+func NewExporter(ctx context.Context, cfg *config.Config, middleware ...slack.Middleware) (*Exporter, error) {
+	creds, err := slack.LoadWorkspaceCredentials(cfg.Workspace)
 	if err != nil {
 		return nil, fmt.Errorf("loading credentials: %w", err)
 	}
@@ -43,12 +62,25 @@ func NewExporter(cfg *config.Config) (*Exporter, error) {
 		return nil, err
 	}
 
-	edgeClient := slack.NewEdgeClient(creds)
-	if _, err := edgeClient.AuthTest(context.Background()); err != nil {
-		return nil, fmt.Errorf("verifying credentials: %w", err)
+	edgeClient := slack.NewEdgeClient(creds).WithMiddleware(middleware...).WithRequestsPerSecond(cfg.EdgeRPS)
+	if cfg.APIHost != "" {
+		edgeClient = edgeClient.WithAPIHost(cfg.APIHost)
 	}
-
-	return &Exporter{cfg: cfg, edgeClient: edgeClient, slackdump: sdPath, creds: creds}, nil
+	e := &Exporter{cfg: cfg, slackdump: sdPath, creds: creds}
+	edgeClient = edgeClient.WithCredentialRefresher(slack.ReloadWorkspaceCredentials(creds.Workspace)).
+		WithChannelDiscovery(cfg.ChannelDiscovery, func(err error) {
+			e.warnf("Edge API unavailable, finding channels with conversations.list: %v", err)
+		}).
+		WithDiscoveryCache(slack.DefaultUserListCacheDir(), func(err error) { e.warnf("%v", err) })
+	if ttl := cfg.UsersCacheDuration(); ttl > 0 {
+		cache := slack.NewUserListCache(slack.DefaultUserListCacheDir(), ttl)
+		edgeClient = edgeClient.WithUserListCache(cache, func(err error) { e.warnf("%v", err) })
+	}
+	if _, err := edgeClient.AuthTest(ctx); err != nil {
+		return nil, classifyError(fmt.Errorf("verifying credentials: %w", err))
+	}
+	e.edgeClient = edgeClient
+	return e, nil
 }
 
 // Config returns the exporter's configuration.
@@ -103,12 +135,13 @@ func ConfigRenderWindow(cfg *config.Config, now time.Time) (string, string, erro
 }
 
 // ExportDate renders Slack messages for a single date from the archive database.
-func (e *Exporter) ExportDate(ctx context.Context, date string) error {
-	return e.ExportRange(ctx, date, date)
+func (e *Exporter) ExportDate(ctx context.Context, date string, opts ExportOptions) error {
+	return e.ExportRange(ctx, date, date, opts)
 }
 
 // ExportRange renders Slack messages for all dates in a range from the archive database.
-func (e *Exporter) ExportRange(ctx context.Context, from, to string) error {
+func (e *Exporter) ExportRange(ctx context.Context, from, to string, opts ExportOptions) (err error) {
+	defer func() { err = classifyError(err) }()
 	if _, err := datesInRange(from, to, e.cfg.Timezone); err != nil {
 		return err
 	}
@@ -133,17 +166,34 @@ func (e *Exporter) ExportRange(ctx context.Context, from, to string) error {
 	if !archiveExists(archiveDir) {
 		return fmt.Errorf("archive does not exist at %s; run slack-export sync first", archiveDir)
 	}
+	if err := e.preflightDates(ctx, from, to, time.Now()); err != nil {
+		return err
+	}
+	if err := e.preflightOutputSpace(archiveDir, estimatedChannelDays(archiveDir, from, to, e.cfg.Timezone)); err != nil {
+		return err
+	}
+	if opts.ChangedOnly {
+		return e.exportChangedRange(ctx, archiveDir, from, to, opts.Scope)
+	}
 
-	writes, err := RenderArchiveRange(ctx, archiveDir, e.cfg.OutputDir, from, to, e.cfg.Timezone)
+	renderOpts := e.renderOptions(ctx)
+	renderOpts.Scope = opts.Scope
+	writes, err := tracedRender(ctx, from, to, func(ctx context.Context) (int, error) {
+		return RenderConfiguredRange(ctx, e.cfg, archiveDir, from, to, renderOpts, nil)
+	})
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Rendered %s through %s (%d changed file(s))\n", from, to, writes)
-	return nil
+	e.verifyWrittenCounts(ctx, renderOpts)
+	e.recordOutputSize(archiveDir, renderOpts)
+	e.stagef("Rendered %s through %s (%d changed file(s))", from, to, writes)
+	e.events().OnStage(renderOpts.Accounting.Summary())
+	return e.finishSkipped(archiveDir, renderOpts.skipped)
 }
 
 // Sync refreshes the persistent archive and renders changed markdown files.
-func (e *Exporter) Sync(ctx context.Context, now time.Time, syncOpts SyncOptions) error {
+func (e *Exporter) Sync(ctx context.Context, now time.Time, syncOpts SyncOptions) (err error) {
+	defer func() { err = classifyError(err) }()
 	archiveDir, err := e.ArchiveDir()
 	if err != nil {
 		return err
@@ -156,77 +206,91 @@ func (e *Exporter) Sync(ctx context.Context, now time.Time, syncOpts SyncOptions
 		if syncOpts.Full {
 			return errors.New("archive refresh already in progress")
 		}
-		fmt.Println("refresh/render skipped, sweep active")
+		e.events().OnStage("refresh/render skipped, sweep active")
 		return nil
 	}
 	defer func() { _ = lock.Release() }()
 	if !syncOpts.Full {
-		warnIfSweepStale(archiveDir, now)
+		e.warnIfSweepStale(archiveDir, now)
 	}
 
-	tracked, err := e.trackedChannels(ctx)
+	discoveryCtx, span := tracing.Start(ctx, "discovery")
+	tracked, err := e.trackedChannels(discoveryCtx)
+	tracked = syncOpts.Scope.FilterChannels(tracked)
+	span.SetAttributes(tracing.Int("slack_export.channels", len(tracked)))
+	span.End(err)
 	if err != nil {
 		return err
 	}
 	if len(tracked) == 0 {
-		fmt.Println("No tracked channels found")
+		e.events().OnStage("No tracked channels found")
 		return nil
 	}
+	tracked = prioritizeChannels(tracked)
+	e.announceNewChannels(archiveDir, tracked)
+	e.refreshUsergroupCache(ctx)
+	e.recordStatusChanges(ctx, archiveDir, now)
+	e.recordReminders(ctx, archiveDir, now)
+	e.recordUserProfiles(ctx, archiveDir, now)
+	e.recordChannelSections(ctx, archiveDir, tracked)
+	e.recordMembershipChanges(ctx, archiveDir, now)
+	e.recordChannelEvents(ctx, now)
+	e.recordReadState(ctx, tracked, now)
 
 	ids := channelIDs(tracked)
 	renderIDs := ids
-	var renderTargets []renderTarget
 
-	if !archiveExists(archiveDir) {
-		seedDate, err := e.seedDate(now)
-		if err != nil {
-			return err
-		}
-		seedStart, _, err := GetDateBounds(seedDate, e.cfg.Timezone)
-		if err != nil {
-			return fmt.Errorf("calculating seed date bounds: %w", err)
-		}
-		fmt.Printf("Bootstrapping archive from %s into %s\n", seedDate, archiveDir)
-		apiConfigPath := ""
-		if syncOpts.Full {
-			apiConfigPath, err = writeSweepAPIConfig(archiveDir)
-			if err != nil {
-				return err
-			}
-		}
-		if err := BootstrapArchive(ctx, e.slackdump, archiveDir, ids, seedStart, apiConfigPath); err != nil {
-			return fmt.Errorf("bootstrapping archive: %w", err)
-		}
-		if err := markSweepSuccess(archiveDir, now); err != nil {
-			return err
-		}
-	} else {
-		opts, err := e.resumeOptions(archiveDir, syncOpts)
+	runID := newRunID(now)
+	tempDir, cleanupTemp, err := e.prepareRunTempDir(archiveDir, runID)
+	if err != nil {
+		return err
+	}
+	defer cleanupTemp()
+	salvage, err := e.startSyncRun(archiveDir, runID, tempDir, now, tracked, syncOpts.Resume)
+	if err != nil {
+		return err
+	}
+	defer func() {
 		if err != nil {
-			return err
+			return
 		}
-		resume, err := e.resumeArchive(ctx, archiveDir, tracked, now, opts)
-		if err != nil {
-			return err
+		if finishErr := finishRun(archiveDir); finishErr != nil {
+			e.warnf("%v", finishErr)
 		}
-		renderTargets = resume.renderTargets
+	}()
+
+	renderTargets, err := e.updateArchive(ctx, archiveDir, tracked, now, tempDir, syncOpts)
+	if err != nil {
+		return err
+	}
+	if renderTargets != nil && len(salvage) > 0 {
+		renderTargets = orderRenderTargets(mergeRenderTargets(renderTargets, salvage), ids)
 	}
 	if err := saveChannelNames(archiveDir, tracked); err != nil {
 		return fmt.Errorf("saving channel names: %w", err)
 	}
 
 	if renderTargets != nil {
-		writes, err := RenderArchiveTargets(ctx, archiveDir, e.cfg.OutputDir, e.cfg.Timezone, renderTargets)
-		if err != nil {
+		if err := e.preflightOutputSpace(archiveDir, len(renderTargets)); err != nil {
 			return err
 		}
+		opts := e.syncRenderOptions(ctx, syncOpts)
 		from, to := renderTargetDateRange(renderTargets)
+		writes, err := tracedRender(ctx, from, to, func(ctx context.Context) (int, error) {
+			return renderConfiguredTargets(ctx, e.cfg, archiveDir, opts, renderTargets)
+		})
+		if err != nil {
+			return err
+		}
+		e.verifyWrittenCounts(ctx, opts)
+		e.recordOutputSize(archiveDir, opts)
 		if from == "" {
-			fmt.Println("Rendered changed archive rows (0 changed file(s))")
+			e.events().OnStage("Rendered changed archive rows (0 changed file(s))")
 		} else {
-			fmt.Printf("Rendered changed archive rows for %s through %s (%d changed file(s))\n", from, to, writes)
+			e.stagef("Rendered changed archive rows for %s through %s (%d changed file(s))", from, to, writes)
+			e.events().OnStage(opts.Accounting.Summary())
 		}
-		return nil
+		return e.finishSkipped(archiveDir, opts.skipped)
 	}
 
 	from, to, err := e.renderWindow(now)
@@ -234,15 +298,133 @@ func (e *Exporter) Sync(ctx context.Context, now time.Time, syncOpts SyncOptions
 		return err
 	}
 	if len(renderIDs) == 0 {
-		fmt.Printf("Rendered %s through %s (0 changed file(s))\n", from, to)
+		e.stagef("Rendered %s through %s (0 changed file(s))", from, to)
 		return nil
 	}
-	writes, err := RenderArchiveRangeForChannels(ctx, archiveDir, e.cfg.OutputDir, from, to, e.cfg.Timezone, renderIDs)
+	dates, err := datesInRange(from, to, e.cfg.Timezone)
+	if err != nil {
+		return err
+	}
+	if err := e.preflightOutputSpace(archiveDir, len(dates)*len(renderIDs)); err != nil {
+		return err
+	}
+	opts := e.syncRenderOptions(ctx, syncOpts)
+	writes, err := tracedRender(ctx, from, to, func(ctx context.Context) (int, error) {
+		return RenderConfiguredRange(ctx, e.cfg, archiveDir, from, to, opts, renderIDs)
+	})
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Rendered %s through %s (%d changed file(s))\n", from, to, writes)
-	return nil
+	e.verifyWrittenCounts(ctx, opts)
+	e.recordOutputSize(archiveDir, opts)
+	e.stagef("Rendered %s through %s (%d changed file(s))", from, to, writes)
+	e.events().OnStage(opts.Accounting.Summary())
+	return e.finishSkipped(archiveDir, opts.skipped)
+}
+
+// startSyncRun records this sync's run state. When an earlier sync crashed,
+// resume returns the channel-dates it archived so they are rendered with this
+// run's; otherwise the crash is reported and left for a later --resume.
+func (e *Exporter) startSyncRun(
+	archiveDir, runID, tempDir string,
+	now time.Time,
+	tracked []slack.Channel,
+	resume bool,
+) ([]renderTarget, error) {
+	crashed, err := startRun(archiveDir, runID, tempDir, now, resume)
+	if err != nil {
+		return nil, err
+	}
+	if crashed == nil {
+		return nil, nil
+	}
+	started := crashed.StartedAt.Local().Format("2006-01-02 15:04")
+	if !resume {
+		e.warnf("sync run %s (started %s) did not finish; run `slack-export sync --resume` to render what it archived",
+			crashed.RunID, started)
+		return nil, nil
+	}
+	if !archiveExists(archiveDir) {
+		return nil, nil
+	}
+	salvage, err := salvageRun(archiveDir, crashed, RenderOptionsFromConfig(e.cfg), channelNameMap(tracked))
+	if err != nil {
+		return nil, err
+	}
+	e.stagef("Recovering run %s (started %s): re-rendering %d channel-date(s) it archived",
+		crashed.RunID, started, len(salvage))
+	return salvage, nil
+}
+
+// updateArchive bootstraps the archive, or resumes it when it exists, under
+// an "archive" span. It returns the channel-dates the resume wrote, or nil
+// after a bootstrap, when the whole render window is rendered instead.
+func (e *Exporter) updateArchive(
+	ctx context.Context,
+	archiveDir string,
+	tracked []slack.Channel,
+	now time.Time,
+	tempDir string,
+	syncOpts SyncOptions,
+) (targets []renderTarget, err error) {
+	ids := channelIDs(tracked)
+	mode := "resume"
+	if !archiveExists(archiveDir) {
+		mode = "bootstrap"
+	}
+	ctx, span := tracing.Start(ctx, "archive",
+		tracing.String("slack_export.archive_mode", mode), tracing.Int("slack_export.channels", len(ids)))
+	defer func() { span.End(err) }()
+
+	if mode == "resume" {
+		opts, err := e.resumeOptions(archiveDir, syncOpts)
+		if err != nil {
+			return nil, err
+		}
+		opts.TempDir = tempDir
+		opts.Workspace = e.cfg.Workspace
+		opts.ExtraArgs = e.cfg.SlackdumpArgs
+		resume, err := e.resumeWithCatchUp(ctx, archiveDir, tracked, now, opts, syncOpts)
+		if err != nil {
+			return nil, err
+		}
+		return orderRenderTargets(resume.renderTargets, ids), nil
+	}
+
+	seedDate, err := e.seedDate(now)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.preflightDates(ctx, seedDate, seedDate, now); err != nil {
+		return nil, err
+	}
+	seedStart, _, err := GetDateBounds(seedDate, e.cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("calculating seed date bounds: %w", err)
+	}
+	e.stagef("Bootstrapping archive from %s into %s", seedDate, archiveDir)
+	apiConfigPath := ""
+	if syncOpts.Full {
+		apiConfigPath, err = writeSweepAPIConfig(archiveDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = BootstrapArchive(
+		ctx, e.slackdump, archiveDir, ids, seedStart, apiConfigPath, tempDir, e.cfg.Workspace, e.cfg.SlackdumpArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("bootstrapping archive: %w", err)
+	}
+	return nil, markSweepSuccess(archiveDir, now)
+}
+
+// tracedRender runs render under a "render" span covering from through to.
+func tracedRender(ctx context.Context, from, to string, render func(context.Context) (int, error)) (int, error) {
+	ctx, span := tracing.Start(ctx, "render", tracing.String("slack_export.from", from), tracing.String("slack_export.to", to))
+	writes, err := render(ctx)
+	span.SetAttributes(tracing.Int("slack_export.files_written", writes))
+	span.End(err)
+	return writes, err
 }
 
 type resumeResult struct {
@@ -262,14 +444,14 @@ func (e *Exporter) resumeArchive(
 		return result, err
 	}
 	if !hasWork {
-		fmt.Println("Archive already current")
+		e.events().OnStage("Archive already current")
 		return result, nil
 	}
 
 	if len(resumeArgs) == 0 {
-		fmt.Println("Resuming archive with existing checkpoints")
+		e.events().OnStage("Resuming archive with existing checkpoints")
 	} else {
-		fmt.Printf("Resuming archive with %d scoped entity arg(s)\n", len(resumeArgs))
+		e.stagef("Resuming archive with %d scoped entity arg(s)", len(resumeArgs))
 	}
 	if err := ResumeArchive(ctx, e.slackdump, archiveDir, resumeArgs, opts); err != nil {
 		return result, fmt.Errorf("resuming archive: %w", err)
@@ -279,7 +461,7 @@ func (e *Exporter) resumeArchive(
 			return result, err
 		}
 	}
-	targets, err := writtenResumeRenderTargets(archiveDir, e.cfg.Timezone)
+	targets, err := writtenResumeRenderTargets(archiveDir, RenderOptionsFromConfig(e.cfg), channelNameMap(tracked))
 	if err != nil {
 		return result, fmt.Errorf("loading written resume render targets: %w", err)
 	}
@@ -296,15 +478,17 @@ func (e *Exporter) trackedChannels(ctx context.Context) ([]slack.Channel, error)
 	if err := cache.Load(); err != nil {
 		return nil, fmt.Errorf("loading user cache: %w", err)
 	}
-	resolver := slack.NewUserResolver(userIndex, cache, e.edgeClient)
+	resolver := slack.NewUserResolver(userIndex, cache, e.edgeClient).WithTeams(e.edgeClient.TeamID(), e.edgeClient).
+		WithNameStyle(ConfiguredNameStyle(e.cfg)).
+		WithWarnings(func(err error) { e.warnf("%v", err) })
 	allChannels, err := e.edgeClient.GetActiveChannelsWithResolver(ctx, time.Time{}, resolver)
 	if err != nil {
 		return nil, fmt.Errorf("getting active channels: %w", err)
 	}
 	if err := cache.Save(); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to save user cache: %v\n", err)
+		e.warnf("failed to save user cache: %v", err)
 	}
-	return channels.FilterChannels(allChannels, e.cfg.Include, e.cfg.Exclude), nil
+	return FilterForTargets(allChannels, e.cfg.OutputTargets()), nil
 }
 
 func (e *Exporter) resumeOptions(archiveDir string, syncOpts SyncOptions) (ResumeOptions, error) {
@@ -321,29 +505,44 @@ func (e *Exporter) resumeOptions(archiveDir string, syncOpts SyncOptions) (Resum
 			APIConfigPath:       apiConfigPath,
 		}, nil
 	}
-	return ResumeOptions{
+	if syncOpts.ReconcileDays > 0 {
+		return ResumeOptions{
+			Lookback:  fmt.Sprintf("%dd", syncOpts.ReconcileDays),
+			Reconcile: true,
+		}, nil
+	}
+	return withThreadLookback(ResumeOptions{
 		Lookback:            e.cfg.Lookback,
 		SkipStaleThreads:    e.cfg.SkipStaleThreads,
 		SkipCompleteThreads: e.cfg.SkipCompleteThreads,
-	}, nil
+	}, e.cfg.ThreadLookbackDays), nil
+}
+
+func (e *Exporter) syncRenderOptions(ctx context.Context, syncOpts SyncOptions) RenderOptions {
+	opts := e.renderOptions(ctx)
+	opts.Scope = syncOpts.Scope
+	if syncOpts.ReconcileDays > 0 {
+		opts.TrackChanges = true
+	}
+	return opts
 }
 
 func (e *Exporter) scopedResumeArgs(ctx context.Context, archiveDir string, tracked []slack.Channel) ([]string, bool) {
 	counts, err := e.edgeClient.ClientCounts(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: counts scoping failed; skipping archive resume to avoid an unscoped Slackdump run: %v\n", err)
+		e.warnf("counts scoping failed; skipping archive resume to avoid an unscoped Slackdump run: %v", err)
 		return nil, false
 	}
 	src, err := source.Load(ctx, archiveDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: archive checkpoint load failed; skipping archive resume to avoid an unscoped Slackdump run: %v\n", err)
+		e.warnf("archive checkpoint load failed; skipping archive resume to avoid an unscoped Slackdump run: %v", err)
 		return nil, false
 	}
 	defer func() { _ = src.Close() }()
 
 	latest, err := src.Latest(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: archive checkpoint read failed; skipping archive resume to avoid an unscoped Slackdump run: %v\n", err)
+		e.warnf("archive checkpoint read failed; skipping archive resume to avoid an unscoped Slackdump run: %v", err)
 		return nil, false
 	}
 
@@ -358,18 +557,19 @@ func (e *Exporter) scopedResumeArgs(ctx context.Context, archiveDir string, trac
 	countLatest := countsLatestByID(counts)
 	coverageStart, err := archiveCoverageStart(archiveDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: archive coverage read failed; skipping archive resume to avoid an unbounded Slackdump run: %v\n", err)
+		e.warnf("archive coverage read failed; skipping archive resume to avoid an unbounded Slackdump run: %v", err)
 		return nil, false
 	}
 	if coverageStart.IsZero() {
-		fmt.Fprintln(os.Stderr, "Warning: archive coverage start unknown; skipping archive resume to avoid an unbounded Slackdump run")
+		e.warnf("archive coverage start unknown; skipping archive resume to avoid an unbounded Slackdump run")
 		return nil, false
 	}
-	movedIDs := movedResumeChannelIDs(tracked, checkpoints, countLatest, coverageStart)
+	newStart := e.newChannelStart(coverageStart, time.Now())
+	movedIDs := movedResumeChannelIDs(tracked, checkpoints, countLatest, newStart)
 	if len(movedIDs) == 0 {
 		return nil, false
 	}
-	return scopedResumeArgsFromLatest(tracked, latest, checkpoints, movedIDs, coverageStart), true
+	return scopedResumeArgsFromLatest(tracked, latest, checkpoints, movedIDs, newStart), true
 }
 
 func scopedResumeArgsFromLatest[K interface {
@@ -453,12 +653,19 @@ func countsLatestByID(counts *slack.CountsResponse) map[string]time.Time {
 }
 
 func laterSlackTS(a, b string) time.Time {
-	at, _ := slack.ParseSlackTS(a)
-	bt, _ := slack.ParseSlackTS(b)
-	if bt.After(at) {
-		return bt
+	if slackts.CompareTS(b, a) > 0 {
+		a = b
+	}
+	t, _ := slack.ParseSlackTS(a)
+	return t
+}
+
+func channelNameMap(chans []slack.Channel) map[string]string {
+	names := make(map[string]string, len(chans))
+	for _, ch := range chans {
+		names[ch.ID] = ch.Name
 	}
-	return at
+	return names
 }
 
 func channelIDs(chans []slack.Channel) []string {
@@ -485,18 +692,18 @@ func renderTargetDateRange(targets []renderTarget) (string, string) {
 	return from, to
 }
 
-func warnIfSweepStale(archiveDir string, now time.Time) {
+func (e *Exporter) warnIfSweepStale(archiveDir string, now time.Time) {
 	last, ok, err := lastSweepSuccess(archiveDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to read last full sweep success: %v\n", err)
+		e.warnf("failed to read last full sweep success: %v", err)
 		return
 	}
 	if !ok {
-		fmt.Fprintln(os.Stderr, "Warning: no successful full sweep recorded; schedule slack-export sync --full")
+		e.warnf("no successful full sweep recorded; schedule slack-export sync --full")
 		return
 	}
 	if now.Sub(last) > 30*24*time.Hour {
-		fmt.Fprintf(os.Stderr, "Warning: last successful full sweep was %s; schedule slack-export sync --full\n",
+		e.warnf("last successful full sweep was %s; schedule slack-export sync --full",
 			last.Format("2006-01-02"))
 	}
 }
@@ -84,7 +84,7 @@ func TestNewExporter_SlackdumpNotFound(t *testing.T) {
 	// Since LoadCredentials() will fail first (no slackdump cache),
 	// we need to verify the error chain
 
-	_, err := NewExporter(cfg)
+	_, err := NewExporter(context.Background(), cfg)
 	if err == nil {
 		t.Fatal("NewExporter() should fail when credentials/slackdump unavailable")
 	}
@@ -389,6 +389,11 @@ func TestResumeOptions_ModeSplitIgnoresOldFullSweepMarker(t *testing.T) {
 			wantSkipComplete:  true,
 			wantAPIConfigPath: true,
 		},
+		{
+			name:         "reconcile run widens lookback and revisits every thread",
+			opts:         SyncOptions{ReconcileDays: 14},
+			wantLookback: "14d",
+		},
 	}
 
 	for _, tt := range tests {
@@ -517,8 +522,10 @@ func TestMarkSweepSuccess_WritesLastSuccessHealthMarker(t *testing.T) {
 	}
 }
 
-func TestWrittenResumeRenderTargets_UsesLatestFinishedResumeMessageDates(t *testing.T) {
-	archiveDir := t.TempDir()
+// writeSessionTestArchive writes an archive of three resume sessions; the
+// second never finished.
+func writeSessionTestArchive(t *testing.T, archiveDir string) {
+	t.Helper()
 	db := openTestArchiveDB(t, archiveDir)
 	defer func() { _ = db.Close() }()
 
@@ -563,8 +570,13 @@ func TestWrittenResumeRenderTargets_UsesLatestFinishedResumeMessageDates(t *test
 			(4, 30, 'C_ALPHA', '1783063800.000000', 1, '{}'),
 			(5, 31, 'C_BETA', '1783094460.000000', 0, '{}')
 	`)
+}
+
+func TestWrittenResumeRenderTargets_UsesLatestFinishedResumeMessageDates(t *testing.T) {
+	archiveDir := t.TempDir()
+	writeSessionTestArchive(t, archiveDir)
 
-	got, err := writtenResumeRenderTargets(archiveDir, "America/Chicago")
+	got, err := writtenResumeRenderTargets(archiveDir, RenderOptions{Timezone: "America/Chicago"}, nil)
 	if err != nil {
 		t.Fatalf("writtenResumeRenderTargets() error = %v", err)
 	}
@@ -610,7 +622,7 @@ func TestExportDate_InvalidDate(t *testing.T) {
 		cfg: &config.Config{Timezone: "America/New_York"},
 	}
 
-	err := e.ExportDate(context.Background(), "not-a-date")
+	err := e.ExportDate(context.Background(), "not-a-date", ExportOptions{})
 	if err == nil {
 		t.Error("ExportDate() should fail with invalid date")
 	}
@@ -624,7 +636,7 @@ func TestExportDate_InvalidTimezone(t *testing.T) {
 		cfg: &config.Config{Timezone: "Invalid/Timezone"},
 	}
 
-	err := e.ExportDate(context.Background(), "2026-01-22")
+	err := e.ExportDate(context.Background(), "2026-01-22", ExportOptions{})
 	if err == nil {
 		t.Error("ExportDate() should fail with invalid timezone")
 	}
@@ -643,7 +655,7 @@ func TestExportDate_NoActiveChannels(t *testing.T) {
 		creds: &slack.Credentials{Workspace: "test"},
 	}
 
-	err := e.ExportDate(context.Background(), "2026-01-22")
+	err := e.ExportDate(context.Background(), "2026-01-22", ExportOptions{})
 	if err == nil {
 		t.Fatal("ExportDate() should fail when archive is missing")
 	}
@@ -663,7 +675,7 @@ func TestExportDate_AllChannelsFilteredOut(t *testing.T) {
 		creds: &slack.Credentials{Workspace: "test"},
 	}
 
-	err := e.ExportDate(context.Background(), "2026-01-22")
+	err := e.ExportDate(context.Background(), "2026-01-22", ExportOptions{})
 	if err == nil {
 		t.Fatal("ExportDate() should fail before seed date")
 	}
@@ -689,7 +701,7 @@ func TestExportDate_EdgeAPIError(t *testing.T) {
 	if !strings.HasSuffix(archiveDir, "test_workspace") {
 		t.Errorf("ArchiveDir() = %q, want sanitized workspace suffix", archiveDir)
 	}
-	err = e.ExportDate(context.Background(), "2026-01-22")
+	err = e.ExportDate(context.Background(), "2026-01-22", ExportOptions{})
 	if err == nil {
 		t.Error("ExportDate() should fail without archive")
 	}
@@ -732,7 +744,7 @@ func TestExportRange_InvalidTimezone(t *testing.T) {
 		cfg: &config.Config{Timezone: "Invalid/Timezone"},
 	}
 
-	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-24")
+	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-24", ExportOptions{})
 	if err == nil {
 		t.Error("ExportRange() should fail with invalid timezone")
 	}
@@ -746,7 +758,7 @@ func TestExportRange_InvalidFromDate(t *testing.T) {
 		cfg: &config.Config{Timezone: "America/New_York"},
 	}
 
-	err := e.ExportRange(context.Background(), "not-a-date", "2026-01-24")
+	err := e.ExportRange(context.Background(), "not-a-date", "2026-01-24", ExportOptions{})
 	if err == nil {
 		t.Error("ExportRange() should fail with invalid from date")
 	}
@@ -760,7 +772,7 @@ func TestExportRange_InvalidToDate(t *testing.T) {
 		cfg: &config.Config{Timezone: "America/New_York"},
 	}
 
-	err := e.ExportRange(context.Background(), "2026-01-22", "not-a-date")
+	err := e.ExportRange(context.Background(), "2026-01-22", "not-a-date", ExportOptions{})
 	if err == nil {
 		t.Error("ExportRange() should fail with invalid to date")
 	}
@@ -774,7 +786,7 @@ func TestExportRange_FromAfterTo(t *testing.T) {
 		cfg: &config.Config{Timezone: "America/New_York"},
 	}
 
-	err := e.ExportRange(context.Background(), "2026-01-24", "2026-01-22")
+	err := e.ExportRange(context.Background(), "2026-01-24", "2026-01-22", ExportOptions{})
 	if err == nil {
 		t.Error("ExportRange() should fail when from is after to")
 	}
@@ -793,7 +805,7 @@ func TestExportRange_SingleDay(t *testing.T) {
 		creds: &slack.Credentials{Workspace: "test"},
 	}
 
-	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-22")
+	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-22", ExportOptions{})
 	if err == nil {
 		t.Fatal("ExportRange() should fail when archive is missing")
 	}
@@ -812,7 +824,7 @@ func TestExportRange_MultiDay(t *testing.T) {
 		creds: &slack.Credentials{Workspace: "test"},
 	}
 
-	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-24")
+	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-24", ExportOptions{})
 	if err == nil {
 		t.Fatal("ExportRange() should fail when archive is missing")
 	}
@@ -828,7 +840,7 @@ func TestExportRange_ContinuesOnErrorAndReturnsFailure(t *testing.T) {
 		creds: &slack.Credentials{Workspace: "test"},
 	}
 
-	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-24")
+	err := e.ExportRange(context.Background(), "2026-01-22", "2026-01-24", ExportOptions{})
 	if err == nil {
 		t.Fatal("ExportRange() should fail when range starts before seed")
 	}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// failedJobsFile queues, in the archive directory, the channel-days that
+// were skipped after render errors until retry-failed renders them.
+const failedJobsFile = "failed-jobs.json"
+
+// Kinds of failure recorded in FailedJob.Kind.
+const (
+	FailureRateLimited     = "rate_limited"
+	FailureAuthExpired     = "auth_expired"
+	FailureSlackdumpFailed = "slackdump_failed"
+	FailureOther           = "error"
+)
+
+// FailedJob is a channel-day that failed to render and was skipped.
+type FailedJob struct {
+	ChannelID string `json:"channel_id"`
+	Channel   string `json:"channel"`
+	Date      string `json:"date"`
+	// Kind classifies the failure, e.g. FailureRateLimited.
+	Kind     string    `json:"kind"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+type failedJobsData struct {
+	Jobs []FailedJob `json:"jobs"`
+}
+
+// failureKind classifies a channel's render error for the queue.
+func failureKind(err error) string {
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return FailureRateLimited
+	case errors.Is(err, ErrAuthExpired):
+		return FailureAuthExpired
+	case errors.Is(err, ErrSlackdumpFailed):
+		return FailureSlackdumpFailed
+	default:
+		return FailureOther
+	}
+}
+
+func failedJobsPath(archiveDir string) string {
+	return filepath.Join(archiveDir, failedJobsFile)
+}
+
+// LoadFailedJobs returns the channel-days queued in archiveDir, or nil when
+// none are.
+func LoadFailedJobs(archiveDir string) ([]FailedJob, error) {
+	data, err := os.ReadFile(failedJobsPath(archiveDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading failed jobs: %w", err)
+	}
+	var queue failedJobsData
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", failedJobsPath(archiveDir), err)
+	}
+	return queue.Jobs, nil
+}
+
+// saveFailedJobs replaces the queue with jobs, removing the file when there
+// are none.
+func saveFailedJobs(archiveDir string, jobs []FailedJob) error {
+	if len(jobs) == 0 {
+		if err := os.Remove(failedJobsPath(archiveDir)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing failed jobs: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(failedJobsData{Jobs: jobs}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(failedJobsPath(archiveDir), append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing failed jobs: %w", err)
+	}
+	return nil
+}
+
+// mergeFailedJobs adds failed to queued, replacing earlier failures of the
+// same channel-day, and sorts the result by date and channel.
+func mergeFailedJobs(queued, failed []FailedJob) []FailedJob {
+	index := make(map[renderTarget]int, len(queued)+len(failed))
+	var merged []FailedJob
+	for _, job := range append(append([]FailedJob(nil), queued...), failed...) {
+		key := renderTarget{channelID: job.ChannelID, date: job.Date}
+		if i, ok := index[key]; ok {
+			merged[i] = job
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, job)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Date != merged[j].Date {
+			return merged[i].Date < merged[j].Date
+		}
+		return merged[i].Channel < merged[j].Channel
+	})
+	return merged
+}
+
+// finishSkipped queues the channel-days skipped during a render for
+// retry-failed and reports the skipped channels as ErrChannelSkipped.
+func (e *Exporter) finishSkipped(archiveDir string, skipped *skippedChannels) error {
+	if failed := mergeFailedJobs(nil, skipped.failedJobs()); len(failed) > 0 {
+		queued, err := LoadFailedJobs(archiveDir)
+		if err == nil {
+			err = saveFailedJobs(archiveDir, mergeFailedJobs(queued, failed))
+		}
+		if err != nil {
+			e.warnf("failed to queue failed jobs: %v", err)
+		} else {
+			e.stagef("Queued %d failed channel-day(s) in %s", len(failed), failedJobsPath(archiveDir))
+		}
+	}
+	return skipped.err()
+}
+
+// RetryFailed renders the queued failed channel-days again from the
+// archive. Channel-days that render are removed from the queue; those
+// skipped again stay queued with their new error. When the run aborts, the
+// queue is left as it was.
+func (e *Exporter) RetryFailed(ctx context.Context) (err error) {
+	defer func() { err = classifyError(err) }()
+	archiveDir, err := e.ArchiveDir()
+	if err != nil {
+		return err
+	}
+	if !archiveExists(archiveDir) {
+		return fmt.Errorf("archive does not exist at %s; run slack-export sync first", archiveDir)
+	}
+	jobs, err := LoadFailedJobs(archiveDir)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		e.events().OnStage("No failed jobs to retry")
+		return nil
+	}
+	targets := make([]renderTarget, 0, len(jobs))
+	for _, job := range jobs {
+		targets = append(targets, renderTarget{channelID: job.ChannelID, date: job.Date})
+	}
+	if err := e.preflightOutputSpace(archiveDir, len(targets)); err != nil {
+		return err
+	}
+
+	opts := e.renderOptions(ctx)
+	from, to := renderTargetDateRange(targets)
+	writes, err := tracedRender(ctx, from, to, func(ctx context.Context) (int, error) {
+		return renderConfiguredTargets(ctx, e.cfg, archiveDir, opts, targets)
+	})
+	if err != nil {
+		return err
+	}
+	e.recordOutputSize(archiveDir, opts)
+	remaining := mergeFailedJobs(nil, opts.skipped.failedJobs())
+	if err := saveFailedJobs(archiveDir, remaining); err != nil {
+		return err
+	}
+	e.stagef("Retried %d failed channel-day(s), %d still failing (%d changed file(s))",
+		len(jobs), len(remaining), writes)
+	return opts.skipped.err()
+}
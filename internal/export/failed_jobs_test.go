@@ -0,0 +1,84 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestFailureKind(t *testing.T) {
+	for err, want := range map[error]string{
+		withKind(ErrRateLimited, errors.New("429")):        FailureRateLimited,
+		fmt.Errorf("render: %w", ErrAuthExpired):           FailureAuthExpired,
+		withKind(ErrSlackdumpFailed, errors.New("exit 1")): FailureSlackdumpFailed,
+		errors.New("database is locked"):                   FailureOther,
+	} {
+		if got := failureKind(err); got != want {
+			t.Errorf("failureKind(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
+
+func TestMergeFailedJobs_ReplacesSameChannelDay(t *testing.T) {
+	queued := []FailedJob{
+		{ChannelID: "C2", Channel: "general", Date: "2026-01-16", Error: "old"},
+		{ChannelID: "C1", Channel: "broken", Date: "2026-01-16", Error: "old"},
+	}
+	failed := []FailedJob{
+		{ChannelID: "C2", Channel: "general", Date: "2026-01-16", Error: "new"},
+		{ChannelID: "C1", Channel: "broken", Date: "2026-01-15", Error: "new"},
+	}
+	merged := mergeFailedJobs(queued, failed)
+	var got []string
+	for _, job := range merged {
+		got = append(got, job.Date+" "+job.Channel+" "+job.Error)
+	}
+	want := []string{"2026-01-15 broken new", "2026-01-16 broken old", "2026-01-16 general new"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("mergeFailedJobs() = %q, want %q", got, want)
+	}
+}
+
+func TestSkippedChannel_QueuesFailedJobs(t *testing.T) {
+	opts := RenderOptions{
+		Timezone:       "UTC",
+		OnChannelError: func(ChannelFailure) string { return ChannelSkip },
+		events:         ConsoleEvents{Out: io.Discard, Err: io.Discard},
+		skipped:        newSkippedChannels(),
+	}
+	_, err := renderSourceRange(context.Background(), channelErrorsFixture(2), t.TempDir(), "2026-01-15", "2026-01-16", opts, nil, nil)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+
+	archiveDir := t.TempDir()
+	rec := &recordingEvents{}
+	e := &Exporter{}
+	e.SetEvents(rec)
+	if err := e.finishSkipped(archiveDir, opts.skipped); !errors.Is(err, ErrChannelSkipped) {
+		t.Errorf("finishSkipped() = %v, want ErrChannelSkipped", err)
+	}
+	jobs, err := LoadFailedJobs(archiveDir)
+	if err != nil {
+		t.Fatalf("LoadFailedJobs() error = %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Date != "2026-01-15" || jobs[1].Date != "2026-01-16" {
+		t.Fatalf("jobs = %+v, want broken's two dates", jobs)
+	}
+	if jobs[0].ChannelID != "C1" || jobs[0].Channel != "broken" || jobs[0].Kind != FailureOther || jobs[0].Error != "loading channel messages C1: database is locked" {
+		t.Errorf("job = %+v", jobs[0])
+	}
+	if len(rec.stages) != 1 {
+		t.Errorf("stages = %q, want the queued notice", rec.stages)
+	}
+
+	if err := saveFailedJobs(archiveDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(failedJobsPath(archiveDir)); !os.IsNotExist(err) {
+		t.Errorf("empty queue left %s: %v", failedJobsFile, err)
+	}
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placements of the date in channel file names (filename_date).
+const (
+	// FilenameDatePrefix names files 2026-01-22-general.md (default).
+	FilenameDatePrefix = "prefix"
+	// FilenameDateSuffix names files general-2026-01-22.md.
+	FilenameDateSuffix = "suffix"
+	// FilenameDateNone names files general.md; the date folder holds the date.
+	FilenameDateNone = "none"
+)
+
+// reservedDayFiles are the names of the other markdown files written into
+// date folders. With filename_date "none", or in weekly and monthly folders
+// where day files carry the date prefix, a channel with one of these names
+// falls back to the suffix form so it does not overwrite them.
+var reservedDayFiles = map[string]bool{
+	"index":              true,
+	"status":             true,
+	"reminders":          true,
+	"membership-changes": true,
+	"channel-events":     true,
+}
+
+var renderedFileSuffixPattern = regexp.MustCompile(`-\d{4}-\d{2}-\d{2}$`)
+
+// IsRenderedChannelFile reports whether name, a file in the output folder
+// folder, is a rendered channel-day. The day's other markdown files are not:
+// those in reservedDayFiles, which weekly and monthly folders prefix with
+// the date, and translated copies in language, the translation target or ""
+// when translation is off.
+func IsRenderedChannelFile(folder, name, language string) bool {
+	base, ok := strings.CutSuffix(name, ".md")
+	if !ok || strings.HasPrefix(name, ".") {
+		return false
+	}
+	if language != "" && strings.HasSuffix(base, "-"+language) {
+		return false
+	}
+	if exportFolderPattern.MatchString(folder) && !exportDateDirPattern.MatchString(folder) {
+		base = renderedFilePrefixPattern.ReplaceAllString(base, "")
+	}
+	return !reservedDayFiles[strings.ToLower(base)]
+}
+
+// channelFileBase is a channel-day's file name without extension or part
+// suffix, with the date placed as configured for layout.
+func channelFileBase(date, name, placement, layout string) string {
+	daily := layout == "" || layout == LayoutDaily
+	if reservedDayFiles[strings.ToLower(name)] && (placement == FilenameDateNone || !daily) {
+		return name + "-" + date
+	}
+	switch placement {
+	case FilenameDateSuffix:
+		return name + "-" + date
+	case FilenameDateNone:
+		return name
+	default:
+		return date + "-" + name
+	}
+}
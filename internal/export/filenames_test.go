@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChannelFileBase(t *testing.T) {
+	tests := []struct {
+		placement, layout, name, want string
+	}{
+		{"", "", "general", "2026-01-22-general"},
+		{FilenameDatePrefix, "", "general", "2026-01-22-general"},
+		{FilenameDateSuffix, "", "general", "general-2026-01-22"},
+		{FilenameDateNone, "", "general", "general"},
+		{FilenameDateNone, "", "index", "index-2026-01-22"},
+		{FilenameDateNone, "", "Status", "Status-2026-01-22"},
+		{FilenameDatePrefix, LayoutDaily, "status", "2026-01-22-status"},
+		{FilenameDatePrefix, LayoutWeekly, "general", "2026-01-22-general"},
+		{FilenameDatePrefix, LayoutWeekly, "status", "status-2026-01-22"},
+		{"", LayoutMonthly, "Reminders", "Reminders-2026-01-22"},
+		{FilenameDateSuffix, LayoutWeekly, "index", "index-2026-01-22"},
+	}
+	for _, tt := range tests {
+		if got := channelFileBase("2026-01-22", tt.name, tt.placement, tt.layout); got != tt.want {
+			t.Errorf("channelFileBase(%q, %q, %q) = %q, want %q", tt.name, tt.placement, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestWriteChannelDate_FilenameDate(t *testing.T) {
+	for placement, want := range map[string]string{
+		FilenameDateSuffix: "general-2026-07-01.md",
+		FilenameDateNone:   "general.md",
+	} {
+		outputDir := t.TempDir()
+		units := []renderedUnit{{text: "> one\n\n", messages: 1}}
+		if _, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", units, RenderOptions{FilenameDate: placement}); err != nil {
+			t.Fatalf("writeChannelDate(%s) error = %v", placement, err)
+		}
+		if _, err := os.Stat(filepath.Join(outputDir, "2026-07-01", want)); err != nil {
+			t.Errorf("filename_date %s: %s not written: %v", placement, want, err)
+		}
+	}
+}
+
+func TestWriteChannelDate_WeeklyLayoutKeepsDayFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	opts := RenderOptions{Layout: LayoutWeekly}
+	units := []renderedUnit{{text: "> one\n\n", messages: 1}}
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-01-22", "status", units, opts); err != nil {
+		t.Fatalf("writeChannelDate() error = %v", err)
+	}
+	week := filepath.Join(outputDir, "2026-W04")
+	dayFile := filepath.Join(week, dayFileName("2026-01-22", LayoutWeekly, "status.md"))
+	if err := os.WriteFile(dayFile, []byte("day status\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(week, "status-2026-01-22.md"))
+	if err != nil || string(got) != "> one\n\n" {
+		t.Fatalf("channel file = %q, %v; want the rendered channel beside %s", got, err, dayFile)
+	}
+	if !IsRenderedChannelFile("2026-W04", "status-2026-01-22.md", "") {
+		t.Error("IsRenderedChannelFile() = false for the status channel's file")
+	}
+	if IsRenderedChannelFile("2026-W04", filepath.Base(dayFile), "") {
+		t.Error("IsRenderedChannelFile() = true for the day's status file")
+	}
+}
+
+func TestRenderedChannelName_AnyDatePlacement(t *testing.T) {
+	for _, filename := range []string{
+		"2026-01-22-general.md", "general-2026-01-22.md", "general.md",
+		"2026-01-22-general-part2.md", "general-2026-01-22-part2.md",
+	} {
+		if got := renderedChannelName(filename); got != "general" {
+			t.Errorf("renderedChannelName(%q) = %q, want general", filename, got)
+		}
+	}
+}
+
+func TestIsRenderedChannelFile(t *testing.T) {
+	tests := []struct {
+		folder, name string
+		want         bool
+	}{
+		{"2026-07-01", "2026-07-01-general.md", true},
+		{"2026-07-01", "general.md", true},
+		{"2026-07-01", "2026-07-01-general-part2.md", true},
+		{"2026-07-01", "2026-07-01-status.md", true},
+		{"2026-07-01", "index.md", false},
+		{"2026-07-01", "status.md", false},
+		{"2026-07-01", "membership-changes.md", false},
+		{"2026-07-01", "2026-07-01-general-fr.md", false},
+		{"2026-07-01", "2026-07-01-general.mbox", false},
+		{"2026-07-01", ".2026-07-01-general.md.tmp", false},
+		{"2026-W27", "2026-07-01-general.md", true},
+		{"2026-W27", "2026-07-01-index.md", false},
+		{"2026-07", "2026-07-01-reminders.md", false},
+		{"2026-07", "2026-07-01-channel-events.md", false},
+	}
+	for _, tt := range tests {
+		if got := IsRenderedChannelFile(tt.folder, tt.name, "fr"); got != tt.want {
+			t.Errorf("IsRenderedChannelFile(%q, %q) = %v, want %v", tt.folder, tt.name, got, tt.want)
+		}
+	}
+	if !IsRenderedChannelFile("2026-07-01", "2026-07-01-general-fr.md", "") {
+		t.Error("without translation, general-fr should be a channel")
+	}
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Output layouts (layout): how date folders group the days.
+const (
+	// LayoutDaily writes each date into its own folder, 2026-01-22/
+	// (default).
+	LayoutDaily = "daily"
+	// LayoutWeekly writes a date into its ISO week's folder, 2026-W04/.
+	LayoutWeekly = "weekly"
+	// LayoutMonthly writes a date into its month's folder, 2026-01/.
+	LayoutMonthly = "monthly"
+)
+
+// exportFolderPattern matches a date folder of any layout.
+var exportFolderPattern = regexp.MustCompile(`^\d{4}-(\d{2}-\d{2}|W\d{2}|\d{2})$`)
+
+// fileDatePattern finds the date in a file name inside a shared folder.
+var fileDatePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// dateFolder is the folder name holding date's files under layout.
+func dateFolder(date, layout string) string {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	switch layout {
+	case LayoutWeekly:
+		year, week := day.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case LayoutMonthly:
+		return day.Format("2006-01")
+	default:
+		return date
+	}
+}
+
+// dateDir is the folder under outputDir holding date's files.
+func dateDir(outputDir, date, layout string) string {
+	return filepath.Join(outputDir, dateFolder(date, layout))
+}
+
+// dayFileName names a per-day file such as index.md. Weekly and monthly
+// folders hold several days, so the name is prefixed with the date there:
+// 2026-01-22-index.md, or .2026-01-22-index.json for hidden files.
+func dayFileName(date, layout, name string) string {
+	if layout == "" || layout == LayoutDaily {
+		return name
+	}
+	if hidden, ok := strings.CutPrefix(name, "."); ok {
+		return "." + date + "-" + hidden
+	}
+	return date + "-" + name
+}
+
+// folderDates returns the dates a folder's entries hold: the folder's own
+// name for a daily folder, or the dates in its file names for a weekly or
+// monthly one.
+func folderDates(folder string, names []string) []string {
+	if exportDateDirPattern.MatchString(folder) {
+		return []string{folder}
+	}
+	seen := make(map[string]bool)
+	var dates []string
+	for _, name := range names {
+		date := fileDatePattern.FindString(name)
+		if date == "" || seen[date] {
+			continue
+		}
+		seen[date] = true
+		dates = append(dates, date)
+	}
+	return dates
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestDateFolder(t *testing.T) {
+	tests := []struct {
+		date, layout, want string
+	}{
+		{"2026-01-22", "", "2026-01-22"},
+		{"2026-01-22", LayoutDaily, "2026-01-22"},
+		{"2026-01-22", LayoutWeekly, "2026-W04"},
+		{"2027-01-01", LayoutWeekly, "2026-W53"},
+		{"2026-01-22", LayoutMonthly, "2026-01"},
+	}
+	for _, tt := range tests {
+		if got := dateFolder(tt.date, tt.layout); got != tt.want {
+			t.Errorf("dateFolder(%s, %q) = %q, want %q", tt.date, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestDayFileName(t *testing.T) {
+	if got := dayFileName("2026-01-22", LayoutDaily, "index.md"); got != "index.md" {
+		t.Errorf("daily index = %q", got)
+	}
+	if got := dayFileName("2026-01-22", LayoutWeekly, "index.md"); got != "2026-01-22-index.md" {
+		t.Errorf("weekly index = %q", got)
+	}
+	if got := dayFileName("2026-01-22", LayoutMonthly, ".index.json"); got != ".2026-01-22-index.json" {
+		t.Errorf("monthly hidden index = %q", got)
+	}
+}
+
+func TestRenderSourceRange_WeeklyLayout(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users: []rslack.User{{ID: "U1", Name: "alice"}},
+		messages: map[string][]rslack.Message{"C123": {
+			{Msg: rslack.Msg{User: "U1", Text: "thursday", Timestamp: "1768485600.000100"}},
+			{Msg: rslack.Msg{User: "U1", Text: "friday", Timestamp: "1768572000.000100"}},
+		}},
+	}
+	outputDir := t.TempDir()
+	opts := RenderOptions{
+		Timezone:  "UTC",
+		Layout:    LayoutWeekly,
+		Index:     NewDateIndex(IndexOrderAlpha, nil),
+		checksums: newDateChecksums(),
+		timezones: newRenderedTimezones("UTC"),
+	}
+	if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-16", opts, nil, nil); err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+
+	week := filepath.Join(outputDir, "2026-W03")
+	for _, name := range []string{
+		"2026-01-15-engineering.md", "2026-01-16-engineering.md",
+		"2026-01-15-index.md", "2026-01-16-manifest.json", checksumFile,
+	} {
+		if _, err := os.Stat(filepath.Join(week, name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-01-15")); !os.IsNotExist(err) {
+		t.Errorf("daily folder should not exist, stat err = %v", err)
+	}
+	report, err := VerifyChecksums(outputDir)
+	if err != nil {
+		t.Fatalf("VerifyChecksums() error = %v", err)
+	}
+	if report.Folders != 1 || !report.OK() || len(report.Unlisted) > 0 {
+		t.Errorf("VerifyChecksums() = %+v, want one clean folder", report)
+	}
+}
+
+func TestFindEarliestExportDate_AllLayouts(t *testing.T) {
+	outputDir := t.TempDir()
+	for _, path := range []string{
+		"2026-01-20/2026-01-20-general.md",
+		"2026-W03/SHA256SUMS",
+		"2026-W03/2026-01-16-general.md",
+		"2026-W03/2026-01-15-index.md",
+		"2026-02/2026-02-01-general.md",
+		"notes/2025-12-01-draft.md",
+	} {
+		writeLayoutTestFile(t, outputDir, path)
+	}
+
+	got, err := findEarliestExportDate(outputDir)
+	if err != nil {
+		t.Fatalf("findEarliestExportDate() error = %v", err)
+	}
+	if got != "2026-01-15" {
+		t.Errorf("findEarliestExportDate() = %q, want 2026-01-15", got)
+	}
+}
+
+func TestPackPaths_FiltersSharedFolderByDate(t *testing.T) {
+	outputDir := t.TempDir()
+	for _, path := range []string{
+		"2026-W03/2026-01-15-general.md",
+		"2026-W03/2026-01-16-general.md",
+		"2026-W03/SHA256SUMS",
+		"2026-W02/2026-01-09-general.md",
+		"2026-W02/SHA256SUMS",
+	} {
+		writeLayoutTestFile(t, outputDir, path)
+	}
+
+	got, err := packPaths(outputDir, "2026-01-16", "2026-01-31", nil, "")
+	if err != nil {
+		t.Fatalf("packPaths() error = %v", err)
+	}
+	want := []string{"2026-W03/2026-01-16-general.md", "2026-W03/SHA256SUMS"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("packPaths() = %v, want %v", got, want)
+	}
+}
+
+func writeLayoutTestFile(t *testing.T, outputDir, path string) {
+	t.Helper()
+	full := filepath.Join(outputDir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte("x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
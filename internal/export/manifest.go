@@ -0,0 +1,123 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const dateManifestFile = "manifest.json"
+
+// DateManifest is the manifest.json kept in each date folder. It records
+// facts about the export that the markdown itself cannot show; sections a
+// run did not produce are left as they were.
+type DateManifest struct {
+	Date string `json:"date"`
+	// Timezone is the default timezone of the latest render into the
+	// folder, and ChannelTimezones the zone each channel's file was last
+	// rendered in, so folders rendered under different zones stay readable.
+	Timezone         string            `json:"timezone,omitempty"`
+	ChannelTimezones map[string]string `json:"channel_timezones,omitempty"`
+	// FilteredMessages counts, per channel, the messages message_include
+	// and message_exclude left out of the latest render.
+	FilteredMessages map[string]int `json:"filtered_messages,omitempty"`
+	// Verification holds the latest message count checks against Slack.
+	Verification []CountCheck `json:"verification,omitempty"`
+	// ReadState is your read position in each channel as of the latest
+	// sync that day (read_state).
+	ReadState *ReadState `json:"read_state,omitempty"`
+}
+
+// loadDateManifest reads the manifest at path, returning an empty one when
+// the folder has none yet.
+func loadDateManifest(path, date string) (DateManifest, error) {
+	manifest := DateManifest{Date: date}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// dateManifestPath is date's manifest under outputDir: manifest.json in a
+// daily folder, <date>-manifest.json in a shared one.
+func dateManifestPath(outputDir, date, layout string) string {
+	return filepath.Join(dateDir(outputDir, date, layout), dayFileName(date, layout, dateManifestFile))
+}
+
+// updateDateManifest applies update to date's manifest under outputDir and
+// writes it back atomically when it changed.
+func updateDateManifest(outputDir, date, layout string, update func(*DateManifest)) error {
+	path := dateManifestPath(outputDir, date, layout)
+	manifest, err := loadDateManifest(path, date)
+	if err != nil {
+		return err
+	}
+	update(&manifest)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writeFileIfChanged(path, append(data, '\n'), false)
+	return err
+}
+
+// renderedTimezones collects the timezone of every channel-day written
+// during a render for the date manifests. A nil *renderedTimezones records
+// nothing.
+type renderedTimezones struct {
+	timezone string
+	// pending maps output dir, then date, then channel to its timezone.
+	pending map[string]map[string]map[string]string
+}
+
+func newRenderedTimezones(timezone string) *renderedTimezones {
+	return &renderedTimezones{timezone: timezone, pending: make(map[string]map[string]map[string]string)}
+}
+
+func (r *renderedTimezones) record(outputDir, date, channel, timezone string) {
+	if r == nil {
+		return
+	}
+	dates, ok := r.pending[outputDir]
+	if !ok {
+		dates = make(map[string]map[string]string)
+		r.pending[outputDir] = dates
+	}
+	if dates[date] == nil {
+		dates[date] = make(map[string]string)
+	}
+	dates[date][channel] = timezone
+}
+
+// flush merges the timezones recorded under outputDir into each date's
+// manifest.
+func (r *renderedTimezones) flush(outputDir, layout string) error {
+	if r == nil {
+		return nil
+	}
+	for date, channels := range r.pending[outputDir] {
+		err := updateDateManifest(outputDir, date, layout, func(m *DateManifest) {
+			m.Timezone = r.timezone
+			if m.ChannelTimezones == nil {
+				m.ChannelTimezones = make(map[string]string, len(channels))
+			}
+			for channel, timezone := range channels {
+				m.ChannelTimezones[channel] = timezone
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("recording timezones for %s: %w", date, err)
+		}
+	}
+	delete(r.pending, outputDir)
+	return nil
+}
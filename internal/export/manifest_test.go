@@ -0,0 +1,59 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRenderedTimezones_MergesIntoManifest(t *testing.T) {
+	outputDir := t.TempDir()
+
+	first := newRenderedTimezones("America/New_York")
+	first.record(outputDir, "2026-01-15", "general", "America/New_York")
+	first.record(outputDir, "2026-01-15", "tokyo-team", "Asia/Tokyo")
+	if err := first.flush(outputDir, ""); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	second := newRenderedTimezones("UTC")
+	second.record(outputDir, "2026-01-15", "general", "UTC")
+	if err := second.flush(outputDir, ""); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	manifest, err := loadDateManifest(dateManifestPath(outputDir, "2026-01-15", ""), "2026-01-15")
+	if err != nil {
+		t.Fatalf("loadDateManifest() error = %v", err)
+	}
+	if manifest.Timezone != "UTC" {
+		t.Errorf("Timezone = %q, want the latest render's", manifest.Timezone)
+	}
+	if manifest.ChannelTimezones["general"] != "UTC" || manifest.ChannelTimezones["tokyo-team"] != "Asia/Tokyo" {
+		t.Errorf("ChannelTimezones = %v", manifest.ChannelTimezones)
+	}
+}
+
+func TestUpdateDateManifest_SkipsUnchangedWrite(t *testing.T) {
+	outputDir := t.TempDir()
+	setTZ := func(m *DateManifest) { m.Timezone = "UTC" }
+	if err := updateDateManifest(outputDir, "2026-01-15", "", setTZ); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(outputDir, "2026-01-15", dateManifestFile)
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateDateManifest(outputDir, "2026-01-15", "", setTZ); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("unchanged manifest was rewritten")
+	}
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+// mboxDomain is the mail domain of generated addresses and Message-IDs.
+// .invalid keeps them from ever resolving to a real mailbox.
+const mboxDomain = "slack-export.invalid"
+
+// mboxFromLine matches body lines that mboxrd readers would take for a
+// message separator; they are escaped with one more '>'.
+var mboxFromLine = regexp.MustCompile(`(?m)^(>*From )`)
+
+// writeChannelDateMbox writes the channel-day's messages as an mboxrd file
+// beside its markdown: one mail per message, from its author, dated by its
+// timestamp, with the channel as subject. Replies are threaded under their
+// parent with In-Reply-To.
+func writeChannelDateMbox(
+	outputDir string,
+	req RenderRequest,
+	ch channelDates,
+	threads threadMessageCache,
+	lookup renderLookup,
+	opts RenderOptions,
+) (bool, error) {
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return false, err
+	}
+	var out bytes.Buffer
+	for _, msg := range dayMessages(ch.messages, threads, req.Date, req.Timezone) {
+		writeMboxMessage(&out, msg, ch, loc, lookup)
+	}
+	path := filepath.Join(dateDir(outputDir, req.Date, opts.Layout), channelFileBase(req.Date, ch.name, opts.FilenameDate, opts.Layout)+".mbox")
+	return writeFileIfChanged(path, out.Bytes(), opts.VerifyWrites)
+}
+
+// dayMessages returns the messages posted on date, top-level and replies,
+// in timestamp order. Thread broadcasts are kept once, as top-level.
+func dayMessages(messages []rslack.Message, threads threadMessageCache, date, timezone string) []rslack.Message {
+	seen := make(map[string]bool)
+	var day []rslack.Message
+	add := func(msg rslack.Message) {
+		if !seen[msg.Timestamp] && messageBelongsToDate(msg, date, timezone) {
+			seen[msg.Timestamp] = true
+			day = append(day, msg)
+		}
+	}
+	for _, msg := range messages {
+		add(msg)
+	}
+	for _, thread := range threads {
+		for _, reply := range thread {
+			if reply.SubType != rslack.MsgSubTypeThreadBroadcast {
+				add(reply)
+			}
+		}
+	}
+	sortMessages(day)
+	return day
+}
+
+func writeMboxMessage(out *bytes.Buffer, msg rslack.Message, ch channelDates, loc *time.Location, lookup renderLookup) {
+	ts, err := parseSlackTimestamp(msg.Timestamp)
+	if err != nil {
+		return
+	}
+	from := mail.Address{Name: lookup.sender(msg), Address: mboxLocalPart(lookup.userRef(msg.User)) + "@" + mboxDomain}
+	text := messageText(msg, lookup)
+	if lookup.pseudonyms != nil {
+		text = scrubContactInfo(text)
+	}
+
+	fmt.Fprintf(out, "From %s %s\n", from.Address, ts.UTC().Format(time.ANSIC))
+	fmt.Fprintf(out, "From: %s\n", from.String())
+	fmt.Fprintf(out, "Date: %s\n", ts.In(loc).Format(time.RFC1123Z))
+	fmt.Fprintf(out, "Subject: %s\n", mime.QEncoding.Encode("utf-8", mboxSubject(ch.name)))
+	fmt.Fprintf(out, "Message-ID: %s\n", mboxMessageID(ch.id, msg.Timestamp))
+	if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
+		parent := mboxMessageID(ch.id, msg.ThreadTimestamp)
+		fmt.Fprintf(out, "In-Reply-To: %s\nReferences: %s\n", parent, parent)
+	}
+	out.WriteString("MIME-Version: 1.0\n")
+	out.WriteString("Content-Type: text/plain; charset=utf-8\n")
+	out.WriteString("Content-Transfer-Encoding: 8bit\n\n")
+	body := mboxFromLine.ReplaceAllString(strings.ReplaceAll(text, "\r\n", "\n"), ">$1")
+	out.WriteString(strings.TrimRight(body, "\n"))
+	out.WriteString("\n\n")
+}
+
+// mboxLocalPart reduces a user ID or pseudonym to characters that are safe
+// unquoted in an address.
+func mboxLocalPart(ref string) string {
+	local := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return -1
+	}, ref)
+	if local = strings.Trim(local, "."); local == "" {
+		return "unknown"
+	}
+	return local
+}
+
+func mboxMessageID(channelID, ts string) string {
+	return fmt.Sprintf("<%s.%s@%s>", ts, channelID, mboxDomain)
+}
+
+// mboxSubject is "#channel" for channels; DM files already say who.
+func mboxSubject(name string) string {
+	if strings.HasPrefix(name, "dm_") || strings.HasPrefix(name, "mpdm_") {
+		return name
+	}
+	return "#" + name
+}
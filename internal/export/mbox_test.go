@@ -0,0 +1,89 @@
+package export
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestRenderSourceRange_WritesMbox(t *testing.T) {
+	// 2026-01-15 14:00 UTC, a reply at 14:05 and a message on the next day.
+	parent := rslack.Message{Msg: rslack.Msg{
+		User: "U1", Text: "Release today?\nFrom here it looks ready", Timestamp: "1768485600.000100",
+		ThreadTimestamp: "1768485600.000100", ReplyCount: 1,
+	}}
+	reply := rslack.Message{Msg: rslack.Msg{
+		User: "U2", Text: "Yes, at 3pm", Timestamp: "1768485900.000200", ThreadTimestamp: "1768485600.000100",
+	}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users: []rslack.User{
+			{ID: "U1", Name: "alice", RealName: "Alice"},
+			{ID: "U2", Name: "bob", RealName: "Bøb"},
+		},
+		messages: map[string][]rslack.Message{"C123": {
+			parent,
+			{Msg: rslack.Msg{User: "U2", Text: "next day", Timestamp: "1768572000.000300"}},
+		}},
+		threads: map[string][]rslack.Message{"C123:1768485600.000100": {parent, reply}},
+	}
+	outputDir := t.TempDir()
+
+	_, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15",
+		RenderOptions{Timezone: "UTC", Mbox: true}, nil, nil)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-01-15", "2026-01-15-engineering.mbox"))
+	if err != nil {
+		t.Fatalf("reading mbox: %v", err)
+	}
+	mbox := string(data)
+
+	if got := strings.Count(mbox, "\nFrom U") + boolCount(strings.HasPrefix(mbox, "From U")); got != 2 {
+		t.Fatalf("mbox has %d messages, want 2:\n%s", got, mbox)
+	}
+	for _, want := range []string{
+		"From U1@slack-export.invalid Thu Jan 15 14:00:00 2026\n",
+		`From: "Alice" <U1@slack-export.invalid>`,
+		"Date: Thu, 15 Jan 2026 14:00:00 +0000",
+		"Subject: #engineering",
+		"Message-ID: <1768485600.000100.C123@slack-export.invalid>",
+		"In-Reply-To: <1768485600.000100.C123@slack-export.invalid>",
+		">From here it looks ready",
+	} {
+		if !strings.Contains(mbox, want) {
+			t.Errorf("mbox missing %q:\n%s", want, mbox)
+		}
+	}
+	if strings.Contains(mbox, "next day") {
+		t.Errorf("mbox includes another day's message:\n%s", mbox)
+	}
+
+	_, replyMail, _ := strings.Cut(mbox[strings.Index(mbox, "\nFrom U2")+1:], "\n")
+	msg, err := mail.ReadMessage(strings.NewReader(replyMail))
+	if err != nil {
+		t.Fatalf("parsing reply: %v", err)
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil || from.Name != "Bøb" {
+		t.Errorf("reply From = %q (%v), want Bøb", msg.Header.Get("From"), err)
+	}
+}
+
+func TestMboxLocalPart(t *testing.T) {
+	tests := map[string]string{"U123": "U123", "User-A": "User-A", "": "unknown", "a b@c": "abc"}
+	for ref, want := range tests {
+		if got := mboxLocalPart(ref); got != want {
+			t.Errorf("mboxLocalPart(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
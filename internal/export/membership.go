@@ -0,0 +1,179 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// membershipEntry is one channel in a membership snapshot.
+type membershipEntry struct {
+	Name     string `json:"name"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+// membershipChange is one line of membership-changes.md.
+type membershipChange struct {
+	name string
+	text string
+}
+
+// recordMembershipChanges snapshots the channels you belong to when
+// track_membership is enabled and appends joins, departures, renames, and
+// archivals since the previous sync to each output directory's
+// <date>/membership-changes.md. Like status_audit, a change is timestamped
+// when a sync first sees it. Failures only warn.
+func (e *Exporter) recordMembershipChanges(ctx context.Context, archiveDir string, now time.Time) {
+	if !e.cfg.TrackMembership {
+		return
+	}
+	boot, err := e.edgeClient.ClientUserBoot(ctx)
+	if err != nil {
+		e.warnf("failed to collect channel memberships: %v", err)
+		return
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		e.warnf("failed to record membership changes: %v", err)
+		return
+	}
+	if err := recordMembership(archiveDir, e.cfg.OutputDirs(), e.cfg.Layout, membershipSnapshot(boot), now.In(loc)); err != nil {
+		e.warnf("failed to record membership changes: %v", err)
+	}
+}
+
+// membershipSnapshot indexes the channels in a userBoot response by ID.
+// Direct messages are left out since they cannot be joined or left.
+func membershipSnapshot(boot *slack.UserBootResponse) map[string]membershipEntry {
+	snapshot := make(map[string]membershipEntry, len(boot.Channels))
+	for _, ch := range boot.Channels {
+		if ch.IsIM {
+			continue
+		}
+		snapshot[ch.ID] = membershipEntry{Name: ch.Name, Archived: ch.IsArchived}
+	}
+	return snapshot
+}
+
+// recordMembership diffs current against the archive's last snapshot. The
+// first run only stores the snapshot.
+func recordMembership(
+	archiveDir string,
+	outputDirs []string,
+	layout string,
+	current map[string]membershipEntry,
+	now time.Time,
+) error {
+	previous, err := loadMembershipSnapshot(archiveDir)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if changes := membershipChanges(previous, current); len(changes) > 0 {
+			for _, dir := range outputDirs {
+				if err := appendMembershipLog(dir, layout, changes, now); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return saveMembershipSnapshot(archiveDir, current)
+}
+
+// membershipChanges lists what changed between snapshots, ordered by
+// channel name.
+func membershipChanges(previous, current map[string]membershipEntry) []membershipChange {
+	var changes []membershipChange
+	for id, cur := range current {
+		prev, ok := previous[id]
+		switch {
+		case !ok:
+			changes = append(changes, membershipChange{cur.Name, "joined #" + cur.Name})
+			continue
+		case prev.Name != cur.Name:
+			changes = append(changes, membershipChange{cur.Name, fmt.Sprintf("#%s renamed to #%s", prev.Name, cur.Name)})
+		}
+		if cur.Archived != prev.Archived {
+			verb := "archived"
+			if !cur.Archived {
+				verb = "unarchived"
+			}
+			changes = append(changes, membershipChange{cur.Name, fmt.Sprintf("#%s %s", cur.Name, verb)})
+		}
+	}
+	for id, prev := range previous {
+		if _, ok := current[id]; !ok {
+			changes = append(changes, membershipChange{prev.Name, "left #" + prev.Name})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].name != changes[j].name {
+			return changes[i].name < changes[j].name
+		}
+		return changes[i].text < changes[j].text
+	})
+	return changes
+}
+
+// appendMembershipLog appends changes to <dir>/<work date>/membership-changes.md.
+func appendMembershipLog(dir, layout string, changes []membershipChange, now time.Time) error {
+	date := workDate(now)
+	path := filepath.Join(dateDir(dir, date, layout), dayFileName(date, layout, "membership-changes.md"))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	var b strings.Builder
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(&b, "# Membership changes %s\n\n", date)
+	}
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- %s %s\n", now.Format("15:04"), change.text)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func membershipSnapshotPath(archiveDir string) string {
+	return filepath.Join(archiveDir, ".slack-export-membership.json")
+}
+
+func loadMembershipSnapshot(archiveDir string) (map[string]membershipEntry, error) {
+	data, err := os.ReadFile(membershipSnapshotPath(archiveDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading membership snapshot: %w", err)
+	}
+	var snapshot map[string]membershipEntry
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("parsing membership snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func saveMembershipSnapshot(archiveDir string, snapshot map[string]membershipEntry) error {
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(membershipSnapshotPath(archiveDir), data, 0600)
+}
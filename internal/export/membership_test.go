@@ -0,0 +1,75 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestMembershipSnapshot_SkipsDMs(t *testing.T) {
+	snapshot := membershipSnapshot(&slack.UserBootResponse{Channels: []slack.UserBootChannel{
+		{ID: "C1", Name: "general"},
+		{ID: "C2", Name: "old", IsArchived: true},
+		{ID: "D1", Name: "alice", IsIM: true},
+	}})
+	if len(snapshot) != 2 || !snapshot["C2"].Archived || snapshot["C1"].Name != "general" {
+		t.Errorf("membershipSnapshot() = %+v", snapshot)
+	}
+}
+
+func TestRecordMembership_AppendsChanges(t *testing.T) {
+	archiveDir, outputDir := t.TempDir(), t.TempDir()
+	morning := time.Date(2026, 7, 3, 9, 30, 0, 0, time.UTC)
+	first := map[string]membershipEntry{
+		"C1": {Name: "general"},
+		"C2": {Name: "team-old"},
+		"C3": {Name: "launch"},
+		"C4": {Name: "infra"},
+	}
+	second := map[string]membershipEntry{
+		"C1": {Name: "general"},
+		"C3": {Name: "launch-2026", Archived: true},
+		"C4": {Name: "infra"},
+		"C5": {Name: "eng-new"},
+	}
+
+	for i, snapshot := range []map[string]membershipEntry{first, second} {
+		at := morning.Add(time.Duration(i) * 2 * time.Hour)
+		if err := recordMembership(archiveDir, []string{outputDir}, "", snapshot, at); err != nil {
+			t.Fatalf("recordMembership() run %d error = %v", i, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-07-03", "membership-changes.md"))
+	if err != nil {
+		t.Fatalf("reading membership-changes.md: %v", err)
+	}
+	want := `# Membership changes 2026-07-03
+
+- 11:30 joined #eng-new
+- 11:30 #launch renamed to #launch-2026
+- 11:30 #launch-2026 archived
+- 11:30 left #team-old
+`
+	if string(data) != want {
+		t.Errorf("membership-changes.md =\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestRecordMembership_FirstRunOnlySnapshots(t *testing.T) {
+	archiveDir, outputDir := t.TempDir(), t.TempDir()
+	now := time.Date(2026, 7, 3, 9, 30, 0, 0, time.UTC)
+
+	if err := recordMembership(archiveDir, []string{outputDir}, "", map[string]membershipEntry{"C1": {Name: "general"}}, now); err != nil {
+		t.Fatalf("recordMembership() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-07-03")); !os.IsNotExist(err) {
+		t.Errorf("first run should not write a log, stat err = %v", err)
+	}
+	if _, err := os.Stat(membershipSnapshotPath(archiveDir)); err != nil {
+		t.Errorf("snapshot not saved: %v", err)
+	}
+}
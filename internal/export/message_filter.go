@@ -0,0 +1,199 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"iter"
+	"regexp"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	rslack "github.com/rusq/slack"
+)
+
+// MessageFilter keeps or drops individual messages by their text
+// (message_include / message_exclude) and counts what it dropped for the
+// date manifests. A nil *MessageFilter keeps every message.
+type MessageFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+
+	// dropped maps channel ID, then timestamp, to each message dropped so
+	// far; a message seen both in history and in its thread counts once.
+	dropped map[string]map[string]droppedMessage
+	// pending maps output dir, then date, then channel to its dropped count.
+	pending map[string]map[string]map[string]int
+}
+
+type droppedMessage struct {
+	msg      rslack.Message
+	topLevel bool
+}
+
+// NewMessageFilter compiles the include and exclude patterns. It returns
+// nil when both are empty.
+func NewMessageFilter(include, exclude []string) (*MessageFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+	f := &MessageFilter{
+		dropped: make(map[string]map[string]droppedMessage),
+		pending: make(map[string]map[string]map[string]int),
+	}
+	var err error
+	if f.include, err = config.CompilePatterns("message_include", include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = config.CompilePatterns("message_exclude", exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// keep reports whether msg survives the filters: it matches no exclude
+// pattern and, when include patterns are set, at least one of those.
+func (f *MessageFilter) keep(msg rslack.Message) bool {
+	if f == nil {
+		return true
+	}
+	text := html.UnescapeString(msg.Text)
+	for _, re := range f.exclude {
+		if re.MatchString(text) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// source wraps src so its messages pass through the filter. Dropping a
+// thread parent hides its replies too, since replies are only rendered
+// below their parent.
+func (f *MessageFilter) source(src ArchiveMessageSource) ArchiveMessageSource {
+	if f == nil {
+		return src
+	}
+	return filteredSource{ArchiveMessageSource: src, filter: f}
+}
+
+type filteredSource struct {
+	ArchiveMessageSource
+	filter *MessageFilter
+}
+
+func (s filteredSource) AllMessages(ctx context.Context, channelID string) (iter.Seq2[rslack.Message, error], error) {
+	seq, err := s.ArchiveMessageSource.AllMessages(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return s.filter.filter(channelID, seq, true), nil
+}
+
+func (s filteredSource) AllThreadMessages(
+	ctx context.Context,
+	channelID string,
+	threadTS string,
+) (iter.Seq2[rslack.Message, error], error) {
+	seq, err := s.ArchiveMessageSource.AllThreadMessages(ctx, channelID, threadTS)
+	if err != nil {
+		return nil, err
+	}
+	return s.filter.filter(channelID, seq, false), nil
+}
+
+func (f *MessageFilter) filter(
+	channelID string,
+	seq iter.Seq2[rslack.Message, error],
+	topLevel bool,
+) iter.Seq2[rslack.Message, error] {
+	return func(yield func(rslack.Message, error) bool) {
+		for msg, err := range seq {
+			if err == nil && !f.keep(msg) {
+				f.drop(channelID, msg, topLevel)
+				continue
+			}
+			if !yield(msg, err) {
+				return
+			}
+		}
+	}
+}
+
+func (f *MessageFilter) drop(channelID string, msg rslack.Message, topLevel bool) {
+	messages, ok := f.dropped[channelID]
+	if !ok {
+		messages = make(map[string]droppedMessage)
+		f.dropped[channelID] = messages
+	}
+	seen := messages[msg.Timestamp]
+	messages[msg.Timestamp] = droppedMessage{msg: msg, topLevel: topLevel || seen.topLevel}
+}
+
+// droppedOn counts the channel's dropped messages posted on date: all of
+// them, and the top-level ones Slack's history counts include.
+func (f *MessageFilter) droppedOn(channelID, date, timezone string) (total, topLevel int) {
+	if f == nil {
+		return 0, 0
+	}
+	for _, dropped := range f.dropped[channelID] {
+		if !messageBelongsToDate(dropped.msg, date, timezone) {
+			continue
+		}
+		total++
+		if dropped.topLevel {
+			topLevel++
+		}
+	}
+	return total, topLevel
+}
+
+// record notes the channel-day's dropped count for outputDir's manifests.
+// Call it after the day is rendered, once its threads have been read.
+func (f *MessageFilter) record(outputDir, date, channelID, channel, timezone string) {
+	if f == nil {
+		return
+	}
+	total, _ := f.droppedOn(channelID, date, timezone)
+	if total == 0 {
+		return
+	}
+	dates, ok := f.pending[outputDir]
+	if !ok {
+		dates = make(map[string]map[string]int)
+		f.pending[outputDir] = dates
+	}
+	if dates[date] == nil {
+		dates[date] = make(map[string]int)
+	}
+	dates[date][channel] = total
+}
+
+// flush merges the counts recorded under outputDir into each date's
+// manifest.
+func (f *MessageFilter) flush(outputDir, layout string) error {
+	if f == nil {
+		return nil
+	}
+	for date, channels := range f.pending[outputDir] {
+		err := updateDateManifest(outputDir, date, layout, func(m *DateManifest) {
+			if m.FilteredMessages == nil {
+				m.FilteredMessages = make(map[string]int, len(channels))
+			}
+			for channel, count := range channels {
+				m.FilteredMessages[channel] = count
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("recording filtered messages for %s: %w", date, err)
+		}
+	}
+	delete(f.pending, outputDir)
+	return nil
+}
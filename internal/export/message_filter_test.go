@@ -0,0 +1,103 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestMessageFilter_Keep(t *testing.T) {
+	filter, err := NewMessageFilter([]string{"deploy"}, []string{`^!standup`})
+	if err != nil {
+		t.Fatalf("NewMessageFilter() error = %v", err)
+	}
+	for text, want := range map[string]bool{
+		"deploy finished":     true,
+		"!standup deploy":     false,
+		"lunch?":              false,
+		"deploy &lt;prod&gt;": true,
+	} {
+		if got := filter.keep(rslack.Message{Msg: rslack.Msg{Text: text}}); got != want {
+			t.Errorf("keep(%q) = %v, want %v", text, got, want)
+		}
+	}
+
+	if filter, err := NewMessageFilter(nil, nil); filter != nil || err != nil {
+		t.Errorf("NewMessageFilter(nil, nil) = %v, %v, want nil filter", filter, err)
+	}
+	if _, err := NewMessageFilter(nil, []string{"("}); err == nil {
+		t.Error("NewMessageFilter() accepted an invalid pattern")
+	}
+}
+
+func TestRenderSourceRange_MessageFilter(t *testing.T) {
+	message := func(ts, text string) rslack.Message {
+		return rslack.Message{Msg: rslack.Msg{Type: "message", User: "U1", Text: text, Timestamp: ts}}
+	}
+	standup := message("1768485600.000100", "!standup yesterday: reviews")
+	standup.ThreadTimestamp = standup.Timestamp
+	standup.ReplyCount = 1
+	standupReply := message("1768485700.000100", "thanks")
+	standupReply.ThreadTimestamp = standup.Timestamp
+	release := message("1768486600.000100", "Release is out")
+	release.ThreadTimestamp = release.Timestamp
+	release.ReplyCount = 2
+	botReply := message("1768486700.000100", "!standup reminder")
+	botReply.ThreadTimestamp = release.Timestamp
+	reply := message("1768486800.000100", "Nice work")
+	reply.ThreadTimestamp = release.Timestamp
+
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users:    []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{"C123": {standup, release}},
+		threads: map[string][]rslack.Message{
+			"C123:" + standup.Timestamp: {standup, standupReply},
+			"C123:" + release.Timestamp: {release, botReply, reply},
+		},
+	}
+	filter, err := NewMessageFilter(nil, []string{`^!standup`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputDir := t.TempDir()
+
+	_, err = renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15",
+		RenderOptions{Timezone: "UTC", MessageFilter: filter}, nil, nil)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-01-15", "2026-01-15-engineering.md"))
+	if err != nil {
+		t.Fatalf("reading rendered file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"Release is out", "Nice work"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("rendered file missing %q:\n%s", want, content)
+		}
+	}
+	for _, dropped := range []string{"!standup", "thanks"} {
+		if strings.Contains(content, dropped) {
+			t.Errorf("rendered file includes filtered %q:\n%s", dropped, content)
+		}
+	}
+
+	manifest, err := loadDateManifest(dateManifestPath(outputDir, "2026-01-15", ""), "2026-01-15")
+	if err != nil {
+		t.Fatalf("loadDateManifest() error = %v", err)
+	}
+	if got := manifest.FilteredMessages["engineering"]; got != 2 {
+		t.Errorf("FilteredMessages[engineering] = %d, want 2 (%v)", got, manifest.FilteredMessages)
+	}
+	if total, topLevel := filter.droppedOn("C123", "2026-01-15", "UTC"); total != 2 || topLevel != 1 {
+		t.Errorf("droppedOn() = %d, %d, want 2, 1", total, topLevel)
+	}
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+// UserSource supplies people missing from the archive's user list, such as
+// deactivated accounts that no longer come back from users.list and Slack
+// Connect guests, so their messages keep a name.
+type UserSource interface {
+	User(id string) (*slack.User, bool)
+}
+
+// cachedUsers serves users from the users.info cache only, so offline
+// renders name whoever an earlier run looked up.
+type cachedUsers struct {
+	cache *slack.UserCache
+}
+
+func (c cachedUsers) User(id string) (*slack.User, bool) {
+	user := c.cache.Get(id)
+	return user, user != nil
+}
+
+func cachedUserSource() UserSource {
+	cache := slack.NewUserCache(slack.DefaultCachePath())
+	if err := cache.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load user cache: %v\n", err)
+		return nil
+	}
+	return cachedUsers{cache: cache}
+}
+
+// fetchingUsers looks up users missing from the cache with users.info once
+// per run and caches what it finds for later renders.
+type fetchingUsers struct {
+	ctx     context.Context
+	fetcher slack.UserFetcher
+	cache   *slack.UserCache
+	fetched map[string]*slack.User
+}
+
+func newFetchingUsers(ctx context.Context, fetcher slack.UserFetcher) *fetchingUsers {
+	cache := slack.NewUserCache(slack.DefaultCachePath())
+	if err := cache.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load user cache: %v\n", err)
+	}
+	return &fetchingUsers{ctx: ctx, fetcher: fetcher, cache: cache, fetched: make(map[string]*slack.User)}
+}
+
+func (u *fetchingUsers) User(id string) (*slack.User, bool) {
+	if user := u.cache.Get(id); user != nil {
+		return user, true
+	}
+	if user, seen := u.fetched[id]; seen {
+		return user, user != nil
+	}
+	user, err := u.fetcher.FetchUserInfo(u.ctx, id)
+	var apiErr *slack.APIError
+	switch {
+	case err == nil:
+		u.cache.Set(user)
+		if err := u.cache.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save user cache: %v\n", err)
+		}
+	case errors.As(err, &apiErr) && apiErr.Code == "user_not_found":
+		user = nil
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: failed to look up user %s: %v\n", id, err)
+		user = nil
+	}
+	u.fetched[id] = user
+	return user, user != nil
+}
+
+// rslackUser converts a users.info result for name resolution.
+func rslackUser(u *slack.User) rslack.User {
+	user := rslack.User{ID: u.ID, Name: u.Name, RealName: u.RealName, Deleted: u.Deleted, IsBot: u.IsBot}
+	user.Profile.DisplayName = u.Profile.DisplayName
+	user.Profile.RealName = u.Profile.RealName
+	return user
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+type fakeUserFetcher struct {
+	users map[string]*slack.User
+	calls map[string]int
+}
+
+func (f *fakeUserFetcher) FetchUserInfo(_ context.Context, id string) (*slack.User, error) {
+	f.calls[id]++
+	if user, ok := f.users[id]; ok {
+		return user, nil
+	}
+	return nil, &slack.APIError{Method: "users.info", Code: "user_not_found"}
+}
+
+func TestFetchingUsers_LooksUpAndCachesMissingUsers(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	fetcher := &fakeUserFetcher{
+		users: map[string]*slack.User{"U9": {ID: "U9", Name: "carol", Deleted: true}},
+		calls: make(map[string]int),
+	}
+	users := newFetchingUsers(context.Background(), fetcher)
+
+	for range 2 {
+		if user, ok := users.User("U9"); !ok || user.Name != "carol" {
+			t.Errorf("User(U9) = %+v, %v", user, ok)
+		}
+		if _, ok := users.User("U404"); ok {
+			t.Error("User(U404) should report a user Slack does not know")
+		}
+	}
+	if fetcher.calls["U9"] != 1 || fetcher.calls["U404"] != 1 {
+		t.Errorf("users.info calls = %v, want one per user", fetcher.calls)
+	}
+
+	cached, ok := cachedUserSource().User("U9")
+	if !ok || cached.Name != "carol" {
+		t.Errorf("cached user = %+v, %v; want carol saved for offline renders", cached, ok)
+	}
+}
+
+func TestDisplayName_MissingAndDeactivatedUsers(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	users := newFetchingUsers(context.Background(), &fakeUserFetcher{
+		users: map[string]*slack.User{"U9": {ID: "U9", Name: "carol", Deleted: true}},
+		calls: make(map[string]int),
+	})
+	archive := userLookup{"U1": rslackUser(&slack.User{ID: "U1", Name: "alice", Deleted: true})}
+
+	lookup := renderLookup{users: archive, missingUsers: users, deactivated: "deactivated"}
+	for id, want := range map[string]string{
+		"U1":   "alice (deactivated)",
+		"U9":   "carol (deactivated)",
+		"U404": "<unknown>:U404",
+	} {
+		if got := lookup.displayName(id); got != want {
+			t.Errorf("displayName(%s) = %q, want %q", id, got, want)
+		}
+	}
+
+	lookup.deactivated = ""
+	if got := lookup.displayName("U1"); got != "alice" {
+		t.Errorf("displayName(U1) without a label = %q, want alice", got)
+	}
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// maxNewChannelNames caps the names listed in the new channels notice.
+const maxNewChannelNames = 10
+
+// announceNewChannels reports tracked channels that no earlier sync
+// recorded, so channels newly matching the include patterns do not join the
+// export unnoticed. The first sync of an archive has nothing to compare
+// against and reports nothing.
+func (e *Exporter) announceNewChannels(archiveDir string, tracked []slack.Channel) {
+	known, err := loadChannelNames(archiveDir)
+	if err != nil || len(known) == 0 {
+		return
+	}
+	names := newChannelNames(tracked, known)
+	if len(names) == 0 {
+		return
+	}
+	listed := names
+	if len(listed) > maxNewChannelNames {
+		listed = append(listed[:maxNewChannelNames:maxNewChannelNames], fmt.Sprintf("and %d more", len(names)-maxNewChannelNames))
+	}
+	message := fmt.Sprintf("%d new channel(s) matched your patterns: %s", len(names), strings.Join(listed, ", "))
+	if days := e.cfg.BackfillNewChannelsDays; days > 0 {
+		message += fmt.Sprintf(" (backfilling the last %d day(s))", days)
+	}
+	e.events().OnStage(message)
+}
+
+// newChannelNames returns the sorted names of tracked channels missing from
+// the recorded channel names.
+func newChannelNames(tracked []slack.Channel, known map[string]string) []string {
+	var names []string
+	for _, ch := range tracked {
+		if _, ok := known[ch.ID]; !ok {
+			names = append(names, ch.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newChannelStart is where history starts for channels without an archive
+// checkpoint: the archive's coverage start, or backfill_new_channels_days
+// work days before now when that is later.
+func (e *Exporter) newChannelStart(coverageStart, now time.Time) time.Time {
+	days := e.cfg.BackfillNewChannelsDays
+	if days <= 0 {
+		return coverageStart
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		return coverageStart
+	}
+	today, err := time.Parse("2006-01-02", workDate(now.In(loc)))
+	if err != nil {
+		return coverageStart
+	}
+	start, _, err := GetDateBounds(today.AddDate(0, 0, -days+1).Format("2006-01-02"), e.cfg.Timezone)
+	if err != nil || start.Before(coverageStart) {
+		return coverageStart
+	}
+	return start
+}
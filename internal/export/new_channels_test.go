@@ -0,0 +1,50 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestAnnounceNewChannels(t *testing.T) {
+	archiveDir := t.TempDir()
+	rec := &recordingEvents{}
+	e := &Exporter{cfg: &config.Config{BackfillNewChannelsDays: 14}}
+	e.SetEvents(rec)
+	tracked := []slack.Channel{{ID: "C1", Name: "general"}, {ID: "C2", Name: "launch"}, {ID: "C3", Name: "design"}}
+
+	e.announceNewChannels(archiveDir, tracked)
+	if len(rec.stages) != 0 {
+		t.Errorf("first sync stages = %q, want no notice", rec.stages)
+	}
+
+	if err := saveChannelNames(archiveDir, tracked[:1]); err != nil {
+		t.Fatal(err)
+	}
+	e.announceNewChannels(archiveDir, tracked)
+	want := "2 new channel(s) matched your patterns: design, launch (backfilling the last 14 day(s))"
+	if len(rec.stages) != 1 || rec.stages[0] != want {
+		t.Errorf("stages = %q, want %q", rec.stages, want)
+	}
+}
+
+func TestNewChannelStart(t *testing.T) {
+	coverage := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	e := &Exporter{cfg: &config.Config{Timezone: "UTC"}}
+	if got := e.newChannelStart(coverage, now); !got.Equal(coverage) {
+		t.Errorf("newChannelStart() without backfill days = %v, want coverage start", got)
+	}
+
+	e.cfg.BackfillNewChannelsDays = 7
+	if got, want := e.newChannelStart(coverage, now), time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("newChannelStart() = %v, want %v", got, want)
+	}
+
+	e.cfg.BackfillNewChannelsDays = 365
+	if got := e.newChannelStart(coverage, now); !got.Equal(coverage) {
+		t.Errorf("newChannelStart() before coverage = %v, want coverage start", got)
+	}
+}
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Output size guard actions for max_daily_output_size.
+const (
+	OutputSizeWarn  = "warn"
+	OutputSizeAbort = "abort"
+)
+
+// OutputAccounting tracks rendered bytes per work day of each output
+// directory during one export and enforces max_daily_output_size. A
+// channel-day rendered into several output targets counts once toward each
+// target's day. A nil *OutputAccounting records nothing.
+type OutputAccounting struct {
+	maxDailyBytes int64
+	abort         bool
+	dayBytes      map[outputDay]int64
+	channelDays   int
+	written       int64
+	files         int
+	warned        map[outputDay]bool
+}
+
+// outputDay is one work day of one output directory.
+type outputDay struct {
+	outputDir string
+	date      string
+}
+
+// NewOutputAccounting creates an accounting for one export. A zero
+// maxDailyBytes tracks sizes without enforcing a limit.
+func NewOutputAccounting(maxDailyBytes int64, action string) *OutputAccounting {
+	return &OutputAccounting{
+		maxDailyBytes: maxDailyBytes,
+		abort:         action != OutputSizeWarn,
+		dayBytes:      make(map[outputDay]int64),
+		warned:        make(map[outputDay]bool),
+	}
+}
+
+// reserve adds a channel-day's rendered size to its date in outputDir before
+// the file is written. In abort mode it fails instead of letting the day
+// exceed the limit.
+func (a *OutputAccounting) reserve(outputDir, date, name string, size int64) error {
+	if a == nil {
+		return nil
+	}
+	day := outputDay{outputDir: outputDir, date: date}
+	total := a.dayBytes[day] + size
+	if a.maxDailyBytes > 0 && total > a.maxDailyBytes {
+		if a.abort {
+			return fmt.Errorf(
+				"%s output in %s would reach %s after %s, over max_daily_output_size %s; "+
+					"narrow include patterns or raise the limit",
+				date, outputDir, FormatBytes(total), name, FormatBytes(a.maxDailyBytes),
+			)
+		}
+		if !a.warned[day] {
+			a.warned[day] = true
+			fmt.Fprintf(os.Stderr, "Warning: %s output in %s exceeds max_daily_output_size %s (reached %s at %s)\n",
+				date, outputDir, FormatBytes(a.maxDailyBytes), FormatBytes(total), name)
+		}
+	}
+	a.dayBytes[day] = total
+	a.channelDays++
+	return nil
+}
+
+func (a *OutputAccounting) recordWrite(size int) {
+	if a == nil {
+		return
+	}
+	a.written += int64(size)
+	a.files++
+}
+
+// Written returns the bytes written to changed files so far.
+func (a *OutputAccounting) Written() int64 {
+	if a == nil {
+		return 0
+	}
+	return a.written
+}
+
+// channelDayAverage returns the mean rendered size of a channel-day, or false
+// when nothing was rendered.
+func (a *OutputAccounting) channelDayAverage() (int64, bool) {
+	if a == nil || a.channelDays == 0 {
+		return 0, false
+	}
+	var total int64
+	for _, size := range a.dayBytes {
+		total += size
+	}
+	return total / int64(a.channelDays), true
+}
+
+// DayBytes returns the rendered size of a work day in outputDir across all
+// channels.
+func (a *OutputAccounting) DayBytes(outputDir, date string) int64 {
+	if a == nil {
+		return 0
+	}
+	return a.dayBytes[outputDay{outputDir: outputDir, date: date}]
+}
+
+// Summary describes bytes written and the largest rendered day, naming its
+// output directory when there are several.
+func (a *OutputAccounting) Summary() string {
+	if a == nil || len(a.dayBytes) == 0 {
+		return "Output: nothing rendered"
+	}
+	days := make([]outputDay, 0, len(a.dayBytes))
+	dirs := make(map[string]bool)
+	for day := range a.dayBytes {
+		days = append(days, day)
+		dirs[day.outputDir] = true
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].date != days[j].date {
+			return days[i].date < days[j].date
+		}
+		return days[i].outputDir < days[j].outputDir
+	})
+	largest := days[0]
+	for _, day := range days[1:] {
+		if a.dayBytes[day] > a.dayBytes[largest] {
+			largest = day
+		}
+	}
+	where := largest.date
+	if len(dirs) > 1 {
+		where += " in " + largest.outputDir
+	}
+	return fmt.Sprintf("Output: %s written to %d file(s); largest day %s (%s)",
+		FormatBytes(a.written), a.files, where, FormatBytes(a.dayBytes[largest]))
+}
+
+// FormatBytes renders a byte count with a binary unit suffix (e.g. 1.5MB).
+func FormatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
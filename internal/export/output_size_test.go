@@ -0,0 +1,97 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutputAccounting_AbortsWhenDayExceedsLimit(t *testing.T) {
+	out := t.TempDir()
+	opts := RenderOptions{Accounting: NewOutputAccounting(10, OutputSizeAbort)}
+
+	if _, err := writeChannelDate(context.Background(), out, "2026-01-21", "small", []renderedUnit{{text: "12345\n"}}, opts); err != nil {
+		t.Fatalf("first channel should fit: %v", err)
+	}
+	_, err := writeChannelDate(context.Background(), out, "2026-01-21", "firehose", []renderedUnit{{text: "123456789\n"}}, opts)
+	if err == nil || !strings.Contains(err.Error(), "max_daily_output_size") {
+		t.Fatalf("writeChannelDate() error = %v, want size guard error", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(out, "2026-01-21", "2026-01-21-firehose.md")); !os.IsNotExist(statErr) {
+		t.Errorf("firehose file should not be written, stat err = %v", statErr)
+	}
+	if _, err := writeChannelDate(context.Background(), out, "2026-01-22", "firehose", []renderedUnit{{text: "123456789\n"}}, opts); err != nil {
+		t.Errorf("limit is per day, next day should fit: %v", err)
+	}
+}
+
+func TestOutputAccounting_LimitIsPerOutputDir(t *testing.T) {
+	personal, team := t.TempDir(), t.TempDir()
+	opts := RenderOptions{Accounting: NewOutputAccounting(10, OutputSizeAbort)}
+	units := []renderedUnit{{text: "123456789\n"}}
+
+	for _, out := range []string{personal, team} {
+		if _, err := writeChannelDate(context.Background(), out, "2026-01-21", "general", units, opts); err != nil {
+			t.Fatalf("writeChannelDate(%s) error = %v; each target has its own limit", out, err)
+		}
+		if got := opts.Accounting.DayBytes(out, "2026-01-21"); got != 10 {
+			t.Errorf("DayBytes(%s) = %d, want 10", out, got)
+		}
+	}
+}
+
+func TestOutputAccounting_WarnModeKeepsWriting(t *testing.T) {
+	out := t.TempDir()
+	opts := RenderOptions{Accounting: NewOutputAccounting(4, OutputSizeWarn)}
+
+	if _, err := writeChannelDate(context.Background(), out, "2026-01-21", "general", []renderedUnit{{text: "123456789\n"}}, opts); err != nil {
+		t.Fatalf("warn mode should not fail: %v", err)
+	}
+	if got := opts.Accounting.DayBytes(out, "2026-01-21"); got != 10 {
+		t.Errorf("DayBytes() = %d, want 10", got)
+	}
+}
+
+func TestOutputAccounting_CountsOnlyChangedWrites(t *testing.T) {
+	out := t.TempDir()
+	units := []renderedUnit{{text: "hello\n"}}
+
+	first := NewOutputAccounting(0, "")
+	if _, err := writeChannelDate(context.Background(), out, "2026-01-21", "general", units, RenderOptions{Accounting: first}); err != nil {
+		t.Fatal(err)
+	}
+	second := NewOutputAccounting(0, "")
+	if _, err := writeChannelDate(context.Background(), out, "2026-01-21", "general", units, RenderOptions{Accounting: second}); err != nil {
+		t.Fatal(err)
+	}
+	if first.Written() != 6 || second.Written() != 0 {
+		t.Errorf("Written() = %d then %d, want 6 then 0", first.Written(), second.Written())
+	}
+	if second.DayBytes(out, "2026-01-21") != 6 {
+		t.Errorf("unchanged files still count toward the day size, got %d", second.DayBytes(out, "2026-01-21"))
+	}
+}
+
+func TestOutputAccounting_Summary(t *testing.T) {
+	var nilAccounting *OutputAccounting
+	if got := nilAccounting.Summary(); got != "Output: nothing rendered" {
+		t.Errorf("nil Summary() = %q", got)
+	}
+
+	a := NewOutputAccounting(0, "")
+	_ = a.reserve("out", "2026-01-21", "a", 100)
+	_ = a.reserve("out", "2026-01-22", "b", 3<<20)
+	a.recordWrite(2048)
+	want := "Output: 2.0KB written to 1 file(s); largest day 2026-01-22 (3.0MB)"
+	if got := a.Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+
+	_ = a.reserve("team", "2026-01-21", "a", 4<<20)
+	want = "Output: 2.0KB written to 1 file(s); largest day 2026-01-21 in team (4.0MB)"
+	if got := a.Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
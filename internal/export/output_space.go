@@ -0,0 +1,116 @@
+package export
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// defaultChannelDayBytes sizes a channel-day before any run has
+	// recorded a real average.
+	defaultChannelDayBytes = 16 << 10
+
+	// filesPerChannelDay counts the inodes one channel-day may take: its
+	// markdown file and its share of the date folder's index and manifest.
+	filesPerChannelDay = 2
+)
+
+// preflightOutputSpace fails before rendering when an output directory's
+// volume lacks the space or inodes channelDays are estimated to need.
+func (e *Exporter) preflightOutputSpace(archiveDir string, channelDays int) error {
+	if channelDays <= 0 {
+		return nil
+	}
+	perDay := int64(defaultChannelDayBytes)
+	if state, err := loadExportState(archiveDir); err == nil && state.ChannelDayBytes > 0 {
+		perDay = state.ChannelDayBytes
+	}
+	for _, dir := range e.cfg.OutputDirs() {
+		if err := checkOutputSpace(dir, channelDays, perDay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOutputSpace checks the volume holding dir, or its nearest existing
+// parent when dir is not created yet.
+func checkOutputSpace(dir string, channelDays int, perDay int64) error {
+	volume := existingParent(dir)
+	need := int64(channelDays) * perDay
+	free, ok, err := freeDiskBytes(volume)
+	if err != nil {
+		return fmt.Errorf("checking free space in %s: %w", volume, err)
+	}
+	if ok && free < uint64(need) {
+		return fmt.Errorf(
+			"output directory %s has %s free but %d channel-day(s) may need about %s; free up space or move output_dir",
+			dir, FormatBytes(int64(min(free, math.MaxInt64))), channelDays, FormatBytes(need),
+		)
+	}
+	inodes, ok, err := freeDiskInodes(volume)
+	if err != nil {
+		return fmt.Errorf("checking free inodes in %s: %w", volume, err)
+	}
+	if needFiles := uint64(channelDays) * filesPerChannelDay; ok && inodes < needFiles {
+		return fmt.Errorf(
+			"output directory %s has %d free inodes but %d channel-day(s) may need about %d files; "+
+				"remove files or move output_dir",
+			dir, inodes, channelDays, needFiles,
+		)
+	}
+	return nil
+}
+
+// estimatedChannelDays sizes a render of from through to as every channel
+// the last sync tracked on every date.
+func estimatedChannelDays(archiveDir, from, to, timezone string) int {
+	dates, err := datesInRange(from, to, timezone)
+	if err != nil {
+		return 0
+	}
+	names, _ := loadChannelNames(archiveDir)
+	return len(dates) * max(len(names), 1)
+}
+
+// recordOutputSize updates the channel-day size estimate after a render.
+// Failing to save it only costs estimate accuracy.
+func (e *Exporter) recordOutputSize(archiveDir string, opts RenderOptions) {
+	if err := recordChannelDayBytes(archiveDir, opts.Accounting); err != nil {
+		e.warnf("recording output size: %v", err)
+	}
+}
+
+func existingParent(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// recordChannelDayBytes folds this run's average channel-day size into the
+// export state so later preflights estimate from real output. The stored
+// value moves halfway toward each run's average.
+func recordChannelDayBytes(archiveDir string, accounting *OutputAccounting) error {
+	average, ok := accounting.channelDayAverage()
+	if !ok {
+		return nil
+	}
+	state, err := loadExportState(archiveDir)
+	if err != nil {
+		return err
+	}
+	if state.ChannelDayBytes > 0 {
+		average = (state.ChannelDayBytes + average) / 2
+	}
+	state.ChannelDayBytes = max(average, 1)
+	return saveExportState(archiveDir, state)
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appslack "github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestCheckOutputSpace_FailsWhenVolumeTooSmall(t *testing.T) {
+	if _, ok, _ := freeDiskBytes(t.TempDir()); !ok {
+		t.Skip("free space not measurable on this platform")
+	}
+	dir := filepath.Join(t.TempDir(), "not", "created")
+	if err := checkOutputSpace(dir, 1, 16<<10); err != nil {
+		t.Fatalf("checkOutputSpace() error = %v for one small channel-day", err)
+	}
+	err := checkOutputSpace(dir, 1, math.MaxInt64)
+	if err == nil {
+		t.Fatal("checkOutputSpace() should fail for an impossible requirement")
+	}
+	if !strings.Contains(err.Error(), dir) || !strings.Contains(err.Error(), "output_dir") {
+		t.Errorf("error should name the directory and output_dir: %v", err)
+	}
+}
+
+func TestRecordChannelDayBytes_AveragesRunsAndKeepsWatermarks(t *testing.T) {
+	archiveDir := t.TempDir()
+	watermark := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	watermarks := exportWatermarks{}
+	watermarks.set("out", "2026-10-16", "C1", watermark)
+	if err := saveExportWatermarks(archiveDir, watermarks); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(sizes ...int64) {
+		t.Helper()
+		accounting := NewOutputAccounting(0, "")
+		for i, size := range sizes {
+			if err := accounting.reserve("out", "2026-10-16", string(rune('a'+i)), size); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := recordChannelDayBytes(archiveDir, accounting); err != nil {
+			t.Fatalf("recordChannelDayBytes() error = %v", err)
+		}
+	}
+	run(1000, 3000)
+	run(6000)
+	run()
+
+	state, err := loadExportState(archiveDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ChannelDayBytes != 4000 {
+		t.Errorf("ChannelDayBytes = %d, want (2000+6000)/2", state.ChannelDayBytes)
+	}
+	if !state.Rendered["out"]["2026-10-16"]["C1"].Equal(watermark) {
+		t.Errorf("watermarks = %v, want C1 kept", state.Rendered)
+	}
+}
+
+func TestEstimatedChannelDays_UsesTrackedChannels(t *testing.T) {
+	archiveDir := t.TempDir()
+	if got := estimatedChannelDays(archiveDir, "2026-10-01", "2026-10-03", "UTC"); got != 3 {
+		t.Errorf("estimatedChannelDays() without channel names = %d, want 3", got)
+	}
+	if err := saveChannelNames(archiveDir, []appslack.Channel{{ID: "C1", Name: "general"}, {ID: "C2", Name: "random"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := estimatedChannelDays(archiveDir, "2026-10-01", "2026-10-03", "UTC"); got != 6 {
+		t.Errorf("estimatedChannelDays() = %d, want 6", got)
+	}
+}
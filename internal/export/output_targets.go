@@ -0,0 +1,150 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// outputSelection is one output directory and the channel IDs rendered into
+// it. Nil ids renders every channel in the archive.
+type outputSelection struct {
+	outputDir string
+	ids       []string
+}
+
+// FilterForTargets returns the channels selected by any output target, in
+// input order, so one archive refresh covers every target.
+func FilterForTargets(all []slack.Channel, targets []config.OutputTarget) []slack.Channel {
+	selected := make(map[string]bool)
+	for _, target := range targets {
+		for _, ch := range channels.FilterChannels(all, target.Include, target.Exclude) {
+			selected[ch.ID] = true
+		}
+	}
+	var result []slack.Channel
+	for _, ch := range all {
+		if selected[ch.ID] {
+			result = append(result, ch)
+		}
+	}
+	return result
+}
+
+// outputSelections splits channels among the configured output targets.
+// Without targets the top-level output_dir receives restrictIDs unchanged.
+// With targets, channel names come from the archive's stored names and
+// targets that select nothing are dropped. A target split by section yields
+// one selection per section directory.
+func outputSelections(cfg *config.Config, archiveDir string, restrictIDs []string) ([]outputSelection, error) {
+	if len(cfg.Targets) == 0 {
+		return []outputSelection{{outputDir: cfg.OutputDir, ids: restrictIDs}}, nil
+	}
+	names, err := loadChannelNames(archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading channel names: %w", err)
+	}
+	ids := restrictIDs
+	if ids == nil {
+		for id := range names {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+	candidates := make([]slack.Channel, 0, len(ids))
+	for _, id := range ids {
+		candidates = append(candidates, slack.Channel{ID: id, Name: names[id]})
+	}
+
+	var sections map[string]string
+	if cfg.UsesSections() {
+		if sections, err = loadChannelSections(archiveDir); err != nil {
+			return nil, fmt.Errorf("loading sidebar sections: %w", err)
+		}
+	}
+
+	var selections []outputSelection
+	for _, target := range cfg.Targets {
+		matched := channels.FilterChannels(candidates, target.Include, target.Exclude)
+		switch {
+		case len(matched) == 0:
+		case target.UsesSections():
+			split, err := sectionSelections(target, matched, sections)
+			if err != nil {
+				return nil, fmt.Errorf("target %q: %w", target.Name, err)
+			}
+			selections = append(selections, split...)
+		default:
+			selections = append(selections, outputSelection{outputDir: target.OutputDir, ids: channelIDs(matched)})
+		}
+	}
+	return selections, nil
+}
+
+// RenderConfiguredRange renders an inclusive date range into every configured
+// output target. A nil channelIDs slice renders all channels each target selects.
+func RenderConfiguredRange(
+	ctx context.Context,
+	cfg *config.Config,
+	archiveDir string,
+	from string,
+	to string,
+	opts RenderOptions,
+	channelIDs []string,
+) (int, error) {
+	selections, err := outputSelections(cfg, archiveDir, channelIDs)
+	if err != nil {
+		return 0, err
+	}
+	writes := 0
+	for _, sel := range selections {
+		n, err := RenderArchiveRangeForChannels(ctx, archiveDir, sel.outputDir, from, to, opts, sel.ids)
+		writes += n
+		if err != nil {
+			return writes, err
+		}
+	}
+	return writes, nil
+}
+
+// renderConfiguredTargets renders changed channel-days into every output
+// target whose filters select the channel.
+func renderConfiguredTargets(
+	ctx context.Context,
+	cfg *config.Config,
+	archiveDir string,
+	opts RenderOptions,
+	targets []renderTarget,
+) (int, error) {
+	selections, err := outputSelections(cfg, archiveDir, targetChannelIDs(targets))
+	if err != nil {
+		return 0, err
+	}
+	writes := 0
+	for _, sel := range selections {
+		n, err := RenderArchiveTargets(ctx, archiveDir, sel.outputDir, opts, selectRenderTargets(targets, sel.ids))
+		writes += n
+		if err != nil {
+			return writes, err
+		}
+	}
+	return writes, nil
+}
+
+func selectRenderTargets(targets []renderTarget, ids []string) []renderTarget {
+	if ids == nil {
+		return targets
+	}
+	wanted := channelIDSet(ids)
+	var selected []renderTarget
+	for _, target := range targets {
+		if wanted[target.channelID] {
+			selected = append(selected, target)
+		}
+	}
+	return selected
+}
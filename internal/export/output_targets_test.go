@@ -0,0 +1,125 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestFilterForTargets_UnionInInputOrder(t *testing.T) {
+	all := []slack.Channel{
+		{ID: "C1", Name: "eng-api"},
+		{ID: "D1", Name: "dm_alice"},
+		{ID: "C2", Name: "random"},
+		{ID: "C3", Name: "eng-random"},
+	}
+	targets := []config.OutputTarget{
+		{Name: "work", Include: []string{"eng-*"}, Exclude: []string{"eng-random"}},
+		{Name: "personal", Include: []string{"dm_*"}},
+	}
+
+	got := channelIDs(FilterForTargets(all, targets))
+	if want := []string{"C1", "D1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterForTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestOutputSelections_WithoutTargetsUsesOutputDir(t *testing.T) {
+	cfg := &config.Config{OutputDir: "/out"}
+
+	got, err := outputSelections(cfg, t.TempDir(), []string{"C1"})
+	if err != nil {
+		t.Fatalf("outputSelections() error = %v", err)
+	}
+	want := []outputSelection{{outputDir: "/out", ids: []string{"C1"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("outputSelections() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOutputSelections_SplitsChannelsByTarget(t *testing.T) {
+	archiveDir := t.TempDir()
+	if err := saveChannelNames(archiveDir, []slack.Channel{
+		{ID: "C1", Name: "eng-api"},
+		{ID: "D1", Name: "dm_alice"},
+		{ID: "C2", Name: "random"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Targets: []config.OutputTarget{
+		{Name: "work", Include: []string{"eng-*"}, OutputDir: "/work"},
+		{Name: "personal", Include: []string{"dm_*"}, OutputDir: "/personal"},
+		{Name: "empty", Include: []string{"nothing-*"}, OutputDir: "/empty"},
+	}}
+
+	got, err := outputSelections(cfg, archiveDir, nil)
+	if err != nil {
+		t.Fatalf("outputSelections() error = %v", err)
+	}
+	want := []outputSelection{
+		{outputDir: "/work", ids: []string{"C1"}},
+		{outputDir: "/personal", ids: []string{"D1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("outputSelections() = %+v, want %+v", got, want)
+	}
+
+	got, err = outputSelections(cfg, archiveDir, []string{"D1", "C2"})
+	if err != nil {
+		t.Fatalf("outputSelections() error = %v", err)
+	}
+	want = []outputSelection{{outputDir: "/personal", ids: []string{"D1"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("restricted outputSelections() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOutputSelections_SplitsTargetBySection(t *testing.T) {
+	archiveDir := t.TempDir()
+	if err := saveChannelNames(archiveDir, []slack.Channel{
+		{ID: "C1", Name: "eng-api"},
+		{ID: "C2", Name: "eng-web"},
+		{ID: "C3", Name: "random"},
+		{ID: "D1", Name: "dm_alice"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveChannelSections(archiveDir, map[string]string{"C1": "Eng/Core", "C2": "Eng/Core", "C3": "Channels"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Targets: []config.OutputTarget{
+		{Name: "sidebar", Include: []string{"*"}, OutputDir: "/slack/{{.Section}}"},
+	}}
+
+	got, err := outputSelections(cfg, archiveDir, nil)
+	if err != nil {
+		t.Fatalf("outputSelections() error = %v", err)
+	}
+	want := []outputSelection{
+		{outputDir: "/slack/Channels", ids: []string{"C3"}},
+		{outputDir: "/slack/Direct messages", ids: []string{"D1"}},
+		{outputDir: "/slack/Eng_Core", ids: []string{"C1", "C2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("outputSelections() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSelectRenderTargets(t *testing.T) {
+	targets := []renderTarget{
+		{channelID: "C1", date: "2026-01-21"},
+		{channelID: "D1", date: "2026-01-21"},
+		{channelID: "C1", date: "2026-01-22"},
+	}
+
+	got := selectRenderTargets(targets, []string{"C1"})
+	want := []renderTarget{targets[0], targets[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectRenderTargets() = %+v, want %+v", got, want)
+	}
+	if got := selectRenderTargets(targets, nil); len(got) != 3 {
+		t.Errorf("nil ids should keep all targets, got %d", len(got))
+	}
+}
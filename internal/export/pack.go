@@ -0,0 +1,343 @@
+package export
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+)
+
+const (
+	packFormatVersion = 1
+	// packManifestFile is the last entry of a pack, so it can list the
+	// checksum of every file before it.
+	packManifestFile = "pack.json"
+	packUsersFile    = "users.json"
+)
+
+// PackOptions selects what Pack bundles.
+type PackOptions struct {
+	// From and To are the inclusive range of date folders to pack.
+	From string
+	To   string
+	// Channels are glob patterns matched against channel file names
+	// without date, part number, or extension. When set, only matching
+	// channel files are packed and day-level files such as index.md are
+	// left out. Empty packs every file in the date folders.
+	Channels []string
+	// Users adds a snapshot of the archive's users.
+	Users bool
+	// Anonymize renders the range afresh from the archive with anonymize
+	// on instead of packing the output directory as it is.
+	Anonymize bool
+	// Passphrase, when set, encrypts the pack.
+	Passphrase string
+}
+
+// PackManifest is the pack.json inside a pack.
+type PackManifest struct {
+	Version    int        `json:"version"`
+	CreatedAt  time.Time  `json:"created_at"`
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Channels   []string   `json:"channels,omitempty"`
+	Anonymized bool       `json:"anonymized,omitempty"`
+	Users      bool       `json:"users,omitempty"`
+	Files      []PackFile `json:"files"`
+}
+
+// PackFile is one packed file, by slash-separated path relative to the
+// output directory.
+type PackFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// PackUser is one entry of a pack's user snapshot.
+type PackUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	RealName    string `json:"real_name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	IsBot       bool   `json:"is_bot,omitempty"`
+	Deleted     bool   `json:"deleted,omitempty"`
+}
+
+// Pack writes the date folders from opts.From through opts.To of outputDir
+// to w as a tar.gz, encrypted when opts.Passphrase is set. archiveDir is
+// read only for Users and Anonymize, which renders with renderOpts.
+func Pack(
+	ctx context.Context,
+	w io.Writer,
+	outputDir string,
+	archiveDir string,
+	renderOpts RenderOptions,
+	opts PackOptions,
+) (*PackManifest, error) {
+	if err := validatePackRange(opts.From, opts.To); err != nil {
+		return nil, err
+	}
+	if opts.Users && opts.Anonymize {
+		return nil, errors.New("a user snapshot would undo anonymization; pack users or anonymize, not both")
+	}
+
+	srcDir := outputDir
+	if opts.Anonymize {
+		tmp, err := os.MkdirTemp("", "slack-export-pack-")
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		renderOpts.Anonymize = true
+		renderOpts.Translator = nil
+		if _, err := RenderArchiveRange(ctx, archiveDir, tmp, opts.From, opts.To, renderOpts); err != nil {
+			return nil, fmt.Errorf("rendering anonymized copy: %w", err)
+		}
+		srcDir = tmp
+	}
+	paths, err := packPaths(srcDir, opts.From, opts.To, opts.Channels, renderOpts.Translator.Language())
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("nothing to pack in %s for %s through %s", outputDir, opts.From, opts.To)
+	}
+
+	manifest := &PackManifest{
+		Version:    packFormatVersion,
+		CreatedAt:  time.Now().UTC(),
+		From:       opts.From,
+		To:         opts.To,
+		Channels:   opts.Channels,
+		Anonymized: opts.Anonymize,
+		Users:      opts.Users,
+	}
+	var users []byte
+	if opts.Users {
+		if users, err = packUserSnapshot(ctx, archiveDir); err != nil {
+			return nil, err
+		}
+	}
+	if err := writePack(w, srcDir, paths, users, manifest, opts.Passphrase); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func validatePackRange(from, to string) error {
+	for _, date := range []string{from, to} {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("invalid date %q: use YYYY-MM-DD", date)
+		}
+	}
+	if from > to {
+		return fmt.Errorf("from date %s is after to date %s", from, to)
+	}
+	return nil
+}
+
+// packPaths lists the files to pack by slash-separated path relative to
+// dir. Hidden files, such as in-progress writes, are skipped. In weekly and
+// monthly folders, dated files outside the range are skipped and undated
+// ones such as SHA256SUMS go along when any day of the folder does.
+func packPaths(dir, from, to string, patterns []string, language string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		folder := entry.Name()
+		daily := exportDateDirPattern.MatchString(folder)
+		if !entry.IsDir() || !exportFolderPattern.MatchString(folder) || daily && (folder < from || folder > to) {
+			continue
+		}
+		var folderPaths []string
+		inRange := daily
+		err := filepath.WalkDir(filepath.Join(dir, folder), func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if len(patterns) > 0 && !packChannelFile(rel, patterns, language) {
+				return nil
+			}
+			if date := fileDatePattern.FindString(d.Name()); !daily && date != "" {
+				if date < from || date > to {
+					return nil
+				}
+				inRange = true
+			}
+			folderPaths = append(folderPaths, rel)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if inRange {
+			paths = append(paths, folderPaths...)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// packChannelFile reports whether rel is a channel file directly in its
+// date folder whose channel name matches one of patterns. A copy translated
+// into language goes along with its channel.
+func packChannelFile(rel string, patterns []string, language string) bool {
+	folder, name, ok := strings.Cut(rel, "/")
+	if !ok || strings.Contains(name, "/") {
+		return false
+	}
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	switch ext {
+	case ".md":
+		if language != "" {
+			if original, ok := strings.CutSuffix(base, "-"+language); ok {
+				base, name = original, original+ext
+			}
+		}
+		if !IsRenderedChannelFile(folder, name, language) {
+			return false
+		}
+	case ".mbox":
+	default:
+		return false
+	}
+	date := fileDatePattern.FindString(name)
+	base = strings.TrimPrefix(base, date+"-")
+	base = strings.TrimSuffix(base, "-"+date)
+	base = renderedPartSuffixPattern.ReplaceAllString(base, "")
+	return channels.MatchAny(patterns, base)
+}
+
+func packUserSnapshot(ctx context.Context, archiveDir string) ([]byte, error) {
+	src, err := LoadArchiveSource(ctx, archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading archive source: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+	archived, err := src.Users(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading users: %w", err)
+	}
+	users := make([]PackUser, 0, len(archived))
+	for _, u := range archived {
+		users = append(users, PackUser{
+			ID:          u.ID,
+			Name:        u.Name,
+			RealName:    u.RealName,
+			DisplayName: u.Profile.DisplayName,
+			IsBot:       u.IsBot,
+			Deleted:     u.Deleted,
+		})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return json.MarshalIndent(users, "", "  ")
+}
+
+func writePack(w io.Writer, srcDir string, paths []string, users []byte, manifest *PackManifest, passphrase string) error {
+	out := w
+	var enc *packEncrypter
+	if passphrase != "" {
+		var err error
+		if enc, err = newPackEncrypter(w, passphrase); err != nil {
+			return err
+		}
+		out = enc
+	}
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	if users != nil {
+		if err := writePackEntry(tw, packUsersFile, users, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	for _, rel := range paths {
+		file, err := writePackFile(tw, srcDir, rel)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writePackEntry(tw, packManifestFile, data, manifest.CreatedAt); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if enc != nil {
+		return enc.Close()
+	}
+	return nil
+}
+
+func writePackEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: modTime})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// writePackFile copies one file into the pack, hashing it on the way so
+// the manifest matches exactly what was packed.
+func writePackFile(tw *tar.Writer, srcDir, rel string) (PackFile, error) {
+	f, err := os.Open(filepath.Join(srcDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return PackFile{}, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return PackFile{}, err
+	}
+	err = tw.WriteHeader(&tar.Header{Name: rel, Mode: 0600, Size: info.Size(), ModTime: info.ModTime()})
+	if err != nil {
+		return PackFile{}, err
+	}
+	h := sha256.New()
+	if _, err := io.CopyN(io.MultiWriter(tw, h), f, info.Size()); err != nil {
+		return PackFile{}, fmt.Errorf("packing %s: %w", rel, err)
+	}
+	return PackFile{Path: rel, Size: info.Size(), SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
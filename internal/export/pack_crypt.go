@@ -0,0 +1,182 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted packs start with packMagic and a random salt, followed by
+// records of [final flag][ciphertext length][AES-256-GCM ciphertext]. Each
+// record's nonce holds its sequence number and final flag, so reordered,
+// dropped, or truncated records fail to decrypt.
+var packMagic = []byte("SLXPACK\x01")
+
+const (
+	packSaltSize  = 16
+	packChunkSize = 64 * 1024
+)
+
+// ErrPackPassphrase is returned when an encrypted pack does not decrypt,
+// usually because the passphrase is wrong.
+var ErrPackPassphrase = errors.New("cannot decrypt pack: wrong passphrase or corrupted file")
+
+func packCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func packNonce(aead cipher.AEAD, seq uint64, final bool) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-9:], seq)
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// packEncrypter encrypts everything written to it in packChunkSize
+// records. Close writes the final record and must be called.
+type packEncrypter struct {
+	w    io.Writer
+	aead cipher.AEAD
+	seq  uint64
+	buf  []byte
+}
+
+func newPackEncrypter(w io.Writer, passphrase string) (*packEncrypter, error) {
+	salt := make([]byte, packSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := packCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(append([]byte{}, packMagic...), salt...)); err != nil {
+		return nil, err
+	}
+	return &packEncrypter{w: w, aead: aead, buf: make([]byte, 0, packChunkSize)}, nil
+}
+
+func (e *packEncrypter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if len(e.buf) == packChunkSize {
+			if err := e.writeRecord(false); err != nil {
+				return written, err
+			}
+		}
+		n := min(len(p), packChunkSize-len(e.buf))
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (e *packEncrypter) Close() error {
+	return e.writeRecord(true)
+}
+
+func (e *packEncrypter) writeRecord(final bool) error {
+	sealed := e.aead.Seal(nil, packNonce(e.aead, e.seq, final), e.buf, nil)
+	header := make([]byte, 5)
+	if final {
+		header[0] = 1
+	}
+	binary.BigEndian.PutUint32(header[1:], uint32(len(sealed)))
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(sealed); err != nil {
+		return err
+	}
+	e.seq++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// packDecrypter reads the plaintext of an encrypted pack.
+type packDecrypter struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	seq   uint64
+	buf   []byte
+	final bool
+}
+
+func newPackDecrypter(r io.Reader, passphrase string) (*packDecrypter, error) {
+	header := make([]byte, len(packMagic)+packSaltSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading pack header: %w", err)
+	}
+	if !bytes.Equal(header[:len(packMagic)], packMagic) {
+		return nil, errors.New("not an encrypted pack")
+	}
+	aead, err := packCipher(passphrase, header[len(packMagic):])
+	if err != nil {
+		return nil, err
+	}
+	return &packDecrypter{r: r, aead: aead}, nil
+}
+
+func (d *packDecrypter) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.readRecord(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *packDecrypter) readRecord() error {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		return fmt.Errorf("pack is truncated: %w", err)
+	}
+	final := header[0] == 1
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > packChunkSize+uint32(d.aead.Overhead()) {
+		return ErrPackPassphrase
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return fmt.Errorf("pack is truncated: %w", err)
+	}
+	plain, err := d.aead.Open(nil, packNonce(d.aead, d.seq, final), sealed, nil)
+	if err != nil {
+		return ErrPackPassphrase
+	}
+	d.seq++
+	d.buf = plain
+	d.final = final
+	return nil
+}
+
+// isEncryptedPack reports whether r starts like an encrypted pack, without
+// consuming it.
+func isEncryptedPack(r *bufio.Reader) bool {
+	head, err := r.Peek(len(packMagic))
+	return err == nil && bytes.Equal(head, packMagic)
+}
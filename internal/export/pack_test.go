@@ -0,0 +1,231 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+func writePackFixture(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPackUnpack_RoundTrip(t *testing.T) {
+	src := t.TempDir()
+	writePackFixture(t, src, map[string]string{
+		"2026-01-14/2026-01-14-general.md":           "too early",
+		"2026-01-15/2026-01-15-general.md":           "general",
+		"2026-01-15/2026-01-15-eng-backend.md":       "backend",
+		"2026-01-15/2026-01-15-eng-backend-part2.md": "backend 2",
+		"2026-01-15/index.md":                        "index",
+		"2026-01-15/.2026-01-15-general.md.tmp":      "in progress",
+		"2026-01-16/2026-01-16-eng-backend.mbox":     "mbox",
+	})
+
+	for _, passphrase := range []string{"", "correct horse"} {
+		var pack bytes.Buffer
+		manifest, err := Pack(context.Background(), &pack, src, "", RenderOptions{}, PackOptions{
+			From: "2026-01-15", To: "2026-01-16", Passphrase: passphrase,
+		})
+		if err != nil {
+			t.Fatalf("Pack() error = %v", err)
+		}
+		if len(manifest.Files) != 5 {
+			t.Errorf("packed %d files, want 5: %+v", len(manifest.Files), manifest.Files)
+		}
+
+		dest := t.TempDir()
+		report, err := Unpack(bytes.NewReader(pack.Bytes()), dest, UnpackOptions{
+			Passphrase: func() (string, error) { return passphrase, nil },
+		})
+		if err != nil {
+			t.Fatalf("Unpack() error = %v", err)
+		}
+		if len(report.Added) != 5 {
+			t.Errorf("Added = %v, want 5 files", report.Added)
+		}
+		data, err := os.ReadFile(filepath.Join(dest, "2026-01-15", "2026-01-15-eng-backend-part2.md"))
+		if err != nil || string(data) != "backend 2" {
+			t.Errorf("unpacked part file = %q, %v", data, err)
+		}
+		if _, err := os.Stat(filepath.Join(dest, "2026-01-14")); !os.IsNotExist(err) {
+			t.Errorf("date outside the range was unpacked")
+		}
+	}
+}
+
+func TestPack_ChannelSelection(t *testing.T) {
+	src := t.TempDir()
+	writePackFixture(t, src, map[string]string{
+		"2026-01-15/2026-01-15-general.md":              "general",
+		"2026-01-15/2026-01-15-eng-backend-part2.md":    "backend",
+		"2026-01-15/2026-01-15-eng-backend-part2-fr.md": "backend fr",
+		"2026-01-15/2026-01-15-general-fr.md":           "general fr",
+		"2026-01-15/eng-frontend-2026-01-15.mbox":       "frontend",
+		"2026-01-15/index.md":                           "index",
+		"2026-01-15/manifest.json":                      "{}",
+	})
+	translator := NewTranslator(config.Translation{Command: "cat", Language: "fr"})
+	var pack bytes.Buffer
+	manifest, err := Pack(context.Background(), &pack, src, "", RenderOptions{Translator: translator}, PackOptions{
+		From: "2026-01-15", To: "2026-01-15", Channels: []string{"eng-*"},
+	})
+	if err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	var got []string
+	for _, file := range manifest.Files {
+		got = append(got, file.Path)
+	}
+	want := []string{
+		"2026-01-15/2026-01-15-eng-backend-part2-fr.md",
+		"2026-01-15/2026-01-15-eng-backend-part2.md",
+		"2026-01-15/eng-frontend-2026-01-15.mbox",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("packed %v, want %v", got, want)
+	}
+}
+
+func TestUnpack_ConflictsAndOverwrite(t *testing.T) {
+	src := t.TempDir()
+	writePackFixture(t, src, map[string]string{
+		"2026-01-15/2026-01-15-general.md": "packed",
+		"2026-01-15/2026-01-15-random.md":  "same",
+	})
+	var pack bytes.Buffer
+	if _, err := Pack(context.Background(), &pack, src, "", RenderOptions{}, PackOptions{From: "2026-01-15", To: "2026-01-15"}); err != nil {
+		t.Fatal(err)
+	}
+	dest := t.TempDir()
+	writePackFixture(t, dest, map[string]string{
+		"2026-01-15/2026-01-15-general.md": "local",
+		"2026-01-15/2026-01-15-random.md":  "same",
+	})
+
+	report, err := Unpack(bytes.NewReader(pack.Bytes()), dest, UnpackOptions{})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if len(report.Conflicts) != 1 || len(report.Unchanged) != 1 {
+		t.Errorf("report = %+v, want one conflict and one unchanged", report)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "2026-01-15", "2026-01-15-general.md")); string(data) != "local" {
+		t.Errorf("conflicting file was replaced without --overwrite: %q", data)
+	}
+
+	report, err = Unpack(bytes.NewReader(pack.Bytes()), dest, UnpackOptions{Overwrite: true})
+	if err != nil {
+		t.Fatalf("Unpack(overwrite) error = %v", err)
+	}
+	if len(report.Replaced) != 1 {
+		t.Errorf("Replaced = %v, want the conflicting file", report.Replaced)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "2026-01-15", "2026-01-15-general.md")); string(data) != "packed" {
+		t.Errorf("file after --overwrite = %q, want the packed copy", data)
+	}
+	entries, _ := os.ReadDir(dest)
+	if len(entries) != 1 {
+		t.Errorf("output dir holds %d entries, want only the date folder (staging left behind?)", len(entries))
+	}
+}
+
+func TestUnpack_RejectsDamagedOrWrongPassphrase(t *testing.T) {
+	src := t.TempDir()
+	writePackFixture(t, src, map[string]string{"2026-01-15/2026-01-15-general.md": "general"})
+	var pack bytes.Buffer
+	_, err := Pack(context.Background(), &pack, src, "", RenderOptions{}, PackOptions{
+		From: "2026-01-15", To: "2026-01-15", Passphrase: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Unpack(bytes.NewReader(pack.Bytes()), t.TempDir(), UnpackOptions{
+		Passphrase: func() (string, error) { return "guess", nil },
+	})
+	if !errors.Is(err, ErrPackPassphrase) {
+		t.Errorf("Unpack(wrong passphrase) error = %v, want ErrPackPassphrase", err)
+	}
+
+	truncated := pack.Bytes()[:pack.Len()-10]
+	dest := t.TempDir()
+	_, err = Unpack(bytes.NewReader(truncated), dest, UnpackOptions{
+		Passphrase: func() (string, error) { return "secret", nil },
+	})
+	if err == nil {
+		t.Error("Unpack() accepted a truncated pack")
+	}
+	if _, statErr := os.Stat(filepath.Join(dest, "2026-01-15")); !os.IsNotExist(statErr) {
+		t.Error("a damaged pack was partly merged")
+	}
+}
+
+func TestPack_RejectsUsersWithAnonymize(t *testing.T) {
+	_, err := Pack(context.Background(), &bytes.Buffer{}, t.TempDir(), "", RenderOptions{}, PackOptions{
+		From: "2026-01-15", To: "2026-01-15", Users: true, Anonymize: true,
+	})
+	if err == nil {
+		t.Error("Pack() allowed a user snapshot in an anonymized pack")
+	}
+}
+
+func TestValidatePackPath(t *testing.T) {
+	for name, wantErr := range map[string]bool{
+		"2026-01-15/2026-01-15-general.md": false,
+		"2026-01-15/attachments/a.png":     false,
+		"2026-01-15/../../etc/passwd":      true,
+		"/2026-01-15/x.md":                 true,
+		"notes/x.md":                       true,
+		"2026-01-15":                       true,
+		"2026-01-15/./x.md":                true,
+	} {
+		if err := validatePackPath(name); (err != nil) != wantErr {
+			t.Errorf("validatePackPath(%q) error = %v, wantErr %v", name, err, wantErr)
+		}
+	}
+}
+
+func TestPackEncrypter_RecordBoundaries(t *testing.T) {
+	for _, size := range []int{0, packChunkSize, 2*packChunkSize + 17} {
+		plain := bytes.Repeat([]byte("slack"), size/5+1)[:size]
+		var sealed bytes.Buffer
+		enc, err := newPackEncrypter(&sealed, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := enc.Write(plain); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		dec, err := newPackDecrypter(&sealed, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(dec)
+		if err != nil {
+			t.Fatalf("size %d: decrypt error = %v", size, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("size %d: round trip returned %d bytes", size, len(got))
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package export
+
+import rslack "github.com/rusq/slack"
+
+// participated reports whether userID wrote a message rendered for date:
+// one of the day's top-level messages or a reply posted that day in a
+// thread the render loaded. An empty userID always participates.
+func participated(userID string, messages []rslack.Message, threads threadMessageCache, date, timezone string) bool {
+	if userID == "" {
+		return true
+	}
+	if authoredOn(userID, messages, date, timezone) {
+		return true
+	}
+	for _, thread := range threads {
+		if authoredOn(userID, thread, date, timezone) {
+			return true
+		}
+	}
+	return false
+}
+
+func authoredOn(userID string, messages []rslack.Message, date, timezone string) bool {
+	for _, msg := range messages {
+		if msg.User == userID && messageBelongsToDate(msg, date, timezone) {
+			return true
+		}
+	}
+	return false
+}
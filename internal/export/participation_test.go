@@ -0,0 +1,40 @@
+package export
+
+import (
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func authoredMessage(user, ts string) rslack.Message {
+	return rslack.Message{Msg: rslack.Msg{User: user, Timestamp: ts}}
+}
+
+func TestParticipated(t *testing.T) {
+	// 2026-01-15 12:00 UTC and 2026-01-16 12:00 UTC.
+	const day1, day2 = "1768478400.000100", "1768564800.000100"
+	messages := []rslack.Message{authoredMessage("U2", day1), authoredMessage("U1", day2)}
+	threads := threadMessageCache{"1768478400.000100": {authoredMessage("U2", day1), authoredMessage("U1", day2)}}
+
+	tests := []struct {
+		name     string
+		userID   string
+		messages []rslack.Message
+		threads  threadMessageCache
+		date     string
+		want     bool
+	}{
+		{"disabled", "", messages, nil, "2026-01-15", true},
+		{"top-level message", "U1", messages, nil, "2026-01-16", true},
+		{"only others posted", "U1", messages, nil, "2026-01-15", false},
+		{"reply that day", "U1", messages[:1], threads, "2026-01-16", true},
+		{"reply another day", "U1", messages[:1], threads, "2026-01-15", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := participated(tt.userID, tt.messages, tt.threads, tt.date, "UTC"); got != tt.want {
+				t.Errorf("participated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// prioritizeChannels orders channels so the ones needing attention are
+// fetched and rendered first and are on disk if a run is interrupted:
+// channels with mentions (most first), then unread ones, then starred ones.
+// Ties keep their original order.
+func prioritizeChannels(chans []slack.Channel) []slack.Channel {
+	ordered := append([]slack.Channel(nil), chans...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if ra, rb := attentionRank(a), attentionRank(b); ra != rb {
+			return ra < rb
+		}
+		return a.MentionCount > b.MentionCount
+	})
+	return ordered
+}
+
+func attentionRank(ch slack.Channel) int {
+	switch {
+	case ch.MentionCount > 0:
+		return 0
+	case ch.HasUnreads:
+		return 1
+	case ch.IsStarred:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// orderRenderTargets sorts render targets to follow the channel order of ids,
+// keeping each channel's dates in order. Channels not in ids go last.
+func orderRenderTargets(targets []renderTarget, ids []string) []renderTarget {
+	position := make(map[string]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	rank := func(id string) int {
+		if i, ok := position[id]; ok {
+			return i
+		}
+		return len(ids)
+	}
+	ordered := append([]renderTarget(nil), targets...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i].channelID) < rank(ordered[j].channelID)
+	})
+	return ordered
+}
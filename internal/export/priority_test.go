@@ -0,0 +1,66 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestPrioritizeChannels(t *testing.T) {
+	chans := []slack.Channel{
+		{ID: "C1", Name: "quiet"},
+		{ID: "C2", Name: "starred", IsStarred: true},
+		{ID: "C3", Name: "unread", HasUnreads: true},
+		{ID: "D4", Name: "dm_alice", MentionCount: 1, HasUnreads: true},
+		{ID: "C5", Name: "also-quiet"},
+		{ID: "C6", Name: "busy", MentionCount: 4, HasUnreads: true},
+	}
+
+	got := channelIDs(prioritizeChannels(chans))
+	want := []string{"C6", "D4", "C3", "C2", "C1", "C5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("prioritizeChannels() order = %v, want %v", got, want)
+	}
+	if chans[0].ID != "C1" {
+		t.Error("prioritizeChannels() should not reorder its input")
+	}
+}
+
+func TestOrderRenderTargets(t *testing.T) {
+	targets := []renderTarget{
+		{channelID: "C1", date: "2026-01-14"},
+		{channelID: "C2", date: "2026-01-14"},
+		{channelID: "C1", date: "2026-01-15"},
+		{channelID: "C9", date: "2026-01-15"},
+		{channelID: "C2", date: "2026-01-15"},
+	}
+	got := orderRenderTargets(targets, []string{"C2", "C1"})
+	want := []renderTarget{
+		{channelID: "C2", date: "2026-01-14"},
+		{channelID: "C2", date: "2026-01-15"},
+		{channelID: "C1", date: "2026-01-14"},
+		{channelID: "C1", date: "2026-01-15"},
+		{channelID: "C9", date: "2026-01-15"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orderRenderTargets() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFilterRenderChannels_FollowsIDOrder(t *testing.T) {
+	archived := []rslack.Channel{
+		{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C1"}}},
+		{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C2"}}},
+		{GroupConversation: rslack.GroupConversation{Conversation: rslack.Conversation{ID: "C3"}}},
+	}
+	var got []string
+	for _, ch := range filterRenderChannels(archived, []string{"C3", "C1", "C4"}) {
+		got = append(got, ch.ID)
+	}
+	if want := []string{"C3", "C1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterRenderChannels() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress is a point-in-time view of a running export.
+type Progress struct {
+	Started time.Time
+	// Stage is the latest stage message and Channel the channel being
+	// rendered, empty between channels.
+	Stage   string
+	Channel string
+	// LastDate is the newest date rendered so far.
+	LastDate     string
+	ChannelsDone int
+	FilesWritten int
+	Warnings     int
+}
+
+// ProgressTracker records events into a Progress that other goroutines can
+// read while the export runs, forwarding every event to the wrapped Events.
+type ProgressTracker struct {
+	next Events
+
+	mu       sync.Mutex
+	progress Progress
+}
+
+// NewProgressTracker wraps next, which receives every event unchanged.
+func NewProgressTracker(next Events, started time.Time) *ProgressTracker {
+	return &ProgressTracker{next: next, progress: Progress{Started: started}}
+}
+
+// Snapshot returns the current progress.
+func (t *ProgressTracker) Snapshot() Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.progress
+}
+
+func (t *ProgressTracker) OnStage(message string) {
+	t.update(func(p *Progress) { p.Stage = message })
+	t.next.OnStage(message)
+}
+
+func (t *ProgressTracker) OnChannelStart(ch ChannelProgress) {
+	t.update(func(p *Progress) { p.Channel = ch.Name })
+	t.next.OnChannelStart(ch)
+}
+
+func (t *ProgressTracker) OnChannelDone(ch ChannelProgress) {
+	t.update(func(p *Progress) {
+		p.Channel = ""
+		p.ChannelsDone++
+		p.FilesWritten += ch.Files
+		for _, date := range ch.Dates {
+			if date > p.LastDate {
+				p.LastDate = date
+			}
+		}
+	})
+	t.next.OnChannelDone(ch)
+}
+
+func (t *ProgressTracker) OnError(err error) {
+	t.update(func(p *Progress) { p.Warnings++ })
+	t.next.OnError(err)
+}
+
+func (t *ProgressTracker) update(apply func(*Progress)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	apply(&t.progress)
+}
+
+// FormatProgress renders p as a short multi-line status report.
+func FormatProgress(p Progress, now time.Time) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "slack-export status after %s\n", now.Sub(p.Started).Round(time.Second))
+	fmt.Fprintf(&out, "  Stage:    %s\n", valueOr(p.Stage, "(starting)"))
+	fmt.Fprintf(&out, "  Channel:  %s\n", valueOr(p.Channel, "(none)"))
+	fmt.Fprintf(&out, "  Date:     %s\n", valueOr(p.LastDate, "(none yet)"))
+	fmt.Fprintf(&out, "  Progress: %d channel(s) rendered, %d file(s) written, %d warning(s)\n",
+		p.ChannelsDone, p.FilesWritten, p.Warnings)
+	return out.String()
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
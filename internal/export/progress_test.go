@@ -0,0 +1,44 @@
+package export
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker(t *testing.T) {
+	started := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	next := &recordingEvents{}
+	tracker := NewProgressTracker(next, started)
+
+	tracker.OnStage("Refreshing archive")
+	tracker.OnChannelStart(ChannelProgress{ID: "C1", Name: "general"})
+	if got := tracker.Snapshot(); got.Channel != "general" || got.Stage != "Refreshing archive" {
+		t.Errorf("Snapshot() mid-channel = %+v", got)
+	}
+	tracker.OnChannelDone(ChannelProgress{ID: "C1", Name: "general", Files: 2, Dates: []string{"2026-01-14", "2026-01-15"}})
+	tracker.OnError(errors.New("canvas missing"))
+
+	got := tracker.Snapshot()
+	want := Progress{Started: started, Stage: "Refreshing archive", LastDate: "2026-01-15", ChannelsDone: 1, FilesWritten: 2, Warnings: 1}
+	if got != want {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+	if len(next.stages) != 1 || len(next.done) != 1 || len(next.errs) != 1 {
+		t.Errorf("events not forwarded: %+v", next)
+	}
+
+	report := FormatProgress(got, started.Add(90*time.Second))
+	for _, line := range []string{
+		"status after 1m30s",
+		"Stage:    Refreshing archive",
+		"Channel:  (none)",
+		"Date:     2026-01-15",
+		"1 channel(s) rendered, 2 file(s) written, 1 warning(s)",
+	} {
+		if !strings.Contains(report, line) {
+			t.Errorf("FormatProgress() missing %q:\n%s", line, report)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	rslack "github.com/rusq/slack"
+)
+
+// rawDir is the output subdirectory holding keep_raw copies of the archive.
+const rawDir = "raw"
+
+// rawUsersFile keeps the archive's user list beside the raw messages so
+// reprocess can name people.
+const rawUsersFile = "users.json"
+
+// writeChannelDateRaw writes the channel-day's messages as slackdump stores
+// them, top-level and replies in timestamp order, to raw/<date>/<channel>.json
+// so they can be reprocessed without the archive or Slack. Each message
+// carries its channel ID for reprocess.
+func writeChannelDateRaw(outputDir string, req RenderRequest, ch channelDates, threads threadMessageCache, opts RenderOptions) (bool, error) {
+	messages := dayMessages(ch.messages, threads, req.Date, req.Timezone)
+	for i := range messages {
+		messages[i].Channel = ch.id
+	}
+	data, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	path := filepath.Join(outputDir, rawDir, req.Date, ch.name+".json")
+	return writeFileIfChanged(path, append(data, '\n'), opts.VerifyWrites)
+}
+
+// writeRawUsers saves the archive's users to raw/users.json, sorted by ID.
+func writeRawUsers(outputDir string, users userLookup, opts RenderOptions) error {
+	if !opts.KeepRaw || opts.Anonymize {
+		return nil
+	}
+	list := make([]rslack.User, 0, len(users))
+	for _, user := range users {
+		list = append(list, user)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writeFileIfChanged(filepath.Join(outputDir, rawDir, rawUsersFile), append(data, '\n'), opts.VerifyWrites)
+	return err
+}
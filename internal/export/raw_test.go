@@ -0,0 +1,62 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestRenderSourceRange_KeepRawWritesDayMessages(t *testing.T) {
+	// 2026-01-15 14:00 UTC, a reply at 14:05 and a message on the next day.
+	parent := rslack.Message{Msg: rslack.Msg{
+		User: "U1", Text: "Release today?", Timestamp: "1768485600.000100",
+		ThreadTimestamp: "1768485600.000100", ReplyCount: 1,
+	}}
+	reply := rslack.Message{Msg: rslack.Msg{
+		User: "U2", Text: "Yes, at 3pm", Timestamp: "1768485900.000200", ThreadTimestamp: "1768485600.000100",
+	}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users: []rslack.User{{ID: "U1", Name: "alice"}, {ID: "U2", Name: "bob"}},
+		messages: map[string][]rslack.Message{"C123": {
+			parent,
+			{Msg: rslack.Msg{User: "U2", Text: "next day", Timestamp: "1768572000.000300"}},
+		}},
+		threads: map[string][]rslack.Message{"C123:1768485600.000100": {parent, reply}},
+	}
+	outputDir := t.TempDir()
+
+	_, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-15",
+		RenderOptions{Timezone: "UTC", KeepRaw: true}, nil, nil)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "raw", "2026-01-15", "engineering.json"))
+	if err != nil {
+		t.Fatalf("reading raw messages: %v", err)
+	}
+	var got []rslack.Message
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("raw messages are not JSON: %v", err)
+	}
+	if len(got) != 2 || got[0].Timestamp != parent.Timestamp || got[1].Timestamp != reply.Timestamp {
+		t.Errorf("raw messages = %+v, want the parent and its reply", got)
+	}
+
+	anonDir := t.TempDir()
+	_, err = renderSourceRange(context.Background(), src, anonDir, "2026-01-15", "2026-01-15",
+		RenderOptions{Timezone: "UTC", KeepRaw: true, Anonymize: true}, nil, nil)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(anonDir, "raw")); !os.IsNotExist(err) {
+		t.Errorf("anonymized render should not keep raw messages, stat err = %v", err)
+	}
+}
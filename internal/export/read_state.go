@@ -0,0 +1,85 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// ReadState records how far you had read each channel at one moment.
+type ReadState struct {
+	CapturedAt time.Time `json:"captured_at"`
+	// Channels maps channel file names to their read position.
+	Channels map[string]ChannelReadState `json:"channels"`
+}
+
+// ChannelReadState is your read position in one channel.
+type ChannelReadState struct {
+	// LastRead is the Slack timestamp of the last message you had read,
+	// and LastReadAt the same moment in the configured timezone.
+	LastRead   string `json:"last_read"`
+	LastReadAt string `json:"last_read_at,omitempty"`
+	// Unread is true when newer messages were waiting.
+	Unread bool `json:"unread,omitempty"`
+}
+
+// recordReadState saves your last_read in each tracked channel to the
+// manifest of the sync's work date when read_state is enabled. Channels
+// you never opened have no read position and are left out. Failures only
+// warn.
+func (e *Exporter) recordReadState(ctx context.Context, tracked []slack.Channel, now time.Time) {
+	if !e.cfg.ReadState {
+		return
+	}
+	counts, err := e.edgeClient.ClientCounts(ctx)
+	if err != nil {
+		e.warnf("failed to collect read state: %v", err)
+		return
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		e.warnf("failed to record read state: %v", err)
+		return
+	}
+	now = now.In(loc)
+	state := readState(counts, tracked, e.cfg.ChannelAliasMap(), now)
+	date := workDate(now)
+	for _, dir := range e.cfg.OutputDirs() {
+		err := updateDateManifest(dir, date, e.cfg.Layout, func(m *DateManifest) {
+			m.ReadState = state
+		})
+		if err != nil {
+			e.warnf("failed to record read state: %v", err)
+		}
+	}
+}
+
+// readState picks the tracked channels' read positions out of counts,
+// naming each channel as its files are named.
+func readState(counts *slack.CountsResponse, tracked []slack.Channel, aliases map[string]string, now time.Time) *ReadState {
+	snapshots := make(map[string]slack.ChannelSnapshot)
+	for _, group := range [][]slack.ChannelSnapshot{counts.Channels, counts.MPIMs, counts.IMs} {
+		for _, snapshot := range group {
+			snapshots[snapshot.ID] = snapshot
+		}
+	}
+	state := &ReadState{CapturedAt: now, Channels: make(map[string]ChannelReadState)}
+	for _, ch := range tracked {
+		snapshot, ok := snapshots[ch.ID]
+		if !ok || snapshot.LastRead == "" || snapshot.LastRead == "0000000000.000000" {
+			continue
+		}
+		name := ch.Name
+		if alias := aliases[strings.ToUpper(ch.ID)]; alias != "" {
+			name = alias
+		}
+		read := ChannelReadState{LastRead: snapshot.LastRead, Unread: snapshot.HasUnreads}
+		if ts, err := parseSlackTimestamp(snapshot.LastRead); err == nil {
+			read.LastReadAt = ts.In(now.Location()).Format(time.RFC3339)
+		}
+		state.Channels[name] = read
+	}
+	return state
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestReadState_TrackedChannelsByFileName(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 7, 3, 18, 0, 0, 0, loc)
+	counts := &slack.CountsResponse{
+		Channels: []slack.ChannelSnapshot{
+			{ID: "C1", LastRead: "1783094460.000100", HasUnreads: true},
+			{ID: "C2", LastRead: "1783000000.000000"},
+			{ID: "C9", LastRead: "1783000000.000000"},
+		},
+		IMs: []slack.ChannelSnapshot{{ID: "D1", LastRead: "0000000000.000000"}},
+	}
+	tracked := []slack.Channel{{ID: "C1", Name: "general"}, {ID: "C2", Name: "old-name"}, {ID: "D1", Name: "dm_alice"}}
+
+	state := readState(counts, tracked, map[string]string{"C2": "pinned"}, now)
+	if !state.CapturedAt.Equal(now) {
+		t.Errorf("CapturedAt = %v, want %v", state.CapturedAt, now)
+	}
+	want := map[string]ChannelReadState{
+		"general": {LastRead: "1783094460.000100", LastReadAt: "2026-07-03T12:01:00-04:00", Unread: true},
+		"pinned":  {LastRead: "1783000000.000000", LastReadAt: "2026-07-02T09:46:40-04:00"},
+	}
+	if len(state.Channels) != len(want) {
+		t.Fatalf("Channels = %+v, want %+v", state.Channels, want)
+	}
+	for name, read := range want {
+		if state.Channels[name] != read {
+			t.Errorf("Channels[%s] = %+v, want %+v", name, state.Channels[name], read)
+		}
+	}
+}
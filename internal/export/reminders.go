@@ -0,0 +1,115 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// recordReminders writes the user's pending reminders and scheduled
+// messages to each output directory's <date>/reminders.md when reminders
+// is enabled. The file is a snapshot replaced by every sync of the day.
+// Failures only warn.
+func (e *Exporter) recordReminders(ctx context.Context, archiveDir string, now time.Time) {
+	if !e.cfg.Reminders {
+		return
+	}
+	reminders, err := e.edgeClient.ListReminders(ctx)
+	if err != nil {
+		e.warnf("failed to list reminders: %v", err)
+		return
+	}
+	scheduled, err := e.edgeClient.ListScheduledMessages(ctx)
+	if err != nil {
+		e.warnf("failed to list scheduled messages: %v", err)
+		return
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		e.warnf("failed to record reminders: %v", err)
+		return
+	}
+	names, err := loadChannelNames(archiveDir)
+	if err != nil {
+		e.warnf("failed to load channel names for scheduled messages: %v", err)
+	}
+	now = now.In(loc)
+	date := workDate(now)
+	content := remindersMarkdown(date, reminders, scheduled, names, loc)
+	for _, dir := range e.cfg.OutputDirs() {
+		path := filepath.Join(dateDir(dir, date, e.cfg.Layout), dayFileName(date, e.cfg.Layout, "reminders.md"))
+		if _, err := writeFileIfChanged(path, []byte(content), e.cfg.VerifyWrites); err != nil {
+			e.warnf("failed to write reminders: %v", err)
+		}
+	}
+}
+
+// workDate is the date now belongs to with the 3am day boundary used for
+// message exports.
+func workDate(now time.Time) string {
+	if now.Hour() < 3 {
+		now = now.AddDate(0, 0, -1)
+	}
+	return now.Format("2006-01-02")
+}
+
+// remindersMarkdown lists pending reminders and scheduled messages, soonest
+// first. Recurring reminders, which have no single due time, come last.
+func remindersMarkdown(
+	date string,
+	reminders []slack.Reminder,
+	scheduled []slack.ScheduledMessage,
+	channelNames map[string]string,
+	loc *time.Location,
+) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Reminders and scheduled messages %s\n\n", date)
+
+	var pending []slack.Reminder
+	for _, reminder := range reminders {
+		if reminder.CompleteTS == 0 {
+			pending = append(pending, reminder)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].Recurring != pending[j].Recurring {
+			return !pending[i].Recurring
+		}
+		return pending[i].Time < pending[j].Time
+	})
+	b.WriteString("## Reminders\n\n")
+	if len(pending) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, reminder := range pending {
+		when := "recurring"
+		if !reminder.Recurring {
+			when = time.Unix(reminder.Time, 0).In(loc).Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", when, singleLine(reminder.Text))
+	}
+
+	sort.SliceStable(scheduled, func(i, j int) bool { return scheduled[i].PostAt < scheduled[j].PostAt })
+	b.WriteString("\n## Scheduled messages\n\n")
+	if len(scheduled) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, msg := range scheduled {
+		channel := msg.ChannelID
+		if name := channelNames[msg.ChannelID]; name != "" {
+			channel = name
+		}
+		fmt.Fprintf(&b, "- %s in %s: %s\n",
+			time.Unix(msg.PostAt, 0).In(loc).Format("2006-01-02 15:04"), channel, singleLine(msg.Text))
+	}
+	return b.String()
+}
+
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestRemindersMarkdown(t *testing.T) {
+	// 2026-01-15 09:00 and 10:00 UTC.
+	const nine, ten = 1768467600, 1768471200
+	reminders := []slack.Reminder{
+		{Text: "Standup notes", Recurring: true},
+		{Text: "Follow up\nwith legal", Time: ten},
+		{Text: "Review PR", Time: nine},
+		{Text: "Already done", Time: nine, CompleteTS: nine},
+	}
+	scheduled := []slack.ScheduledMessage{
+		{ChannelID: "C2", PostAt: ten, Text: "Unknown channel"},
+		{ChannelID: "C1", PostAt: nine, Text: "Good morning"},
+	}
+
+	got := remindersMarkdown("2026-01-15", reminders, scheduled, map[string]string{"C1": "general"}, time.UTC)
+	want := `# Reminders and scheduled messages 2026-01-15
+
+## Reminders
+
+- 2026-01-15 09:00: Review PR
+- 2026-01-15 10:00: Follow up with legal
+- recurring: Standup notes
+
+## Scheduled messages
+
+- 2026-01-15 09:00 in general: Good morning
+- 2026-01-15 10:00 in C2: Unknown channel
+`
+	if got != want {
+		t.Errorf("remindersMarkdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRemindersMarkdown_Empty(t *testing.T) {
+	got := remindersMarkdown("2026-01-15", nil, nil, nil, time.UTC)
+	if strings.Count(got, "(none)") != 2 {
+		t.Errorf("remindersMarkdown() = %q, want both sections empty", got)
+	}
+}
+
+func TestWorkDate(t *testing.T) {
+	if got := workDate(time.Date(2026, 1, 16, 2, 59, 0, 0, time.UTC)); got != "2026-01-15" {
+		t.Errorf("workDate(02:59) = %s, want previous day", got)
+	}
+	if got := workDate(time.Date(2026, 1, 16, 3, 0, 0, 0, time.UTC)); got != "2026-01-16" {
+		t.Errorf("workDate(03:00) = %s", got)
+	}
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisedwards/slack-export/internal/tracing"
+	rslack "github.com/rusq/slack"
+)
+
+// channelDates is one channel's loaded messages and the dates to render.
+type channelDates struct {
+	id       string
+	name     string
+	kind     string
+	dates    []string
+	messages []rslack.Message
+}
+
+// renderChannelDates renders and writes each date for one channel, reporting
+// channel start and completion to opts.events.
+func renderChannelDates(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	outputDir string,
+	ch channelDates,
+	opts RenderOptions,
+	lookup renderLookup,
+) (writes int, err error) {
+	ctx, span := tracing.Start(ctx, "render channel",
+		tracing.String("slack_export.channel.id", ch.id),
+		tracing.String("slack_export.channel.name", ch.name),
+		tracing.Int("slack_export.dates", len(ch.dates)))
+	defer func() {
+		span.SetAttributes(tracing.Int("slack_export.files_written", writes))
+		span.End(err)
+	}()
+	opts.channelStarted(ch.id, ch.name)
+	threads := make(threadMessageCache)
+	timezone := opts.timezoneFor(ch.id, ch.name)
+	messages := 0
+	for _, date := range ch.dates {
+		req := RenderRequest{
+			Date:        date,
+			Timezone:    timezone,
+			ChannelID:   ch.id,
+			ChannelName: ch.name,
+		}
+		units, err := renderChannelDateUnits(ctx, src, req, lookup, ch.messages, threads)
+		if err != nil {
+			return writes, fmt.Errorf("rendering %s %s: %w", date, ch.id, err)
+		}
+		opts.MessageFilter.record(outputDir, date, ch.id, ch.name, timezone)
+		if len(units) == 0 || !participated(opts.ParticipantID, ch.messages, threads, date, timezone) {
+			continue
+		}
+		written, err := writeChannelDate(ctx, outputDir, date, ch.name, units, opts)
+		if err != nil {
+			return writes, err
+		}
+		if opts.Mbox {
+			mboxWritten, err := writeChannelDateMbox(outputDir, req, ch, threads, lookup.forChannel(req), opts)
+			if err != nil {
+				return writes, fmt.Errorf("writing mbox for %s %s: %w", date, ch.name, err)
+			}
+			writes += boolCount(mboxWritten)
+		}
+		if opts.Avatars != nil && !opts.Anonymize {
+			opts.Avatars.record(outputDir, dayMessages(ch.messages, threads, date, timezone), lookup.users)
+		}
+		if opts.KeepRaw && !opts.Anonymize {
+			rawWritten, err := writeChannelDateRaw(outputDir, req, ch, threads, opts)
+			if err != nil {
+				return writes, fmt.Errorf("writing raw messages for %s %s: %w", date, ch.name, err)
+			}
+			writes += boolCount(rawWritten)
+		}
+		opts.Index.record(outputDir, date, ch.name, ch.kind, units, opts)
+		_, filtered := opts.MessageFilter.droppedOn(ch.id, date, timezone)
+		opts.written.record(outputDir, ch, date, timezone, filtered)
+		opts.timezones.record(outputDir, date, ch.name, timezone)
+		opts.checksums.record(outputDir, date)
+		writes += written
+		messages += unitMessages(units)
+	}
+	opts.channelDone(ch.id, ch.name, writes, messages, ch.dates)
+	return writes, nil
+}
+
+// forChannel specializes the lookup for one channel-day: timestamps in the
+// channel's timezone and the channel's message template.
+func (l renderLookup) forChannel(req RenderRequest) renderLookup {
+	l.times = l.times.in(req.Timezone)
+	if tmpl, ok := matchChannelOverride(l.templates, req.ChannelID, req.ChannelName); ok {
+		l.template = channelTemplate{tmpl: tmpl, channel: req.ChannelName, date: req.Date}
+	}
+	return l
+}
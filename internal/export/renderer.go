@@ -3,6 +3,7 @@ package export
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"html"
 	"iter"
@@ -12,8 +13,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/chrisedwards/slack-export/pkg/slackts"
 	rslack "github.com/rusq/slack"
 	"github.com/rusq/slackdump/v4/source"
 )
@@ -38,6 +43,168 @@ type RenderRequest struct {
 	ChannelName string
 }
 
+// RenderOptions controls how archive rows are rendered to markdown files.
+type RenderOptions struct {
+	// Timezone is the default IANA zone for work-day boundaries.
+	Timezone string
+	// ChannelTimezones maps channel name/ID glob patterns to zones that
+	// override Timezone for matching channels.
+	ChannelTimezones map[string]string
+	// ChannelAliases maps upper-case channel IDs to the names used for
+	// their files in place of the Slack name.
+	ChannelAliases map[string]string
+	// MaxFileSize and MaxMessagesPerFile split a channel-day into part files
+	// when exceeded. Zero disables the corresponding limit.
+	MaxFileSize        int64
+	MaxMessagesPerFile int
+	// FilenameDate places the date in channel file names: prefix (default),
+	// suffix, or none.
+	FilenameDate string
+	// Layout groups date folders by day (default), ISO week, or month.
+	Layout string
+	// Scope limits the run to direct messages or to channels.
+	Scope ChannelScope
+	// Usergroups maps user group (subteam) IDs to handles for rendering
+	// <!subteam^ID> mentions as @handle.
+	Usergroups map[string]string
+	// TrackChanges appends edits and deletions found when re-rendering an
+	// existing channel-day to that date's changes.log.
+	TrackChanges bool
+	// Canvases supplies content embedded below messages that link a Slack
+	// canvas or post. Nil leaves links as plain URLs.
+	Canvases CanvasSource
+	// RenderBlocks renders rich_text blocks as markdown in place of the
+	// plain message text.
+	RenderBlocks bool
+	// IncludeUnfurls quotes link previews (attachment title, text, and
+	// footer) under the message text.
+	IncludeUnfurls bool
+	// Accounting tracks bytes rendered per day and enforces
+	// max_daily_output_size. Nil disables size accounting.
+	Accounting *OutputAccounting
+	// Anonymize replaces people with stable pseudonyms and scrubs email
+	// addresses and phone numbers. The mapping is kept in the archive.
+	Anonymize bool
+	// TimeLayout is the Go layout for message timestamps and TimeLocale the
+	// language of their month and weekday names. LocalTimestamps shows them
+	// in the channel's timezone instead of UTC.
+	TimeLayout      string
+	TimeLocale      string
+	LocalTimestamps bool
+	// Templates maps channel patterns to message templates; see
+	// config.Config.Templates.
+	Templates map[string]string
+	// VerifyWrites re-reads every written file and compares checksums.
+	VerifyWrites bool
+	// ThreadLookbackDays marks thread continuations with how many days
+	// earlier the thread started. Zero keeps the plain heading.
+	ThreadLookbackDays int
+	// ParticipantID, when set, skips channel-days with no message from
+	// this user (participated_only).
+	ParticipantID string
+	// Translator writes translated copies of written files. Nil disables
+	// translation.
+	Translator *Translator
+	// Index keeps an index.md in each date folder written. Nil disables it.
+	Index *DateIndex
+	// Mbox also writes each channel-day as <date>-<channel>.mbox for mail
+	// clients and e-discovery tools.
+	Mbox bool
+	// KeepRaw also writes each channel-day's archived messages as JSON under
+	// raw/<date>/. It is ignored when anonymizing.
+	KeepRaw bool
+	// Avatars downloads the photos of people in written channel-days into
+	// avatars/. Nil disables it, and anonymized renders skip it.
+	Avatars *Avatars
+	// MessageFilter drops messages by their text before rendering. Nil
+	// keeps every message.
+	MessageFilter *MessageFilter
+	// Dedup drops messages the archive holds more than once. Nil keeps
+	// duplicates.
+	Dedup *Deduplicator
+	// OnChannelError decides what happens when a channel fails to render.
+	// Nil ends the render with the channel's error.
+	OnChannelError ChannelErrorHandler
+	// NameStyle chooses the names shown for senders and mentions.
+	NameStyle slack.NameStyle
+	// Users names people missing from the archive's user list. Nil leaves
+	// them as <unknown>:ID.
+	Users UserSource
+	// DeactivatedLabel marks deactivated users' names; see
+	// config.Config.DeactivatedLabel.
+	DeactivatedLabel string
+	// Chronological lists a day's replies among the top-level messages in
+	// timestamp order instead of nesting them under their parents.
+	Chronological bool
+
+	pseudonyms *pseudonymMap
+	events     Events
+	written    *writtenCounts
+	timezones  *renderedTimezones
+	checksums  *dateChecksums
+	skipped    *skippedChannels
+}
+
+// RenderOptionsFromConfig builds render options from the loaded configuration
+// and the cached usergroup index and canvases.
+func RenderOptionsFromConfig(cfg *config.Config) RenderOptions {
+	return renderOptionsFromConfig(cfg, nil)
+}
+
+// renderOptionsFromConfig is RenderOptionsFromConfig reporting progress and
+// warnings to events, or to the console when it is nil.
+func renderOptionsFromConfig(cfg *config.Config, events Events) RenderOptions {
+	// Validate has already rejected malformed sizes; treat them as unlimited here.
+	maxFileSize, _ := config.ParseByteSize(cfg.MaxFileSize)
+	maxDailyOutput, _ := config.ParseByteSize(cfg.MaxDailyOutputSize)
+	timeLayout, _ := config.ParseTimeLayout(cfg.TimeFormat, cfg.TimeClock)
+	messageFilter, _ := NewMessageFilter(cfg.MessageInclude, cfg.MessageExclude)
+	opts := RenderOptions{
+		Timezone:           cfg.Timezone,
+		ChannelTimezones:   cfg.ChannelTimezones,
+		ChannelAliases:     cfg.ChannelAliasMap(),
+		MaxFileSize:        maxFileSize,
+		MaxMessagesPerFile: cfg.MaxMessagesPerFile,
+		FilenameDate:       cfg.FilenameDate,
+		Layout:             cfg.Layout,
+		TrackChanges:       cfg.TrackChanges,
+		Canvases:           cachedCanvasSource(cfg),
+		Accounting:         NewOutputAccounting(maxDailyOutput, cfg.OutputSizeAction),
+		Anonymize:          cfg.Anonymize,
+		RenderBlocks:       cfg.RenderBlocks,
+		IncludeUnfurls:     cfg.IncludeUnfurls,
+		TimeLayout:         timeLayout,
+		TimeLocale:         cfg.TimeLocale,
+		LocalTimestamps:    cfg.CustomTimestamps(),
+		Templates:          cfg.Templates,
+		VerifyWrites:       cfg.VerifyWrites,
+		ThreadLookbackDays: cfg.ThreadLookbackDays,
+		Translator:         NewTranslator(cfg.Translation),
+		Index:              dateIndexFromConfig(cfg),
+		Mbox:               cfg.Mbox,
+		KeepRaw:            cfg.KeepRaw,
+		Avatars:            avatarsFromConfig(cfg),
+		MessageFilter:      messageFilter,
+		Dedup:              NewDeduplicator(),
+		NameStyle:          ConfiguredNameStyle(cfg),
+		Users:              cachedUserSource(),
+		DeactivatedLabel:   cfg.DeactivatedLabel,
+		Chronological:      cfg.Sort == "chronological",
+		checksums:          checksumsFromConfig(cfg),
+		timezones:          newRenderedTimezones(cfg.Timezone),
+		events:             events,
+	}
+	opts.Usergroups = loadUsergroupHandles(slack.DefaultUsergroupCachePath(), opts.warn)
+	return opts
+}
+
+// ConfiguredNameStyle returns the configured name_style. Validate has
+// already rejected malformed styles; they fall back to the defaults here.
+func ConfiguredNameStyle(cfg *config.Config) slack.NameStyle {
+	style, _ := slack.ParseNameStyle(cfg.NameStyle)
+	return style
+}
+
 // LoadArchiveSource opens a slackdump v4 archive database source.
 func LoadArchiveSource(ctx context.Context, archiveDir string) (ArchiveSourceCloser, error) {
 	src, err := source.Load(ctx, archiveDir)
@@ -48,8 +215,8 @@ func LoadArchiveSource(ctx context.Context, archiveDir string) (ArchiveSourceClo
 }
 
 // RenderArchiveRange renders all channel files for an inclusive date range.
-func RenderArchiveRange(ctx context.Context, archiveDir, outputDir, from, to, timezone string) (int, error) {
-	return RenderArchiveRangeForChannels(ctx, archiveDir, outputDir, from, to, timezone, nil)
+func RenderArchiveRange(ctx context.Context, archiveDir, outputDir, from, to string, opts RenderOptions) (int, error) {
+	return RenderArchiveRangeForChannels(ctx, archiveDir, outputDir, from, to, opts, nil)
 }
 
 // RenderArchiveRangeForChannels renders selected channel files for an inclusive date range.
@@ -60,7 +227,7 @@ func RenderArchiveRangeForChannels(
 	outputDir string,
 	from string,
 	to string,
-	timezone string,
+	opts RenderOptions,
 	channelIDs []string,
 ) (int, error) {
 	src, err := LoadArchiveSource(ctx, archiveDir)
@@ -73,14 +240,18 @@ func RenderArchiveRangeForChannels(
 	if err != nil {
 		return 0, fmt.Errorf("loading channel names: %w", err)
 	}
-	return renderSourceRange(ctx, src, outputDir, from, to, timezone, channelNames, channelIDs)
+	if opts, err = attachPseudonyms(opts, archiveDir); err != nil {
+		return 0, err
+	}
+	writes, err := renderSourceRange(ctx, src, outputDir, from, to, opts, channelNames, channelIDs)
+	return writes, errors.Join(err, opts.pseudonyms.save())
 }
 
 func RenderArchiveTargets(
 	ctx context.Context,
 	archiveDir string,
 	outputDir string,
-	timezone string,
+	opts RenderOptions,
 	targets []renderTarget,
 ) (int, error) {
 	if len(targets) == 0 {
@@ -96,7 +267,11 @@ func RenderArchiveTargets(
 	if err != nil {
 		return 0, fmt.Errorf("loading channel names: %w", err)
 	}
-	return renderSourceTargets(ctx, src, outputDir, timezone, channelNames, targets)
+	if opts, err = attachPseudonyms(opts, archiveDir); err != nil {
+		return 0, err
+	}
+	writes, err := renderSourceTargets(ctx, src, outputDir, opts, channelNames, targets)
+	return writes, errors.Join(err, opts.pseudonyms.save())
 }
 
 // RenderSourceRange renders all channels from an already opened source.
@@ -108,7 +283,7 @@ func RenderSourceRange(
 	to string,
 	timezone string,
 ) (int, error) {
-	return renderSourceRange(ctx, src, outputDir, from, to, timezone, nil, nil)
+	return renderSourceRange(ctx, src, outputDir, from, to, RenderOptions{Timezone: timezone}, nil, nil)
 }
 
 func renderSourceRange(
@@ -117,17 +292,18 @@ func renderSourceRange(
 	outputDir string,
 	from string,
 	to string,
-	timezone string,
+	opts RenderOptions,
 	channelNames channelNameResolver,
 	channelIDs []string,
 ) (int, error) {
+	src = opts.MessageFilter.source(opts.Dedup.source(src))
 	channels, err := src.Channels(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("loading channels: %w", err)
 	}
-	channels = filterRenderChannels(channels, channelIDs)
+	channels = opts.Scope.filterArchive(filterRenderChannels(channels, channelIDs))
 
-	dates, err := datesInRange(from, to, timezone)
+	dates, err := datesInRange(from, to, opts.Timezone)
 	if err != nil {
 		return 0, err
 	}
@@ -135,99 +311,110 @@ func renderSourceRange(
 	if err != nil {
 		return 0, err
 	}
+	if err := writeRawUsers(outputDir, users, opts); err != nil {
+		return 0, fmt.Errorf("writing raw users: %w", err)
+	}
+	lookup := newRenderLookup(users, opts)
 
 	writes := 0
 	for _, ch := range channels {
-		messages, err := loadChannelMessages(ctx, src, ch.ID)
-		if err != nil {
-			return writes, fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
-		}
-		threads := make(threadMessageCache)
-		for _, date := range dates {
-			name := channelNames.fileName(ch)
-			content, err := renderChannelDateFromMessages(ctx, src, RenderRequest{
-				Date:        date,
-				Timezone:    timezone,
-				ChannelID:   ch.ID,
-				ChannelName: name,
-			}, users, messages, threads)
+		name := lookup.channelFileName(channelNames, ch)
+		written, err := opts.renderChannel(ctx, ch, name, dates, func() (int, error) {
+			messages, err := loadChannelMessages(ctx, src, ch.ID)
 			if err != nil {
-				return writes, fmt.Errorf("rendering %s %s: %w", date, ch.ID, err)
-			}
-			if content == "" {
-				continue
-			}
-			path := filepath.Join(outputDir, date, fmt.Sprintf("%s-%s.md", date, name))
-			written, err := writeFileIfChanged(path, []byte(content))
-			if err != nil {
-				return writes, err
-			}
-			if written {
-				writes++
+				return 0, fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
 			}
+			return renderChannelDates(ctx, src, outputDir, channelDates{
+				id:       ch.ID,
+				name:     name,
+				kind:     channelKind(ch),
+				dates:    dates,
+				messages: messages,
+			}, opts, lookup)
+		})
+		writes += written
+		if err != nil {
+			return writes, err
 		}
 	}
-	return writes, nil
+	if err := opts.Index.flush(outputDir, opts.Layout); err != nil {
+		return writes, err
+	}
+	if err := opts.timezones.flush(outputDir, opts.Layout); err != nil {
+		return writes, err
+	}
+	if err := opts.MessageFilter.flush(outputDir, opts.Layout); err != nil {
+		return writes, err
+	}
+	opts.Dedup.report(opts.stage)
+	opts.Avatars.flush(ctx, outputDir, opts.warn)
+	return writes, opts.checksums.flush(outputDir, opts.Layout)
 }
 
 func renderSourceTargets(
 	ctx context.Context,
 	src ArchiveMessageSource,
 	outputDir string,
-	timezone string,
+	opts RenderOptions,
 	channelNames channelNameResolver,
 	targets []renderTarget,
 ) (int, error) {
+	src = opts.MessageFilter.source(opts.Dedup.source(src))
 	channels, err := src.Channels(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("loading channels: %w", err)
 	}
 	targetDates := make(map[string][]string)
 	for _, target := range targets {
-		if _, _, err := GetDateBounds(target.date, timezone); err != nil {
+		if _, _, err := GetDateBounds(target.date, opts.Timezone); err != nil {
 			return 0, err
 		}
 		targetDates[target.channelID] = append(targetDates[target.channelID], target.date)
 	}
-	channels = filterRenderChannels(channels, targetChannelIDs(targets))
+	channels = opts.Scope.filterArchive(filterRenderChannels(channels, targetChannelIDs(targets)))
 
 	users, err := loadUsers(ctx, src)
 	if err != nil {
 		return 0, err
 	}
+	if err := writeRawUsers(outputDir, users, opts); err != nil {
+		return 0, fmt.Errorf("writing raw users: %w", err)
+	}
+	lookup := newRenderLookup(users, opts)
 
 	writes := 0
 	for _, ch := range channels {
-		messages, err := loadChannelMessages(ctx, src, ch.ID)
-		if err != nil {
-			return writes, fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
-		}
-		threads := make(threadMessageCache)
-		for _, date := range targetDates[ch.ID] {
-			name := channelNames.fileName(ch)
-			content, err := renderChannelDateFromMessages(ctx, src, RenderRequest{
-				Date:        date,
-				Timezone:    timezone,
-				ChannelID:   ch.ID,
-				ChannelName: name,
-			}, users, messages, threads)
+		name := lookup.channelFileName(channelNames, ch)
+		written, err := opts.renderChannel(ctx, ch, name, targetDates[ch.ID], func() (int, error) {
+			messages, err := loadChannelMessages(ctx, src, ch.ID)
 			if err != nil {
-				return writes, fmt.Errorf("rendering %s %s: %w", date, ch.ID, err)
-			}
-			if content == "" {
-				continue
-			}
-			path := filepath.Join(outputDir, date, fmt.Sprintf("%s-%s.md", date, name))
-			written, err := writeFileIfChanged(path, []byte(content))
-			if err != nil {
-				return writes, err
-			}
-			if written {
-				writes++
+				return 0, fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
 			}
+			return renderChannelDates(ctx, src, outputDir, channelDates{
+				id:       ch.ID,
+				name:     name,
+				kind:     channelKind(ch),
+				dates:    targetDates[ch.ID],
+				messages: messages,
+			}, opts, lookup)
+		})
+		writes += written
+		if err != nil {
+			return writes, err
 		}
 	}
-	return writes, nil
+	if err := opts.Index.flush(outputDir, opts.Layout); err != nil {
+		return writes, err
+	}
+	if err := opts.timezones.flush(outputDir, opts.Layout); err != nil {
+		return writes, err
+	}
+	if err := opts.MessageFilter.flush(outputDir, opts.Layout); err != nil {
+		return writes, err
+	}
+	opts.Dedup.report(opts.stage)
+	opts.Avatars.flush(ctx, outputDir, opts.warn)
+	return writes, opts.checksums.flush(outputDir, opts.Layout)
 }
 
 func targetChannelIDs(targets []renderTarget) []string {
@@ -242,24 +429,29 @@ func targetChannelIDs(targets []renderTarget) []string {
 	return ids
 }
 
+// filterRenderChannels keeps the channels in channelIDs, in that order, so
+// renders follow the priority the caller chose. Empty channelIDs keeps all.
 func filterRenderChannels(channels []rslack.Channel, channelIDs []string) []rslack.Channel {
 	if len(channelIDs) == 0 {
 		return channels
 	}
-	allowed := make(map[string]struct{}, len(channelIDs))
-	for _, id := range channelIDs {
-		allowed[id] = struct{}{}
-	}
-	filtered := make([]rslack.Channel, 0, len(channels))
+	byID := make(map[string]rslack.Channel, len(channels))
 	for _, ch := range channels {
-		if _, ok := allowed[ch.ID]; ok {
+		byID[ch.ID] = ch
+	}
+	filtered := make([]rslack.Channel, 0, len(channelIDs))
+	for _, id := range channelIDs {
+		if ch, ok := byID[id]; ok {
 			filtered = append(filtered, ch)
+			delete(byID, id)
 		}
 	}
 	return filtered
 }
 
-func writeFileIfChanged(path string, content []byte) (bool, error) {
+// writeFileIfChanged atomically replaces path when content differs. With
+// verify set, the written file is re-read and checksummed.
+func writeFileIfChanged(path string, content []byte, verify bool) (bool, error) {
 	cleanPath := filepath.Clean(path)
 	if existing, err := os.ReadFile(cleanPath); err == nil && bytes.Equal(existing, content) {
 		return false, nil
@@ -267,13 +459,44 @@ func writeFileIfChanged(path string, content []byte) (bool, error) {
 	if err := os.MkdirAll(filepath.Dir(cleanPath), 0750); err != nil {
 		return false, fmt.Errorf("creating output directory: %w", err)
 	}
-	if err := os.WriteFile(cleanPath, content, 0600); err != nil {
+	if err := writeFileAtomic(cleanPath, content, 0600); err != nil {
 		return false, fmt.Errorf("writing %s: %w", cleanPath, err)
 	}
+	if verify {
+		if err := verifyWrite(cleanPath, content); err != nil {
+			return false, err
+		}
+	}
 	return true, nil
 }
 
 type userLookup map[string]rslack.User
+
+// renderLookup holds the indexes used to resolve names while rendering.
+type renderLookup struct {
+	users      userLookup
+	usergroups map[string]string
+	canvases   CanvasSource
+	pseudonyms *pseudonymMap
+	blocks     bool
+	unfurls    bool
+	times      timestampFormat
+	templates  map[string]*template.Template
+	template   channelTemplate
+	// threadLookback is thread_lookback_days; see continuationHeading.
+	threadLookback int
+	filenameDate   string
+	layout         string
+	aliases        map[string]string
+	names          slack.NameStyle
+	missingUsers   UserSource
+	deactivated    string
+	chronological  bool
+	trackChanges   bool
+	// tracker collects the messages written into the channel-day being
+	// rendered when trackChanges is set.
+	tracker *messageTracker
+}
 type threadMessageCache map[string][]rslack.Message
 
 type continuationBlock struct {
@@ -291,47 +514,59 @@ func RenderChannelDate(ctx context.Context, src ArchiveMessageSource, req Render
 	if err != nil {
 		return "", err
 	}
-	return renderChannelDate(ctx, src, req, users)
+	return renderChannelDate(ctx, src, req, renderLookup{users: users})
 }
 
-func renderChannelDate(ctx context.Context, src ArchiveMessageSource, req RenderRequest, users userLookup) (string, error) {
+func renderChannelDate(ctx context.Context, src ArchiveMessageSource, req RenderRequest, lookup renderLookup) (string, error) {
 	messages, err := loadChannelMessages(ctx, src, req.ChannelID)
 	if err != nil {
 		return "", err
 	}
-	return renderChannelDateFromMessages(ctx, src, req, users, messages, make(threadMessageCache))
+	return renderChannelDateFromMessages(ctx, src, req, lookup, messages, make(threadMessageCache))
 }
 
 func renderChannelDateFromMessages(
 	ctx context.Context,
 	src ArchiveMessageSource,
 	req RenderRequest,
-	users userLookup,
+	lookup renderLookup,
 	messages []rslack.Message,
 	threads threadMessageCache,
 ) (string, error) {
-	if _, _, err := GetDateBounds(req.Date, req.Timezone); err != nil {
+	units, err := renderChannelDateUnits(ctx, src, req, lookup, messages, threads)
+	if err != nil {
 		return "", err
 	}
+	return joinRenderedUnits(units), nil
+}
 
-	base, err := renderBaseSection(ctx, src, req, users, messages, threads)
-	if err != nil {
-		return "", err
+// renderChannelDateUnits renders one channel-day as indivisible units: a
+// top-level message with its same-day replies, or one continuation block.
+func renderChannelDateUnits(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	req RenderRequest,
+	lookup renderLookup,
+	messages []rslack.Message,
+	threads threadMessageCache,
+) ([]renderedUnit, error) {
+	if _, _, err := GetDateBounds(req.Date, req.Timezone); err != nil {
+		return nil, err
 	}
-	continuations, err := renderContinuations(ctx, src, req, users, messages, threads)
-	if err != nil {
-		return "", err
+	lookup = lookup.forChannel(req)
+	if lookup.trackChanges {
+		lookup.tracker = &messageTracker{}
 	}
 
-	var out bytes.Buffer
-	out.WriteString(base)
-	if continuations != "" {
-		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
-			out.WriteByte('\n')
-		}
-		out.WriteString(continuations)
+	base, err := renderBaseSection(ctx, src, req, lookup, messages, threads)
+	if err != nil {
+		return nil, err
 	}
-	return out.String(), nil
+	continuations, err := renderContinuations(ctx, src, req, lookup, messages, threads)
+	if err != nil {
+		return nil, err
+	}
+	return append(base, continuations...), nil
 }
 
 func loadChannelMessages(ctx context.Context, src ArchiveMessageSource, channelID string) ([]rslack.Message, error) {
@@ -361,23 +596,124 @@ func renderBaseSection(
 	ctx context.Context,
 	src ArchiveMessageSource,
 	req RenderRequest,
-	users userLookup,
+	lookup renderLookup,
 	messages []rslack.Message,
 	threads threadMessageCache,
-) (string, error) {
-	var out bytes.Buffer
+) ([]renderedUnit, error) {
+	if lookup.chronological {
+		return renderChronologicalSection(ctx, src, req, lookup, messages, threads)
+	}
+	nested, err := nestedBroadcasts(ctx, src, req, messages, threads)
+	if err != nil {
+		return nil, err
+	}
+	var units []renderedUnit
 	for _, msg := range messages {
-		if !messageBelongsToDate(msg, req.Date, req.Timezone) {
+		if !messageBelongsToDate(msg, req.Date, req.Timezone) || nested[msg.Timestamp] {
 			continue
 		}
-		writeMessage(&out, msg, "", users)
+		var out bytes.Buffer
+		writeMessage(&out, msg, "", lookup)
+		count := 1
 		if isThreadParent(msg) {
-			if err := writeSameDayReplies(ctx, &out, src, req, users, msg, threads); err != nil {
-				return "", err
+			replies, err := writeSameDayReplies(ctx, &out, src, req, lookup, msg, threads)
+			if err != nil {
+				return nil, err
+			}
+			count += replies
+		}
+		if out.Len() > 0 {
+			units = append(units, renderedUnit{text: out.String(), messages: count, tracked: lookup.tracker.take()})
+		}
+	}
+	return units, nil
+}
+
+// nestedBroadcasts returns the timestamps of broadcast replies ("also send
+// to channel") listed under their parent on the request's day. The archive
+// also holds them among the channel's messages; they are shown once, in
+// their thread.
+func nestedBroadcasts(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	req RenderRequest,
+	messages []rslack.Message,
+	threads threadMessageCache,
+) (map[string]bool, error) {
+	nested := make(map[string]bool)
+	for _, parent := range messages {
+		if !isThreadParent(parent) || !messageBelongsToDate(parent, req.Date, req.Timezone) {
+			continue
+		}
+		replies, err := sameDayReplies(ctx, src, req, parent, threads)
+		if err != nil {
+			return nil, err
+		}
+		for _, reply := range replies {
+			if isBroadcast(reply) {
+				nested[reply.Timestamp] = true
 			}
 		}
 	}
-	return out.String(), nil
+	return nested, nil
+}
+
+// renderChronologicalSection renders the day's top-level messages and the
+// same-day replies of its threads as one list in timestamp order, each
+// message its own unit. Replies keep the reply prefix; a broadcast reply
+// already among the top-level messages is listed once.
+func renderChronologicalSection(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	req RenderRequest,
+	lookup renderLookup,
+	messages []rslack.Message,
+	threads threadMessageCache,
+) ([]renderedUnit, error) {
+	type entry struct {
+		msg   rslack.Message
+		reply bool
+	}
+	var entries []entry
+	listed := make(map[string]bool)
+	for _, msg := range messages {
+		if messageBelongsToDate(msg, req.Date, req.Timezone) {
+			entries = append(entries, entry{msg: msg})
+			listed[msg.Timestamp] = true
+		}
+	}
+	for _, parent := range messages {
+		if !isThreadParent(parent) || !messageBelongsToDate(parent, req.Date, req.Timezone) {
+			continue
+		}
+		replies, err := sameDayReplies(ctx, src, req, parent, threads)
+		if err != nil {
+			return nil, err
+		}
+		for _, reply := range replies {
+			if !listed[reply.Timestamp] {
+				entries = append(entries, entry{msg: reply, reply: true})
+				listed[reply.Timestamp] = true
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return slackts.Less(entries[i].msg.Timestamp, entries[j].msg.Timestamp)
+	})
+
+	units := make([]renderedUnit, 0, len(entries))
+	for _, e := range entries {
+		var out bytes.Buffer
+		prefix := ""
+		if e.reply {
+			prefix = "|   "
+		}
+		writeMessage(&out, e.msg, prefix, lookup)
+		if out.Len() > 0 {
+			units = append(units, renderedUnit{text: out.String(), messages: 1, tracked: lookup.tracker.take()})
+		}
+	}
+	return units, nil
 }
 
 func writeSameDayReplies(
@@ -385,31 +721,50 @@ func writeSameDayReplies(
 	out *bytes.Buffer,
 	src ArchiveMessageSource,
 	req RenderRequest,
-	users userLookup,
+	lookup renderLookup,
 	parent rslack.Message,
 	threads threadMessageCache,
-) error {
+) (int, error) {
+	replies, err := sameDayReplies(ctx, src, req, parent, threads)
+	if err != nil {
+		return 0, err
+	}
+	for _, reply := range replies {
+		writeMessage(out, reply, "|   ", lookup)
+	}
+	return len(replies), nil
+}
+
+// sameDayReplies returns the replies to parent posted on the request's
+// day, oldest first.
+func sameDayReplies(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	req RenderRequest,
+	parent rslack.Message,
+	threads threadMessageCache,
+) ([]rslack.Message, error) {
 	thread, err := threads.get(ctx, src, req.ChannelID, parent.ThreadTimestamp)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var replies []rslack.Message
 	for _, reply := range thread {
-		if reply.Timestamp == parent.Timestamp || !messageBelongsToDate(reply, req.Date, req.Timezone) {
-			continue
+		if reply.Timestamp != parent.Timestamp && messageBelongsToDate(reply, req.Date, req.Timezone) {
+			replies = append(replies, reply)
 		}
-		writeMessage(out, reply, "|   ", users)
 	}
-	return nil
+	return replies, nil
 }
 
 func renderContinuations(
 	ctx context.Context,
 	src ArchiveMessageSource,
 	req RenderRequest,
-	users userLookup,
+	lookup renderLookup,
 	messages []rslack.Message,
 	threads threadMessageCache,
-) (string, error) {
+) ([]renderedUnit, error) {
 	var blocks []continuationBlock
 	for _, parent := range messages {
 		if !isThreadParent(parent) {
@@ -417,47 +772,48 @@ func renderContinuations(
 		}
 		parentDate, err := messageWorkDate(parent, req.Timezone)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if parentDate >= req.Date {
 			continue
 		}
 		block, ok, err := continuationForThread(ctx, src, req, parent, parentDate, threads)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if ok {
 			blocks = append(blocks, block)
 		}
 	}
 	if len(blocks) == 0 {
-		return "", nil
+		return nil, nil
 	}
 	sort.Slice(blocks, func(i, j int) bool {
 		return blocks[i].firstReply.Before(blocks[j].firstReply)
 	})
 
-	var out bytes.Buffer
-	out.WriteString("---\n\n")
-	out.WriteString("## Thread continuations\n")
-	out.WriteString("Replies posted this day in threads started on earlier days.\n")
-	out.WriteString("Lines marked [context] are repeated from the original day for readability.\n")
-	for _, block := range blocks {
-		fmt.Fprintf(
-			&out,
-			"\n### Thread started %s (see %s/%s-%s.md)\n",
-			block.parentDate,
-			block.parentDate,
-			block.parentDate,
-			req.ChannelName,
-		)
-		writeContextMessage(&out, block.parent, users)
+	units := make([]renderedUnit, 0, len(blocks))
+	for i, block := range blocks {
+		var out bytes.Buffer
+		if i == 0 {
+			out.WriteString("---\n\n")
+			out.WriteString("## Thread continuations\n")
+			out.WriteString("Replies posted this day in threads started on earlier days.\n")
+			out.WriteString("Lines marked [context] are repeated from the original day for readability.\n")
+		}
+		out.WriteString(continuationHeading(block.parentDate, req, lookup))
+		writeContextMessage(&out, block.parent, lookup)
 		out.WriteByte('\n')
 		for _, reply := range block.replies {
-			writeMessage(&out, reply, "|   ", users)
+			writeMessage(&out, reply, "|   ", lookup)
 		}
+		units = append(units, renderedUnit{
+			text:     out.String(),
+			messages: len(block.replies),
+			tracked:  lookup.tracker.take(),
+		})
 	}
-	return out.String(), nil
+	return units, nil
 }
 
 func continuationForThread(
@@ -476,7 +832,7 @@ func continuationForThread(
 	var replies []rslack.Message
 	var first time.Time
 	for _, reply := range thread {
-		if reply.Timestamp == parent.Timestamp || reply.SubType == rslack.MsgSubTypeThreadBroadcast {
+		if reply.Timestamp == parent.Timestamp || isBroadcast(reply) {
 			continue
 		}
 		if !messageBelongsToDate(reply, req.Date, req.Timezone) {
@@ -539,12 +895,20 @@ func collectMessages(
 	return messages, nil
 }
 
+// sortMessages orders messages by timestamp. The sort is stable so
+// messages sharing a timestamp keep the archive's order between runs.
 func sortMessages(messages []rslack.Message) {
-	sort.Slice(messages, func(i, j int) bool {
-		return messages[i].Timestamp < messages[j].Timestamp
+	sort.SliceStable(messages, func(i, j int) bool {
+		return slackts.Less(messages[i].Timestamp, messages[j].Timestamp)
 	})
 }
 
+// isBroadcast reports whether msg is a thread reply also sent to the
+// channel.
+func isBroadcast(msg rslack.Message) bool {
+	return msg.SubType == rslack.MsgSubTypeThreadBroadcast
+}
+
 func isThreadParent(msg rslack.Message) bool {
 	return msg.ThreadTimestamp != "" && msg.ThreadTimestamp == msg.Timestamp && msg.ReplyCount > 0
 }
@@ -564,10 +928,11 @@ func messageWorkDate(msg rslack.Message, timezone string) (string, error) {
 		return "", err
 	}
 	local := ts.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
 	if local.Hour() < 3 {
-		local = local.AddDate(0, 0, -1)
+		day = day.AddDate(0, 0, -1)
 	}
-	return local.Format("2006-01-02"), nil
+	return day.Format("2006-01-02"), nil
 }
 
 func parseSlackTimestamp(ts string) (time.Time, error) {
@@ -593,19 +958,61 @@ func parseSlackTimestamp(ts string) (time.Time, error) {
 	return time.Unix(sec, nsec).UTC(), nil
 }
 
-func writeMessage(out *bytes.Buffer, msg rslack.Message, prefix string, users userLookup) {
+// broadcastMarker follows the header of a thread reply also sent to the
+// channel.
+const broadcastMarker = " (also sent to channel)"
+
+func writeMessage(out *bytes.Buffer, msg rslack.Message, prefix string, lookup renderLookup) {
 	ts, err := parseSlackTimestamp(msg.Timestamp)
 	if err != nil {
 		return
 	}
-	fmt.Fprintf(out, "%s> %s [%s] @ %s:\n", prefix, senderName(msg, users), msg.User, ts.Format("02/01/2006 15:04:05 Z0700"))
-	writeTextLines(out, prefix, resolveMentions(html.UnescapeString(msg.Text), users))
+	// The sender is resolved before the text so pseudonyms keep being
+	// assigned in the order people appear.
+	data := MessageData{
+		Sender:    lookup.sender(msg),
+		User:      lookup.userRef(msg.User),
+		Time:      lookup.times.format(ts),
+		Timestamp: ts,
+		Text:      messageText(msg, lookup),
+		Reply:     prefix != "",
+		Broadcast: isBroadcast(msg),
+	}
+	if lookup.pseudonyms != nil {
+		data.Text = scrubContactInfo(data.Text)
+	}
+	lookup.tracker.record(msg, data)
+	if !writeTemplatedMessage(out, prefix, lookup, data) {
+		marker := ""
+		if data.Broadcast {
+			marker = broadcastMarker
+		}
+		fmt.Fprintf(out, "%s> %s [%s] @ %s%s:\n", prefix, data.Sender, data.User, data.Time, marker)
+		writeTextLines(out, prefix, data.Text)
+	}
+	writeCanvasEmbeds(out, prefix, msg.Text, lookup.canvases)
 	out.WriteByte('\n')
 }
 
-func writeContextMessage(out *bytes.Buffer, msg rslack.Message, users userLookup) {
+func messageText(msg rslack.Message, lookup renderLookup) string {
+	text, ok := "", false
+	if lookup.blocks {
+		text, ok = blocksMarkdown(msg, lookup)
+	}
+	if !ok {
+		text = withWorkflowFields(msg, resolveMentions(html.UnescapeString(msg.Text), lookup), lookup)
+	}
+	if lookup.unfurls {
+		text = withUnfurls(msg, text, lookup)
+	}
+	return text
+}
+
+func writeContextMessage(out *bytes.Buffer, msg rslack.Message, lookup renderLookup) {
+	// The message belongs to an earlier day, whose render tracks it.
+	lookup.tracker = nil
 	var rendered bytes.Buffer
-	writeMessage(&rendered, msg, "", users)
+	writeMessage(&rendered, msg, "", lookup)
 	for _, line := range strings.Split(strings.TrimRight(rendered.String(), "\n"), "\n") {
 		out.WriteString("[context] ")
 		out.WriteString(line)
@@ -621,39 +1028,41 @@ func writeTextLines(out *bytes.Buffer, prefix, text string) {
 	}
 }
 
-func resolveMentions(text string, users userLookup) string {
-	return mentionPattern.ReplaceAllStringFunc(text, func(token string) string {
+func resolveMentions(text string, lookup renderLookup) string {
+	text = mentionPattern.ReplaceAllStringFunc(text, func(token string) string {
 		matches := mentionPattern.FindStringSubmatch(token)
 		if len(matches) != 2 {
 			return token
 		}
-		return displayName(matches[1], users)
+		return lookup.userName(matches[1])
 	})
+	return resolveSubteamMentions(text, lookup.usergroups)
 }
 
-func senderName(msg rslack.Message, users userLookup) string {
-	if msg.User == "" && msg.Username != "" {
-		return msg.Username
-	}
-	return displayName(msg.User, users)
-}
-
-func displayName(userID string, users userLookup) string {
+// displayName returns the name shown for a user, looking up people missing
+// from the archive in the lookup's user source and marking deactivated
+// accounts with the configured label.
+func (l renderLookup) displayName(userID string) string {
 	if userID == "" {
 		return "unknown"
 	}
-	user, ok := users[userID]
+	user, ok := l.users[userID]
+	if !ok && l.missingUsers != nil {
+		var found *slack.User
+		if found, ok = l.missingUsers.User(userID); ok {
+			user = rslackUser(found)
+		}
+	}
 	if !ok {
 		return "<unknown>:" + userID
 	}
-	if user.Profile.DisplayName != "" {
-		return user.Profile.DisplayName
-	}
-	if user.RealName != "" {
-		return user.RealName
+	fields := slack.NameFields{ID: user.ID, Name: user.Name, DisplayName: user.Profile.DisplayName, RealName: user.RealName}
+	name := l.names.Name(fields)
+	if name == "" {
+		return "<unknown>:" + userID
 	}
-	if user.Name != "" {
-		return user.Name
+	if user.Deleted && l.deactivated != "" {
+		name += " (" + l.deactivated + ")"
 	}
-	return "<unknown>:" + userID
+	return name
 }
@@ -6,15 +6,16 @@ import (
 )
 
 func datesInRange(from, to, timezone string) ([]string, error) {
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
+	if _, err := time.LoadLocation(timezone); err != nil {
 		return nil, fmt.Errorf("loading timezone: %w", err)
 	}
-	start, err := time.ParseInLocation("2006-01-02", from, loc)
+	// Calendar dates are stepped in UTC: local midnight does not exist on
+	// some DST days, and parsing there can land on the previous date.
+	start, err := time.Parse("2006-01-02", from)
 	if err != nil {
 		return nil, fmt.Errorf("parsing from date: %w", err)
 	}
-	end, err := time.ParseInLocation("2006-01-02", to, loc)
+	end, err := time.Parse("2006-01-02", to)
 	if err != nil {
 		return nil, fmt.Errorf("parsing to date: %w", err)
 	}
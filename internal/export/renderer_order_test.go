@@ -0,0 +1,92 @@
+package export
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// orderingSource is one day in #engineering (2026-07-02, UTC): a thread
+// whose replies interleave with later top-level messages, a broadcast
+// reply, and an archive listing messages out of order.
+func orderingSource() memoryArchiveSource {
+	msg := func(user, text, ts, threadTS string) rslack.Message {
+		return rslack.Message{Msg: rslack.Msg{Type: "message", User: user, Text: text, Timestamp: ts, ThreadTimestamp: threadTS}}
+	}
+	parent := msg("U1", "Deploy is starting", "1782986400.000100", "1782986400.000100")
+	parent.ReplyCount = 3
+	broadcast := msg("U2", "Deploy finished, also posted to the channel", "1782990000.000100", "1782986400.000100")
+	broadcast.SubType = rslack.MsgSubTypeThreadBroadcast
+
+	return memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users: []rslack.User{
+			{ID: "U1", Name: "alice", RealName: "Alice"},
+			{ID: "U2", Name: "bob", RealName: "Bob"},
+		},
+		messages: map[string][]rslack.Message{
+			"C123": {
+				msg("U2", "Lunch at noon?", "1782993600.000100", ""),
+				broadcast,
+				parent,
+				msg("U1", "Morning all", "1782982800.000100", ""),
+				msg("U2", "Standup moved to 10:30", "1782988200.000100", ""),
+			},
+		},
+		threads: map[string][]rslack.Message{
+			"C123:1782986400.000100": {
+				parent,
+				msg("U2", "Watching the dashboards", "1782987300.000100", "1782986400.000100"),
+				broadcast,
+				msg("U1", "Rollback plan is ready", "1782989100.000100", "1782986400.000100"),
+			},
+		},
+	}
+}
+
+func TestRenderChannelDate_OrderingGolden(t *testing.T) {
+	for name, chronological := range map[string]bool{"threads-grouped": false, "chronological": true} {
+		t.Run(name, func(t *testing.T) {
+			src := orderingSource()
+			users, err := loadUsers(context.Background(), src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := renderChannelDate(context.Background(), src, RenderRequest{
+				Date:        "2026-07-02",
+				Timezone:    "UTC",
+				ChannelID:   "C123",
+				ChannelName: "engineering",
+			}, renderLookup{users: users, chronological: chronological})
+			if err != nil {
+				t.Fatalf("renderChannelDate() error = %v", err)
+			}
+
+			golden := filepath.Join("testdata", "ordering", name+".md")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(golden), 0750); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, []byte(got), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("reading golden file (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("render differs from %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// renderedUnit is a span of rendered markdown that must stay in one file.
+type renderedUnit struct {
+	text     string
+	messages int
+	// tracked holds the unit's messages for track_changes.
+	tracked []trackedMessage
+}
+
+// unitMessages returns the number of top-level messages in units.
+func unitMessages(units []renderedUnit) int {
+	messages := 0
+	for _, unit := range units {
+		messages += unit.messages
+	}
+	return messages
+}
+
+func joinRenderedUnits(units []renderedUnit) string {
+	var out strings.Builder
+	for _, unit := range units {
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+			out.WriteByte('\n')
+		}
+		out.WriteString(unit.text)
+	}
+	return out.String()
+}
+
+// splitRenderedUnits packs units into parts that stay within maxBytes and
+// maxMessages. A unit larger than a limit on its own gets a part to itself.
+func splitRenderedUnits(units []renderedUnit, maxBytes int64, maxMessages int) [][]renderedUnit {
+	if maxBytes <= 0 && maxMessages <= 0 {
+		return [][]renderedUnit{units}
+	}
+	var parts [][]renderedUnit
+	var current []renderedUnit
+	var size int64
+	count := 0
+	for _, unit := range units {
+		overBytes := maxBytes > 0 && size+int64(len(unit.text)) > maxBytes
+		overMessages := maxMessages > 0 && count+unit.messages > maxMessages
+		if len(current) > 0 && (overBytes || overMessages) {
+			parts = append(parts, current)
+			current, size, count = nil, 0, 0
+		}
+		current = append(current, unit)
+		size += int64(len(unit.text))
+		count += unit.messages
+	}
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+	return parts
+}
+
+// writeChannelDate writes a channel-day as one file, or as numbered part files
+// with continuation headers when it exceeds the configured limits. Files left
+// over from a previous render with a different part count are removed.
+func writeChannelDate(ctx context.Context, outputDir, date, name string, units []renderedUnit, opts RenderOptions) (int, error) {
+	dir := dateDir(outputDir, date, opts.Layout)
+	base := channelFileBase(date, name, opts.FilenameDate, opts.Layout)
+	content := joinRenderedUnits(units)
+	if err := opts.Accounting.reserve(outputDir, date, name, int64(len(content))); err != nil {
+		return 0, err
+	}
+	if opts.TrackChanges {
+		if err := recordChannelDateChanges(dir, base, name, content, trackedMessages(units), time.Now()); err != nil {
+			return 0, fmt.Errorf("recording changes for %s: %w", base, err)
+		}
+	}
+	parts := splitRenderedUnits(units, opts.MaxFileSize, opts.MaxMessagesPerFile)
+
+	if len(parts) <= 1 {
+		written, err := writeChannelFile(ctx, filepath.Join(dir, base+".md"), content, name, date, opts)
+		if err != nil {
+			return 0, err
+		}
+		removed, err := removeStaleParts(dir, base, 1)
+		if err == nil {
+			err = opts.Translator.removeStaleVariants(dir, base, 1, false)
+		}
+		return boolCount(written) + removed, err
+	}
+	return writeChannelDateParts(ctx, dir, base, name, date, parts, opts)
+}
+
+func writeChannelDateParts(ctx context.Context, dir, base, name, date string, parts [][]renderedUnit, opts RenderOptions) (int, error) {
+	writes := 0
+	for i, part := range parts {
+		partText := partContent(base, part, i+1, len(parts))
+		written, err := writeChannelFile(ctx, partPath(dir, base, i+1), partText, name, date, opts)
+		if err != nil {
+			return writes, err
+		}
+		writes += boolCount(written)
+	}
+	removed, err := removeStaleParts(dir, base, len(parts)+1)
+	writes += removed
+	if err != nil {
+		return writes, err
+	}
+	if err := opts.Translator.removeStaleVariants(dir, base, len(parts)+1, true); err != nil {
+		return writes, err
+	}
+	if err := os.Remove(filepath.Join(dir, base+".md")); err == nil {
+		writes++
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return writes, fmt.Errorf("removing unsplit file: %w", err)
+	}
+	return writes, nil
+}
+
+// writeChannelFile writes one rendered file when its bytes changed, counts
+// it toward the daily output size, and refreshes its translation.
+func writeChannelFile(ctx context.Context, path, content, name, date string, opts RenderOptions) (bool, error) {
+	written, err := writeFileIfChanged(path, []byte(content), opts.VerifyWrites)
+	if err != nil {
+		return false, err
+	}
+	if written {
+		opts.Accounting.recordWrite(len(content))
+	}
+	opts.translate(ctx, path, []byte(content), name, date, written)
+	return written, nil
+}
+
+func partContent(base string, units []renderedUnit, part, total int) string {
+	var out strings.Builder
+	if part > 1 {
+		fmt.Fprintf(&out, "(continued from %s-part%d.md)\n\n", base, part-1)
+	}
+	out.WriteString(joinRenderedUnits(units))
+	if part < total {
+		fmt.Fprintf(&out, "\n(continued in %s-part%d.md)\n", base, part+1)
+	}
+	return out.String()
+}
+
+func partPath(dir, base string, part int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s-part%d.md", base, part))
+}
+
+func removeStaleParts(dir, base string, from int) (int, error) {
+	removed := 0
+	for part := from; ; part++ {
+		err := os.Remove(partPath(dir, base, part))
+		if errors.Is(err, os.ErrNotExist) {
+			return removed, nil
+		}
+		if err != nil {
+			return removed, fmt.Errorf("removing stale part file: %w", err)
+		}
+		removed++
+	}
+}
+
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestSplitRenderedUnits_NoLimitsKeepsOnePart(t *testing.T) {
+	units := []renderedUnit{{text: "a\n", messages: 1}, {text: "b\n", messages: 1}}
+
+	parts := splitRenderedUnits(units, 0, 0)
+	if len(parts) != 1 || len(parts[0]) != 2 {
+		t.Fatalf("splitRenderedUnits() = %v, want one part with both units", parts)
+	}
+}
+
+func TestSplitRenderedUnits_RespectsMessageLimit(t *testing.T) {
+	units := []renderedUnit{
+		{text: "a\n", messages: 1},
+		{text: "thread\n", messages: 3},
+		{text: "b\n", messages: 1},
+	}
+
+	parts := splitRenderedUnits(units, 0, 3)
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3: %v", len(parts), parts)
+	}
+	if parts[1][0].text != "thread\n" {
+		t.Errorf("thread unit should stay whole in its own part, got %v", parts[1])
+	}
+}
+
+func TestSplitRenderedUnits_RespectsByteLimit(t *testing.T) {
+	units := []renderedUnit{
+		{text: strings.Repeat("x", 6), messages: 1},
+		{text: strings.Repeat("y", 6), messages: 1},
+		{text: strings.Repeat("z", 20), messages: 1},
+	}
+
+	parts := splitRenderedUnits(units, 12, 0)
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2: %v", len(parts), parts)
+	}
+	if len(parts[0]) != 2 {
+		t.Errorf("first part has %d units, want 2", len(parts[0]))
+	}
+}
+
+func TestWriteChannelDate_SplitsAndCleansUpStaleFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	dir := filepath.Join(outputDir, "2026-07-01")
+	units := []renderedUnit{
+		{text: "> one\n\n", messages: 1},
+		{text: "> two\n\n", messages: 1},
+		{text: "> three\n\n", messages: 1},
+	}
+
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", units[:1], RenderOptions{}); err != nil {
+		t.Fatalf("writeChannelDate() unsplit error = %v", err)
+	}
+	writes, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", units, RenderOptions{MaxMessagesPerFile: 1})
+	if err != nil {
+		t.Fatalf("writeChannelDate() split error = %v", err)
+	}
+	if writes != 4 {
+		t.Errorf("writes = %d, want 3 parts plus removed unsplit file", writes)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2026-07-01-general.md")); !os.IsNotExist(err) {
+		t.Errorf("unsplit file should be removed after splitting, stat err = %v", err)
+	}
+
+	second, err := os.ReadFile(filepath.Join(dir, "2026-07-01-general-part2.md"))
+	if err != nil {
+		t.Fatalf("reading part2: %v", err)
+	}
+	for _, want := range []string{
+		"(continued from 2026-07-01-general-part1.md)",
+		"> two",
+		"(continued in 2026-07-01-general-part3.md)",
+	} {
+		if !strings.Contains(string(second), want) {
+			t.Errorf("part2 missing %q:\n%s", want, second)
+		}
+	}
+
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-07-01", "general", units, RenderOptions{}); err != nil {
+		t.Fatalf("writeChannelDate() rejoin error = %v", err)
+	}
+	for part := 1; part <= 3; part++ {
+		if _, err := os.Stat(partPath(dir, "2026-07-01-general", part)); !os.IsNotExist(err) {
+			t.Errorf("part%d should be removed after rejoining, stat err = %v", part, err)
+		}
+	}
+}
+
+func TestRenderSourceRange_SplitsOversizedChannelDay(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{
+			GroupConversation: rslack.GroupConversation{
+				Conversation: rslack.Conversation{ID: "C1"},
+				Name:         "busy",
+			},
+		}},
+		users: []rslack.User{{ID: "U1", Name: "alice"}},
+		messages: map[string][]rslack.Message{"C1": {
+			{Msg: rslack.Msg{Type: "message", User: "U1", Text: "first", Timestamp: "1782910800.000000"}},
+			{Msg: rslack.Msg{Type: "message", User: "U1", Text: "second", Timestamp: "1782910860.000000"}},
+			{Msg: rslack.Msg{Type: "message", User: "U1", Text: "third", Timestamp: "1782910920.000000"}},
+		}},
+	}
+	outputDir := t.TempDir()
+
+	writes, err := renderSourceRange(
+		context.Background(), src, outputDir, "2026-07-01", "2026-07-01",
+		RenderOptions{Timezone: "America/Chicago", MaxMessagesPerFile: 2}, nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	if writes != 2 {
+		t.Fatalf("writes = %d, want 2", writes)
+	}
+	dir := filepath.Join(outputDir, "2026-07-01")
+	first, err := os.ReadFile(filepath.Join(dir, "2026-07-01-busy-part1.md"))
+	if err != nil {
+		t.Fatalf("reading part1: %v", err)
+	}
+	if !strings.Contains(string(first), "second") || strings.Contains(string(first), "third") {
+		t.Errorf("part1 should hold the first two messages:\n%s", first)
+	}
+	last, err := os.ReadFile(filepath.Join(dir, "2026-07-01-busy-part2.md"))
+	if err != nil {
+		t.Fatalf("reading part2: %v", err)
+	}
+	if !strings.Contains(string(last), "third") {
+		t.Errorf("part2 should hold the third message:\n%s", last)
+	}
+}
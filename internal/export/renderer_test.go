@@ -163,7 +163,7 @@ func TestRenderSourceRange_CanRenderOnlySelectedChannels(t *testing.T) {
 		outputDir,
 		"2026-07-03",
 		"2026-07-03",
-		"America/Chicago",
+		RenderOptions{Timezone: "America/Chicago"},
 		nil,
 		[]string{"C_KEEP"},
 	)
@@ -184,6 +184,72 @@ func TestRenderSourceRange_CanRenderOnlySelectedChannels(t *testing.T) {
 	}
 }
 
+func TestRenderSourceRange_UsesChannelTimezoneOverride(t *testing.T) {
+	// 07:30 UTC on 2026-07-03 is 02:30 in Chicago (previous work day) but
+	// 16:30 in Tokyo.
+	msg := rslack.Message{Msg: rslack.Msg{
+		Type:      "message",
+		User:      "U1",
+		Text:      "Morning standup",
+		Timestamp: "1783063800.000000",
+	}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{
+			{
+				GroupConversation: rslack.GroupConversation{
+					Conversation: rslack.Conversation{ID: "C_HQ"},
+					Name:         "engineering",
+				},
+			},
+			{
+				GroupConversation: rslack.GroupConversation{
+					Conversation: rslack.Conversation{ID: "C_TOKYO"},
+					Name:         "tokyo-office",
+				},
+			},
+		},
+		users:    []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{"C_HQ": {msg}, "C_TOKYO": {msg}},
+	}
+	outputDir := t.TempDir()
+
+	writes, err := renderSourceRange(
+		context.Background(),
+		src,
+		outputDir,
+		"2026-07-02",
+		"2026-07-03",
+		RenderOptions{
+			Timezone:         "America/Chicago",
+			ChannelTimezones: map[string]string{"tokyo-*": "Asia/Tokyo"},
+		},
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	if writes != 2 {
+		t.Fatalf("writes = %d, want 2", writes)
+	}
+
+	for _, path := range []string{
+		filepath.Join(outputDir, "2026-07-02", "2026-07-02-engineering.md"),
+		filepath.Join(outputDir, "2026-07-03", "2026-07-03-tokyo-office.md"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("stat %s: %v", path, err)
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "2026-07-03", "2026-07-03-tokyo-office.md"))
+	if err != nil {
+		t.Fatalf("reading tokyo file: %v", err)
+	}
+	if !strings.Contains(string(content), "Morning standup") {
+		t.Fatalf("tokyo file missing message:\n%s", content)
+	}
+}
+
 func TestRenderSourceTargets_RendersOnlyRequestedChannelDates(t *testing.T) {
 	src := memoryArchiveSource{
 		channels: []rslack.Channel{
@@ -232,7 +298,7 @@ func TestRenderSourceTargets_RendersOnlyRequestedChannelDates(t *testing.T) {
 		context.Background(),
 		src,
 		outputDir,
-		"America/Chicago",
+		RenderOptions{Timezone: "America/Chicago"},
 		nil,
 		[]renderTarget{
 			{channelID: "C_ALPHA", date: "2026-07-01"},
@@ -491,6 +557,57 @@ func TestRenderChannelDate_IncludesLateReplyInContinuationSection(t *testing.T)
 	}
 }
 
+func TestRenderChannelDate_BroadcastOutsideItsThreadStaysTopLevel(t *testing.T) {
+	msg := func(text, ts, threadTS string) rslack.Message {
+		return rslack.Message{Msg: rslack.Msg{Type: "message", User: "U2", Text: text, Timestamp: ts, ThreadTimestamp: threadTS}}
+	}
+	// One broadcast answers a thread from the day before; the other's
+	// thread was archived without it.
+	oldParent := msg("Deploy is starting", "1782900000.000100", "1782900000.000100")
+	oldParent.ReplyCount = 1
+	lateBroadcast := msg("Deploy finished", "1782990000.000100", "1782900000.000100")
+	lateBroadcast.SubType = rslack.MsgSubTypeThreadBroadcast
+	parent := msg("Lunch at noon?", "1782993600.000100", "1782993600.000100")
+	parent.ReplyCount = 1
+	unthreaded := msg("Booked a table", "1782997200.000100", "1782993600.000100")
+	unthreaded.SubType = rslack.MsgSubTypeThreadBroadcast
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users: []rslack.User{{ID: "U2", Name: "bob", RealName: "Bob"}},
+		messages: map[string][]rslack.Message{
+			"C123": {oldParent, lateBroadcast, parent, unthreaded},
+		},
+		threads: map[string][]rslack.Message{
+			"C123:1782900000.000100": {oldParent, lateBroadcast},
+			"C123:1782993600.000100": {parent},
+		},
+	}
+
+	got, err := RenderChannelDate(context.Background(), src, RenderRequest{
+		Date:        "2026-07-02",
+		Timezone:    "UTC",
+		ChannelID:   "C123",
+		ChannelName: "engineering",
+	})
+	if err != nil {
+		t.Fatalf("RenderChannelDate() error = %v", err)
+	}
+	for _, want := range []string{
+		"> Bob [U2] @ 02/07/2026 11:00:00 Z (also sent to channel):\nDeploy finished\n",
+		"> Bob [U2] @ 02/07/2026 13:00:00 Z (also sent to channel):\nBooked a table\n",
+	} {
+		if strings.Count(got, want) != 1 {
+			t.Errorf("want %q once at top level:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "Deploy finished") != 1 || strings.Contains(got, "Thread continuations") {
+		t.Errorf("broadcast repeated as a thread continuation:\n%s", got)
+	}
+}
+
 func TestRenderChannelDate_UsesWorkdayBoundaryForContinuations(t *testing.T) {
 	src := memoryArchiveSource{
 		channels: []rslack.Channel{{
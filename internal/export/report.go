@@ -0,0 +1,350 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+// ReportPasswordEnv supplies the report SMTP password when the config
+// leaves it out.
+const ReportPasswordEnv = "SLACK_EXPORT_SMTP_PASSWORD"
+
+// RunReport summarizes one run for the report config.
+type RunReport struct {
+	Command  string
+	Started  time.Time
+	Finished time.Time
+	// From and To are the first and last work days rendered.
+	From     string
+	To       string
+	Channels []ReportChannel
+	Warnings []string
+	Err      error
+}
+
+// ReportChannel is one rendered channel in a RunReport.
+type ReportChannel struct {
+	Name     string
+	Files    int
+	Messages int
+	Dates    int
+}
+
+// Files returns the number of changed files written in the run.
+func (r RunReport) Files() int {
+	files := 0
+	for _, ch := range r.Channels {
+		files += ch.Files
+	}
+	return files
+}
+
+// Messages returns the number of top-level messages rendered in the run.
+func (r RunReport) Messages() int {
+	messages := 0
+	for _, ch := range r.Channels {
+		messages += ch.Messages
+	}
+	return messages
+}
+
+// TopChannels returns up to n channels with the most messages.
+func (r RunReport) TopChannels(n int) []ReportChannel {
+	top := make([]ReportChannel, 0, len(r.Channels))
+	for _, ch := range r.Channels {
+		if ch.Messages > 0 {
+			top = append(top, ch)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Messages != top[j].Messages {
+			return top[i].Messages > top[j].Messages
+		}
+		return top[i].Name < top[j].Name
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Subject is the one-line summary used as the mail subject.
+func (r RunReport) Subject() string {
+	outcome := "ok"
+	if r.Err != nil {
+		outcome = "FAILED"
+	}
+	subject := fmt.Sprintf("slack-export %s %s", r.Command, outcome)
+	if r.From != "" {
+		subject += fmt.Sprintf(": %s through %s", r.From, r.To)
+	}
+	return subject + fmt.Sprintf(", %d channel(s)", len(r.Channels))
+}
+
+// ReportCollector passes events on to next and gathers them into a
+// RunReport.
+type ReportCollector struct {
+	next    Events
+	command string
+	started time.Time
+
+	mu       sync.Mutex
+	channels map[string]*ReportChannel
+	order    []string
+	from, to string
+	warnings []string
+}
+
+// NewReportCollector starts collecting a report for command.
+func NewReportCollector(next Events, command string, started time.Time) *ReportCollector {
+	return &ReportCollector{
+		next:     next,
+		command:  command,
+		started:  started,
+		channels: make(map[string]*ReportChannel),
+	}
+}
+
+func (c *ReportCollector) OnStage(message string) {
+	c.next.OnStage(message)
+}
+
+func (c *ReportCollector) OnChannelStart(ch ChannelProgress) {
+	c.next.OnChannelStart(ch)
+}
+
+// OnChannelDone adds the channel's counts. A channel rendered into several
+// output targets counts its messages once.
+func (c *ReportCollector) OnChannelDone(ch ChannelProgress) {
+	c.mu.Lock()
+	entry, ok := c.channels[ch.ID]
+	if !ok {
+		entry = &ReportChannel{Name: ch.Name}
+		c.channels[ch.ID] = entry
+		c.order = append(c.order, ch.ID)
+	}
+	entry.Files += ch.Files
+	entry.Messages = max(entry.Messages, ch.Messages)
+	entry.Dates = max(entry.Dates, len(ch.Dates))
+	for _, date := range ch.Dates {
+		if c.from == "" || date < c.from {
+			c.from = date
+		}
+		if date > c.to {
+			c.to = date
+		}
+	}
+	c.mu.Unlock()
+	c.next.OnChannelDone(ch)
+}
+
+func (c *ReportCollector) OnError(err error) {
+	c.mu.Lock()
+	c.warnings = append(c.warnings, err.Error())
+	c.mu.Unlock()
+	c.next.OnError(err)
+}
+
+// Report returns what was collected, for a run that finished with err.
+func (c *ReportCollector) Report(finished time.Time, err error) RunReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := RunReport{
+		Command:  c.command,
+		Started:  c.started,
+		Finished: finished,
+		From:     c.from,
+		To:       c.to,
+		Warnings: append([]string(nil), c.warnings...),
+		Err:      err,
+	}
+	for _, id := range c.order {
+		report.Channels = append(report.Channels, *c.channels[id])
+	}
+	return report
+}
+
+// RenderReport formats r as markdown or html, listing up to top of the
+// most active channels.
+func RenderReport(r RunReport, format string, top int) (string, error) {
+	if format == "html" {
+		var out bytes.Buffer
+		if err := reportHTML.Execute(&out, reportView(r, top)); err != nil {
+			return "", err
+		}
+		return out.String(), nil
+	}
+	return reportMarkdown(r, top), nil
+}
+
+func reportMarkdown(r RunReport, top int) string {
+	v := reportView(r, top)
+	var out strings.Builder
+	fmt.Fprintf(&out, "# %s\n\n", v.Subject)
+	fmt.Fprintf(&out, "- Run: %s (%s)\n", v.Started, v.Duration)
+	fmt.Fprintf(&out, "- Outcome: %s\n", v.Outcome)
+	fmt.Fprintf(&out, "- Dates: %s\n", v.Dates)
+	fmt.Fprintf(&out, "- Channels: %d rendered, %d changed file(s), %d message(s)\n",
+		len(r.Channels), v.Files, v.Messages)
+	if len(v.Top) > 0 {
+		out.WriteString("\n## Most active channels\n\n| Channel | Messages | Changed files |\n|---|---:|---:|\n")
+		for _, ch := range v.Top {
+			fmt.Fprintf(&out, "| %s | %d | %d |\n", ch.Name, ch.Messages, ch.Files)
+		}
+	}
+	if len(v.Failures) > 0 {
+		out.WriteString("\n## Failures and warnings\n\n")
+		for _, failure := range v.Failures {
+			fmt.Fprintf(&out, "- %s\n", singleLine(failure))
+		}
+	}
+	return out.String()
+}
+
+type reportData struct {
+	Subject  string
+	Started  string
+	Duration time.Duration
+	Outcome  string
+	Dates    string
+	Files    int
+	Messages int
+	Channels int
+	Top      []ReportChannel
+	Failures []string
+}
+
+func reportView(r RunReport, top int) reportData {
+	v := reportData{
+		Subject:  r.Subject(),
+		Started:  r.Started.Format("2006-01-02 15:04 MST"),
+		Duration: r.Finished.Sub(r.Started).Round(time.Second),
+		Outcome:  "ok",
+		Dates:    "none rendered",
+		Files:    r.Files(),
+		Messages: r.Messages(),
+		Channels: len(r.Channels),
+		Top:      r.TopChannels(top),
+	}
+	if r.From != "" {
+		v.Dates = r.From + " through " + r.To
+	}
+	if r.Err != nil {
+		v.Outcome = "failed"
+		v.Failures = append(v.Failures, r.Err.Error())
+	}
+	v.Failures = append(v.Failures, r.Warnings...)
+	return v
+}
+
+var reportHTML = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body>
+<h1>{{.Subject}}</h1>
+<ul>
+<li>Run: {{.Started}} ({{.Duration}})</li>
+<li>Outcome: {{.Outcome}}</li>
+<li>Dates: {{.Dates}}</li>
+<li>Channels: {{.Channels}} rendered, {{.Files}} changed file(s), {{.Messages}} message(s)</li>
+</ul>
+{{- if .Top}}
+<h2>Most active channels</h2>
+<table>
+<tr><th>Channel</th><th>Messages</th><th>Changed files</th></tr>
+{{- range .Top}}
+<tr><td>{{.Name}}</td><td>{{.Messages}}</td><td>{{.Files}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Failures}}
+<h2>Failures and warnings</h2>
+<ul>
+{{- range .Failures}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body></html>
+`))
+
+// sendMail is smtp.SendMail, replaced in tests.
+var sendMail = smtp.SendMail
+
+// DeliverReport writes r to cfg.Path and mails it when SMTP is configured.
+// Both are attempted; their errors are joined.
+func DeliverReport(cfg config.Report, r RunReport) error {
+	body, err := RenderReport(r, cfg.ReportFormat(), cfg.TopChannelCount())
+	if err != nil {
+		return fmt.Errorf("rendering report: %w", err)
+	}
+	var errs []error
+	if cfg.Path != "" {
+		errs = append(errs, writeReportFile(cfg.Path, body))
+	}
+	if cfg.SMTP.Host != "" {
+		errs = append(errs, mailReport(cfg, r.Subject(), body))
+	}
+	return errors.Join(errs...)
+}
+
+func writeReportFile(path, body string) error {
+	path, err := expandPath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("creating report directory: %w", err)
+	}
+	if err := writeFileAtomic(path, []byte(body), 0600); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
+
+func mailReport(cfg config.Report, subject, body string) error {
+	s := cfg.SMTP
+	var auth smtp.Auth
+	if s.Username != "" {
+		password := s.Password
+		if password == "" {
+			password = os.Getenv(ReportPasswordEnv)
+		}
+		auth = smtp.PlainAuth("", s.Username, password, s.Host)
+	}
+	addr := s.Host + ":" + strconv.Itoa(s.SMTPPort())
+	msg := reportMessage(s.From, s.To, subject, body, cfg.ReportFormat(), time.Now())
+	if err := sendMail(addr, auth, s.From, s.To, msg); err != nil {
+		return fmt.Errorf("mailing report: %w", err)
+	}
+	return nil
+}
+
+func reportMessage(from string, to []string, subject, body, format string, date time.Time) []byte {
+	contentType := "text/plain"
+	if format == "html" {
+		contentType = "text/html"
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return msg.Bytes()
+}
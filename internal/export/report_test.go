@@ -0,0 +1,99 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+func collectTestReport(runErr error) RunReport {
+	started := time.Date(2026, 1, 16, 6, 0, 0, 0, time.UTC)
+	c := NewReportCollector(ConsoleEvents{Out: io.Discard, Err: io.Discard}, "sync", started)
+	c.OnChannelDone(ChannelProgress{ID: "C1", Name: "general", Files: 1, Messages: 3, Dates: []string{"2026-01-15"}})
+	c.OnChannelDone(ChannelProgress{ID: "C2", Name: "eng-backend", Files: 2, Messages: 40, Dates: []string{"2026-01-14", "2026-01-15"}})
+	c.OnChannelDone(ChannelProgress{ID: "C2", Name: "eng-backend", Files: 2, Messages: 40, Dates: []string{"2026-01-14", "2026-01-15"}})
+	c.OnChannelDone(ChannelProgress{ID: "C3", Name: "quiet", Dates: []string{"2026-01-15"}})
+	c.OnError(errors.New("skipped random: database is locked"))
+	return c.Report(started.Add(90*time.Second), runErr)
+}
+
+func TestReportCollector(t *testing.T) {
+	r := collectTestReport(nil)
+	if r.From != "2026-01-14" || r.To != "2026-01-15" {
+		t.Errorf("range = %s..%s, want 2026-01-14..2026-01-15", r.From, r.To)
+	}
+	if len(r.Channels) != 3 || r.Files() != 5 || r.Messages() != 43 {
+		t.Errorf("channels = %+v, files %d, messages %d; want 3 channels, 5 files, 43 messages",
+			r.Channels, r.Files(), r.Messages())
+	}
+	top := r.TopChannels(5)
+	if len(top) != 2 || top[0].Name != "eng-backend" {
+		t.Errorf("TopChannels() = %+v, want eng-backend then general", top)
+	}
+	if got := r.Subject(); got != "slack-export sync ok: 2026-01-14 through 2026-01-15, 3 channel(s)" {
+		t.Errorf("Subject() = %q", got)
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	r := collectTestReport(errors.New("bootstrapping archive: <exit 1>"))
+	md, err := RenderReport(r, "markdown", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# slack-export sync FAILED", "- Outcome: failed", "- Dates: 2026-01-14 through 2026-01-15",
+		"| eng-backend | 40 | 4 |", "- skipped random: database is locked",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "| general |") {
+		t.Errorf("markdown report lists more than the top channel:\n%s", md)
+	}
+
+	html, err := RenderReport(r, "html", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "<td>eng-backend</td>") || !strings.Contains(html, "&lt;exit 1&gt;") {
+		t.Errorf("html report =\n%s", html)
+	}
+}
+
+func TestDeliverReport(t *testing.T) {
+	var sentTo []string
+	var sent string
+	defer func(saved func(string, smtp.Auth, string, []string, []byte) error) { sendMail = saved }(sendMail)
+	sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		if addr != "smtp.example.com:587" || from != "bot@example.com" {
+			t.Errorf("sendMail(%s, from %s)", addr, from)
+		}
+		sentTo, sent = to, string(msg)
+		return nil
+	}
+	path := filepath.Join(t.TempDir(), "reports", "latest.html")
+	cfg := config.Report{
+		Format: "html",
+		Path:   path,
+		SMTP:   config.ReportSMTP{Host: "smtp.example.com", From: "bot@example.com", To: []string{"team@example.com"}},
+	}
+	if err := DeliverReport(cfg, collectTestReport(nil)); err != nil {
+		t.Fatalf("DeliverReport() error = %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.HasPrefix(string(data), "<!DOCTYPE html>") {
+		t.Errorf("report file = %q, %v", data, err)
+	}
+	if len(sentTo) != 1 || !strings.Contains(sent, "Content-Type: text/html; charset=utf-8\r\n") ||
+		!strings.Contains(sent, "Subject: slack-export sync ok: ") {
+		t.Errorf("mailed to %v:\n%s", sentTo, sent)
+	}
+}
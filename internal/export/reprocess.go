@@ -0,0 +1,192 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	rslack "github.com/rusq/slack"
+)
+
+// ErrNoRawFiles reports an output directory without keep_raw files for any
+// of the dates reprocess was asked for.
+var ErrNoRawFiles = errors.New("no raw files for these dates; keep_raw must be on when a date is rendered")
+
+// ReprocessRaw renders dates again from the keep_raw files under outputDir,
+// with the current templates, filters, and user names, and without the
+// archive or Slack. Only channel-days with a raw file are rendered.
+func ReprocessRaw(ctx context.Context, outputDir string, dates []string, opts RenderOptions) (int, error) {
+	if opts.Anonymize {
+		return 0, errors.New("reprocess cannot anonymize: raw files are only kept without anonymize; use render instead")
+	}
+	for _, date := range dates {
+		if _, _, err := GetDateBounds(date, opts.Timezone); err != nil {
+			return 0, err
+		}
+	}
+	src, err := loadRawArchive(outputDir)
+	if err != nil {
+		return 0, err
+	}
+	var targets []renderTarget
+	for _, date := range dates {
+		for _, id := range src.dates[date] {
+			targets = append(targets, renderTarget{channelID: id, date: date})
+		}
+	}
+	if len(targets) == 0 {
+		return 0, ErrNoRawFiles
+	}
+	return renderSourceTargets(ctx, src, outputDir, opts, src.names, targets)
+}
+
+// rawArchive serves every raw/<date>/<channel>.json under an output
+// directory as one archive. Messages kept on several dates, such as a thread
+// parent and its later replies, are merged.
+type rawArchive struct {
+	channels []rslack.Channel
+	users    []rslack.User
+	names    channelNameResolver
+	// dates lists the channel IDs with a raw file on each date.
+	dates    map[string][]string
+	messages map[string][]rslack.Message
+	threads  map[string][]rslack.Message
+}
+
+func loadRawArchive(outputDir string) (*rawArchive, error) {
+	paths, err := filepath.Glob(filepath.Join(outputDir, rawDir, "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	users, err := loadRawUsers(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	raw := &rawArchive{
+		users:    users,
+		names:    make(channelNameResolver),
+		dates:    make(map[string][]string),
+		messages: make(map[string][]rslack.Message),
+		threads:  make(map[string][]rslack.Message),
+	}
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		date := filepath.Base(filepath.Dir(path))
+		if !exportDateDirPattern.MatchString(date) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var messages []rslack.Message
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		id := rawChannelID(messages, name)
+		if _, ok := raw.names[id]; !ok {
+			raw.names[id] = name
+			raw.channels = append(raw.channels, rawChannel(id, name))
+		}
+		raw.dates[date] = append(raw.dates[date], id)
+		for _, msg := range messages {
+			key := id + ":" + msg.Timestamp
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if msg.ThreadTimestamp != "" {
+				thread := id + ":" + msg.ThreadTimestamp
+				raw.threads[thread] = append(raw.threads[thread], msg)
+			}
+			if msg.ThreadTimestamp == "" || msg.ThreadTimestamp == msg.Timestamp ||
+				msg.SubType == rslack.MsgSubTypeThreadBroadcast {
+				raw.messages[id] = append(raw.messages[id], msg)
+			}
+		}
+	}
+	for _, messages := range raw.messages {
+		sortMessages(messages)
+	}
+	for _, messages := range raw.threads {
+		sortMessages(messages)
+	}
+	return raw, nil
+}
+
+// loadRawUsers reads raw/users.json. A missing file leaves names to the
+// users cache.
+func loadRawUsers(outputDir string) ([]rslack.User, error) {
+	path := filepath.Join(outputDir, rawDir, rawUsersFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var users []rslack.User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return users, nil
+}
+
+// rawChannelID returns the channel ID the messages carry, or the file name
+// for files without one.
+func rawChannelID(messages []rslack.Message, name string) string {
+	for _, msg := range messages {
+		if msg.Channel != "" {
+			return msg.Channel
+		}
+	}
+	return name
+}
+
+// rawChannel describes a channel known only by ID and file name. Direct
+// messages are recognized by their D prefix.
+func rawChannel(id, name string) rslack.Channel {
+	ch := rslack.Channel{}
+	ch.ID = id
+	ch.Name = name
+	ch.IsIM = strings.HasPrefix(id, "D")
+	return ch
+}
+
+func (r *rawArchive) Channels(context.Context) ([]rslack.Channel, error) {
+	return r.channels, nil
+}
+
+func (r *rawArchive) Users(context.Context) ([]rslack.User, error) {
+	return r.users, nil
+}
+
+func (r *rawArchive) AllMessages(_ context.Context, channelID string) (iter.Seq2[rslack.Message, error], error) {
+	return rawMessages(r.messages[channelID]), nil
+}
+
+func (r *rawArchive) AllThreadMessages(
+	_ context.Context,
+	channelID string,
+	threadID string,
+) (iter.Seq2[rslack.Message, error], error) {
+	return rawMessages(r.threads[channelID+":"+threadID]), nil
+}
+
+func rawMessages(messages []rslack.Message) iter.Seq2[rslack.Message, error] {
+	return func(yield func(rslack.Message, error) bool) {
+		for _, msg := range messages {
+			if !yield(msg, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestReprocessRaw_RendersFromRawFiles(t *testing.T) {
+	// A thread started on 2026-01-15 14:00 UTC with a reply the next day.
+	parent := rslack.Message{Msg: rslack.Msg{
+		User: "U1", Text: "Release today?", Timestamp: "1768485600.000100",
+		ThreadTimestamp: "1768485600.000100", ReplyCount: 1,
+	}}
+	reply := rslack.Message{Msg: rslack.Msg{
+		User: "U2", Text: "Shipped this morning", Timestamp: "1768572000.000200", ThreadTimestamp: "1768485600.000100",
+	}}
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: "C123"},
+			Name:         "engineering",
+		}}},
+		users:    []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}, {ID: "U2", Name: "bob", RealName: "Bob"}},
+		messages: map[string][]rslack.Message{"C123": {parent}},
+		threads:  map[string][]rslack.Message{"C123:1768485600.000100": {parent, reply}},
+	}
+	outputDir := t.TempDir()
+	opts := RenderOptions{Timezone: "UTC", KeepRaw: true}
+	if _, err := renderSourceRange(context.Background(), src, outputDir, "2026-01-15", "2026-01-16", opts, nil, nil); err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+	paths := []string{
+		filepath.Join(outputDir, "2026-01-15", "2026-01-15-engineering.md"),
+		filepath.Join(outputDir, "2026-01-16", "2026-01-16-engineering.md"),
+	}
+	var rendered []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading %s: %v", path, err)
+		}
+		rendered = append(rendered, string(data))
+		if err := os.Remove(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writes, err := ReprocessRaw(context.Background(), outputDir, []string{"2026-01-15", "2026-01-16"}, RenderOptions{Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("ReprocessRaw() error = %v", err)
+	}
+	if writes != 2 {
+		t.Errorf("ReprocessRaw() writes = %d, want 2", writes)
+	}
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading reprocessed %s: %v", path, err)
+		}
+		if string(data) != rendered[i] {
+			t.Errorf("reprocessed %s =\n%s\nwant\n%s", filepath.Base(path), data, rendered[i])
+		}
+	}
+	if !strings.Contains(rendered[1], "Alice") || !strings.Contains(rendered[1], "Bob") {
+		t.Errorf("reply day should name both people from raw/users.json:\n%s", rendered[1])
+	}
+}
+
+func TestReprocessRaw_Errors(t *testing.T) {
+	outputDir := t.TempDir()
+	if _, err := ReprocessRaw(context.Background(), outputDir, []string{"2026-01-15"}, RenderOptions{Timezone: "UTC"}); !errors.Is(err, ErrNoRawFiles) {
+		t.Errorf("ReprocessRaw() without raw files error = %v, want ErrNoRawFiles", err)
+	}
+	if _, err := ReprocessRaw(context.Background(), outputDir, []string{"2026-01-15"}, RenderOptions{Timezone: "UTC", Anonymize: true}); err == nil {
+		t.Error("ReprocessRaw() should refuse to anonymize")
+	}
+	if _, err := ReprocessRaw(context.Background(), outputDir, []string{"not-a-date"}, RenderOptions{Timezone: "UTC"}); err == nil {
+		t.Error("ReprocessRaw() accepted an invalid date")
+	}
+}
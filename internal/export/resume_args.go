@@ -16,7 +16,7 @@ func (e *Exporter) resumeArgs(
 	tracked []slack.Channel,
 	opts ResumeOptions,
 ) ([]string, bool, error) {
-	if opts.Dedupe {
+	if opts.Dedupe || opts.Reconcile {
 		args, err := fullSweepResumeArgs(ctx, archiveDir, tracked)
 		return args, true, err
 	}
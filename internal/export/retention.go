@@ -0,0 +1,68 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// freePlanHistoryDays is how many days of messages Slack's free plan keeps.
+const freePlanHistoryDays = 90
+
+// planHistoryDays returns how many days of history a Slack plan keeps, or 0
+// for plans with full history and plans Slack did not report.
+func planHistoryDays(plan string) int {
+	if plan == "free" {
+		return freePlanHistoryDays
+	}
+	return 0
+}
+
+// preflightDates fails fast when a requested range lies entirely in the
+// future and warns when part of it is in the future or older than the
+// workspace plan keeps, instead of quietly producing empty days. The plan is
+// only looked up when the range reaches back further than any plan limit.
+func (e *Exporter) preflightDates(ctx context.Context, from, to string, now time.Time) error {
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	local := now.In(loc)
+	today := workDate(local)
+	if err := checkFutureDates(from, to, today, e.warnf); err != nil {
+		return err
+	}
+	if from >= workDate(local.AddDate(0, 0, -freePlanHistoryDays)) {
+		return nil
+	}
+	team, err := e.edgeClient.FetchTeamInfo(ctx, e.creds.TeamID)
+	if err != nil {
+		e.warnf("could not check workspace history limit: %v", err)
+		return nil
+	}
+	if days := planHistoryDays(team.Plan); days > 0 {
+		warnBeyondHistory(from, workDate(local.AddDate(0, 0, -days)), days, e.warnf)
+	}
+	return nil
+}
+
+// checkFutureDates rejects ranges starting after today and warns about
+// ranges ending after it.
+func checkFutureDates(from, to, today string, warnf func(string, ...any)) error {
+	if from > today {
+		return fmt.Errorf("date %s is in the future (today is %s)", from, today)
+	}
+	if to > today {
+		warnf("dates after %s are in the future and will be empty", today)
+	}
+	return nil
+}
+
+// warnBeyondHistory warns when from predates the first date the plan still
+// serves.
+func warnBeyondHistory(from, cutoff string, days int, warnf func(string, ...any)) {
+	if from < cutoff {
+		warnf("this workspace's plan keeps %d days of history; messages before %s are only "+
+			"available if an earlier sync archived them", days, cutoff)
+	}
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPlanHistoryDays(t *testing.T) {
+	for plan, want := range map[string]int{"free": 90, "std": 0, "plus": 0, "enterprise": 0, "": 0} {
+		if got := planHistoryDays(plan); got != want {
+			t.Errorf("planHistoryDays(%q) = %d, want %d", plan, got, want)
+		}
+	}
+}
+
+func TestCheckFutureDates(t *testing.T) {
+	var warnings []string
+	warnf := func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+
+	if err := checkFutureDates("2026-01-10", "2026-01-15", "2026-01-15", warnf); err != nil || len(warnings) != 0 {
+		t.Errorf("past range: err = %v, warnings = %v", err, warnings)
+	}
+	if err := checkFutureDates("2026-01-10", "2026-01-20", "2026-01-15", warnf); err != nil {
+		t.Errorf("range ending in the future should only warn, got %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "after 2026-01-15") {
+		t.Errorf("warnings = %v", warnings)
+	}
+	err := checkFutureDates("2026-01-16", "2026-01-20", "2026-01-15", warnf)
+	if err == nil || !strings.Contains(err.Error(), "2026-01-16 is in the future") {
+		t.Errorf("future range error = %v", err)
+	}
+}
+
+func TestWarnBeyondHistory(t *testing.T) {
+	var warnings []string
+	warnf := func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+
+	warnBeyondHistory("2025-11-01", "2025-10-17", 90, warnf)
+	if len(warnings) != 0 {
+		t.Errorf("date inside history warned: %v", warnings)
+	}
+	warnBeyondHistory("2025-09-01", "2025-10-17", 90, warnf)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "keeps 90 days") || !strings.Contains(warnings[0], "2025-10-17") {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runStateFile marks a sync in progress in the archive directory. It is
+// removed when the sync finishes rendering, unless a crashed run is still
+// waiting for --resume, so one left behind names a run that crashed or was
+// killed.
+const runStateFile = ".slack-export-run.json"
+
+// RunState describes a sync in progress.
+type RunState struct {
+	// RunID names the run and its temp directory (slack-export-<RunID>).
+	RunID     string    `json:"run_id"`
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	TempDir   string    `json:"temp_dir,omitempty"`
+	// LastSession is the newest archive session before the run; sessions
+	// after it hold what the run archived.
+	LastSession int64 `json:"last_session"`
+	// Crashed is an earlier run that did not finish and that no --resume
+	// has salvaged yet. It is written back when this run finishes.
+	Crashed *RunState `json:"crashed,omitempty"`
+}
+
+// newRunID returns a sortable, unique run ID such as 20261016T101500-a1b2c3.
+func newRunID(now time.Time) string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return now.UTC().Format("20060102T150405") + "-" + hex.EncodeToString(b)
+}
+
+func runStatePath(archiveDir string) string {
+	return filepath.Join(archiveDir, runStateFile)
+}
+
+// loadRunState returns the run state left in archiveDir, or nil when no run
+// is recorded.
+func loadRunState(archiveDir string) (*RunState, error) {
+	data, err := os.ReadFile(runStatePath(archiveDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading run state: %w", err)
+	}
+	var state RunState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", runStatePath(archiveDir), err)
+	}
+	return &state, nil
+}
+
+func saveRunState(archiveDir string, state RunState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(runStatePath(archiveDir), append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing run state: %w", err)
+	}
+	return nil
+}
+
+func clearRunState(archiveDir string) error {
+	if err := os.Remove(runStatePath(archiveDir)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing run state: %w", err)
+	}
+	return nil
+}
+
+// startRun records a sync starting under the archive lock. A state left by
+// a crashed run is returned. Unless resume salvages it now, the new state
+// carries the crashed run, and finishRun leaves it on disk, so a later
+// --resume still covers what it archived.
+func startRun(archiveDir string, runID, tempDir string, now time.Time, resume bool) (crashed *RunState, err error) {
+	left, err := loadRunState(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+	state := RunState{RunID: runID, PID: os.Getpid(), StartedAt: now, TempDir: tempDir}
+	if archiveExists(archiveDir) {
+		if state.LastSession, err = latestSessionID(archiveDir); err != nil {
+			return nil, err
+		}
+	}
+	if left != nil {
+		crashed = pendingCrash(left)
+		if !resume {
+			state.Crashed = crashed
+		}
+	}
+	return crashed, saveRunState(archiveDir, state)
+}
+
+// pendingCrash folds a crashed run and the unsalvaged run it carried, if
+// any, into one record with the earlier session floor.
+func pendingCrash(crashed *RunState) *RunState {
+	pending := *crashed
+	pending.Crashed = nil
+	if earlier := crashed.Crashed; earlier != nil && earlier.LastSession < pending.LastSession {
+		pending.LastSession = earlier.LastSession
+	}
+	return &pending
+}
+
+// finishRun removes the run state of a sync that finished, or replaces it
+// with the crashed run it carried so the next sync still reports it.
+func finishRun(archiveDir string) error {
+	state, err := loadRunState(archiveDir)
+	if err != nil {
+		return err
+	}
+	if state != nil && state.Crashed != nil {
+		return saveRunState(archiveDir, *state.Crashed)
+	}
+	return clearRunState(archiveDir)
+}
+
+// salvageRun returns the channel-dates a crashed run archived, which it may
+// never have rendered, and removes the temp directory it left behind.
+func salvageRun(archiveDir string, crashed *RunState, opts RenderOptions, channelNames map[string]string) ([]renderTarget, error) {
+	if crashed.TempDir != "" {
+		if err := os.RemoveAll(crashed.TempDir); err != nil {
+			return nil, fmt.Errorf("removing temp directory of run %s: %w", crashed.RunID, err)
+		}
+	}
+	return sessionRenderTargets(archiveDir, crashed.LastSession, opts, channelNames)
+}
+
+// mergeRenderTargets appends the targets of extra missing from targets.
+func mergeRenderTargets(targets, extra []renderTarget) []renderTarget {
+	seen := make(map[renderTarget]bool, len(targets))
+	for _, t := range targets {
+		seen[t] = true
+	}
+	for _, t := range extra {
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
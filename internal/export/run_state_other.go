@@ -0,0 +1,20 @@
+//go:build !unix
+
+package export
+
+import "os"
+
+// processRunning reports whether a process with pid exists. Where the
+// platform cannot look a process up, it is assumed to be running so its
+// temp files are kept.
+func processRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+	return true
+}
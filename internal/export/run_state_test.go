@@ -0,0 +1,143 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewRunID_SortableAndUnique(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)
+	a, b := newRunID(now), newRunID(now)
+	if !regexp.MustCompile(`^20261016T101500-[0-9a-f]{6}$`).MatchString(a) {
+		t.Errorf("newRunID() = %q, want 20261016T101500-<hex>", a)
+	}
+	if a == b {
+		t.Errorf("newRunID() returned %q twice", a)
+	}
+}
+
+func TestStartRun_ReportsCrashedRun(t *testing.T) {
+	archiveDir := t.TempDir()
+	writeSessionTestArchive(t, archiveDir)
+	now := time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)
+
+	crashed, err := startRun(archiveDir, "first", "/tmp/slack-export-first", now, false)
+	if err != nil {
+		t.Fatalf("startRun() error = %v", err)
+	}
+	if crashed != nil {
+		t.Fatalf("startRun() crashed = %+v, want nil on a clean archive", crashed)
+	}
+	state, err := loadRunState(archiveDir)
+	if err != nil || state == nil {
+		t.Fatalf("loadRunState() = %+v, %v", state, err)
+	}
+	if state.RunID != "first" || state.PID != os.Getpid() || state.LastSession != 3 {
+		t.Errorf("run state = %+v, want run first at session 3", state)
+	}
+
+	crashed, err = startRun(archiveDir, "second", "", now.Add(time.Hour), false)
+	if err != nil {
+		t.Fatalf("startRun() error = %v", err)
+	}
+	if crashed == nil || crashed.RunID != "first" || crashed.TempDir != "/tmp/slack-export-first" {
+		t.Fatalf("startRun() crashed = %+v, want run first", crashed)
+	}
+
+	if err := clearRunState(archiveDir); err != nil {
+		t.Fatalf("clearRunState() error = %v", err)
+	}
+	if state, err := loadRunState(archiveDir); state != nil || err != nil {
+		t.Errorf("loadRunState() after clear = %+v, %v, want nil", state, err)
+	}
+}
+
+func TestRunState_CrashThenPlainSyncThenResume(t *testing.T) {
+	archiveDir := t.TempDir()
+	writeSessionTestArchive(t, archiveDir)
+	now := time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)
+	if err := saveRunState(archiveDir, RunState{RunID: "crashed", LastSession: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	crashed, err := startRun(archiveDir, "plain", "", now, false)
+	if err != nil || crashed == nil || crashed.RunID != "crashed" {
+		t.Fatalf("plain startRun() = %+v, %v; want the crashed run reported", crashed, err)
+	}
+	if err := finishRun(archiveDir); err != nil {
+		t.Fatalf("finishRun() error = %v", err)
+	}
+	state, err := loadRunState(archiveDir)
+	if err != nil || state == nil || state.RunID != "crashed" || state.LastSession != 1 {
+		t.Fatalf("run state after plain sync = %+v, %v; want the crashed run kept", state, err)
+	}
+
+	crashed, err = startRun(archiveDir, "resumed", "", now.Add(time.Hour), true)
+	if err != nil || crashed == nil || crashed.RunID != "crashed" || crashed.LastSession != 1 {
+		t.Fatalf("resume startRun() = %+v, %v; want the crashed run at session 1", crashed, err)
+	}
+	got, err := salvageRun(archiveDir, crashed, RenderOptions{Timezone: "America/Chicago"}, nil)
+	if err != nil || len(got) == 0 {
+		t.Fatalf("salvageRun() = %v, %v; want the crashed run's channel-dates", renderTargetsForTest(got), err)
+	}
+	if err := finishRun(archiveDir); err != nil {
+		t.Fatalf("finishRun() error = %v", err)
+	}
+	if state, err := loadRunState(archiveDir); state != nil || err != nil {
+		t.Errorf("run state after resume = %+v, %v; want none", state, err)
+	}
+}
+
+func TestStartRun_KeepsEarliestFloorAcrossCrashes(t *testing.T) {
+	archiveDir := t.TempDir()
+	writeSessionTestArchive(t, archiveDir)
+	now := time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)
+	left := RunState{RunID: "second", LastSession: 2, Crashed: &RunState{RunID: "first", LastSession: 1}}
+	if err := saveRunState(archiveDir, left); err != nil {
+		t.Fatal(err)
+	}
+
+	crashed, err := startRun(archiveDir, "third", "", now, true)
+	if err != nil {
+		t.Fatalf("startRun() error = %v", err)
+	}
+	if crashed.RunID != "second" || crashed.LastSession != 1 || crashed.Crashed != nil {
+		t.Errorf("crashed = %+v, want run second with the first run's floor 1", crashed)
+	}
+}
+
+func TestSalvageRun_TargetsSessionsAfterFloorAndRemovesTempDir(t *testing.T) {
+	archiveDir := t.TempDir()
+	writeSessionTestArchive(t, archiveDir)
+	tempDir := filepath.Join(t.TempDir(), RunTempPrefix+"crashed")
+	if err := os.Mkdir(tempDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := salvageRun(archiveDir, &RunState{RunID: "crashed", TempDir: tempDir, LastSession: 1},
+		RenderOptions{Timezone: "America/Chicago"}, nil)
+	if err != nil {
+		t.Fatalf("salvageRun() error = %v", err)
+	}
+	want := []string{"C_ALPHA:2026-07-01", "C_ALPHA:2026-07-02", "C_BETA:2026-07-03", "C_UNFINISHED:2026-07-03"}
+	if strings.Join(renderTargetsForTest(got), "|") != strings.Join(want, "|") {
+		t.Errorf("salvageRun() = %v, want %v", renderTargetsForTest(got), want)
+	}
+	if _, err := os.Stat(tempDir); !os.IsNotExist(err) {
+		t.Errorf("crashed run's temp dir should be removed, stat err = %v", err)
+	}
+}
+
+func TestMergeRenderTargets_AppendsMissing(t *testing.T) {
+	got := mergeRenderTargets(
+		[]renderTarget{{channelID: "C1", date: "2026-07-01"}},
+		[]renderTarget{{channelID: "C1", date: "2026-07-01"}, {channelID: "C2", date: "2026-07-01"}},
+	)
+	if want := "C1:2026-07-01|C2:2026-07-01"; strings.Join(renderTargetsForTest(got), "|") != want {
+		t.Errorf("mergeRenderTargets() = %v, want %s", renderTargetsForTest(got), want)
+	}
+}
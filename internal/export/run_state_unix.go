@@ -0,0 +1,17 @@
+//go:build unix
+
+package export
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processRunning reports whether a process with pid exists.
+func processRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
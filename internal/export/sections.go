@@ -0,0 +1,111 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// channelSectionsFilename records the sidebar section of each tracked
+// channel, for output targets whose output_dir uses {{.Section}}.
+const channelSectionsFilename = ".slack-export-sections.json"
+
+type channelSectionsData struct {
+	Channels map[string]string `json:"channels"`
+}
+
+// recordChannelSections stores the sidebar section of each tracked channel
+// when an output target is split by section. Sections stored for other
+// channels are kept. Failures only warn, and the previous sections are used.
+func (e *Exporter) recordChannelSections(ctx context.Context, archiveDir string, tracked []slack.Channel) {
+	if !e.cfg.UsesSections() {
+		return
+	}
+	sections, err := e.edgeClient.FetchChannelSections(ctx)
+	if err != nil {
+		e.warnf("failed to fetch sidebar sections: %v", err)
+		return
+	}
+	if err := saveChannelSections(archiveDir, slack.ChannelSectionLabels(sections, tracked)); err != nil {
+		e.warnf("failed to record sidebar sections: %v", err)
+	}
+}
+
+func saveChannelSections(archiveDir string, labels map[string]string) error {
+	stored, err := loadChannelSections(archiveDir)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		stored = make(map[string]string, len(labels))
+	}
+	for id, label := range labels {
+		stored[id] = label
+	}
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(channelSectionsData{Channels: stored}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writeFileIfChanged(filepath.Join(archiveDir, channelSectionsFilename), append(data, '\n'), false)
+	return err
+}
+
+func loadChannelSections(archiveDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(archiveDir, channelSectionsFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored channelSectionsData
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	return stored.Channels, nil
+}
+
+// sectionSelections splits a target's channels into one selection per
+// sidebar section directory. Channels with no recorded section go where
+// the Slack sidebar would show them.
+func sectionSelections(
+	target config.OutputTarget,
+	matched []slack.Channel,
+	sections map[string]string,
+) ([]outputSelection, error) {
+	byDir := make(map[string][]string)
+	for _, ch := range matched {
+		section, ok := sections[ch.ID]
+		if !ok {
+			section = slack.SectionChannels
+			if strings.HasPrefix(ch.Name, "dm_") || strings.HasPrefix(ch.Name, "mpdm") {
+				section = slack.SectionDirectMessages
+			}
+		}
+		dir, err := target.SectionDir(section)
+		if err != nil {
+			return nil, err
+		}
+		byDir[dir] = append(byDir[dir], ch.ID)
+	}
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	selections := make([]outputSelection, 0, len(dirs))
+	for _, dir := range dirs {
+		selections = append(selections, outputSelection{outputDir: dir, ids: byDir[dir]})
+	}
+	return selections, nil
+}
@@ -120,21 +120,43 @@ func findSlackdumpInDir(dir string) (string, error) {
 // Empty string means use the real executable directory.
 var testExeDir string
 
+// SlackdumpInstall describes the slackdump binary slack-export uses.
+type SlackdumpInstall struct {
+	Path string
+	// Version is empty when the binary does not report one.
+	Version string
+	// Bundled is set for the copy installed next to slack-export.
+	Bundled bool
+	// Skipped explains why a slackdump on PATH was passed over.
+	Skipped string
+}
+
 // FindSlackdump locates the slackdump binary.
 // Priority order:
 // 1. System PATH if version >= MinSlackdumpVersion
 // 2. Bundled binary next to the executable
 func FindSlackdump() (string, error) {
+	install, err := LocateSlackdump()
+	if install.Skipped != "" {
+		fmt.Println(install.Skipped)
+	}
+	return install.Path, err
+}
+
+// LocateSlackdump finds slackdump as FindSlackdump does, without printing,
+// and reports its version.
+func LocateSlackdump() (SlackdumpInstall, error) {
+	var install SlackdumpInstall
 	// Try system PATH first, check version
 	if path, err := exec.LookPath("slackdump"); err == nil {
 		version, verr := SlackdumpVersion(path)
 		if verr == nil {
 			cmp, cerr := CompareVersions(version, MinSlackdumpVersion)
 			if cerr == nil && cmp >= 0 {
-				return path, nil
+				return SlackdumpInstall{Path: path, Version: version}, nil
 			}
 			// Version is below minimum, fall back to bundled
-			fmt.Printf("System slackdump version %s is below minimum %s, using bundled binary\n",
+			install.Skipped = fmt.Sprintf("System slackdump version %s is below minimum %s, using bundled binary",
 				version, MinSlackdumpVersion)
 		}
 		// Version check failed (unknown or parse error), fall back to bundled
@@ -150,11 +172,13 @@ func FindSlackdump() (string, error) {
 
 	if exeDir != "" {
 		if path, err := findSlackdumpInDir(exeDir); err == nil {
-			return path, nil
+			install.Path, install.Bundled = path, true
+			install.Version, _ = SlackdumpVersion(path)
+			return install, nil
 		}
 	}
 
-	return "", errors.New("slackdump not found - ensure it's installed alongside slack-export")
+	return install, errors.New("slackdump not found - ensure it's installed alongside slack-export")
 }
 
 // ResumeOptions configures a slackdump v4 resume run.
@@ -164,9 +188,19 @@ type ResumeOptions struct {
 	SkipCompleteThreads bool
 	Dedupe              bool
 	APIConfigPath       string
+	TempDir             string
+	// Workspace selects the slackdump workspace; empty uses its current one.
+	Workspace string
+	// Reconcile resumes every tracked channel, not only channels whose
+	// counts moved, so edits inside the lookback window are re-fetched.
+	Reconcile bool
+	// ExtraArgs are the configured slackdump_args, placed after the
+	// managed flags so they take precedence.
+	ExtraArgs []string
 }
 
 // BootstrapArchive creates a persistent slackdump v4 database archive.
+// extraArgs are the configured slackdump_args.
 func BootstrapArchive(
 	ctx context.Context,
 	slackdumpPath string,
@@ -174,6 +208,9 @@ func BootstrapArchive(
 	channelIDs []string,
 	timeFrom time.Time,
 	apiConfigPath string,
+	tempDir string,
+	workspace string,
+	extraArgs ...string,
 ) error {
 	if len(channelIDs) == 0 {
 		return errors.New("no channels to archive")
@@ -189,9 +226,13 @@ func BootstrapArchive(
 	if apiConfigPath != "" {
 		args = append(args, "-api-config", apiConfigPath)
 	}
+	if workspace != "" {
+		args = append(args, "-workspace", workspace)
+	}
+	args = append(args, extraArgs...)
 	args = append(args, channelIDs...)
 
-	return runSlackdump(ctx, slackdumpPath, args, "slackdump archive failed")
+	return runSlackdump(ctx, slackdumpPath, args, tempDir, "slackdump archive failed")
 }
 
 // ResumeArchive refreshes a persistent slackdump v4 database archive.
@@ -218,10 +259,14 @@ func ResumeArchive(
 	if opts.APIConfigPath != "" {
 		args = append(args, "-api-config", opts.APIConfigPath)
 	}
+	if opts.Workspace != "" {
+		args = append(args, "-workspace", opts.Workspace)
+	}
+	args = append(args, opts.ExtraArgs...)
 	args = append(args, archiveDir)
 	args = append(args, entityArgs...)
 
-	return runSlackdump(ctx, slackdumpPath, args, "slackdump resume failed")
+	return runSlackdump(ctx, slackdumpPath, args, opts.TempDir, "slackdump resume failed")
 }
 
 func toISODuration(value string) string {
@@ -231,18 +276,37 @@ func toISODuration(value string) string {
 	return "p" + value
 }
 
-func runSlackdump(ctx context.Context, slackdumpPath string, args []string, errPrefix string) error {
+func runSlackdump(ctx context.Context, slackdumpPath string, args []string, tempDir, errPrefix string) error {
 	// #nosec G204 -- slackdumpPath comes from FindSlackdump, not untrusted input
 	cmd := exec.CommandContext(ctx, slackdumpPath, args...)
 	fmt.Printf("EXECUTING: %s %s\n", slackdumpPath, strings.Join(args, " "))
+	cmd.Env = SlackdumpEnv(tempDir)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", errPrefix, err)
+		return withKind(ErrSlackdumpFailed, fmt.Errorf("%s: %w", errPrefix, err))
 	}
 	return nil
 }
 
+// SlackdumpEnv returns the environment for a slackdump subprocess, or nil
+// to inherit ours unchanged. Temporary files go to tempDir when it is set,
+// and slackdump decrypts its credentials with the slack.MachineIDEnv
+// override so it reads what slack-export wrote.
+func SlackdumpEnv(tempDir string) []string {
+	var extra []string
+	if tempDir != "" {
+		extra = append(extra, "TMPDIR="+tempDir, "TMP="+tempDir, "TEMP="+tempDir, "SQLITE_TMPDIR="+tempDir)
+	}
+	if id := strings.TrimSpace(os.Getenv(slack.MachineIDEnv)); id != "" {
+		extra = append(extra, "MACHINE_ID_OVERRIDE="+id)
+	}
+	if len(extra) == 0 {
+		return nil
+	}
+	return append(os.Environ(), extra...)
+}
+
 // SlackdumpRunner wraps the slackdump CLI for message export.
 type SlackdumpRunner struct {
 	binPath string
@@ -74,7 +74,7 @@ func TestBootstrapArchive_EmptyChannels(t *testing.T) {
 	ctx := context.Background()
 	timeFrom := time.Date(2026, 1, 22, 0, 0, 0, 0, time.UTC)
 
-	err := BootstrapArchive(ctx, "/nonexistent/slackdump", t.TempDir(), nil, timeFrom, "")
+	err := BootstrapArchive(ctx, "/nonexistent/slackdump", t.TempDir(), nil, timeFrom, "", "", "")
 	if err == nil {
 		t.Fatal("BootstrapArchive() with empty channels should return error")
 	}
@@ -82,7 +82,7 @@ func TestBootstrapArchive_EmptyChannels(t *testing.T) {
 		t.Errorf("error %q should mention 'no channels to archive'", err.Error())
 	}
 
-	err = BootstrapArchive(ctx, "/nonexistent/slackdump", t.TempDir(), []string{}, timeFrom, "")
+	err = BootstrapArchive(ctx, "/nonexistent/slackdump", t.TempDir(), []string{}, timeFrom, "", "", "")
 	if err == nil {
 		t.Fatal("BootstrapArchive() with empty slice should return error")
 	}
@@ -104,7 +104,8 @@ func TestBootstrapArchive_CommandShape(t *testing.T) {
 	archiveDir := filepath.Join(tmpDir, "archive")
 	apiConfigPath := filepath.Join(tmpDir, "slackdump-api-limits.yaml")
 	seed := time.Date(2026, 1, 22, 8, 0, 0, 0, time.UTC)
-	err := BootstrapArchive(context.Background(), fakeBin, archiveDir, []string{"C123", "D456"}, seed, apiConfigPath)
+	ids := []string{"C123", "D456"}
+	err := BootstrapArchive(context.Background(), fakeBin, archiveDir, ids, seed, apiConfigPath, "", "", "-member-only")
 	if err != nil {
 		t.Fatalf("BootstrapArchive() error = %v", err)
 	}
@@ -120,6 +121,7 @@ func TestBootstrapArchive_CommandShape(t *testing.T) {
 		"-o", archiveDir,
 		"-time-from=2026-01-22T08:00:00",
 		"-api-config", apiConfigPath,
+		"-member-only",
 		"C123",
 		"D456",
 		"",
@@ -149,6 +151,8 @@ func TestResumeArchive_CommandShape(t *testing.T) {
 		SkipCompleteThreads: true,
 		Dedupe:              true,
 		APIConfigPath:       filepath.Join(tmpDir, "slackdump-api-limits.yaml"),
+		Workspace:           "acme",
+		ExtraArgs:           []string{"-member-only", "-api-config=limits.toml"},
 	}
 	err := ResumeArchive(context.Background(), fakeBin, archiveDir, []string{"C123"}, opts)
 	if err != nil {
@@ -167,6 +171,9 @@ func TestResumeArchive_CommandShape(t *testing.T) {
 		"-skip-complete-threads",
 		"-dedupe",
 		"-api-config", opts.APIConfigPath,
+		"-workspace", "acme",
+		"-member-only",
+		"-api-config=limits.toml",
 		archiveDir,
 		"C123",
 		"",
@@ -203,7 +210,7 @@ func TestBootstrapArchive_InvalidBinary(t *testing.T) {
 	ctx := context.Background()
 	timeFrom := time.Date(2026, 1, 22, 0, 0, 0, 0, time.UTC)
 
-	err := BootstrapArchive(ctx, "/nonexistent/slackdump", t.TempDir(), []string{"C123"}, timeFrom, "")
+	err := BootstrapArchive(ctx, "/nonexistent/slackdump", t.TempDir(), []string{"C123"}, timeFrom, "", "", "")
 	if err == nil {
 		t.Fatal("BootstrapArchive() with nonexistent binary should return error")
 	}
@@ -0,0 +1,164 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// statusChange is one observed change of a user's presence or status.
+type statusChange struct {
+	name  string
+	field string
+	from  string
+	to    string
+}
+
+// recordStatusChanges snapshots statuses when status_audit is enabled and
+// appends changes since the previous sync to each output directory's
+// <date>/status.md. Slack keeps no status history, so a change is
+// timestamped when a sync first sees it. Failures only warn.
+func (e *Exporter) recordStatusChanges(ctx context.Context, archiveDir string, now time.Time) {
+	if e.cfg.StatusAudit == "" {
+		return
+	}
+	statuses, err := e.edgeClient.CollectStatuses(ctx, e.cfg.StatusAudit == "all")
+	if err != nil {
+		e.warnf("failed to collect user statuses: %v", err)
+		return
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		e.warnf("failed to record status changes: %v", err)
+		return
+	}
+	if err := recordStatuses(archiveDir, e.cfg.OutputDirs(), e.cfg.Layout, statuses, now.In(loc)); err != nil {
+		e.warnf("failed to record status changes: %v", err)
+	}
+}
+
+// recordStatuses diffs statuses against the archive's last snapshot. The
+// first run only stores the snapshot.
+func recordStatuses(
+	archiveDir string,
+	outputDirs []string,
+	layout string,
+	statuses []slack.UserStatus,
+	now time.Time,
+) error {
+	current := make(map[string]slack.UserStatus, len(statuses))
+	for _, status := range statuses {
+		current[status.UserID] = status
+	}
+	previous, err := loadStatusSnapshot(archiveDir)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if changes := statusChanges(previous, current); len(changes) > 0 {
+			for _, dir := range outputDirs {
+				if err := appendStatusLog(dir, layout, changes, now); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return saveStatusSnapshot(archiveDir, current)
+}
+
+// statusChanges compares users present in both snapshots, ordered by name.
+// Presence is compared only when both snapshots recorded it.
+func statusChanges(previous, current map[string]slack.UserStatus) []statusChange {
+	var changes []statusChange
+	for id, cur := range current {
+		prev, ok := previous[id]
+		if !ok {
+			continue
+		}
+		if prev.Presence != "" && cur.Presence != "" && prev.Presence != cur.Presence {
+			changes = append(changes, statusChange{name: cur.Name, field: "presence", from: prev.Presence, to: cur.Presence})
+		}
+		if from, to := statusLabel(prev), statusLabel(cur); from != to {
+			changes = append(changes, statusChange{name: cur.Name, field: "status", from: from, to: to})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].name != changes[j].name {
+			return changes[i].name < changes[j].name
+		}
+		return changes[i].field < changes[j].field
+	})
+	return changes
+}
+
+func statusLabel(status slack.UserStatus) string {
+	label := strings.TrimSpace(status.Emoji + " " + status.Text)
+	if label == "" {
+		return "(none)"
+	}
+	return label
+}
+
+// appendStatusLog appends changes to <dir>/<work date>/status.md, using the
+// same 3am day boundary as message exports.
+func appendStatusLog(dir, layout string, changes []statusChange, now time.Time) error {
+	date := workDate(now)
+	path := filepath.Join(dateDir(dir, date, layout), dayFileName(date, layout, "status.md"))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	var b strings.Builder
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(&b, "# Status changes %s\n\n", date)
+	}
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- %s %s: %s %s → %s\n", now.Format("15:04"), change.name, change.field, change.from, change.to)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func statusSnapshotPath(archiveDir string) string {
+	return filepath.Join(archiveDir, ".slack-export-status.json")
+}
+
+func loadStatusSnapshot(archiveDir string) (map[string]slack.UserStatus, error) {
+	data, err := os.ReadFile(statusSnapshotPath(archiveDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading status snapshot: %w", err)
+	}
+	var snapshot map[string]slack.UserStatus
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("parsing status snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func saveStatusSnapshot(archiveDir string, snapshot map[string]slack.UserStatus) error {
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(statusSnapshotPath(archiveDir), data, 0600)
+}
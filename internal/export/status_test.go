@@ -0,0 +1,75 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestRecordStatuses_FirstRunOnlySnapshots(t *testing.T) {
+	archiveDir, outputDir := t.TempDir(), t.TempDir()
+	now := time.Date(2026, 7, 3, 9, 30, 0, 0, time.UTC)
+
+	statuses := []slack.UserStatus{{UserID: "U1", Name: "alice", Presence: "active"}}
+	if err := recordStatuses(archiveDir, []string{outputDir}, "", statuses, now); err != nil {
+		t.Fatalf("recordStatuses() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-07-03", "status.md")); !os.IsNotExist(err) {
+		t.Errorf("first run should not write status.md, stat err = %v", err)
+	}
+	if _, err := os.Stat(statusSnapshotPath(archiveDir)); err != nil {
+		t.Errorf("snapshot not saved: %v", err)
+	}
+}
+
+func TestRecordStatuses_AppendsChanges(t *testing.T) {
+	archiveDir, outputDir := t.TempDir(), t.TempDir()
+	morning := time.Date(2026, 7, 3, 9, 30, 0, 0, time.UTC)
+	first := []slack.UserStatus{
+		{UserID: "U1", Name: "alice", Presence: "active"},
+		{UserID: "U2", Name: "bob", Text: "Vacation", Emoji: ":palm_tree:"},
+	}
+	second := []slack.UserStatus{
+		{UserID: "U1", Name: "alice", Presence: "away", Text: "Lunch"},
+		{UserID: "U2", Name: "bob", Text: "Vacation", Emoji: ":palm_tree:"},
+		{UserID: "U3", Name: "carol", Text: "New here"},
+	}
+	third := []slack.UserStatus{{UserID: "U1", Name: "alice", Presence: "active"}}
+
+	for i, statuses := range [][]slack.UserStatus{first, second, third} {
+		at := morning.Add(time.Duration(i) * 2 * time.Hour)
+		if err := recordStatuses(archiveDir, []string{outputDir}, "", statuses, at); err != nil {
+			t.Fatalf("recordStatuses() run %d error = %v", i, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-07-03", "status.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Status changes 2026-07-03\n\n" +
+		"- 11:30 alice: presence active → away\n" +
+		"- 11:30 alice: status (none) → Lunch\n" +
+		"- 13:30 alice: presence away → active\n" +
+		"- 13:30 alice: status Lunch → (none)\n"
+	if string(data) != want {
+		t.Errorf("status.md =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestAppendStatusLog_UsesWorkDayBoundary(t *testing.T) {
+	outputDir := t.TempDir()
+	lateNight := time.Date(2026, 7, 4, 1, 15, 0, 0, time.UTC)
+
+	if err := appendStatusLog(outputDir, "", []statusChange{{name: "alice", field: "presence", from: "active",
+		to: "away"}}, lateNight); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2026-07-03", "status.md")); err != nil {
+		t.Errorf("01:15 change should belong to the previous work day: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/chrisedwards/slack-export/pkg/slackts"
+	rslack "github.com/rusq/slack"
+)
+
+// DefaultTailInterval is how often Tail polls for new messages, and
+// MinTailInterval the shortest interval the tail command accepts.
+const (
+	DefaultTailInterval = 5 * time.Second
+	MinTailInterval     = time.Second
+)
+
+// TailOptions controls Tail.
+type TailOptions struct {
+	// Channel is a tracked channel's file name (e.g. dm_alice) or ID.
+	Channel string
+	// Backlog is the number of recent messages shown before following.
+	Backlog int
+	// Interval is the polling interval. Zero uses DefaultTailInterval.
+	Interval time.Duration
+	// NoAppend only prints messages, leaving today's export files alone.
+	NoAppend bool
+}
+
+// tailSource fetches a channel's top-level messages for Tail.
+type tailSource interface {
+	RecentMessages(ctx context.Context, channelID string, limit int) ([]json.RawMessage, error)
+	MessagesAfter(ctx context.Context, channelID, ts string) ([]json.RawMessage, error)
+}
+
+// Tail polls a tracked channel and writes each new top-level message to w,
+// formatted as in the export, until ctx is cancelled. Unless NoAppend is
+// set, each message is also appended to its day's file in every output
+// directory that selects the channel; the next sync re-renders that file
+// from the archive. Thread replies are not followed.
+func (e *Exporter) Tail(ctx context.Context, w io.Writer, opts TailOptions) (err error) {
+	defer func() { err = classifyError(err) }()
+	archiveDir, err := e.ArchiveDir()
+	if err != nil {
+		return err
+	}
+	tracked, err := e.trackedChannels(ctx)
+	if err != nil {
+		return err
+	}
+	ch, ok := findTailChannel(tracked, opts.Channel)
+	if !ok {
+		return withKind(ErrChannelSkipped, fmt.Errorf("channel %q is not tracked; check your include patterns", opts.Channel))
+	}
+	userIndex, err := e.edgeClient.FetchUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching users: %w", err)
+	}
+	renderOpts, err := attachPseudonyms(e.renderOptions(ctx), archiveDir)
+	if err != nil {
+		return err
+	}
+
+	var outputDirs []string
+	if !opts.NoAppend {
+		selections, err := outputSelections(e.cfg, archiveDir, []string{ch.ID})
+		if err != nil {
+			return err
+		}
+		for _, sel := range selections {
+			outputDirs = append(outputDirs, sel.outputDir)
+		}
+		if len(outputDirs) == 0 {
+			outputDirs = []string{e.cfg.OutputDir}
+		}
+	}
+
+	t := &tailer{
+		src:        e.edgeClient,
+		out:        w,
+		lookup:     newRenderLookup(tailUsers(userIndex), renderOpts),
+		opts:       renderOpts,
+		outputDirs: outputDirs,
+		warnf:      e.warnf,
+	}
+	t.channel = t.lookup.channelFileName(channelNameResolver{ch.ID: ch.Name}, tailRSlackChannel(ch))
+	t.channelID = ch.ID
+	e.stagef("Following %s (Ctrl-C to stop)", t.channel)
+	if err := t.run(ctx, opts); err != nil {
+		return err
+	}
+	return renderOpts.pseudonyms.save()
+}
+
+// findTailChannel matches channel against tracked channels by ID or,
+// case-insensitively, by name.
+func findTailChannel(tracked []slack.Channel, channel string) (slack.Channel, bool) {
+	channel = strings.TrimPrefix(strings.TrimSpace(channel), "#")
+	for _, ch := range tracked {
+		if ch.ID == channel || strings.EqualFold(ch.Name, channel) {
+			return ch, true
+		}
+	}
+	return slack.Channel{}, false
+}
+
+// tailUsers converts the workspace's users for name resolution.
+func tailUsers(index slack.UserIndex) userLookup {
+	users := make(userLookup, len(index))
+	for id, u := range index {
+		users[id] = rslackUser(u)
+	}
+	return users
+}
+
+func tailRSlackChannel(ch slack.Channel) rslack.Channel {
+	var out rslack.Channel
+	out.ID = ch.ID
+	out.Name = ch.Name
+	out.IsIM = ch.IsIM
+	out.IsMpIM = ch.IsMPIM
+	out.IsPrivate = ch.IsPrivate
+	return out
+}
+
+// tailer follows one channel.
+type tailer struct {
+	src        tailSource
+	out        io.Writer
+	lookup     renderLookup
+	opts       RenderOptions
+	channelID  string
+	channel    string
+	outputDirs []string
+	warnf      func(format string, args ...any)
+	// last is the timestamp of the newest message seen.
+	last string
+}
+
+// run prints the backlog, then polls every interval until ctx is done.
+// Failed polls only warn so a brief outage does not end the tail; expired
+// credentials do.
+func (t *tailer) run(ctx context.Context, opts TailOptions) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultTailInterval
+	}
+	if opts.Backlog > 0 {
+		recent, err := t.src.RecentMessages(ctx, t.channelID, opts.Backlog)
+		if err != nil {
+			return fmt.Errorf("fetching recent messages: %w", err)
+		}
+		if err := t.emit(recent, false); err != nil {
+			return err
+		}
+	}
+	if t.last == "" {
+		t.last = slackts.FormatSlackTS(time.Now())
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		messages, err := t.src.MessagesAfter(ctx, t.channelID, t.last)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case slack.IsAuthFailure(err):
+			return err
+		case err != nil:
+			t.warnf("polling %s: %v", t.channel, err)
+			continue
+		}
+		if err := t.emit(messages, true); err != nil {
+			return err
+		}
+	}
+}
+
+// emit writes messages, oldest first, and appends new ones to the export
+// files when record is set. Messages at or before the last one seen are
+// skipped, so a backlog overlapping the first poll is not repeated.
+func (t *tailer) emit(raw []json.RawMessage, record bool) error {
+	for _, data := range raw {
+		var msg rslack.Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.warnf("skipping undecodable message in %s: %v", t.channel, err)
+			continue
+		}
+		if record && slackts.CompareTS(msg.Timestamp, t.last) <= 0 {
+			continue
+		}
+		if slackts.CompareTS(msg.Timestamp, t.last) > 0 {
+			t.last = msg.Timestamp
+		}
+		if !t.opts.MessageFilter.keep(msg) {
+			continue
+		}
+		timezone := t.opts.timezoneFor(t.channelID, t.channel)
+		date, err := messageWorkDate(msg, timezone)
+		if err != nil {
+			continue
+		}
+		req := RenderRequest{Date: date, Timezone: timezone, ChannelID: t.channelID, ChannelName: t.channel}
+		var rendered bytes.Buffer
+		writeMessage(&rendered, msg, "", t.lookup.forChannel(req))
+		if _, err := t.out.Write(rendered.Bytes()); err != nil {
+			return err
+		}
+		if record {
+			if err := t.appendToExports(date, rendered.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendToExports appends a rendered message to the channel-day's file in
+// each output directory.
+func (t *tailer) appendToExports(date string, rendered []byte) error {
+	for _, dir := range t.outputDirs {
+		path := filepath.Join(dateDir(dir, date, t.opts.Layout), channelFileBase(date, t.channel, t.opts.FilenameDate, t.opts.Layout)+".md")
+		if err := appendFile(path, rendered); err != nil {
+			return fmt.Errorf("appending to %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func appendFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return errors.Join(err, f.Close())
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// fakeTailSource serves a backlog, then one batch of messages per poll,
+// cancelling the tail once the batches run out.
+type fakeTailSource struct {
+	recent  []json.RawMessage
+	polls   [][]json.RawMessage
+	afterTS []string
+	cancel  context.CancelFunc
+}
+
+func (f *fakeTailSource) RecentMessages(context.Context, string, int) ([]json.RawMessage, error) {
+	return f.recent, nil
+}
+
+func (f *fakeTailSource) MessagesAfter(_ context.Context, _ string, ts string) ([]json.RawMessage, error) {
+	f.afterTS = append(f.afterTS, ts)
+	if len(f.polls) == 0 {
+		f.cancel()
+		return nil, context.Canceled
+	}
+	batch := f.polls[0]
+	f.polls = f.polls[1:]
+	return batch, nil
+}
+
+func TestTailer_PrintsAndAppendsNewMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	raw := func(ts, user, text string) json.RawMessage {
+		return json.RawMessage(`{"ts": "` + ts + `", "user": "` + user + `", "text": "` + text + `"}`)
+	}
+	src := &fakeTailSource{
+		recent: []json.RawMessage{raw("1768478400.000100", "U1", "earlier")},
+		polls: [][]json.RawMessage{
+			{raw("1768478400.000100", "U1", "earlier"), raw("1768478460.000100", "U1", "paging <@U1>")},
+			{raw("1768478520.000100", "U1", "ack")},
+		},
+		cancel: cancel,
+	}
+	outputDir := t.TempDir()
+	var out bytes.Buffer
+	tl := &tailer{
+		src:        src,
+		out:        &out,
+		lookup:     newRenderLookup(userLookup{"U1": {ID: "U1", Name: "alice"}}, RenderOptions{Timezone: "UTC"}),
+		opts:       RenderOptions{Timezone: "UTC"},
+		channelID:  "C1",
+		channel:    "eng-incidents",
+		outputDirs: []string{outputDir},
+		warnf:      func(string, ...any) {},
+	}
+
+	if err := tl.run(ctx, TailOptions{Backlog: 1, Interval: time.Millisecond}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	printed := out.String()
+	if strings.Count(printed, "earlier") != 1 || !strings.Contains(printed, "paging alice") ||
+		!strings.Contains(printed, "ack") {
+		t.Errorf("printed:\n%s", printed)
+	}
+	if src.afterTS[0] != "1768478400.000100" {
+		t.Errorf("first poll after %q, want the newest backlog message", src.afterTS[0])
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "2026-01-15", "2026-01-15-eng-incidents.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "earlier") || !strings.Contains(string(data), "paging alice") ||
+		!strings.Contains(string(data), "ack") {
+		t.Errorf("appended file:\n%s", data)
+	}
+}
+
+func TestFindTailChannel(t *testing.T) {
+	tracked := []slack.Channel{{ID: "C1", Name: "eng-incidents"}, {ID: "D1", Name: "dm_alice"}}
+	for _, channel := range []string{"eng-incidents", "#ENG-incidents", "C1"} {
+		if ch, ok := findTailChannel(tracked, channel); !ok || ch.ID != "C1" {
+			t.Errorf("findTailChannel(%q) = %+v, %v", channel, ch, ok)
+		}
+	}
+	if _, ok := findTailChannel(tracked, "random"); ok {
+		t.Error("findTailChannel matched an untracked channel")
+	}
+}
+
+func TestTailUsers(t *testing.T) {
+	users := tailUsers(slack.UserIndex{"U1": {ID: "U1", Name: "alice", Profile: slack.UserProfile{DisplayName: "Alice A"}}})
+	if got := (renderLookup{users: users}).displayName("U1"); got != "Alice A" {
+		t.Errorf("displayName() = %q", got)
+	}
+}
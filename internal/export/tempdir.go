@@ -0,0 +1,162 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/rusq/slackdump/v4/source"
+)
+
+const (
+	// RunTempPrefix names the per-run temporary directories handed to slackdump.
+	RunTempPrefix = "slack-export-"
+
+	// minTempFreeBytes is the free-space floor for new or small archives.
+	minTempFreeBytes = 64 << 20
+)
+
+// runTempDirPattern matches the directories prepareRunTempDir creates,
+// slack-export-<runID>; see newRunID.
+var runTempDirPattern = regexp.MustCompile(`^` + RunTempPrefix + `\d{8}T\d{6}-[0-9a-f]{6}$`)
+
+// TempBaseDir returns the configured temp_dir, or the system temp directory.
+func TempBaseDir(cfg *config.Config) (string, error) {
+	if strings.TrimSpace(cfg.TempDir) == "" {
+		return os.TempDir(), nil
+	}
+	return expandPath(cfg.TempDir)
+}
+
+// prepareRunTempDir creates the run's temp directory, slack-export-<runID>,
+// after checking the base volume has room for slackdump's SQLite scratch
+// files. The cleanup function removes the directory; crashed runs leave it
+// for sync --resume or clean-temp.
+func (e *Exporter) prepareRunTempDir(archiveDir, runID string) (string, func(), error) {
+	base, err := TempBaseDir(e.cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.MkdirAll(base, 0750); err != nil {
+		return "", nil, fmt.Errorf("creating temp directory %s: %w", base, err)
+	}
+	if err := checkTempSpace(base, estimatedTempBytes(archiveDir)); err != nil {
+		return "", nil, err
+	}
+	dir := filepath.Join(base, RunTempPrefix+runID)
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return "", nil, fmt.Errorf("creating run temp directory: %w", err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }, nil
+}
+
+// estimatedTempBytes sizes the scratch space a run may need. SQLite can spill
+// up to a full copy of the database while deduplicating or rebuilding indexes.
+func estimatedTempBytes(archiveDir string) int64 {
+	info, err := os.Stat(filepath.Join(archiveDir, source.DefaultDBFile))
+	if err != nil || info.Size() < minTempFreeBytes {
+		return minTempFreeBytes
+	}
+	return info.Size()
+}
+
+func checkTempSpace(base string, need int64) error {
+	free, ok, err := freeDiskBytes(base)
+	if err != nil {
+		return fmt.Errorf("checking free space in %s: %w", base, err)
+	}
+	if ok && free < uint64(need) {
+		return fmt.Errorf(
+			"temp directory %s has %d MB free but this run may need about %d MB; set temp_dir to a larger volume",
+			base, free>>20, need>>20,
+		)
+	}
+	return nil
+}
+
+// LiveRunTempDirs returns the temp directories of the runs in progress on
+// the workspace archives under cfg's archive_dir. A run is live when the
+// PID in its run state is still running or it holds the archive lock. A
+// run holding the lock without a run state naming a running PID, such as a
+// dm export, is an error: its temp directory cannot be told apart.
+func LiveRunTempDirs(cfg *config.Config) (map[string]bool, error) {
+	archiveBase, err := expandPath(cfg.ArchiveDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(archiveBase)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading archive directory %s: %w", archiveBase, err)
+	}
+	live := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		archiveDir := filepath.Join(archiveBase, entry.Name())
+		state, err := loadRunState(archiveDir)
+		if err != nil {
+			return nil, err
+		}
+		running := state != nil && processRunning(state.PID)
+		if running && state.TempDir != "" {
+			live[filepath.Clean(state.TempDir)] = true
+			continue
+		}
+		if !archiveExists(archiveDir) {
+			continue
+		}
+		lock, acquired, err := acquireArchiveLock(archiveDir)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			return nil, fmt.Errorf("a run is in progress on %s; try again when it finishes", archiveDir)
+		}
+		if err := lock.Release(); err != nil {
+			return nil, err
+		}
+	}
+	return live, nil
+}
+
+// CleanTempDirs removes the slack-export-<runID> directories under base that
+// were last modified more than olderThan before now, except the live ones
+// from LiveRunTempDirs. With dryRun it only reports them.
+func CleanTempDirs(base string, live map[string]bool, olderThan time.Duration, now time.Time, dryRun bool) ([]string, error) {
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil, fmt.Errorf("reading temp directory %s: %w", base, err)
+	}
+	var removed []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !runTempDirPattern.MatchString(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(base, entry.Name())
+		if live[filepath.Clean(path)] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < olderThan {
+			continue
+		}
+		if !dryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return removed, fmt.Errorf("removing %s: %w", path, err)
+			}
+		}
+		removed = append(removed, path)
+	}
+	sort.Strings(removed)
+	return removed, nil
+}
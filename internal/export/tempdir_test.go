@@ -0,0 +1,230 @@
+package export
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/slack"
+	"github.com/rusq/slackdump/v4/source"
+)
+
+func TestTempBaseDir_DefaultsToSystemTemp(t *testing.T) {
+	got, err := TempBaseDir(&config.Config{})
+	if err != nil {
+		t.Fatalf("TempBaseDir() error = %v", err)
+	}
+	if got != os.TempDir() {
+		t.Errorf("TempBaseDir() = %q, want %q", got, os.TempDir())
+	}
+}
+
+func TestTempBaseDir_ExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	got, err := TempBaseDir(&config.Config{TempDir: "~/scratch"})
+	if err != nil {
+		t.Fatalf("TempBaseDir() error = %v", err)
+	}
+	if got != filepath.Join(home, "scratch") {
+		t.Errorf("TempBaseDir() = %q, want %q", got, filepath.Join(home, "scratch"))
+	}
+}
+
+func TestPrepareRunTempDir_CreatesAndCleansUp(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "tmp")
+	e := &Exporter{cfg: &config.Config{TempDir: base}}
+
+	dir, cleanup, err := e.prepareRunTempDir(t.TempDir(), "20261016T101500-a1b2c3")
+	if err != nil {
+		t.Fatalf("prepareRunTempDir() error = %v", err)
+	}
+	if want := filepath.Join(base, RunTempPrefix+"20261016T101500-a1b2c3"); dir != want {
+		t.Errorf("run temp dir = %q, want %q", dir, want)
+	}
+	cleanup()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("run temp dir should be removed by cleanup, stat err = %v", err)
+	}
+}
+
+func TestCheckTempSpace_FailsWhenVolumeTooSmall(t *testing.T) {
+	if _, ok, _ := freeDiskBytes(t.TempDir()); !ok {
+		t.Skip("free space not measurable on this platform")
+	}
+	err := checkTempSpace(t.TempDir(), math.MaxInt64)
+	if err == nil {
+		t.Fatal("checkTempSpace() should fail for an impossible requirement")
+	}
+	if !strings.Contains(err.Error(), "temp_dir") {
+		t.Errorf("error should point at temp_dir: %v", err)
+	}
+}
+
+func TestEstimatedTempBytes_UsesFloorForMissingArchive(t *testing.T) {
+	if got := estimatedTempBytes(t.TempDir()); got != minTempFreeBytes {
+		t.Errorf("estimatedTempBytes() = %d, want floor %d", got, minTempFreeBytes)
+	}
+}
+
+// makeTempDirs creates the named directories under base, backdating those
+// in old by two hours.
+func makeTempDirs(t *testing.T, base string, names []string, old ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.Mkdir(filepath.Join(base, name), 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	then := time.Now().Add(-2 * time.Hour)
+	for _, name := range old {
+		if err := os.Chtimes(filepath.Join(base, name), then, then); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCleanTempDirs_RemovesOnlyOldRunDirs(t *testing.T) {
+	base := t.TempDir()
+	now := time.Now()
+	const oldRun, newRun = "slack-export-20261016T101500-a1b2c3", "slack-export-20261016T111500-d4e5f6"
+	makeTempDirs(t, base, []string{oldRun, newRun, "slack-export-notes", "other-old"},
+		oldRun, "slack-export-notes", "other-old")
+
+	dryRun, err := CleanTempDirs(base, nil, time.Hour, now, true)
+	if err != nil {
+		t.Fatalf("CleanTempDirs() dry run error = %v", err)
+	}
+	if len(dryRun) != 1 {
+		t.Fatalf("dry run = %v, want only %s", dryRun, oldRun)
+	}
+	if _, err := os.Stat(dryRun[0]); err != nil {
+		t.Errorf("dry run should not remove %s: %v", dryRun[0], err)
+	}
+
+	removed, err := CleanTempDirs(base, nil, time.Hour, now, false)
+	if err != nil {
+		t.Fatalf("CleanTempDirs() error = %v", err)
+	}
+	if len(removed) != 1 || filepath.Base(removed[0]) != oldRun {
+		t.Fatalf("removed = %v, want only %s", removed, oldRun)
+	}
+	for _, name := range []string{newRun, "slack-export-notes", "other-old"} {
+		if _, err := os.Stat(filepath.Join(base, name)); err != nil {
+			t.Errorf("%s should be kept: %v", name, err)
+		}
+	}
+}
+
+func TestCleanTempDirs_KeepsLiveRunDir(t *testing.T) {
+	base := t.TempDir()
+	archiveBase := t.TempDir()
+	const liveRun, deadRun = "slack-export-20261016T101500-a1b2c3", "slack-export-20261015T101500-d4e5f6"
+	makeTempDirs(t, base, []string{liveRun, deadRun}, liveRun, deadRun)
+	archiveDir := filepath.Join(archiveBase, "acme")
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	state := RunState{RunID: "20261016T101500-a1b2c3", PID: os.Getpid(), TempDir: filepath.Join(base, liveRun)}
+	if err := saveRunState(archiveDir, state); err != nil {
+		t.Fatal(err)
+	}
+
+	live, err := LiveRunTempDirs(&config.Config{ArchiveDir: archiveBase})
+	if err != nil {
+		t.Fatalf("LiveRunTempDirs() error = %v", err)
+	}
+	removed, err := CleanTempDirs(base, live, time.Hour, time.Now(), false)
+	if err != nil {
+		t.Fatalf("CleanTempDirs() error = %v", err)
+	}
+	if len(removed) != 1 || filepath.Base(removed[0]) != deadRun {
+		t.Errorf("removed = %v, want only %s", removed, deadRun)
+	}
+}
+
+func TestLiveRunTempDirs_LockedArchiveWithoutRunState(t *testing.T) {
+	archiveBase := t.TempDir()
+	archiveDir := filepath.Join(archiveBase, "acme")
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(archiveDir, source.DefaultDBFile), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	lock, acquired, err := acquireArchiveLock(archiveDir)
+	if err != nil || !acquired {
+		t.Fatalf("acquireArchiveLock() = %v, %v", acquired, err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	if _, err := LiveRunTempDirs(&config.Config{ArchiveDir: archiveBase}); err == nil || !strings.Contains(err.Error(), "in progress") {
+		t.Errorf("LiveRunTempDirs() error = %v, want a run in progress", err)
+	}
+}
+
+func TestRunSlackdump_SetsTempEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "env.txt")
+	fakeBin := filepath.Join(tmpDir, "slackdump")
+	script := "#!/bin/sh\nprintf '%s\\n%s\\n' \"$TMPDIR\" \"$SQLITE_TMPDIR\" > " + logPath + "\n"
+	if err := os.WriteFile(fakeBin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	runTemp := filepath.Join(tmpDir, "slack-export-run")
+	if err := runSlackdump(context.Background(), fakeBin, nil, runTemp, "failed"); err != nil {
+		t.Fatalf("runSlackdump() error = %v", err)
+	}
+	got, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading env: %v", err)
+	}
+	if string(got) != runTemp+"\n"+runTemp+"\n" {
+		t.Errorf("slackdump temp env = %q, want %q twice", got, runTemp)
+	}
+}
+
+func TestRunSlackdump_PassesMachineIDOverride(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on Windows")
+	}
+	t.Setenv(slack.MachineIDEnv, "container-id")
+
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "env.txt")
+	fakeBin := filepath.Join(tmpDir, "slackdump")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$MACHINE_ID_OVERRIDE\" > " + logPath + "\n"
+	if err := os.WriteFile(fakeBin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSlackdump(context.Background(), fakeBin, []string{"archive"}, "", "failed"); err != nil {
+		t.Fatalf("runSlackdump() error = %v", err)
+	}
+	got, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading env: %v", err)
+	}
+	if string(got) != "container-id\n" {
+		t.Errorf("MACHINE_ID_OVERRIDE = %q, want container-id", got)
+	}
+}
+
+func TestSlackdumpEnv_InheritsWithoutOverrides(t *testing.T) {
+	t.Setenv(slack.MachineIDEnv, "")
+	if env := SlackdumpEnv(""); env != nil {
+		t.Errorf("SlackdumpEnv() = %d entries, want nil to inherit the environment", len(env))
+	}
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+// MessageData is the value a per-channel message template executes against.
+// config.templateSample must list the same fields.
+type MessageData struct {
+	Sender    string
+	User      string
+	Time      string
+	Timestamp time.Time
+	Text      string
+	Reply     bool
+	// Broadcast is true for thread replies also sent to the channel.
+	Broadcast bool
+	Channel   string
+	Date      string
+}
+
+// channelTemplate is the template selected for one channel-day.
+type channelTemplate struct {
+	tmpl    *template.Template
+	channel string
+	date    string
+}
+
+// compileTemplates parses the configured templates. Config validation has
+// already rejected invalid ones, so they are skipped here.
+func compileTemplates(sources map[string]string) map[string]*template.Template {
+	if len(sources) == 0 {
+		return nil
+	}
+	compiled := make(map[string]*template.Template, len(sources))
+	for pattern, text := range sources {
+		if tmpl, err := config.ParseTemplate(pattern, text); err == nil {
+			compiled[pattern] = tmpl
+		}
+	}
+	return compiled
+}
+
+// writeTemplatedMessage renders msg with the channel's template, prefixing
+// each output line. It reports false when the channel has no template or
+// the template fails, so the caller writes the default format instead.
+func writeTemplatedMessage(out *bytes.Buffer, prefix string, lookup renderLookup, data MessageData) bool {
+	if lookup.template.tmpl == nil {
+		return false
+	}
+	data.Channel = lookup.template.channel
+	data.Date = lookup.template.date
+	var rendered bytes.Buffer
+	if err := lookup.template.tmpl.Execute(&rendered, data); err != nil {
+		return false
+	}
+	writeTextLines(out, prefix, strings.TrimRight(rendered.String(), "\n"))
+	return true
+}
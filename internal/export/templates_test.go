@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+func TestWriteMessage_UsesChannelTemplate(t *testing.T) {
+	lookup := newRenderLookup(userLookup{"U1": {ID: "U1", Name: "alice"}}, RenderOptions{
+		Templates: map[string]string{
+			"alerts-*": `{{.Timestamp.Format "15:04"}} {{.Sender}}: {{.Text}}`,
+			"*":        `[{{.Channel}} {{.Date}}] {{.Sender}}{{if .Reply}} (reply){{end}}` + "\n{{.Text}}",
+		},
+	})
+	msg := rslack.Message{Msg: rslack.Msg{User: "U1", Timestamp: "1768050000.000100", Text: "disk full\nsecond line"}}
+
+	var out bytes.Buffer
+	alerts := lookup.forChannel(RenderRequest{ChannelID: "C1", ChannelName: "alerts-prod", Date: "2026-01-10",
+		Timezone: "UTC"})
+	writeMessage(&out, msg, "", alerts)
+	if want := "13:00 alice: disk full\nsecond line\n\n"; out.String() != want {
+		t.Errorf("alerts output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	general := lookup.forChannel(RenderRequest{ChannelID: "C2", ChannelName: "general", Date: "2026-01-10",
+		Timezone: "UTC"})
+	writeMessage(&out, msg, "|   ", general)
+	if want := "|   [general 2026-01-10] alice (reply)\n|   disk full\n|   second line\n\n"; out.String() != want {
+		t.Errorf("general output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriteMessage_MarksBroadcastReplies(t *testing.T) {
+	users := userLookup{"U1": {ID: "U1", Name: "alice"}}
+	msg := rslack.Message{Msg: rslack.Msg{
+		User: "U1", Timestamp: "1768050000.000100", ThreadTimestamp: "1768040000.000100",
+		SubType: rslack.MsgSubTypeThreadBroadcast, Text: "shipped",
+	}}
+
+	var out bytes.Buffer
+	writeMessage(&out, msg, "|   ", newRenderLookup(users, RenderOptions{}).forChannel(RenderRequest{ChannelName: "general", Timezone: "UTC"}))
+	if want := "|   > alice [U1] @ 10/01/2026 13:00:00 Z (also sent to channel):\n|   shipped\n\n"; out.String() != want {
+		t.Errorf("native output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	templated := newRenderLookup(users, RenderOptions{
+		Templates: map[string]string{"*": `{{.Sender}}{{if .Broadcast}} [also in channel]{{end}}: {{.Text}}`},
+	}).forChannel(RenderRequest{ChannelName: "general", Timezone: "UTC"})
+	writeMessage(&out, msg, "", templated)
+	if want := "alice [also in channel]: shipped\n\n"; out.String() != want {
+		t.Errorf("templated output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriteMessage_WithoutMatchingTemplateUsesDefault(t *testing.T) {
+	lookup := newRenderLookup(userLookup{"U1": {ID: "U1", Name: "alice"}}, RenderOptions{
+		Templates: map[string]string{"alerts-*": `{{.Text}}`},
+	}).forChannel(RenderRequest{ChannelID: "C2", ChannelName: "general", Timezone: "UTC"})
+	msg := rslack.Message{Msg: rslack.Msg{User: "U1", Timestamp: "1768050000.000100", Text: "hi"}}
+
+	var out bytes.Buffer
+	writeMessage(&out, msg, "", lookup)
+
+	if want := "> alice [U1] @ 10/01/2026 13:00:00 Z:\nhi\n\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriteMessage_UsesNameStyle(t *testing.T) {
+	users := userLookup{
+		"U1": {ID: "U1", Name: "alice.w", RealName: "Alice Wong", Profile: rslack.UserProfile{DisplayName: "Ali"}},
+		"U2": {ID: "U2", Name: "bob", RealName: "Bob Stone"},
+	}
+	msg := rslack.Message{Msg: rslack.Msg{User: "U1", Timestamp: "1768050000.000100", Text: "ping <@U2>"}}
+	for style, want := range map[string]string{
+		"":         "> Ali [U1] @ 10/01/2026 13:00:00 Z:\nping Bob Stone\n\n",
+		"username": "> alice.w [U1] @ 10/01/2026 13:00:00 Z:\nping bob\n\n",
+		"real":     "> Alice Wong [U1] @ 10/01/2026 13:00:00 Z:\nping Bob Stone\n\n",
+	} {
+		nameStyle, err := slack.ParseNameStyle(style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lookup := newRenderLookup(users, RenderOptions{NameStyle: nameStyle}).
+			forChannel(RenderRequest{ChannelID: "C1", ChannelName: "general", Timezone: "UTC"})
+		var out bytes.Buffer
+		writeMessage(&out, msg, "", lookup)
+		if out.String() != want {
+			t.Errorf("name_style %q: output = %q, want %q", style, out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"fmt"
+	"time"
+)
+
+// withThreadLookback widens a daily resume so threads started up to days
+// ago are rescanned and their new replies fetched. Slackdump only revisits
+// threads whose parents fall inside the lookback and whose last reply is
+// newer than the stale-thread bound, so both are raised to at least days.
+// Zero leaves opts unchanged.
+func withThreadLookback(opts ResumeOptions, days int) ResumeOptions {
+	if days <= 0 {
+		return opts
+	}
+	minimum := time.Duration(days) * 24 * time.Hour
+	window := fmt.Sprintf("%dd", days)
+	if lookback, err := ParseFriendlyDuration(opts.Lookback); err == nil && lookback < minimum {
+		opts.Lookback = window
+	}
+	if opts.SkipStaleThreads != "" {
+		if stale, err := ParseFriendlyDuration(opts.SkipStaleThreads); err == nil && stale < minimum {
+			opts.SkipStaleThreads = window
+		}
+	}
+	return opts
+}
+
+// continuationHeading introduces one thread continuation. With
+// thread_lookback_days set it also says how many days earlier the thread
+// started, so context pulled from an older day stands out.
+func continuationHeading(parentDate string, req RenderRequest, lookup renderLookup) string {
+	link := fmt.Sprintf("%s/%s.md", dateFolder(parentDate, lookup.layout),
+		channelFileBase(parentDate, req.ChannelName, lookup.filenameDate, lookup.layout))
+	if lookup.threadLookback <= 0 {
+		return fmt.Sprintf("\n### Thread started %s (see %s)\n", parentDate, link)
+	}
+	days := daysBetween(parentDate, req.Date)
+	unit := "days"
+	if days == 1 {
+		unit = "day"
+	}
+	return fmt.Sprintf("\n### Thread started %s, %d %s earlier (see %s)\n", parentDate, days, unit, link)
+}
+
+// daysBetween counts calendar days from one YYYY-MM-DD date to another.
+func daysBetween(from, to string) int {
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return 0
+	}
+	return int(end.Sub(start).Hours() / 24)
+}
@@ -0,0 +1,45 @@
+package export
+
+import "testing"
+
+func TestWithThreadLookback(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          ResumeOptions
+		days          int
+		wantLookback  string
+		wantSkipStale string
+	}{
+		{"disabled", ResumeOptions{Lookback: "7d", SkipStaleThreads: "21d"}, 0, "7d", "21d"},
+		{"within bounds", ResumeOptions{Lookback: "7d", SkipStaleThreads: "21d"}, 5, "7d", "21d"},
+		{"widens both", ResumeOptions{Lookback: "7d", SkipStaleThreads: "21d"}, 30, "30d", "30d"},
+		{"go duration", ResumeOptions{Lookback: "36h", SkipStaleThreads: "21d"}, 3, "3d", "21d"},
+		{"stale skipping off", ResumeOptions{Lookback: "7d"}, 14, "14d", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := withThreadLookback(tt.opts, tt.days)
+			if got.Lookback != tt.wantLookback || got.SkipStaleThreads != tt.wantSkipStale {
+				t.Errorf("withThreadLookback() = %q/%q, want %q/%q",
+					got.Lookback, got.SkipStaleThreads, tt.wantLookback, tt.wantSkipStale)
+			}
+		})
+	}
+}
+
+func TestContinuationHeading(t *testing.T) {
+	req := RenderRequest{ChannelName: "general", Date: "2026-03-10"}
+
+	if got, want := continuationHeading("2026-03-02", req, renderLookup{}),
+		"\n### Thread started 2026-03-02 (see 2026-03-02/2026-03-02-general.md)\n"; got != want {
+		t.Errorf("continuationHeading() = %q, want %q", got, want)
+	}
+	if got, want := continuationHeading("2026-03-02", req, renderLookup{threadLookback: 14}),
+		"\n### Thread started 2026-03-02, 8 days earlier (see 2026-03-02/2026-03-02-general.md)\n"; got != want {
+		t.Errorf("continuationHeading() = %q, want %q", got, want)
+	}
+	if got, want := continuationHeading("2026-03-09", req, renderLookup{threadLookback: 14}),
+		"\n### Thread started 2026-03-09, 1 day earlier (see 2026-03-09/2026-03-09-general.md)\n"; got != want {
+		t.Errorf("continuationHeading() = %q, want %q", got, want)
+	}
+}
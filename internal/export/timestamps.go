@@ -0,0 +1,117 @@
+package export
+
+import (
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+// timeLocale holds translated month and weekday names, Sunday first.
+type timeLocale struct {
+	months      [12]string
+	shortMonths [12]string
+	days        [7]string
+	shortDays   [7]string
+}
+
+var timeLocales = map[string]timeLocale{
+	"de": {
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August",
+			"September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+	"es": {
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto",
+			"septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"fr": {
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août",
+			"septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.",
+			"nov.", "déc."},
+		days:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	"it": {
+		months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto",
+			"settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"nl": {
+		months: [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus",
+			"september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+	"pt": {
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto",
+			"setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		days: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira",
+			"sábado"},
+		shortDays: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+}
+
+// timestampFormat formats message header timestamps. The zero value is the
+// default: DefaultTimeLayout in UTC.
+type timestampFormat struct {
+	layout string
+	locale string
+	// local shows times in the channel's timezone; loc is set per channel.
+	local bool
+	loc   *time.Location
+}
+
+func newTimestampFormat(opts RenderOptions) timestampFormat {
+	return timestampFormat{layout: opts.TimeLayout, locale: opts.TimeLocale, local: opts.LocalTimestamps}
+}
+
+// in returns the format for a channel rendered in timezone. Timezones were
+// validated with the config, so a load failure keeps UTC.
+func (f timestampFormat) in(timezone string) timestampFormat {
+	if !f.local {
+		return f
+	}
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		f.loc = loc
+	}
+	return f
+}
+
+func (f timestampFormat) format(ts time.Time) string {
+	if f.loc != nil {
+		ts = ts.In(f.loc)
+	}
+	layout := f.layout
+	if layout == "" {
+		layout = config.DefaultTimeLayout
+	}
+	return localizeTime(ts.Format(layout), ts, f.locale)
+}
+
+// localizeTime replaces the English month and weekday names of ts in text.
+// Full names are listed before abbreviations, and strings.Replacer makes a
+// single pass, so "Monday" never becomes a translated "Mon" plus "day".
+func localizeTime(text string, ts time.Time, locale string) string {
+	names, ok := timeLocales[locale]
+	if !ok {
+		return text
+	}
+	month, day := ts.Month().String(), ts.Weekday().String()
+	return strings.NewReplacer(
+		month, names.months[ts.Month()-1],
+		day, names.days[ts.Weekday()],
+		month[:3], names.shortMonths[ts.Month()-1],
+		day[:3], names.shortDays[ts.Weekday()],
+	).Replace(text)
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampFormat_DefaultIsUTC(t *testing.T) {
+	ts := time.Date(2026, 7, 3, 14, 5, 9, 0, time.UTC)
+	format := timestampFormat{}.in("America/Chicago")
+
+	if got := format.format(ts); got != "03/07/2026 14:05:09 Z" {
+		t.Errorf("format() = %q", got)
+	}
+}
+
+func TestTimestampFormat_LocalAndLocalized(t *testing.T) {
+	ts := time.Date(2026, 7, 6, 14, 5, 0, 0, time.UTC)
+	format := newTimestampFormat(RenderOptions{
+		TimeLayout:      "Monday 2 January 3:04 PM",
+		TimeLocale:      "de",
+		LocalTimestamps: true,
+	}).in("Europe/Berlin")
+
+	if got := format.format(ts); got != "Montag 6 Juli 4:05 PM" {
+		t.Errorf("format() = %q", got)
+	}
+}
+
+func TestLocalizeTime_AbbreviationsDoNotClobberFullNames(t *testing.T) {
+	ts := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC) // a Monday
+	if got := localizeTime("Mon, Monday 1 Jun (June)", ts, "fr"); got != "lun., lundi 1 juin (juin)" {
+		t.Errorf("localizeTime() = %q", got)
+	}
+	if got := localizeTime("Mon 1 Jun", ts, "en"); got != "Mon 1 Jun" {
+		t.Errorf("localizeTime(en) = %q", got)
+	}
+}
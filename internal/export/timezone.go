@@ -2,7 +2,11 @@ package export
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
 )
 
 // GetDateBounds calculates the UTC start and end times for a given date in the specified timezone.
@@ -15,7 +19,10 @@ func GetDateBounds(date, timezone string) (start, end time.Time, err error) {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid timezone: %w", err)
 	}
 
-	t, err := time.ParseInLocation("2006-01-02", date, loc)
+	// Parse the calendar date in UTC: where DST starts at midnight (e.g.
+	// America/Santiago), local midnight does not exist and parsing in loc
+	// can land on the previous day.
+	t, err := time.Parse("2006-01-02", date)
 	if err != nil {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid date: %w", err)
 	}
@@ -24,10 +31,52 @@ func GetDateBounds(date, timezone string) (start, end time.Time, err error) {
 	startLocal := time.Date(t.Year(), t.Month(), t.Day(), 3, 0, 0, 0, loc)
 	start = startLocal.UTC()
 
-	// End at 2:59:59am on the next day
-	nextDay := t.AddDate(0, 0, 1)
-	endLocal := time.Date(nextDay.Year(), nextDay.Month(), nextDay.Day(), 2, 59, 59, 0, loc)
-	end = endLocal.UTC()
+	// End one second before 3am the next day. 2:59:59 is not built directly
+	// because it does not exist where clocks spring forward at 2am, and Go
+	// would normalize it past the next day's start.
+	nextLocal := time.Date(t.Year(), t.Month(), t.Day()+1, 3, 0, 0, 0, loc)
+	end = nextLocal.Add(-time.Second).UTC()
 
 	return start, end, nil
 }
+
+// timezoneFor returns the zone used for a channel's work-day boundaries.
+// Channel overrides are matched against the channel name or ID; when several
+// patterns match, the longest (most specific) pattern wins.
+func (o RenderOptions) timezoneFor(channelID, channelName string) string {
+	if tz, ok := matchChannelOverride(o.ChannelTimezones, channelID, channelName); ok {
+		return tz
+	}
+	return o.Timezone
+}
+
+// channelName returns the channel_aliases name for channelID, or its
+// recorded Slack name.
+func (o RenderOptions) channelName(names channelNameResolver, channelID string) string {
+	if alias := o.ChannelAliases[strings.ToUpper(channelID)]; alias != "" {
+		return alias
+	}
+	return names[channelID]
+}
+
+// matchChannelOverride returns the value of the longest pattern matching the
+// channel name or ID. Equal-length patterns are tried in sorted order.
+func matchChannelOverride[V any](overrides map[string]V, channelID, channelName string) (V, bool) {
+	patterns := make([]string, 0, len(overrides))
+	for pattern := range overrides {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		if channels.MatchPattern(pattern, channelName) || channels.MatchPattern(pattern, channelID) {
+			return overrides[pattern], true
+		}
+	}
+	var zero V
+	return zero, false
+}
@@ -1,8 +1,11 @@
 package export
 
 import (
+	"fmt"
 	"testing"
 	"time"
+
+	rslack "github.com/rusq/slack"
 )
 
 func TestGetDateBounds_EST(t *testing.T) {
@@ -87,6 +90,44 @@ func TestGetDateBounds_DSTTransitionFall(t *testing.T) {
 	}
 }
 
+// TestGetDateBounds_ConsecutiveDaysTile checks every day of a year in zones
+// with DST shifts of different sizes and times: each work day ends one second
+// before the next begins, so no message falls between or into two days, and
+// messageWorkDate assigns both boundary seconds to that day.
+func TestGetDateBounds_ConsecutiveDaysTile(t *testing.T) {
+	zones := []string{"UTC", "America/New_York", "Europe/Berlin", "Australia/Lord_Howe", "America/Santiago", "Asia/Kolkata"}
+	for _, zone := range zones {
+		day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, prevEnd, err := GetDateBounds(day.Format("2006-01-02"), zone)
+		if err != nil {
+			t.Fatalf("GetDateBounds(%s) error = %v", zone, err)
+		}
+		for day = day.AddDate(0, 0, 1); day.Year() == 2026; day = day.AddDate(0, 0, 1) {
+			date := day.Format("2006-01-02")
+			start, end, err := GetDateBounds(date, zone)
+			if err != nil {
+				t.Fatalf("GetDateBounds(%s, %s) error = %v", date, zone, err)
+			}
+			if !start.Equal(prevEnd.Add(time.Second)) {
+				t.Errorf("%s %s: start %v does not follow previous end %v", zone, date, start, prevEnd)
+			}
+			if length := end.Sub(start) + time.Second; length < 23*time.Hour || length > 25*time.Hour {
+				t.Errorf("%s %s: work day is %v long", zone, date, length)
+			}
+			if dates, err := datesInRange(date, date, zone); err != nil || len(dates) != 1 || dates[0] != date {
+				t.Errorf("%s: datesInRange(%s) = %v, %v", zone, date, dates, err)
+			}
+			for _, ts := range []time.Time{start, end} {
+				msg := rslack.Message{Msg: rslack.Msg{Timestamp: fmt.Sprintf("%d.000000", ts.Unix())}}
+				if got, _ := messageWorkDate(msg, zone); got != date {
+					t.Errorf("%s: messageWorkDate(%v) = %s, want %s", zone, ts, got, date)
+				}
+			}
+			prevEnd = end
+		}
+	}
+}
+
 func TestGetDateBounds_LosAngeles(t *testing.T) {
 	// America/Los_Angeles is UTC-8 in winter (PST)
 	start, end, err := GetDateBounds("2026-01-22", "America/Los_Angeles")
@@ -207,3 +248,49 @@ func TestGetDateBounds_ReturnedTimesAreUTC(t *testing.T) {
 		t.Errorf("end location = %v, want UTC", end.Location())
 	}
 }
+
+func TestRenderOptionsTimezoneFor_DefaultsToGlobalZone(t *testing.T) {
+	opts := RenderOptions{
+		Timezone:         "America/New_York",
+		ChannelTimezones: map[string]string{"tokyo-*": "Asia/Tokyo"},
+	}
+
+	if got := opts.timezoneFor("C123", "engineering"); got != "America/New_York" {
+		t.Errorf("timezoneFor(engineering) = %q, want America/New_York", got)
+	}
+}
+
+func TestRenderOptionsTimezoneFor_MatchesNameOrID(t *testing.T) {
+	opts := RenderOptions{
+		Timezone: "America/New_York",
+		ChannelTimezones: map[string]string{
+			"tokyo-*":  "Asia/Tokyo",
+			"c0london": "Europe/London",
+		},
+	}
+
+	if got := opts.timezoneFor("C999", "Tokyo-Office"); got != "Asia/Tokyo" {
+		t.Errorf("timezoneFor(Tokyo-Office) = %q, want Asia/Tokyo", got)
+	}
+	if got := opts.timezoneFor("C0LONDON", "uk-team"); got != "Europe/London" {
+		t.Errorf("timezoneFor(C0LONDON) = %q, want Europe/London", got)
+	}
+}
+
+func TestRenderOptionsTimezoneFor_LongestPatternWins(t *testing.T) {
+	opts := RenderOptions{
+		Timezone: "UTC",
+		ChannelTimezones: map[string]string{
+			"*-office":      "America/Chicago",
+			"tokyo-office":  "Asia/Tokyo",
+			"tokyo-office*": "Europe/Paris",
+		},
+	}
+
+	if got := opts.timezoneFor("C1", "tokyo-office"); got != "Europe/Paris" {
+		t.Errorf("timezoneFor(tokyo-office) = %q, want Europe/Paris", got)
+	}
+	if got := opts.timezoneFor("C2", "denver-office"); got != "America/Chicago" {
+		t.Errorf("timezoneFor(denver-office) = %q, want America/Chicago", got)
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/channels"
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+// translateTimeout bounds one file's translation.
+const translateTimeout = 2 * time.Minute
+
+// Translator writes a translated copy of each rendered file using the
+// configured command or HTTP endpoint.
+type Translator struct {
+	cfg    config.Translation
+	client *http.Client
+}
+
+// NewTranslator returns nil when no translation provider is configured.
+func NewTranslator(cfg config.Translation) *Translator {
+	if !cfg.Enabled() {
+		return nil
+	}
+	return &Translator{cfg: cfg, client: &http.Client{Timeout: translateTimeout}}
+}
+
+// Language returns the language code translated copies are named with, or
+// "" for a nil Translator.
+func (t *Translator) Language() string {
+	if t == nil {
+		return ""
+	}
+	return t.cfg.TargetLanguage()
+}
+
+// variantPath is the translated copy's path: 2026-01-02-general.md becomes
+// 2026-01-02-general-en.md.
+func (t *Translator) variantPath(path string) string {
+	return strings.TrimSuffix(path, ".md") + "-" + t.cfg.TargetLanguage() + ".md"
+}
+
+// translateFile refreshes path's translated copy when the original changed
+// or the copy is missing, giving up after translateTimeout or when ctx is
+// done. A nil Translator or an unselected channel does nothing.
+func (t *Translator) translateFile(ctx context.Context, path string, content []byte, channel, date string, changed bool) error {
+	if t == nil || (len(t.cfg.Channels) > 0 && !channels.MatchAny(t.cfg.Channels, channel)) {
+		return nil
+	}
+	variant := t.variantPath(path)
+	if !changed {
+		if _, err := os.Stat(variant); err == nil {
+			return nil
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, translateTimeout)
+	defer cancel()
+	translated, err := t.translate(ctx, content, channel, date)
+	if err != nil {
+		return fmt.Errorf("translating %s: %w", path, err)
+	}
+	return writeFileAtomic(variant, translated, 0600)
+}
+
+func (t *Translator) translate(ctx context.Context, content []byte, channel, date string) ([]byte, error) {
+	if t.cfg.Command != "" {
+		return t.translateCommand(ctx, content, channel, date)
+	}
+	return t.translateHTTP(ctx, content, channel, date)
+}
+
+func (t *Translator) translateCommand(ctx context.Context, content []byte, channel, date string) ([]byte, error) {
+	// #nosec G204 -- the command comes from the user's own configuration
+	cmd := exec.CommandContext(ctx, "sh", "-c", t.cfg.Command)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Env = append(os.Environ(),
+		"SLACK_EXPORT_CHANNEL="+channel,
+		"SLACK_EXPORT_DATE="+date,
+		"SLACK_EXPORT_LANGUAGE="+t.cfg.TargetLanguage())
+	// A cancelled command's children may still hold its output open.
+	cmd.WaitDelay = time.Second
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("translation command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, errors.New("translation command printed nothing")
+	}
+	return out, nil
+}
+
+func (t *Translator) translateHTTP(ctx context.Context, content []byte, channel, date string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.URL, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/markdown; charset=utf-8")
+	req.Header.Set("X-Slack-Export-Channel", channel)
+	req.Header.Set("X-Slack-Export-Date", date)
+	req.Header.Set("X-Slack-Export-Language", t.cfg.TargetLanguage())
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("translation endpoint returned %s", resp.Status)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, errors.New("translation endpoint returned an empty body")
+	}
+	return body, nil
+}
+
+// removeStaleVariants removes translated copies left behind when
+// writeChannelDate removes original files: part files from part from
+// onward, and the unsplit file when unsplit is set.
+func (t *Translator) removeStaleVariants(dir, base string, from int, unsplit bool) error {
+	if t == nil {
+		return nil
+	}
+	if unsplit {
+		if err := removeIfExists(t.variantPath(filepath.Join(dir, base+".md"))); err != nil {
+			return err
+		}
+	}
+	for part := from; ; part++ {
+		path := t.variantPath(partPath(dir, base, part))
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err := removeIfExists(path); err != nil {
+			return err
+		}
+	}
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing stale translation: %w", err)
+	}
+	return nil
+}
+
+// translate refreshes a written file's translated copy. Translation
+// failures only warn so the original export is never held back.
+func (o RenderOptions) translate(ctx context.Context, path string, content []byte, channel, date string, changed bool) {
+	if err := o.Translator.translateFile(ctx, path, content, channel, date, changed); err != nil {
+		o.warn(err)
+	}
+}
@@ -0,0 +1,177 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+)
+
+func TestTranslator_CommandWritesVariant(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell commands not supported on Windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2026-03-02-general.md")
+	tr := NewTranslator(config.Translation{Command: `tr a-z A-Z; printf '%s' "$SLACK_EXPORT_LANGUAGE"`, Language: "de"})
+
+	if err := tr.translateFile(context.Background(), path, []byte("hola\n"), "general", "2026-03-02", true); err != nil {
+		t.Fatalf("translateFile() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "2026-03-02-general-de.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "HOLA\nde" {
+		t.Errorf("translated = %q", got)
+	}
+}
+
+func TestTranslator_HTTPEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("X-Slack-Export-Channel") != "general" || r.Header.Get("X-Slack-Export-Language") != "en" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		_, _ = w.Write([]byte("EN: " + string(body)))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2026-03-02-general.md")
+	tr := NewTranslator(config.Translation{URL: server.URL})
+
+	if err := tr.translateFile(context.Background(), path, []byte("hola"), "general", "2026-03-02", true); err != nil {
+		t.Fatalf("translateFile() error = %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "2026-03-02-general-en.md"))
+	if string(got) != "EN: hola" {
+		t.Errorf("translated = %q", got)
+	}
+}
+
+func TestTranslator_SkipsUnchangedAndUnselected(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("translated"))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2026-03-02-intl-sales.md")
+	tr := NewTranslator(config.Translation{URL: server.URL, Channels: []string{"intl-*"}})
+
+	steps := []struct {
+		path    string
+		channel string
+		changed bool
+	}{
+		{path, "intl-sales", false},                                    // variant missing: translated
+		{path, "intl-sales", false},                                    // unchanged with variant: skipped
+		{path, "intl-sales", true},                                     // changed: translated again
+		{filepath.Join(dir, "2026-03-02-general.md"), "general", true}, // not selected
+	}
+	for _, step := range steps {
+		if err := tr.translateFile(context.Background(), step.path, []byte("x"), step.channel, "2026-03-02", step.changed); err != nil {
+			t.Fatalf("translateFile() error = %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("translation calls = %d, want 2", calls)
+	}
+}
+
+func TestTranslator_StopsWhenContextIsCancelled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell commands not supported on Windows")
+	}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2026-03-02-general.md")
+
+	for name, cfg := range map[string]config.Translation{
+		"command": {Command: "sleep 30"},
+		"http":    {URL: server.URL},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start := time.Now()
+			err := NewTranslator(cfg).translateFile(ctx, path, []byte("hola"), "general", "2026-03-02", true)
+			if err == nil {
+				t.Fatal("translateFile() error = nil, want cancellation error")
+			}
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("translateFile() took %s after cancellation", elapsed)
+			}
+		})
+	}
+}
+
+func TestWriteChannelDate_TranslationFailureOnlyWarns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	outputDir := t.TempDir()
+	var warnings bytes.Buffer
+	opts := RenderOptions{
+		Translator: NewTranslator(config.Translation{URL: server.URL}),
+		events:     ConsoleEvents{Out: io.Discard, Err: &warnings},
+	}
+
+	units := []renderedUnit{{text: "hello\n", messages: 1}}
+	writes, err := writeChannelDate(context.Background(), outputDir, "2026-03-02", "general", units, opts)
+	if err != nil || writes != 1 {
+		t.Fatalf("writeChannelDate() = %d, %v; want 1 write", writes, err)
+	}
+	if !strings.Contains(warnings.String(), "503") {
+		t.Errorf("warnings = %q, want translation failure", warnings.String())
+	}
+}
+
+func TestWriteChannelDate_RemovesStaleTranslatedParts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell commands not supported on Windows")
+	}
+	outputDir := t.TempDir()
+	dir := filepath.Join(outputDir, "2026-03-02")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2026-03-02-general-part1-en.md", "2026-03-02-general-part2-en.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts := RenderOptions{Translator: NewTranslator(config.Translation{Command: "cat"})}
+
+	units := []renderedUnit{{text: "hi\n", messages: 1}}
+	if _, err := writeChannelDate(context.Background(), outputDir, "2026-03-02", "general", units, opts); err != nil {
+		t.Fatalf("writeChannelDate() error = %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if got := strings.Join(names, ","); got != "2026-03-02-general-en.md,2026-03-02-general.md" {
+		t.Errorf("files = %s", got)
+	}
+}
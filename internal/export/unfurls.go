@@ -0,0 +1,58 @@
+package export
+
+import (
+	"strings"
+
+	rslack "github.com/rusq/slack"
+)
+
+// withUnfurls appends each link preview of msg to text as a blockquote:
+// the title, linked when Slack gave a link, the preview text, and the
+// footer. Attachments with fields are already rendered as tables by
+// withWorkflowFields and are skipped.
+func withUnfurls(msg rslack.Message, text string, lookup renderLookup) string {
+	parts := []string{text}
+	for _, attachment := range msg.Attachments {
+		if len(attachment.Fields) > 0 {
+			continue
+		}
+		if quote := unfurlMarkdown(attachment, lookup); quote != "" {
+			parts = append(parts, quote)
+		}
+	}
+	if len(parts) == 1 {
+		return text
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n\n"))
+}
+
+// unfurlMarkdown quotes one attachment, or returns "" when it has neither
+// a title nor text.
+func unfurlMarkdown(attachment rslack.Attachment, lookup renderLookup) string {
+	title := workflowText(attachment.Title, lookup)
+	body := workflowText(attachment.Text, lookup)
+	if title == "" && body == "" {
+		return ""
+	}
+	var lines []string
+	if title != "" {
+		if link := attachment.TitleLink; link != "" {
+			title = "[" + title + "](" + link + ")"
+		}
+		lines = append(lines, "**"+title+"**")
+	}
+	if body != "" {
+		lines = append(lines, strings.Split(body, "\n")...)
+	}
+	footer := workflowText(attachment.Footer, lookup)
+	if footer == "" {
+		footer = workflowText(attachment.ServiceName, lookup)
+	}
+	if footer != "" {
+		lines = append(lines, "_"+footer+"_")
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
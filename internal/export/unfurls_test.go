@@ -0,0 +1,39 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestWithUnfurls_QuotesLinkPreviews(t *testing.T) {
+	var msg rslack.Message
+	raw := `{"type": "message", "ts": "1768050000.000100",
+	  "text": "Worth a read <https://example.com/post>",
+	  "attachments": [
+	    {"title": "Scaling Postgres", "title_link": "https://example.com/post",
+	     "text": "How we sharded &amp; survived\nPart two", "service_name": "Example Blog"},
+	    {"title": "INC-42", "fields": [{"title": "Severity", "value": "SEV2"}]},
+	    {"image_url": "https://example.com/cat.png"},
+	    {"text": "Shared by <@U2>", "footer": "Posted in #general"}
+	  ]}`
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatal(err)
+	}
+	lookup := renderLookup{users: userLookup{"U2": {ID: "U2", Name: "bob"}}, unfurls: true}
+
+	got := messageText(msg, lookup)
+	want := "Worth a read <https://example.com/post>\n\n" +
+		"**INC-42**\n\n| Field | Value |\n| --- | --- |\n| Severity | SEV2 |\n\n" +
+		"> **[Scaling Postgres](https://example.com/post)**\n> How we sharded & survived\n> Part two\n> _Example Blog_\n\n" +
+		"> Shared by bob\n> _Posted in #general_"
+	if got != want {
+		t.Errorf("messageText() =\n%s\nwant\n%s", got, want)
+	}
+
+	lookup.unfurls = false
+	if got := messageText(msg, lookup); got != "Worth a read <https://example.com/post>\n\n**INC-42**\n\n| Field | Value |\n| --- | --- |\n| Severity | SEV2 |" {
+		t.Errorf("messageText() without include_unfurls = %q", got)
+	}
+}
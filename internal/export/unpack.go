@@ -0,0 +1,270 @@
+package export
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxPackMetadataSize bounds pack.json and users.json, which are read
+// into memory.
+const maxPackMetadataSize = 64 << 20
+
+// UnpackOptions controls how Unpack merges a pack.
+type UnpackOptions struct {
+	// Passphrase is asked for only when the pack is encrypted.
+	Passphrase func() (string, error)
+	// Overwrite replaces existing files that differ from the packed copy.
+	// Otherwise they are kept and reported as conflicts.
+	Overwrite bool
+}
+
+// UnpackReport lists what Unpack did with each packed file, by path
+// relative to the output directory.
+type UnpackReport struct {
+	Manifest  *PackManifest
+	Added     []string
+	Unchanged []string
+	Replaced  []string
+	Conflicts []string
+	// Users is the number of users merged into users.json.
+	Users int
+}
+
+// Unpack verifies a pack written by Pack and merges its files into
+// outputDir. Nothing is merged unless every file matches the pack's
+// manifest. A user snapshot is merged into outputDir/users.json.
+func Unpack(r io.Reader, outputDir string, opts UnpackOptions) (*UnpackReport, error) {
+	br := bufio.NewReader(r)
+	in := io.Reader(br)
+	if isEncryptedPack(br) {
+		if opts.Passphrase == nil {
+			return nil, errors.New("pack is encrypted; a passphrase is required")
+		}
+		passphrase, err := opts.Passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if in, err = newPackDecrypter(br, passphrase); err != nil {
+			return nil, err
+		}
+	}
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("not a slack-export pack: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(outputDir, ".slack-export-unpack-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	extracted, err := extractPack(tar.NewReader(gz), staging)
+	if err != nil {
+		return nil, err
+	}
+	if err := extracted.verify(); err != nil {
+		return nil, err
+	}
+
+	report := &UnpackReport{Manifest: extracted.manifest}
+	for _, file := range extracted.manifest.Files {
+		if err := mergePackFile(staging, outputDir, file, opts.Overwrite, report); err != nil {
+			return report, err
+		}
+	}
+	if extracted.users != nil {
+		if report.Users, err = mergePackUsers(outputDir, extracted.users); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// extractedPack is a pack read into a staging directory.
+type extractedPack struct {
+	manifest *PackManifest
+	users    []PackUser
+	files    map[string]PackFile
+}
+
+func extractPack(tr *tar.Reader, staging string) (*extractedPack, error) {
+	pack := &extractedPack{files: make(map[string]PackFile)}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return pack, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading pack: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("pack entry %s is not a regular file", hdr.Name)
+		}
+		switch hdr.Name {
+		case packManifestFile:
+			pack.manifest = &PackManifest{}
+			if err := readPackJSON(tr, hdr.Name, pack.manifest); err != nil {
+				return nil, err
+			}
+		case packUsersFile:
+			if err := readPackJSON(tr, hdr.Name, &pack.users); err != nil {
+				return nil, err
+			}
+		default:
+			if err := validatePackPath(hdr.Name); err != nil {
+				return nil, err
+			}
+			file, err := extractPackFile(tr, staging, hdr.Name)
+			if err != nil {
+				return nil, err
+			}
+			pack.files[hdr.Name] = file
+		}
+	}
+}
+
+func readPackJSON(r io.Reader, name string, v any) error {
+	data, err := io.ReadAll(io.LimitReader(r, maxPackMetadataSize))
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", name, err)
+	}
+	return nil
+}
+
+// validatePackPath rejects entries that would land outside a date folder,
+// such as absolute paths or paths with "..".
+func validatePackPath(name string) error {
+	date, rest, ok := strings.Cut(name, "/")
+	if !ok || rest == "" || path.Clean(name) != name || strings.Contains(name, `\`) ||
+		!exportFolderPattern.MatchString(date) {
+		return fmt.Errorf("pack entry %q is not inside a date folder", name)
+	}
+	return nil
+}
+
+func extractPackFile(r io.Reader, staging, rel string) (PackFile, error) {
+	dest := filepath.Join(staging, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+		return PackFile{}, err
+	}
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return PackFile{}, fmt.Errorf("extracting %s: %w", rel, err)
+	}
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return PackFile{}, fmt.Errorf("extracting %s: %w", rel, err)
+	}
+	return PackFile{Path: rel, Size: size, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// verify checks that the pack holds exactly the files its manifest lists,
+// with matching checksums.
+func (p *extractedPack) verify() error {
+	if p.manifest == nil {
+		return fmt.Errorf("not a slack-export pack: %s is missing", packManifestFile)
+	}
+	if p.manifest.Version > packFormatVersion {
+		return fmt.Errorf("pack format %d is newer than this slack-export supports; upgrade to unpack it",
+			p.manifest.Version)
+	}
+	if p.manifest.Users && p.users == nil {
+		return fmt.Errorf("pack is damaged: %s is missing", packUsersFile)
+	}
+	for _, want := range p.manifest.Files {
+		got, ok := p.files[want.Path]
+		if !ok {
+			return fmt.Errorf("pack is damaged: %s is missing", want.Path)
+		}
+		if got != want {
+			return fmt.Errorf("pack is damaged: %s does not match its checksum", want.Path)
+		}
+	}
+	if len(p.files) != len(p.manifest.Files) {
+		return errors.New("pack is damaged: it holds files its manifest does not list")
+	}
+	return nil
+}
+
+func mergePackFile(staging, outputDir string, file PackFile, overwrite bool, report *UnpackReport) error {
+	dest := filepath.Join(outputDir, filepath.FromSlash(file.Path))
+	existing, err := fileSHA256(dest)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		report.Added = append(report.Added, file.Path)
+	case err != nil:
+		return err
+	case existing == file.SHA256:
+		report.Unchanged = append(report.Unchanged, file.Path)
+		return nil
+	case !overwrite:
+		report.Conflicts = append(report.Conflicts, file.Path)
+		return nil
+	default:
+		report.Replaced = append(report.Replaced, file.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	if err := os.Rename(filepath.Join(staging, filepath.FromSlash(file.Path)), dest); err != nil {
+		return fmt.Errorf("writing %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// mergePackUsers adds the snapshot to outputDir/users.json, replacing
+// earlier entries for the same user.
+func mergePackUsers(outputDir string, users []PackUser) (int, error) {
+	usersPath := filepath.Join(outputDir, packUsersFile)
+	var merged []PackUser
+	data, err := os.ReadFile(usersPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return 0, err
+	default:
+		if err := json.Unmarshal(data, &merged); err != nil {
+			return 0, fmt.Errorf("parsing %s: %w", usersPath, err)
+		}
+	}
+	byID := make(map[string]PackUser, len(merged)+len(users))
+	for _, u := range append(merged, users...) {
+		byID[u.ID] = u
+	}
+	merged = merged[:0]
+	for _, u := range byID {
+		merged = append(merged, u)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].ID < merged[j].ID })
+	data, err = json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if _, err := writeFileIfChanged(usersPath, append(data, '\n'), false); err != nil {
+		return 0, err
+	}
+	return len(users), nil
+}
@@ -0,0 +1,184 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+// UnreadOptions controls the unread digest.
+type UnreadOptions struct {
+	// Limit caps the messages shown per conversation, keeping the newest.
+	// Zero shows every unread message.
+	Limit int
+}
+
+// unreadDigest is a rendered digest and the conversations whose newest
+// message is not in the archive yet.
+type unreadDigest struct {
+	text   string
+	behind []string
+}
+
+// ExportUnread writes a markdown digest of the tracked conversations with
+// messages newer than their last_read mark, read from the local archive.
+// It writes nothing to the dated output folders; run sync first so the
+// archive holds the newest messages.
+func (e *Exporter) ExportUnread(ctx context.Context, w io.Writer, now time.Time, opts UnreadOptions) (err error) {
+	defer func() { err = classifyError(err) }()
+	archiveDir, err := e.ArchiveDir()
+	if err != nil {
+		return err
+	}
+	if !archiveExists(archiveDir) {
+		return fmt.Errorf("archive does not exist at %s; run slack-export sync first", archiveDir)
+	}
+	tracked, err := e.trackedChannels(ctx)
+	if err != nil {
+		return err
+	}
+	unread := prioritizeChannels(unreadChannels(tracked))
+
+	renderOpts, err := attachPseudonyms(e.renderOptions(ctx), archiveDir)
+	if err != nil {
+		return err
+	}
+	src, err := LoadArchiveSource(ctx, archiveDir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	digest, err := renderUnreadDigest(ctx, renderOpts.MessageFilter.source(renderOpts.Dedup.source(src)), unread, renderOpts, opts, now)
+	if err != nil {
+		return err
+	}
+	if len(digest.behind) > 0 {
+		e.warnf("archive is missing the newest messages of %d conversation(s) (%s); run slack-export sync first",
+			len(digest.behind), strings.Join(digest.behind, ", "))
+	}
+	if _, err := io.WriteString(w, digest.text); err != nil {
+		return err
+	}
+	return renderOpts.pseudonyms.save()
+}
+
+// unreadChannels returns the channels whose latest message is newer than
+// their last_read mark. Muted channels count, since catching up is the point.
+func unreadChannels(chans []slack.Channel) []slack.Channel {
+	var unread []slack.Channel
+	for _, ch := range chans {
+		if ch.LastMessage.After(ch.LastRead) {
+			unread = append(unread, ch)
+		}
+	}
+	return unread
+}
+
+// renderUnreadDigest renders each channel's top-level messages posted after
+// its last_read mark, with their replies posted after it too. Replies in
+// older threads are left out: last_read does not cover threads.
+func renderUnreadDigest(
+	ctx context.Context,
+	src ArchiveMessageSource,
+	chans []slack.Channel,
+	renderOpts RenderOptions,
+	opts UnreadOptions,
+	now time.Time,
+) (unreadDigest, error) {
+	users, err := loadUsers(ctx, src)
+	if err != nil {
+		return unreadDigest{}, err
+	}
+	base := newRenderLookup(users, renderOpts)
+
+	var digest unreadDigest
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# Unread since you last looked (%s)\n", now.Format("2006-01-02"))
+	if len(chans) == 0 {
+		out.WriteString("\nNothing unread.\n")
+	}
+	for _, ch := range chans {
+		timezone := renderOpts.timezoneFor(ch.ID, ch.Name)
+		lookup := base.forChannel(RenderRequest{Timezone: timezone, ChannelID: ch.ID, ChannelName: ch.Name})
+		messages, err := loadChannelMessages(ctx, src, ch.ID)
+		if err != nil {
+			return digest, fmt.Errorf("loading channel messages %s: %w", ch.ID, err)
+		}
+		if newest := newestMessageTime(messages); newest.Before(ch.LastMessage) {
+			digest.behind = append(digest.behind, ch.Name)
+		}
+		unread := messagesAfter(messages, ch.LastRead)
+		fmt.Fprintf(&out, "\n## %s\n\n", unreadHeading(lookup, ch, len(unread), timezone))
+		if len(unread) == 0 {
+			out.WriteString("No unread messages in the archive yet.\n\n")
+			continue
+		}
+		if opts.Limit > 0 && len(unread) > opts.Limit {
+			fmt.Fprintf(&out, "(%d older unread message(s) not shown)\n\n", len(unread)-opts.Limit)
+			unread = unread[len(unread)-opts.Limit:]
+		}
+		threads := make(threadMessageCache)
+		for _, msg := range unread {
+			writeMessage(&out, msg, "", lookup)
+			if !isThreadParent(msg) {
+				continue
+			}
+			replies, err := threads.get(ctx, src, ch.ID, msg.ThreadTimestamp)
+			if err != nil {
+				return digest, err
+			}
+			for _, reply := range replies {
+				if reply.Timestamp != msg.Timestamp {
+					writeMessage(&out, reply, "|   ", lookup)
+				}
+			}
+		}
+	}
+	digest.text = out.String()
+	return digest, nil
+}
+
+func unreadHeading(lookup renderLookup, ch slack.Channel, count int, timezone string) string {
+	name := ch.Name
+	if lookup.pseudonyms != nil && (ch.IsIM || ch.IsMPIM) {
+		name = lookup.channelFileName(nil, rslack.Channel{GroupConversation: rslack.GroupConversation{
+			Conversation: rslack.Conversation{ID: ch.ID, IsIM: ch.IsIM, IsMpIM: ch.IsMPIM},
+		}})
+	}
+	if ch.LastRead.IsZero() {
+		return fmt.Sprintf("%s (%d unread, never read)", name, count)
+	}
+	since := ch.LastRead
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		since = since.In(loc)
+	}
+	return fmt.Sprintf("%s (%d unread since %s)", name, count, since.Format("2006-01-02 15:04"))
+}
+
+// messagesAfter returns the sorted messages posted after t.
+func messagesAfter(messages []rslack.Message, t time.Time) []rslack.Message {
+	var after []rslack.Message
+	for _, msg := range messages {
+		if ts, err := parseSlackTimestamp(msg.Timestamp); err == nil && ts.After(t) {
+			after = append(after, msg)
+		}
+	}
+	return after
+}
+
+func newestMessageTime(messages []rslack.Message) time.Time {
+	var newest time.Time
+	for _, msg := range messages {
+		if ts, err := parseSlackTimestamp(msg.Timestamp); err == nil && ts.After(newest) {
+			newest = ts
+		}
+	}
+	return newest
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+	rslack "github.com/rusq/slack"
+)
+
+func TestUnreadChannels(t *testing.T) {
+	read := time.Unix(1000, 0)
+	chans := []slack.Channel{
+		{ID: "C1", LastRead: read, LastMessage: time.Unix(2000, 0)},
+		{ID: "C2", LastRead: read, LastMessage: read},
+		{ID: "D1", LastMessage: time.Unix(500, 0)},
+		{ID: "C3"},
+	}
+	var got []string
+	for _, ch := range unreadChannels(chans) {
+		got = append(got, ch.ID)
+	}
+	if want := []string{"C1", "D1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unreadChannels() = %v, want %v", got, want)
+	}
+}
+
+func TestRenderUnreadDigest(t *testing.T) {
+	message := func(ts, text string) rslack.Message {
+		return rslack.Message{Msg: rslack.Msg{Type: "message", User: "U1", Text: text, Timestamp: ts}}
+	}
+	parent := message("1783094500.000000", "New thread")
+	parent.ThreadTimestamp = parent.Timestamp
+	parent.ReplyCount = 1
+	reply := message("1783094600.000000", "Reply")
+	reply.ThreadTimestamp = parent.Timestamp
+	src := memoryArchiveSource{
+		users: []rslack.User{{ID: "U1", Name: "alice", RealName: "Alice"}},
+		messages: map[string][]rslack.Message{
+			"C1": {message("1783094400.000000", "Already read"), parent, message("1783094700.000000", "Latest")},
+		},
+		threads: map[string][]rslack.Message{"C1:" + parent.Timestamp: {parent, reply}},
+	}
+	chans := []slack.Channel{
+		{ID: "C1", Name: "general", LastRead: time.Unix(1783094450, 0), LastMessage: time.Unix(1783094700, 0)},
+		{ID: "C2", Name: "random", LastRead: time.Unix(1783094450, 0), LastMessage: time.Unix(1783094800, 0)},
+	}
+
+	digest, err := renderUnreadDigest(context.Background(), src, chans, RenderOptions{Timezone: "UTC"},
+		UnreadOptions{}, time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("renderUnreadDigest() error = %v", err)
+	}
+	for _, want := range []string{
+		"# Unread since you last looked (2026-07-04)",
+		"## general (2 unread since 2026-07-03 16:00)",
+		"New thread", "|   Reply", "Latest",
+		"## random (0 unread since 2026-07-03 16:00)",
+	} {
+		if !strings.Contains(digest.text, want) {
+			t.Errorf("digest missing %q:\n%s", want, digest.text)
+		}
+	}
+	if strings.Contains(digest.text, "Already read") {
+		t.Errorf("digest includes a read message:\n%s", digest.text)
+	}
+	if !reflect.DeepEqual(digest.behind, []string{"random"}) {
+		t.Errorf("behind = %v, want [random]", digest.behind)
+	}
+}
+
+func TestRenderUnreadDigest_Limit(t *testing.T) {
+	var messages []rslack.Message
+	for _, ts := range []string{"1783094500.000000", "1783094600.000000", "1783094700.000000"} {
+		messages = append(messages, rslack.Message{Msg: rslack.Msg{Type: "message", User: "U1", Text: "msg " + ts, Timestamp: ts}})
+	}
+	src := memoryArchiveSource{messages: map[string][]rslack.Message{"D1": messages}}
+	chans := []slack.Channel{{ID: "D1", Name: "dm_bob", IsIM: true, LastMessage: time.Unix(1783094700, 0)}}
+
+	digest, err := renderUnreadDigest(context.Background(), src, chans, RenderOptions{Timezone: "UTC"},
+		UnreadOptions{Limit: 2}, time.Now())
+	if err != nil {
+		t.Fatalf("renderUnreadDigest() error = %v", err)
+	}
+	if !strings.Contains(digest.text, "## dm_bob (3 unread, never read)") ||
+		!strings.Contains(digest.text, "(1 older unread message(s) not shown)") ||
+		strings.Contains(digest.text, "msg 1783094500") {
+		t.Errorf("unexpected limited digest:\n%s", digest.text)
+	}
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// userProfilesFile holds the latest profile snapshot in each output
+// directory; a copy is kept in the date folder of each day it changed.
+const userProfilesFile = "user-profiles.json"
+
+// userProfileState is the archive's record of the last profile snapshot.
+type userProfileState struct {
+	Date  string                      `json:"date"`
+	Users []slack.UserProfileSnapshot `json:"users"`
+}
+
+// recordUserProfiles snapshots members' titles and custom profile fields
+// when user_profiles is enabled. Collecting needs one API call per member,
+// so it runs on the first sync of each work day. Failures only warn.
+func (e *Exporter) recordUserProfiles(ctx context.Context, archiveDir string, now time.Time) {
+	if !e.cfg.UserProfiles {
+		return
+	}
+	loc, err := time.LoadLocation(e.cfg.Timezone)
+	if err != nil {
+		e.warnf("failed to record user profiles: %v", err)
+		return
+	}
+	date := workDate(now.In(loc))
+	previous, err := loadUserProfileState(archiveDir)
+	if err != nil {
+		e.warnf("failed to record user profiles: %v", err)
+		return
+	}
+	if previous != nil && previous.Date == date {
+		return
+	}
+	e.stagef("Collecting user profiles")
+	profiles, err := e.edgeClient.CollectProfiles(ctx)
+	if err != nil {
+		e.warnf("failed to collect user profiles: %v", err)
+		return
+	}
+	if err := recordProfiles(archiveDir, e.cfg.OutputDirs(), e.cfg.Layout, previous, profiles, date); err != nil {
+		e.warnf("failed to record user profiles: %v", err)
+	}
+}
+
+// recordProfiles writes profiles to each output directory, plus a dated
+// copy when they differ from previous, and stores them in the archive.
+func recordProfiles(
+	archiveDir string,
+	outputDirs []string,
+	layout string,
+	previous *userProfileState,
+	profiles []slack.UserProfileSnapshot,
+	date string,
+) error {
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	changed := previous == nil || !reflect.DeepEqual(previous.Users, profiles)
+	for _, dir := range outputDirs {
+		if _, err := writeFileIfChanged(filepath.Join(dir, userProfilesFile), data, false); err != nil {
+			return err
+		}
+		if changed {
+			if _, err := writeFileIfChanged(filepath.Join(dateDir(dir, date, layout), dayFileName(date, layout, userProfilesFile)), data, false); err != nil {
+				return err
+			}
+		}
+	}
+	return saveUserProfileState(archiveDir, userProfileState{Date: date, Users: profiles})
+}
+
+func userProfileStatePath(archiveDir string) string {
+	return filepath.Join(archiveDir, ".slack-export-user-profiles.json")
+}
+
+func loadUserProfileState(archiveDir string) (*userProfileState, error) {
+	data, err := os.ReadFile(userProfileStatePath(archiveDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading user profile snapshot: %w", err)
+	}
+	var state userProfileState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing user profile snapshot: %w", err)
+	}
+	return &state, nil
+}
+
+func saveUserProfileState(archiveDir string, state userProfileState) error {
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(userProfileStatePath(archiveDir), data, 0600)
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+func TestRecordProfiles_DatedCopyOnlyOnChange(t *testing.T) {
+	archiveDir, outputDir := t.TempDir(), t.TempDir()
+	profiles := []slack.UserProfileSnapshot{{ID: "U1", Name: "alice", Title: "Engineer", Fields: map[string]string{"Team": "Platform"}}}
+
+	if err := recordProfiles(archiveDir, []string{outputDir}, "", nil, profiles, "2026-01-15"); err != nil {
+		t.Fatalf("recordProfiles() error = %v", err)
+	}
+	state, err := loadUserProfileState(archiveDir)
+	if err != nil || state == nil || state.Date != "2026-01-15" {
+		t.Fatalf("stored state = %+v, %v", state, err)
+	}
+	if err := recordProfiles(archiveDir, []string{outputDir}, "", state, profiles, "2026-01-16"); err != nil {
+		t.Fatal(err)
+	}
+	moved := []slack.UserProfileSnapshot{{ID: "U1", Name: "alice", Title: "Engineer", Fields: map[string]string{"Team": "Payments"}}}
+	state, _ = loadUserProfileState(archiveDir)
+	if err := recordProfiles(archiveDir, []string{outputDir}, "", state, moved, "2026-01-17"); err != nil {
+		t.Fatal(err)
+	}
+
+	for date, want := range map[string]bool{"2026-01-15": true, "2026-01-16": false, "2026-01-17": true} {
+		_, err := os.Stat(filepath.Join(outputDir, date, userProfilesFile))
+		if (err == nil) != want {
+			t.Errorf("%s dated snapshot present = %v, want %v", date, err == nil, want)
+		}
+	}
+	latest, err := os.ReadFile(filepath.Join(outputDir, userProfilesFile))
+	if err != nil || !strings.Contains(string(latest), "Payments") {
+		t.Errorf("latest snapshot = %s, %v", latest, err)
+	}
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+var subteamMentionPattern = regexp.MustCompile(`<!subteam\^([A-Z0-9]+)(?:\|([^>]*))?>`)
+
+// refreshUsergroupCache fetches usergroups.list and stores the index next to
+// the user cache. Failures only warn: some tokens lack the usergroups scope and
+// mentions then fall back to the label embedded in the message.
+func (e *Exporter) refreshUsergroupCache(ctx context.Context) {
+	groups, err := e.edgeClient.FetchUsergroups(ctx)
+	if err != nil {
+		e.warnf("failed to fetch user groups: %v", err)
+		return
+	}
+	cache := slack.NewUsergroupCache(slack.DefaultUsergroupCachePath())
+	cache.Replace(groups)
+	if err := cache.Save(); err != nil {
+		e.warnf("failed to save user group cache: %v", err)
+	}
+}
+
+// loadUsergroupHandles reads the usergroup index cached at path. A cache
+// that cannot be read is reported to warn and leaves mentions on their
+// embedded labels.
+func loadUsergroupHandles(path string, warn func(error)) map[string]string {
+	cache := slack.NewUsergroupCache(path)
+	if err := cache.Load(); err != nil {
+		warn(fmt.Errorf("failed to load user group cache: %w", err))
+		return nil
+	}
+	return cache.Handles()
+}
+
+// resolveSubteamMentions renders <!subteam^ID> and <!subteam^ID|@label> as
+// @handle, preferring the cached handle over the embedded label.
+func resolveSubteamMentions(text string, usergroups map[string]string) string {
+	return subteamMentionPattern.ReplaceAllStringFunc(text, func(token string) string {
+		matches := subteamMentionPattern.FindStringSubmatch(token)
+		if handle := usergroups[matches[1]]; handle != "" {
+			return "@" + strings.TrimPrefix(handle, "@")
+		}
+		if label := strings.TrimSpace(matches[2]); label != "" {
+			return "@" + strings.TrimPrefix(label, "@")
+		}
+		return token
+	})
+}
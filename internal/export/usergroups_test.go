@@ -0,0 +1,82 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestResolveSubteamMentions(t *testing.T) {
+	usergroups := map[string]string{"S123": "eng-team"}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"cached handle", "ping <!subteam^S123>", "ping @eng-team"},
+		{"cached handle wins over label", "ping <!subteam^S123|@old-name>", "ping @eng-team"},
+		{"label fallback", "ping <!subteam^S999|@oncall>", "ping @oncall"},
+		{"label without at sign", "ping <!subteam^S999|oncall>", "ping @oncall"},
+		{"unknown stays raw", "ping <!subteam^S999>", "ping <!subteam^S999>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveSubteamMentions(tt.text, usergroups); got != tt.want {
+				t.Errorf("resolveSubteamMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderSourceRange_ResolvesSubteamMentions(t *testing.T) {
+	src := memoryArchiveSource{
+		channels: []rslack.Channel{{
+			GroupConversation: rslack.GroupConversation{
+				Conversation: rslack.Conversation{ID: "C1"},
+				Name:         "general",
+			},
+		}},
+		users: []rslack.User{{ID: "U1", Name: "alice"}},
+		messages: map[string][]rslack.Message{"C1": {{Msg: rslack.Msg{
+			Type:      "message",
+			User:      "U1",
+			Text:      "heads up <!subteam^S123> and <@U1>",
+			Timestamp: "1782910800.000000",
+		}}}},
+	}
+	outputDir := t.TempDir()
+
+	if _, err := renderSourceRange(
+		context.Background(), src, outputDir, "2026-07-01", "2026-07-01",
+		RenderOptions{Timezone: "America/Chicago", Usergroups: map[string]string{"S123": "eng-team"}}, nil, nil,
+	); err != nil {
+		t.Fatalf("renderSourceRange() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "2026-07-01", "2026-07-01-general.md"))
+	if err != nil {
+		t.Fatalf("reading rendered file: %v", err)
+	}
+	if !strings.Contains(string(content), "heads up @eng-team and alice") {
+		t.Errorf("rendered content missing resolved mentions:\n%s", content)
+	}
+}
+
+func TestLoadUsergroupHandles_WarnsOnUnreadableCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usergroups.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var warnings []error
+	handles := loadUsergroupHandles(path, func(err error) { warnings = append(warnings, err) })
+	if handles != nil {
+		t.Errorf("handles = %v, want nil", handles)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "failed to load user group cache") {
+		t.Errorf("warnings = %v, want one cache load warning", warnings)
+	}
+}
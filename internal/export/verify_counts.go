@@ -0,0 +1,168 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+// CountCheck compares one channel-day's written top-level messages, plus
+// those message filters dropped, with the count Slack reports.
+type CountCheck struct {
+	ChannelID string    `json:"channel_id"`
+	Channel   string    `json:"channel"`
+	Written   int       `json:"written"`
+	Filtered  int       `json:"filtered,omitempty"`
+	Slack     int       `json:"slack"`
+	Match     bool      `json:"match"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type channelDay struct {
+	id   string
+	date string
+}
+
+// writtenDay is what a render wrote for one channel-day.
+type writtenDay struct {
+	name     string
+	timezone string
+	messages int
+	filtered int
+	dirs     []string
+}
+
+// writtenCounts collects the top-level message count of every channel-day
+// written during a render, for verify_counts. A nil *writtenCounts records
+// nothing.
+type writtenCounts struct {
+	days map[channelDay]*writtenDay
+}
+
+func newWrittenCounts() *writtenCounts {
+	return &writtenCounts{days: make(map[channelDay]*writtenDay)}
+}
+
+func (w *writtenCounts) record(outputDir string, ch channelDates, date, timezone string, filtered int) {
+	if w == nil {
+		return
+	}
+	key := channelDay{id: ch.id, date: date}
+	day, ok := w.days[key]
+	if !ok {
+		day = &writtenDay{
+			name:     ch.name,
+			timezone: timezone,
+			messages: topLevelMessages(ch.messages, date, timezone),
+			filtered: filtered,
+		}
+		w.days[key] = day
+	}
+	day.dirs = append(day.dirs, outputDir)
+}
+
+func topLevelMessages(messages []rslack.Message, date, timezone string) int {
+	count := 0
+	for _, msg := range messages {
+		if messageBelongsToDate(msg, date, timezone) {
+			count++
+		}
+	}
+	return count
+}
+
+// sample picks up to perDate of each date's busiest channel-days, which
+// are the likeliest to be truncated.
+func (w *writtenCounts) sample(perDate int) []channelDay {
+	byDate := make(map[string][]channelDay)
+	for key := range w.days {
+		byDate[key.date] = append(byDate[key.date], key)
+	}
+	var picked []channelDay
+	for _, keys := range byDate {
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := w.days[keys[i]], w.days[keys[j]]
+			if a.messages != b.messages {
+				return a.messages > b.messages
+			}
+			return keys[i].id < keys[j].id
+		})
+		picked = append(picked, keys[:min(perDate, len(keys))]...)
+	}
+	sort.Slice(picked, func(i, j int) bool {
+		if picked[i].date != picked[j].date {
+			return picked[i].date < picked[j].date
+		}
+		return picked[i].id < picked[j].id
+	})
+	return picked
+}
+
+// verifyWrittenCounts compares a sample of the channel-days just written
+// with Slack's own counts and records the result in each date's manifest.
+// Discrepancies and API failures only warn.
+func (e *Exporter) verifyWrittenCounts(ctx context.Context, opts RenderOptions) {
+	if opts.written == nil || len(opts.written.days) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	checks := make(map[channelDay]CountCheck)
+	for _, key := range opts.written.sample(e.cfg.VerifyCounts) {
+		day := opts.written.days[key]
+		start, end, err := GetDateBounds(key.date, day.timezone)
+		if err != nil {
+			e.warnf("verifying message counts: %v", err)
+			return
+		}
+		slackCount, err := e.edgeClient.CountMessages(ctx, key.id, start, end)
+		if err != nil {
+			e.warnf("verifying message counts for %s %s: %v", day.name, key.date, err)
+			break
+		}
+		check := CountCheck{
+			ChannelID: key.id, Channel: day.name, Written: day.messages, Filtered: day.filtered, Slack: slackCount,
+			Match: slackCount == day.messages+day.filtered, CheckedAt: now,
+		}
+		if !check.Match {
+			exported := fmt.Sprintf("%d message(s) in the export", day.messages)
+			if day.filtered > 0 {
+				exported += fmt.Sprintf(" and %d filtered out", day.filtered)
+			}
+			e.warnf("%s %s has %s but %d in Slack", day.name, key.date, exported, slackCount)
+		}
+		checks[key] = check
+	}
+	if err := recordCountChecks(opts.written, checks, opts.Layout); err != nil {
+		e.warnf("recording message count checks: %v", err)
+	}
+}
+
+func recordCountChecks(written *writtenCounts, checks map[channelDay]CountCheck, layout string) error {
+	for key, check := range checks {
+		for _, dir := range written.days[key].dirs {
+			err := updateDateManifest(dir, key.date, layout, func(m *DateManifest) {
+				m.Verification = mergeCountCheck(m.Verification, check)
+			})
+			if err != nil {
+				return fmt.Errorf("updating manifest for %s: %w", key.date, err)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeCountCheck replaces the channel's earlier check, keeping the list
+// ordered by channel name.
+func mergeCountCheck(checks []CountCheck, check CountCheck) []CountCheck {
+	merged := []CountCheck{check}
+	for _, existing := range checks {
+		if existing.ChannelID != check.ChannelID {
+			merged = append(merged, existing)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Channel < merged[j].Channel })
+	return merged
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	rslack "github.com/rusq/slack"
+)
+
+func countMessage(ts string) rslack.Message {
+	return rslack.Message{Msg: rslack.Msg{Timestamp: ts}}
+}
+
+func TestWrittenCounts_RecordsTopLevelMessagesOnce(t *testing.T) {
+	written := newWrittenCounts()
+	// 2026-01-15 12:00 UTC, then 2026-01-16 12:00 UTC.
+	ch := channelDates{id: "C1", name: "general", messages: []rslack.Message{
+		countMessage("1768478400.000100"), countMessage("1768478500.000100"), countMessage("1768564800.000100"),
+	}}
+	written.record("/out/a", ch, "2026-01-15", "UTC", 0)
+	written.record("/out/b", ch, "2026-01-15", "UTC", 0)
+
+	day := written.days[channelDay{id: "C1", date: "2026-01-15"}]
+	if day == nil || day.messages != 2 || len(day.dirs) != 2 {
+		t.Fatalf("recorded %+v, want 2 messages in 2 dirs", day)
+	}
+	var none *writtenCounts
+	none.record("/out", ch, "2026-01-15", "UTC", 0)
+}
+
+func TestWrittenCounts_SamplesBusiestPerDate(t *testing.T) {
+	written := newWrittenCounts()
+	written.days[channelDay{id: "C1", date: "2026-01-15"}] = &writtenDay{messages: 3}
+	written.days[channelDay{id: "C2", date: "2026-01-15"}] = &writtenDay{messages: 9}
+	written.days[channelDay{id: "C3", date: "2026-01-15"}] = &writtenDay{messages: 1}
+	written.days[channelDay{id: "C1", date: "2026-01-16"}] = &writtenDay{messages: 1}
+
+	got := written.sample(2)
+	want := []channelDay{{id: "C1", date: "2026-01-15"}, {id: "C2", date: "2026-01-15"}, {id: "C1", date: "2026-01-16"}}
+	if len(got) != len(want) {
+		t.Fatalf("sample() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRecordCountChecks_MergesIntoManifest(t *testing.T) {
+	dir := t.TempDir()
+	written := newWrittenCounts()
+	key := channelDay{id: "C1", date: "2026-01-15"}
+	written.days[key] = &writtenDay{name: "general", messages: 2, dirs: []string{dir}}
+	checkedAt := time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)
+
+	first := CountCheck{ChannelID: "C1", Channel: "general", Written: 2, Slack: 3, CheckedAt: checkedAt}
+	if err := recordCountChecks(written, map[channelDay]CountCheck{key: first}, ""); err != nil {
+		t.Fatalf("recordCountChecks() error = %v", err)
+	}
+	second := CountCheck{ChannelID: "C1", Channel: "general", Written: 3, Slack: 3, Match: true, CheckedAt: checkedAt}
+	if err := recordCountChecks(written, map[channelDay]CountCheck{key: second}, ""); err != nil {
+		t.Fatalf("recordCountChecks() error = %v", err)
+	}
+
+	manifest, err := loadDateManifest(dateManifestPath(dir, "2026-01-15", ""), "2026-01-15")
+	if err != nil {
+		t.Fatalf("loadDateManifest() error = %v", err)
+	}
+	if manifest.Date != "2026-01-15" || len(manifest.Verification) != 1 || manifest.Verification[0] != second {
+		t.Errorf("manifest = %+v, want only the latest check", manifest)
+	}
+}
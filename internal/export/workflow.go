@@ -0,0 +1,123 @@
+package export
+
+import (
+	"html"
+	"strings"
+
+	rslack "github.com/rusq/slack"
+)
+
+// workflowField is one key-value pair from a bot post's structured fields.
+type workflowField struct {
+	key   string
+	value string
+}
+
+// withWorkflowFields renders the structured fields that Workflow Builder
+// and other bots post as key-value markdown tables. Section block fields
+// replace text, which for those posts is a run-together fallback; attachment
+// fields are appended below it. Messages without fields keep text.
+func withWorkflowFields(msg rslack.Message, text string, lookup renderLookup) string {
+	if blocksText, ok := sectionFieldsMarkdown(msg, lookup); ok {
+		text = blocksText
+	}
+	parts := []string{text}
+	for _, attachment := range msg.Attachments {
+		if len(attachment.Fields) > 0 {
+			parts = append(parts, attachmentMarkdown(attachment, lookup))
+		}
+	}
+	if len(parts) == 1 {
+		return text
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n\n"))
+}
+
+// sectionFieldsMarkdown renders header and section blocks when at least one
+// section carries fields, and reports false otherwise.
+func sectionFieldsMarkdown(msg rslack.Message, lookup renderLookup) (string, bool) {
+	var parts []string
+	hasFields := false
+	for _, block := range msg.Blocks.BlockSet {
+		switch b := block.(type) {
+		case *rslack.HeaderBlock:
+			if b.Text != nil {
+				parts = append(parts, "**"+strings.TrimSpace(b.Text.Text)+"**")
+			}
+		case *rslack.SectionBlock:
+			if b.Text != nil && strings.TrimSpace(b.Text.Text) != "" {
+				parts = append(parts, workflowText(b.Text.Text, lookup))
+			}
+			if len(b.Fields) > 0 {
+				hasFields = true
+				parts = append(parts, fieldsTable(sectionFields(b.Fields, lookup)))
+			}
+		}
+	}
+	if !hasFields {
+		return "", false
+	}
+	return strings.Join(parts, "\n\n"), true
+}
+
+// sectionFields splits each field into its bold label and value, the shape
+// Workflow Builder uses ("*Label*\nvalue"). Fields without a label keep an
+// empty key.
+func sectionFields(objects []*rslack.TextBlockObject, lookup renderLookup) []workflowField {
+	fields := make([]workflowField, 0, len(objects))
+	for _, object := range objects {
+		if object == nil {
+			continue
+		}
+		label, value, ok := strings.Cut(strings.TrimSpace(object.Text), "\n")
+		if !ok || !isBoldLabel(label) {
+			fields = append(fields, workflowField{value: workflowText(object.Text, lookup)})
+			continue
+		}
+		key := strings.TrimSuffix(strings.Trim(strings.TrimSpace(label), "*"), ":")
+		fields = append(fields, workflowField{key: workflowText(key, lookup), value: workflowText(value, lookup)})
+	}
+	return fields
+}
+
+func isBoldLabel(line string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) > 2 && strings.HasPrefix(line, "*") && strings.HasSuffix(strings.TrimSuffix(line, ":"), "*")
+}
+
+func attachmentMarkdown(attachment rslack.Attachment, lookup renderLookup) string {
+	var parts []string
+	if pretext := workflowText(attachment.Pretext, lookup); pretext != "" {
+		parts = append(parts, pretext)
+	}
+	if title := workflowText(attachment.Title, lookup); title != "" {
+		parts = append(parts, "**"+title+"**")
+	}
+	if text := workflowText(attachment.Text, lookup); text != "" {
+		parts = append(parts, text)
+	}
+	fields := make([]workflowField, 0, len(attachment.Fields))
+	for _, field := range attachment.Fields {
+		fields = append(fields, workflowField{key: workflowText(field.Title, lookup), value: workflowText(field.Value, lookup)})
+	}
+	return strings.Join(append(parts, fieldsTable(fields)), "\n\n")
+}
+
+func workflowText(text string, lookup renderLookup) string {
+	return strings.TrimSpace(resolveMentions(html.UnescapeString(text), lookup))
+}
+
+// fieldsTable lays fields out as a two-column markdown table.
+func fieldsTable(fields []workflowField) string {
+	var b strings.Builder
+	b.WriteString("| Field | Value |\n| --- | --- |")
+	for _, field := range fields {
+		b.WriteString("\n| " + tableCell(field.key) + " | " + tableCell(field.value) + " |")
+	}
+	return b.String()
+}
+
+func tableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(strings.TrimSpace(text), "\n", "<br>")
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	rslack "github.com/rusq/slack"
+)
+
+func TestWithWorkflowFields_SectionFields(t *testing.T) {
+	var msg rslack.Message
+	raw := `{"type": "message", "subtype": "bot_message", "ts": "1768050000.000100",
+	  "text": "New request Request type Bug Owner <@U2>",
+	  "blocks": [
+	    {"type": "header", "text": {"type": "plain_text", "text": "New request"}},
+	    {"type": "section", "text": {"type": "mrkdwn", "text": "Submitted via form"}},
+	    {"type": "section", "fields": [
+	      {"type": "mrkdwn", "text": "*Request type*\nBug"},
+	      {"type": "mrkdwn", "text": "*Owner:*\n<@U2>"},
+	      {"type": "mrkdwn", "text": "a | b"}
+	    ]}
+	  ]}`
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatal(err)
+	}
+	lookup := renderLookup{users: userLookup{"U2": {ID: "U2", Name: "bob"}}}
+
+	got := messageText(msg, lookup)
+	want := "**New request**\n\nSubmitted via form\n\n" +
+		"| Field | Value |\n| --- | --- |\n" +
+		"| Request type | Bug |\n| Owner | bob |\n|  | a \\| b |"
+	if got != want {
+		t.Errorf("messageText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWithWorkflowFields_AttachmentFields(t *testing.T) {
+	var msg rslack.Message
+	raw := `{"type": "message", "ts": "1768050000.000100", "text": "Incident opened",
+	  "attachments": [
+	    {"title": "INC-42", "text": "Checkout errors",
+	     "fields": [{"title": "Severity", "value": "SEV2", "short": true},
+	                {"title": "Notes", "value": "line one\nline two"}]},
+	    {"text": "no fields here"}
+	  ]}`
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	got := messageText(msg, renderLookup{})
+	want := "Incident opened\n\n**INC-42**\n\nCheckout errors\n\n" +
+		"| Field | Value |\n| --- | --- |\n| Severity | SEV2 |\n| Notes | line one<br>line two |"
+	if got != want {
+		t.Errorf("messageText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWithWorkflowFields_KeepsPlainMessages(t *testing.T) {
+	msg := rslack.Message{Msg: rslack.Msg{Text: "  spaced text  "}}
+
+	if got := messageText(msg, renderLookup{}); got != "  spaced text  " {
+		t.Errorf("messageText() = %q, want text unchanged", got)
+	}
+}
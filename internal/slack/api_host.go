@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"net/url"
+	"strings"
+)
+
+// defaultAPIDomain is the domain of Slack's commercial deployment. Other
+// deployments, such as GovSlack or data-residency regions, serve the API
+// from their own domain.
+const defaultAPIDomain = "slack.com"
+
+// WithAPIHost returns a new EdgeClient whose Slack API and Edge requests go
+// to the deployment serving host, e.g. "slack-gov.com". A scheme or path
+// on host is ignored.
+func (c *EdgeClient) WithAPIHost(host string) *EdgeClient {
+	apiURL, edgeURL := APIURLs(host)
+	return c.WithSlackAPIURL(apiURL).WithBaseURL(edgeURL)
+}
+
+// APIURLs returns the Slack API and Edge base URLs of the deployment
+// serving host.
+func APIURLs(host string) (apiURL, edgeURL string) {
+	host = hostName(host)
+	return "https://" + host + "/api", "https://edgeapi." + registrableDomain(host)
+}
+
+// adoptWorkspaceHost points a client still using the default API URLs at
+// the deployment serving workspaceURL, as reported by auth.test. Workspaces
+// on slack.com, and clients given explicit URLs, are left unchanged.
+func (c *EdgeClient) adoptWorkspaceHost(workspaceURL string) {
+	if c.slackAPIURL != DefaultSlackAPIURL || c.baseURL != DefaultEdgeBaseURL {
+		return
+	}
+	if apiURL, edgeURL, ok := workspaceAPIURLs(workspaceURL); ok {
+		c.slackAPIURL, c.baseURL = apiURL, edgeURL
+	}
+}
+
+// workspaceAPIURLs derives the API URLs of a workspace outside slack.com.
+// The workspace's own host serves the Web API for its deployment.
+func workspaceAPIURLs(workspaceURL string) (apiURL, edgeURL string, ok bool) {
+	host := hostName(workspaceURL)
+	if host == "" || host == defaultAPIDomain || strings.HasSuffix(host, "."+defaultAPIDomain) {
+		return "", "", false
+	}
+	return "https://" + host + "/api", "https://edgeapi." + registrableDomain(host), true
+}
+
+// hostName strips any scheme, path, and port from host.
+func hostName(host string) string {
+	host = strings.TrimSpace(host)
+	if strings.Contains(host, "://") {
+		if u, err := url.Parse(host); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+	}
+	host, _, _ = strings.Cut(host, "/")
+	host, _, _ = strings.Cut(host, ":")
+	return strings.ToLower(host)
+}
+
+// registrableDomain keeps the last two labels of host, dropping workspace
+// and region subdomains.
+func registrableDomain(host string) string {
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return host
+	}
+	return strings.Join(labels[len(labels)-2:], ".")
+}
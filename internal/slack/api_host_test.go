@@ -0,0 +1,38 @@
+package slack
+
+import "testing"
+
+func TestAPIURLs(t *testing.T) {
+	tests := []struct {
+		host, api, edge string
+	}{
+		{"slack.com", DefaultSlackAPIURL, DefaultEdgeBaseURL},
+		{"slack-gov.com", "https://slack-gov.com/api", "https://edgeapi.slack-gov.com"},
+		{"https://Acme.Slack-Gov.com/", "https://acme.slack-gov.com/api", "https://edgeapi.slack-gov.com"},
+	}
+	for _, tt := range tests {
+		api, edge := APIURLs(tt.host)
+		if api != tt.api || edge != tt.edge {
+			t.Errorf("APIURLs(%q) = %q, %q; want %q, %q", tt.host, api, edge, tt.api, tt.edge)
+		}
+	}
+}
+
+func TestAdoptWorkspaceHost(t *testing.T) {
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"})
+	client.adoptWorkspaceHost("https://acme.enterprise.slack.com/")
+	if client.slackAPIURL != DefaultSlackAPIURL || client.baseURL != DefaultEdgeBaseURL {
+		t.Errorf("slack.com workspace moved the client to %q, %q", client.slackAPIURL, client.baseURL)
+	}
+
+	client.adoptWorkspaceHost("https://acme.slack-gov.com/")
+	if client.slackAPIURL != "https://acme.slack-gov.com/api" || client.baseURL != "https://edgeapi.slack-gov.com" {
+		t.Errorf("adoptWorkspaceHost() = %q, %q", client.slackAPIURL, client.baseURL)
+	}
+
+	pinned := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL("http://localhost:1234")
+	pinned.adoptWorkspaceHost("https://acme.slack-gov.com/")
+	if pinned.slackAPIURL != "http://localhost:1234" {
+		t.Errorf("explicit API URL replaced by %q", pinned.slackAPIURL)
+	}
+}
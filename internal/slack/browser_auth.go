@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// workspaceNamePattern matches a Slack workspace subdomain.
+var workspaceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// apiTokenPattern finds the client token in a signed-in Slack page.
+var apiTokenPattern = regexp.MustCompile(`"api_token":"(xoxc-[^"]+)"`)
+
+// WorkspaceName returns the workspace subdomain from "acme",
+// "acme.slack.com", or "https://acme.slack.com/".
+func WorkspaceName(input string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(input))
+	if strings.Contains(name, "://") {
+		u, err := url.Parse(name)
+		if err != nil {
+			return "", fmt.Errorf("invalid workspace URL %q: %w", input, err)
+		}
+		name = u.Hostname()
+	}
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "/"), ".slack.com")
+	if !workspaceNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid workspace %q; use its name or URL, e.g. acme.slack.com", input)
+	}
+	return name, nil
+}
+
+// CredentialsFromCookie exchanges a "d" session cookie for workspace
+// credentials by loading the signed-in workspace page, which carries the
+// client token.
+func CredentialsFromCookie(ctx context.Context, workspace string, cookie *http.Cookie) (*Credentials, error) {
+	pageURL := "https://" + workspace + ".slack.com/ssb/redirect"
+	client := &http.Client{Timeout: DefaultHTTPTimeout}
+	return credentialsFromCookie(ctx, client, pageURL, workspace, cookie)
+}
+
+func credentialsFromCookie(ctx context.Context, client *http.Client, pageURL, workspace string, cookie *http.Cookie) (*Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace page: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to load workspace page: HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace page: %w", err)
+	}
+	match := apiTokenPattern.FindSubmatch(body)
+	if match == nil {
+		return nil, errors.New("no token on the workspace page; the browser session may have expired or belong to another workspace")
+	}
+	cookies := []*http.Cookie{cookie}
+	for _, c := range resp.Cookies() {
+		if c.Name != cookie.Name && c.Value != "" {
+			cookies = append(cookies, c)
+		}
+	}
+	return &Credentials{Token: string(match[1]), Cookies: cookies, Workspace: workspace}, nil
+}
@@ -0,0 +1,50 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWorkspaceName(t *testing.T) {
+	for _, input := range []string{"acme", "Acme.slack.com", "https://acme.slack.com/", " acme.slack.com/ "} {
+		if got, err := WorkspaceName(input); err != nil || got != "acme" {
+			t.Errorf("WorkspaceName(%q) = %q, %v; want acme", input, got, err)
+		}
+	}
+	for _, input := range []string{"", "../acme", "acme corp", "-acme"} {
+		if _, err := WorkspaceName(input); err == nil {
+			t.Errorf("WorkspaceName(%q) should fail", input)
+		}
+	}
+}
+
+func TestCredentialsFromCookie(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("d"); err != nil || c.Value != "xoxd-session" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "d-s", Value: "1700000000"})
+		_, _ = w.Write([]byte(`<script>var boot = {"team_id":"T1","api_token":"xoxc-from-page","x":1};</script>`))
+	}))
+	defer server.Close()
+
+	cookie := &http.Cookie{Name: "d", Value: "xoxd-session", Domain: ".slack.com"}
+	creds, err := credentialsFromCookie(context.Background(), server.Client(), server.URL, "acme", cookie)
+	if err != nil {
+		t.Fatalf("credentialsFromCookie() error = %v", err)
+	}
+	if creds.Token != "xoxc-from-page" || creds.Workspace != "acme" {
+		t.Errorf("creds = %+v, want the page's token for acme", creds)
+	}
+	if len(creds.Cookies) != 2 || creds.Cookies[0] != cookie || creds.Cookies[1].Name != "d-s" {
+		t.Errorf("Cookies = %v, want the d cookie and the page's d-s cookie", creds.Cookies)
+	}
+
+	expired := &http.Cookie{Name: "d", Value: "xoxd-expired"}
+	if _, err := credentialsFromCookie(context.Background(), server.Client(), server.URL, "acme", expired); err == nil {
+		t.Error("credentialsFromCookie() should fail when the page rejects the cookie")
+	}
+}
@@ -0,0 +1,351 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1" //nolint:gosec // Chromium derives its cookie key with PBKDF2-SHA1
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	_ "modernc.org/sqlite"
+)
+
+// Browser names a local cookie store the Slack "d" cookie can be read from.
+type Browser string
+
+const (
+	// BrowserChrome is Google Chrome's default profile.
+	BrowserChrome Browser = "chrome"
+	// BrowserSlack is the Slack desktop app.
+	BrowserSlack Browser = "slack"
+	// BrowserSafari is Safari, which needs Full Disk Access to be read.
+	BrowserSafari Browser = "safari"
+)
+
+// Browsers lists the supported cookie stores.
+var Browsers = []Browser{BrowserChrome, BrowserSlack, BrowserSafari}
+
+// errNoSlackCookie reports a cookie store without a Slack session.
+var errNoSlackCookie = errors.New("no Slack session cookie found; sign in to Slack in that browser first")
+
+// chromiumStore describes where a Chromium-based app keeps its cookies and
+// the keychain item its cookie key is derived from.
+type chromiumStore struct {
+	paths           []string
+	keychainService string
+}
+
+// chromiumStores maps Chromium-based browsers to their stores, relative to
+// the home directory. Newer versions keep cookies under Network/.
+//
+//nolint:gochecknoglobals // fixed per-browser locations
+var chromiumStores = map[Browser]chromiumStore{
+	BrowserChrome: {
+		paths: []string{
+			"Library/Application Support/Google/Chrome/Default/Network/Cookies",
+			"Library/Application Support/Google/Chrome/Default/Cookies",
+		},
+		keychainService: "Chrome Safe Storage",
+	},
+	BrowserSlack: {
+		paths: []string{
+			"Library/Application Support/Slack/Network/Cookies",
+			"Library/Application Support/Slack/Cookies",
+			"Library/Containers/com.tinyspeck.slackmacgap/Data/Library/Application Support/Slack/Network/Cookies",
+			"Library/Containers/com.tinyspeck.slackmacgap/Data/Library/Application Support/Slack/Cookies",
+		},
+		keychainService: "Slack Safe Storage",
+	},
+}
+
+// safariCookiesPath is Safari's cookie file, relative to the home directory.
+const safariCookiesPath = "Library/Containers/com.apple.Safari/Data/Library/Cookies/Cookies.binarycookies"
+
+// ReadBrowserCookie returns the Slack "d" session cookie stored by browser
+// for the current user. Chromium-based stores are decrypted with a key from
+// the login keychain, so macOS asks before handing it over.
+func ReadBrowserCookie(browser Browser) (*http.Cookie, error) {
+	if runtime.GOOS != "darwin" {
+		return nil, fmt.Errorf("reading browser cookies is only supported on macOS")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("could not determine home directory: %w", err)
+	}
+	if browser == BrowserSafari {
+		path := filepath.Join(home, safariCookiesPath)
+		data, err := os.ReadFile(path) //nolint:gosec // fixed location under the home directory
+		if err != nil {
+			if os.IsPermission(err) {
+				return nil, fmt.Errorf("cannot read %s: grant your terminal Full Disk Access in System Settings", path)
+			}
+			return nil, fmt.Errorf("failed to read Safari cookies: %w", err)
+		}
+		return safariCookie(data)
+	}
+	store, ok := chromiumStores[browser]
+	if !ok {
+		return nil, fmt.Errorf("unknown browser %q", browser)
+	}
+	path := ""
+	for _, rel := range store.paths {
+		if _, err := os.Stat(filepath.Join(home, rel)); err == nil {
+			path = filepath.Join(home, rel)
+			break
+		}
+	}
+	if path == "" {
+		return nil, fmt.Errorf("no %s cookie store found", browser)
+	}
+	password, err := keychainPassword(store.keychainService)
+	if err != nil {
+		return nil, err
+	}
+	return chromiumCookie(path, password)
+}
+
+// keychainPassword reads a generic password from the login keychain.
+func keychainPassword(service string) ([]byte, error) {
+	out, err := exec.Command("security", "find-generic-password", "-w", "-s", service).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q from the keychain: %w", service, err)
+	}
+	return bytes.TrimSpace(out), nil
+}
+
+const (
+	// chromiumSalt and chromiumIterations derive Chromium's macOS cookie key.
+	chromiumSalt       = "saltysalt"
+	chromiumIterations = 1003
+	chromiumKeySize    = 16
+	// chromiumPrefix marks values encrypted with the keychain key.
+	chromiumPrefix = "v10"
+	// chromiumEpochOffset is the number of microseconds from 1601-01-01,
+	// Chromium's epoch, to the Unix epoch.
+	chromiumEpochOffset = 11644473600000000
+)
+
+// chromiumCookie reads the Slack "d" cookie from a Chromium cookie
+// database, decrypting it with the key derived from password. The database
+// is copied first, since the browser may hold a lock on it.
+func chromiumCookie(dbPath string, password []byte) (*http.Cookie, error) {
+	tmpDir, err := os.MkdirTemp("", "slack-export-cookies-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	copyPath := filepath.Join(tmpDir, "Cookies")
+	if err := copyFile(dbPath, copyPath); err != nil {
+		return nil, fmt.Errorf("failed to copy cookie store: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", copyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cookie store: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+	var (
+		host      string
+		value     string
+		encrypted []byte
+		expires   int64
+	)
+	err = db.QueryRow(`SELECT host_key, value, encrypted_value, expires_utc FROM cookies
+		WHERE name = 'd' AND host_key IN ('.slack.com', 'slack.com')
+		ORDER BY expires_utc DESC LIMIT 1`).Scan(&host, &value, &encrypted, &expires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errNoSlackCookie
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cookie store: %w", err)
+	}
+	if value == "" {
+		key := pbkdf2.Key(password, []byte(chromiumSalt), chromiumIterations, chromiumKeySize, sha1.New)
+		if value, err = decryptChromiumValue(encrypted, host, key); err != nil {
+			return nil, err
+		}
+	}
+	cookie := &http.Cookie{Name: "d", Value: value, Domain: ".slack.com", Path: "/", Secure: true, HttpOnly: true}
+	if expires > 0 {
+		cookie.Expires = time.UnixMicro(expires - chromiumEpochOffset).UTC()
+	}
+	return cookie, nil
+}
+
+// decryptChromiumValue decrypts a "v10" cookie value with AES-128-CBC.
+// Recent Chromium versions prefix the plaintext with the SHA-256 of the
+// cookie's host, which is dropped.
+func decryptChromiumValue(encrypted []byte, host string, key []byte) (string, error) {
+	if !bytes.HasPrefix(encrypted, []byte(chromiumPrefix)) {
+		return "", errors.New("unsupported cookie encryption")
+	}
+	ciphertext := encrypted[len(chromiumPrefix):]
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return "", errors.New("malformed encrypted cookie")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	iv := bytes.Repeat([]byte{' '}, aes.BlockSize)
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(plaintext) {
+		return "", errors.New("failed to decrypt cookie; the keychain password does not match")
+	}
+	plaintext = plaintext[:len(plaintext)-padding]
+	hostHash := sha256.Sum256([]byte(host))
+	plaintext = bytes.TrimPrefix(plaintext, hostHash[:])
+	if !isCookieValue(plaintext) {
+		return "", errors.New("failed to decrypt cookie; the keychain password does not match")
+	}
+	return string(plaintext), nil
+}
+
+// isCookieValue reports whether b could be a cookie value: printable ASCII
+// with no separators.
+func isCookieValue(b []byte) bool {
+	for _, c := range b {
+		if c <= ' ' || c >= 0x7f || c == ';' || c == '"' {
+			return false
+		}
+	}
+	return len(b) > 0
+}
+
+// copyFile copies src to dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src) //nolint:gosec // path is a known cookie store
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // dst is in our temp dir
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// macEpoch is the Core Data epoch Safari's cookie dates count from.
+var macEpoch = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// safariCookie finds the Slack "d" cookie in a Cookies.binarycookies file.
+func safariCookie(data []byte) (*http.Cookie, error) {
+	cookies, err := parseBinaryCookies(data)
+	if err != nil {
+		return nil, err
+	}
+	var found *http.Cookie
+	for _, c := range cookies {
+		if c.Name != "d" || strings.TrimPrefix(c.Domain, ".") != "slack.com" {
+			continue
+		}
+		if found == nil || c.Expires.After(found.Expires) {
+			found = c
+		}
+	}
+	if found == nil {
+		return nil, errNoSlackCookie
+	}
+	return found, nil
+}
+
+// parseBinaryCookies decodes Safari's binarycookies format: a big-endian
+// page table, then pages of little-endian cookie records whose strings are
+// NUL-terminated at offsets from the start of the record.
+func parseBinaryCookies(data []byte) ([]*http.Cookie, error) {
+	malformed := errors.New("malformed binarycookies file")
+	if len(data) < 8 || string(data[:4]) != "cook" {
+		return nil, malformed
+	}
+	pageCount := int(binary.BigEndian.Uint32(data[4:8]))
+	if pageCount > (len(data)-8)/4 {
+		return nil, malformed
+	}
+	offset := 8 + 4*pageCount
+	var cookies []*http.Cookie
+	for i := 0; i < pageCount; i++ {
+		size := int(binary.BigEndian.Uint32(data[8+4*i:]))
+		if size < 8 || offset+size > len(data) {
+			return nil, malformed
+		}
+		page := data[offset : offset+size]
+		offset += size
+		count := int(binary.LittleEndian.Uint32(page[4:8]))
+		if count > (len(page)-8)/4 {
+			return nil, malformed
+		}
+		for j := 0; j < count; j++ {
+			start := int(binary.LittleEndian.Uint32(page[8+4*j:]))
+			cookie, err := parseBinaryCookie(page, start)
+			if err != nil {
+				return nil, malformed
+			}
+			cookies = append(cookies, cookie)
+		}
+	}
+	return cookies, nil
+}
+
+// parseBinaryCookie decodes the cookie record at start in page.
+func parseBinaryCookie(page []byte, start int) (*http.Cookie, error) {
+	if start < 0 || start+48 > len(page) {
+		return nil, errors.New("cookie record out of range")
+	}
+	size := int(binary.LittleEndian.Uint32(page[start:]))
+	if size < 48 || start+size > len(page) {
+		return nil, errors.New("cookie record out of range")
+	}
+	record := page[start : start+size]
+	field := func(at int) (string, error) {
+		pos := int(binary.LittleEndian.Uint32(record[at:]))
+		if pos >= len(record) {
+			return "", errors.New("cookie field out of range")
+		}
+		end := bytes.IndexByte(record[pos:], 0)
+		if end < 0 {
+			return "", errors.New("unterminated cookie field")
+		}
+		return string(record[pos : pos+end]), nil
+	}
+	var values [4]string
+	for i := range values {
+		v, err := field(16 + 4*i)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	flags := binary.LittleEndian.Uint32(record[8:])
+	expires := math.Float64frombits(binary.LittleEndian.Uint64(record[40:]))
+	return &http.Cookie{
+		Domain:   values[0],
+		Name:     values[1],
+		Path:     values[2],
+		Value:    values[3],
+		Secure:   flags&1 != 0,
+		HttpOnly: flags&4 != 0,
+		Expires:  macEpoch.Add(time.Duration(expires * float64(time.Second))),
+	}, nil
+}
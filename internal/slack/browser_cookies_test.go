@@ -0,0 +1,167 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1" //nolint:gosec // matches Chromium's key derivation
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// encryptChromiumValue encrypts a cookie value as Chromium does on macOS,
+// with the host hash prefix of recent versions.
+func encryptChromiumValue(t *testing.T, value, host string, password []byte) []byte {
+	t.Helper()
+	key := pbkdf2.Key(password, []byte(chromiumSalt), chromiumIterations, chromiumKeySize, sha1.New)
+	hostHash := sha256.Sum256([]byte(host))
+	plaintext := append(hostHash[:], value...)
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	plaintext = append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, bytes.Repeat([]byte{' '}, aes.BlockSize)).CryptBlocks(ciphertext, plaintext)
+	return append([]byte(chromiumPrefix), ciphertext...)
+}
+
+func writeChromiumCookies(t *testing.T, password []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Cookies")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	if _, err := db.Exec(`CREATE TABLE cookies (host_key TEXT, name TEXT, value TEXT, encrypted_value BLOB, expires_utc INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC).UnixMicro() + chromiumEpochOffset
+	rows := []struct {
+		host, name string
+		value      string
+		expires    int64
+	}{
+		{".slack.com", "d", "xoxd-current%2Fsession", expires},
+		{".slack.com", "d", "xoxd-old", expires - 1},
+		{".slack.com", "b", "other", expires},
+		{".example.com", "d", "xoxd-elsewhere", expires},
+	}
+	for _, r := range rows {
+		if _, err := db.Exec(`INSERT INTO cookies VALUES (?, ?, '', ?, ?)`,
+			r.host, r.name, encryptChromiumValue(t, r.value, r.host, password), r.expires); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestChromiumCookie_DecryptsNewestSlackSession(t *testing.T) {
+	password := []byte("keychain-secret")
+	path := writeChromiumCookies(t, password)
+
+	cookie, err := chromiumCookie(path, password)
+	if err != nil {
+		t.Fatalf("chromiumCookie() error = %v", err)
+	}
+	if cookie.Name != "d" || cookie.Value != "xoxd-current%2Fsession" {
+		t.Errorf("cookie = %s=%s, want the newest Slack d cookie", cookie.Name, cookie.Value)
+	}
+	if want := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC); !cookie.Expires.Equal(want) {
+		t.Errorf("Expires = %v, want %v", cookie.Expires, want)
+	}
+	if _, err := chromiumCookie(path, []byte("wrong")); err == nil {
+		t.Error("chromiumCookie() with the wrong password should fail")
+	}
+}
+
+func TestChromiumCookie_NoSlackSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Cookies")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE cookies (host_key TEXT, name TEXT, value TEXT, encrypted_value BLOB, expires_utc INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+	if _, err := chromiumCookie(path, nil); err != errNoSlackCookie {
+		t.Errorf("chromiumCookie() error = %v, want %v", err, errNoSlackCookie)
+	}
+}
+
+// binaryCookie is one record for encodeBinaryCookies.
+type binaryCookie struct {
+	domain, name, path, value string
+	flags                     uint32
+	expires                   time.Time
+}
+
+// encodeBinaryCookies writes cookies as a one-page binarycookies file.
+func encodeBinaryCookies(cookies []binaryCookie) []byte {
+	var records [][]byte
+	for _, c := range cookies {
+		const header = 56
+		strs := []string{c.domain, c.name, c.path, c.value}
+		record := make([]byte, header)
+		pos := header
+		for i, s := range strs {
+			binary.LittleEndian.PutUint32(record[16+4*i:], uint32(pos))
+			record = append(record, s...)
+			record = append(record, 0)
+			pos += len(s) + 1
+		}
+		binary.LittleEndian.PutUint32(record[0:], uint32(len(record)))
+		binary.LittleEndian.PutUint32(record[8:], c.flags)
+		binary.LittleEndian.PutUint64(record[40:], math.Float64bits(c.expires.Sub(macEpoch).Seconds()))
+		records = append(records, record)
+	}
+	page := []byte{0, 0, 1, 0}
+	page = binary.LittleEndian.AppendUint32(page, uint32(len(records)))
+	offset := len(page) + 4*len(records) + 4
+	for _, r := range records {
+		page = binary.LittleEndian.AppendUint32(page, uint32(offset))
+		offset += len(r)
+	}
+	page = append(page, 0, 0, 0, 0)
+	for _, r := range records {
+		page = append(page, r...)
+	}
+	data := []byte("cook")
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(page)))
+	return append(data, page...)
+}
+
+func TestSafariCookie(t *testing.T) {
+	expires := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := encodeBinaryCookies([]binaryCookie{
+		{domain: ".example.com", name: "d", path: "/", value: "elsewhere", expires: expires},
+		{domain: ".slack.com", name: "d", path: "/", value: "xoxd-old", flags: 5, expires: expires.AddDate(0, -1, 0)},
+		{domain: ".slack.com", name: "d", path: "/", value: "xoxd-safari", flags: 5, expires: expires},
+	})
+
+	cookie, err := safariCookie(data)
+	if err != nil {
+		t.Fatalf("safariCookie() error = %v", err)
+	}
+	if cookie.Value != "xoxd-safari" || !cookie.Secure || !cookie.HttpOnly || !cookie.Expires.Equal(expires) {
+		t.Errorf("cookie = %+v, want the newest Slack d cookie", cookie)
+	}
+
+	if _, err := safariCookie(encodeBinaryCookies(nil)); err != errNoSlackCookie {
+		t.Errorf("safariCookie() without Slack = %v, want %v", err, errNoSlackCookie)
+	}
+	if _, err := safariCookie(data[:len(data)-20]); err == nil {
+		t.Error("safariCookie() should reject a truncated file")
+	}
+}
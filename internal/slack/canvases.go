@@ -0,0 +1,180 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxCanvasBytes caps how much of a canvas or post body is downloaded.
+const maxCanvasBytes = 5 << 20
+
+var (
+	canvasIDPattern       = regexp.MustCompile(`^F[A-Z0-9]+$`)
+	htmlHeadingPattern    = regexp.MustCompile(`(?i)<h([1-6])[^>]*>`)
+	htmlListItemPattern   = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlLineBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|h[1-6]|ul|ol|pre|blockquote|tr)>`)
+	htmlDroppedPattern    = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(?:script|style|head)>`)
+	htmlTagPattern        = regexp.MustCompile(`<[^>]+>`)
+	repeatedBlankPattern  = regexp.MustCompile(`\n{3,}`)
+	trailingSpacesPattern = regexp.MustCompile(`[ \t]+\n`)
+)
+
+// Canvas is the readable content of a Slack canvas or post file.
+type Canvas struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Filetype  string `json:"filetype"`
+	Content   string `json:"content"`
+	FetchedAt int64  `json:"fetched_at"`
+}
+
+// CanvasFile is the subset of files.info metadata needed to download a canvas.
+type CanvasFile struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	Name               string `json:"name"`
+	Filetype           string `json:"filetype"`
+	URLPrivate         string `json:"url_private"`
+	URLPrivateDownload string `json:"url_private_download"`
+}
+
+// FilesInfoResponse is the response from the Slack files.info API.
+type FilesInfoResponse struct {
+	OK    bool       `json:"ok"`
+	Error string     `json:"error,omitempty"`
+	File  CanvasFile `json:"file"`
+}
+
+// FetchCanvas looks up a canvas or post with files.info and downloads its
+// body, converting the HTML Slack serves into plain markdown-ish text.
+func (c *EdgeClient) FetchCanvas(ctx context.Context, fileID string) (*Canvas, error) {
+	file, err := c.fetchFileInfo(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	downloadURL := file.URLPrivateDownload
+	if downloadURL == "" {
+		downloadURL = file.URLPrivate
+	}
+	if downloadURL == "" {
+		return nil, fmt.Errorf("files.info: no download URL for %s", fileID)
+	}
+	body, err := c.downloadPrivateFile(ctx, downloadURL)
+	if err != nil {
+		return nil, err
+	}
+	title := file.Title
+	if title == "" {
+		title = file.Name
+	}
+	return &Canvas{
+		ID:        file.ID,
+		Title:     title,
+		Filetype:  file.Filetype,
+		Content:   CanvasHTMLToText(body),
+		FetchedAt: time.Now().Unix(),
+	}, nil
+}
+
+func (c *EdgeClient) fetchFileInfo(ctx context.Context, fileID string) (*CanvasFile, error) {
+	form := url.Values{}
+	form.Set("file", fileID)
+
+	result, err := callWebAPI[FilesInfoResponse](ctx, c, "files.info", form)
+	if err != nil {
+		return nil, err
+	}
+	return &result.File, nil
+}
+
+func (c *EdgeClient) downloadPrivateFile(ctx context.Context, fileURL string) (string, error) {
+	resp, err := c.openPrivateFile(ctx, fileURL)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCanvasBytes))
+	if err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	return string(data), nil
+}
+
+// CanvasHTMLToText flattens canvas HTML into plain text, keeping headings and
+// list items recognizable.
+func CanvasHTMLToText(body string) string {
+	text := htmlDroppedPattern.ReplaceAllString(body, "")
+	text = htmlHeadingPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		level := htmlHeadingPattern.FindStringSubmatch(tag)[1]
+		return "\n" + strings.Repeat("#", int(level[0]-'0')) + " "
+	})
+	text = htmlListItemPattern.ReplaceAllString(text, "\n- ")
+	text = htmlLineBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	text = trailingSpacesPattern.ReplaceAllString(text, "\n")
+	text = repeatedBlankPattern.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// CanvasCache stores fetched canvases as one JSON file per file ID so renders
+// can embed them without network access.
+type CanvasCache struct {
+	dir string
+}
+
+// NewCanvasCache creates a CanvasCache rooted at dir.
+func NewCanvasCache(dir string) *CanvasCache {
+	return &CanvasCache{dir: dir}
+}
+
+// Get returns the cached canvas for fileID, or false if it is not cached.
+func (c *CanvasCache) Get(fileID string) (*Canvas, bool, error) {
+	if !canvasIDPattern.MatchString(fileID) {
+		return nil, false, nil
+	}
+	data, err := os.ReadFile(filepath.Join(c.dir, fileID+".json"))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var canvas Canvas
+	if err := json.Unmarshal(data, &canvas); err != nil {
+		return nil, false, err
+	}
+	return &canvas, true, nil
+}
+
+// Put writes canvas to the cache, replacing any earlier copy.
+func (c *CanvasCache) Put(canvas *Canvas) error {
+	if !canvasIDPattern.MatchString(canvas.ID) {
+		return fmt.Errorf("invalid canvas file ID %q", canvas.ID)
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(canvas, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, canvas.ID+".json"), data, 0600)
+}
+
+// DefaultCanvasCacheDir returns the default canvas cache directory:
+// ~/.cache/slack-export/canvases
+func DefaultCanvasCacheDir() string {
+	return filepath.Join(filepath.Dir(DefaultCachePath()), "canvases")
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEdgeClient_FetchCanvas(t *testing.T) {
+	var server *httptest.Server
+	var authHeader string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/files.info":
+			_, _ = w.Write([]byte(`{"ok": true, "file": {"id": "F0CANVAS", "title": "Runbook", "filetype": "quip",
+				"url_private_download": "` + server.URL + `/download/F0CANVAS"}}`))
+		case "/download/F0CANVAS":
+			authHeader = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`<h1>Deploys</h1><p>Run <b>make</b> &amp; wait.</p><ul><li>one</li><li>two</li></ul>`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	canvas, err := client.FetchCanvas(context.Background(), "F0CANVAS")
+	if err != nil {
+		t.Fatalf("FetchCanvas() error = %v", err)
+	}
+	if canvas.ID != "F0CANVAS" || canvas.Title != "Runbook" || canvas.Filetype != "quip" {
+		t.Errorf("canvas metadata = %+v", canvas)
+	}
+	if want := "# Deploys\nRun make & wait.\n\n- one\n- two"; canvas.Content != want {
+		t.Errorf("Content = %q, want %q", canvas.Content, want)
+	}
+	if authHeader != "Bearer xoxc-test-token" {
+		t.Errorf("Authorization = %q, want bearer token", authHeader)
+	}
+}
+
+func TestEdgeClient_FetchCanvas_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": false, "error": "file_not_found"}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.FetchCanvas(context.Background(), "F0MISSING")
+	if err == nil || !strings.Contains(err.Error(), "file_not_found") {
+		t.Fatalf("FetchCanvas() error = %v, want file_not_found", err)
+	}
+}
+
+func TestCanvasCache_PutGet(t *testing.T) {
+	cache := NewCanvasCache(t.TempDir())
+
+	if _, ok, err := cache.Get("F0NONE"); ok || err != nil {
+		t.Fatalf("Get() on empty cache = %v, %v; want miss", ok, err)
+	}
+	if err := cache.Put(&Canvas{ID: "F0ABC", Title: "Plan", Content: "body"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	canvas, ok, err := cache.Get("F0ABC")
+	if err != nil || !ok {
+		t.Fatalf("Get() = %v, %v; want hit", ok, err)
+	}
+	if canvas.Title != "Plan" || canvas.Content != "body" {
+		t.Errorf("Get() = %+v", canvas)
+	}
+
+	if err := cache.Put(&Canvas{ID: "../escape"}); err == nil {
+		t.Error("Put() should reject IDs that are not Slack file IDs")
+	}
+	if _, ok, _ := cache.Get("../escape"); ok {
+		t.Error("Get() should not read paths outside the cache")
+	}
+}
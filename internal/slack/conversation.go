@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+)
+
+// Conversation is a channel, DM, or group DM from conversations.info.
+type Conversation struct {
+	UserBootChannel
+	// User is the other member of a DM.
+	User       string       `json:"user,omitempty"`
+	Topic      ChannelTopic `json:"topic"`
+	Purpose    ChannelTopic `json:"purpose"`
+	NumMembers int          `json:"num_members,omitempty"`
+}
+
+// ChannelTopic is a channel's topic or purpose.
+type ChannelTopic struct {
+	Value string `json:"value"`
+}
+
+// ConversationInfoResponse is the response from the Slack conversations.info API.
+type ConversationInfoResponse struct {
+	OK      bool         `json:"ok"`
+	Error   string       `json:"error,omitempty"`
+	Channel Conversation `json:"channel"`
+}
+
+// FetchConversationInfo fetches a single conversation's details via the
+// Slack conversations.info API.
+func (c *EdgeClient) FetchConversationInfo(ctx context.Context, channelID string) (*Conversation, error) {
+	form := url.Values{}
+	form.Set("channel", channelID)
+	form.Set("include_num_members", "true")
+
+	result, err := callWebAPI[ConversationInfoResponse](ctx, c, "conversations.info", form)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Channel, nil
+}
@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEdgeClient_FetchConversationInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/conversations.info" || r.Form.Get("channel") != "C1" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Form)
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "C1", "name": "general", "is_channel": true,
+			"created": 1700000000, "creator": "U1", "topic": {"value": "Announcements"}, "num_members": 42}}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	conv, err := client.FetchConversationInfo(context.Background(), "C1")
+	if err != nil {
+		t.Fatalf("FetchConversationInfo() error = %v", err)
+	}
+	if conv.Name != "general" || conv.Creator != "U1" || conv.Topic.Value != "Announcements" || conv.NumMembers != 42 {
+		t.Errorf("FetchConversationInfo() = %+v", conv)
+	}
+}
+
+func TestEdgeClient_FetchConversationInfo_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.FetchConversationInfo(context.Background(), "C_MISSING")
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("FetchConversationInfo() error = %v, want channel_not_found", err)
+	}
+}
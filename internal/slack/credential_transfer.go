@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportCredentials re-encrypts a workspace's cached credentials for the
+// machine whose ID is targetMachineID, in slackdump's .bin format. An empty
+// workspace selects the current one. It returns the workspace name and the
+// encrypted file.
+func ExportCredentials(workspace, targetMachineID string) (string, []byte, error) {
+	if strings.TrimSpace(targetMachineID) == "" {
+		return "", nil, errors.New("target machine ID is empty")
+	}
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return "", nil, err
+	}
+	if workspace == "" {
+		if workspace, err = getWorkspace(cacheDir); err != nil {
+			return "", nil, err
+		}
+	} else if err := checkWorkspaceName(cacheDir, workspace); err != nil {
+		return "", nil, err
+	}
+	ciphertext, err := os.ReadFile(workspaceCredentialPath(cacheDir, workspace))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	_, plaintext, err := decryptCredentials(ciphertext, workspace)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := encrypt(plaintext, deriveKey(strings.TrimSpace(targetMachineID)))
+	if err != nil {
+		return "", nil, err
+	}
+	return workspace, data, nil
+}
+
+// ImportCredentials checks that data, a credential file exported for this
+// machine, decrypts here and saves it to slackdump's cache as the
+// workspace's credentials, creating the cache when missing. The workspace
+// becomes current when none is selected. It returns the file written.
+func ImportCredentials(workspace string, data []byte) (string, error) {
+	if err := checkNewWorkspaceName(workspace); err != nil {
+		return "", err
+	}
+	creds, _, err := decryptCredentials(data, workspace)
+	if err != nil {
+		return "", err
+	}
+	if err := creds.Validate(); err != nil {
+		return "", fmt.Errorf("invalid credentials: %w", err)
+	}
+	return writeCredentialFile(workspace, data)
+}
+
+// SaveCredentials encrypts creds for this machine and saves them to
+// slackdump's cache as the workspace's credentials, as ImportCredentials
+// does, so slackdump can use them too. It returns the file written.
+func SaveCredentials(workspace string, creds *Credentials) (string, error) {
+	if err := checkNewWorkspaceName(workspace); err != nil {
+		return "", err
+	}
+	if err := creds.Validate(); err != nil {
+		return "", fmt.Errorf("invalid credentials: %w", err)
+	}
+	machineID, err := GetMachineID()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := json.Marshal(slackdumpCredentials{Token: creds.Token, Cookie: creds.Cookies})
+	if err != nil {
+		return "", err
+	}
+	data, err := encrypt(plaintext, deriveKey(machineID))
+	if err != nil {
+		return "", err
+	}
+	return writeCredentialFile(workspace, data)
+}
+
+// checkNewWorkspaceName rejects workspace names that are not plain file
+// names in the cache.
+func checkNewWorkspaceName(workspace string) error {
+	if workspace == "" || workspace != filepath.Base(workspace) || strings.HasPrefix(workspace, ".") {
+		return fmt.Errorf("invalid workspace name %q", workspace)
+	}
+	return nil
+}
+
+// writeCredentialFile writes an encrypted credential file to slackdump's
+// cache, creating the cache when missing, and selects the workspace when
+// none is current.
+func writeCredentialFile(workspace string, data []byte) (string, error) {
+	cacheDir, err := cacheDirPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return "", fmt.Errorf("creating slackdump cache: %w", err)
+	}
+	path := workspaceCredentialPath(cacheDir, workspace)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("writing credentials: %w", err)
+	}
+	if _, err := getWorkspace(cacheDir); err != nil {
+		workspaceFile := filepath.Join(cacheDir, "workspace.txt")
+		if err := os.WriteFile(workspaceFile, []byte(workspace+"\n"), 0o600); err != nil {
+			return "", fmt.Errorf("selecting workspace: %w", err)
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportImportCredentials_MovesToOverriddenMachineID(t *testing.T) {
+	setupWorkspaceCache(t, "acme", map[string]string{
+		"acme":   `{"Token":"xoxc-acme"}`,
+		"globex": `{"Token":"xoxc-globex"}`,
+	})
+
+	workspace, data, err := ExportCredentials("globex", "container-secret")
+	if err != nil {
+		t.Fatalf("ExportCredentials() error = %v", err)
+	}
+	if workspace != "globex" {
+		t.Errorf("workspace = %q, want globex", workspace)
+	}
+	if _, err := ImportCredentials("globex", data); err == nil {
+		t.Error("ImportCredentials() should reject a file encrypted for another machine")
+	}
+
+	// The container: an empty home and the chosen ID in the environment.
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(MachineIDEnv, "container-secret")
+	path, err := ImportCredentials("globex", data)
+	if err != nil {
+		t.Fatalf("ImportCredentials() error = %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("imported file %s: %v, %v; want mode 0600", path, info, err)
+	}
+	creds, err := LoadWorkspaceCredentials("")
+	if err != nil {
+		t.Fatalf("LoadWorkspaceCredentials() after import error = %v", err)
+	}
+	if creds.Token != "xoxc-globex" || creds.Workspace != "globex" {
+		t.Errorf("creds = %+v, want globex selected as current", creds)
+	}
+}
+
+func TestLoadCredentials_FallsBackToHardwareMachineID(t *testing.T) {
+	cacheDir := setupWorkspaceCache(t, "acme", map[string]string{"acme": `{"Token":"xoxc-acme"}`})
+	t.Setenv(MachineIDEnv, "override")
+
+	id, err := GetMachineID()
+	if err != nil || id != "override" {
+		t.Errorf("GetMachineID() = %q, %v; want the override", id, err)
+	}
+	creds, err := loadCredentialsFile(cacheDir, "acme")
+	if err != nil || creds.Token != "xoxc-acme" {
+		t.Errorf("loadCredentialsFile() = %+v, %v; want credentials made before the override", creds, err)
+	}
+}
+
+func TestImportCredentials_RejectsPathNames(t *testing.T) {
+	for _, name := range []string{"", "../acme", filepath.Join("a", "b"), ".hidden"} {
+		if _, err := ImportCredentials(name, nil); err == nil {
+			t.Errorf("ImportCredentials(%q) should fail", name)
+		}
+	}
+}
+
+func TestSaveCredentials_LoadsBack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(MachineIDEnv, "browser-test")
+	cookie := &http.Cookie{Name: "d", Value: "xoxd-session", Domain: ".slack.com", Path: "/"}
+
+	if _, err := SaveCredentials("acme", &Credentials{Token: "xoxd-not-a-token"}); err == nil {
+		t.Error("SaveCredentials() should reject invalid credentials")
+	}
+	if _, err := SaveCredentials("acme", &Credentials{Token: "xoxc-acme", Cookies: []*http.Cookie{cookie}}); err != nil {
+		t.Fatalf("SaveCredentials() error = %v", err)
+	}
+	creds, err := LoadWorkspaceCredentials("")
+	if err != nil {
+		t.Fatalf("LoadWorkspaceCredentials() error = %v", err)
+	}
+	if creds.Workspace != "acme" || creds.Token != "xoxc-acme" || len(creds.Cookies) != 1 || creds.Cookies[0].Value != "xoxd-session" {
+		t.Errorf("creds = %+v, want the saved token and cookie", creds)
+	}
+}
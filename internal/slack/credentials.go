@@ -6,6 +6,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
@@ -48,6 +49,11 @@ const (
 	ErrCodeDecryptFailed
 	// ErrCodeParseFailed indicates credentials could not be parsed.
 	ErrCodeParseFailed
+	// ErrCodeUnknownWorkspace indicates the requested workspace has no credentials.
+	ErrCodeUnknownWorkspace
+	// ErrCodeNoMachineID indicates the machine ID needed to decrypt
+	// credentials is unavailable, as in containers without /etc/machine-id.
+	ErrCodeNoMachineID
 )
 
 // Error returns the Go-conventional error message.
@@ -71,6 +77,8 @@ func (e *CredentialError) UserMessage() string {
 			"It looks like slackdump has not been authenticated yet.\n\n" +
 			"To authenticate, run:\n" +
 			"  slackdump auth\n\n" +
+			"Or reuse a browser where you are signed in to Slack:\n" +
+			"  slack-export auth browser <workspace>.slack.com\n\n" +
 			"More info: https://github.com/rusq/slackdump#authentication"
 
 	case ErrCodeNoWorkspace:
@@ -104,7 +112,22 @@ func (e *CredentialError) UserMessage() string {
 			"  - The credential file is corrupted\n\n" +
 			"To fix this, run:\n" +
 			"  slackdump auth\n\n" +
-			"This will create fresh credentials for this machine."
+			"This will create fresh credentials for this machine.\n\n" +
+			"To reuse credentials from another machine, run there:\n" +
+			"  slack-export auth export --machine-id <id> -o creds.bin\n" +
+			"with the ID printed here by \"slack-export auth machine-id\", then:\n" +
+			"  slack-export auth import creds.bin"
+
+	case ErrCodeNoMachineID:
+		return "Cannot determine this machine's ID.\n\n" +
+			"Slackdump credentials are encrypted with the machine ID, which is\n" +
+			"missing in most Docker and CI containers (no /etc/machine-id).\n\n" +
+			"To fix this, choose any secret ID and set it in the container:\n" +
+			"  " + MachineIDEnv + "=<secret>\n\n" +
+			"then, on a machine with working credentials, run:\n" +
+			"  slack-export auth export --machine-id <secret> -o creds.bin\n\n" +
+			"and import the file in the container:\n" +
+			"  slack-export auth import creds.bin"
 
 	case ErrCodeParseFailed:
 		return "Failed to parse credentials.\n\n" +
@@ -116,6 +139,14 @@ func (e *CredentialError) UserMessage() string {
 			"  slackdump auth\n\n" +
 			"This will create fresh credentials."
 
+	case ErrCodeUnknownWorkspace:
+		return "Unknown workspace.\n\n" +
+			"No slackdump credentials exist for the requested workspace.\n\n" +
+			"To see available workspaces, run:\n" +
+			"  slack-export workspaces\n\n" +
+			"To add this workspace, run:\n" +
+			"  slackdump workspace new <name>"
+
 	default:
 		return e.Message
 	}
@@ -176,34 +207,63 @@ var salt = []byte{
 	0x68, 0xeb, 0x4a, 0xb0,
 }
 
-// GetMachineID returns the machine's unique hardware identifier.
+// MachineIDEnv overrides the machine ID credentials are encrypted with, for
+// containers that have none.
+const MachineIDEnv = "SLACK_EXPORT_MACHINE_ID"
+
+// GetMachineID returns the machine's unique hardware identifier, or the
+// MachineIDEnv override when set.
 // This is used as the encryption key for slackdump's credential cache.
 // On macOS, this returns the IOPlatformUUID.
 func GetMachineID() (string, error) {
-	return machineid.ID()
-}
-
-// LoadCredentials reads slackdump's cached credentials from the filesystem.
-// Returns credentials needed for Slack Edge API calls.
-func LoadCredentials() (*Credentials, error) {
-	cacheDir, err := getCacheDir()
-	if err != nil {
-		return nil, err
+	if id := strings.TrimSpace(os.Getenv(MachineIDEnv)); id != "" {
+		return id, nil
 	}
-
-	workspace, err := getWorkspace(cacheDir)
+	id, err := machineid.ID()
 	if err != nil {
-		return nil, err
+		return "", &CredentialError{
+			Code:    ErrCodeNoMachineID,
+			Message: "failed to get machine ID",
+			Cause:   err,
+		}
 	}
+	return id, nil
+}
 
-	machineID, err := GetMachineID()
+// machineIDs lists the IDs to try when decrypting: the MachineIDEnv
+// override, then the hardware ID, so credentials made before the override
+// was set still load.
+func machineIDs() ([]string, error) {
+	var ids []string
+	if id := strings.TrimSpace(os.Getenv(MachineIDEnv)); id != "" {
+		ids = append(ids, id)
+	}
+	hardware, err := machineid.ID()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get machine ID: %w", err)
+		if len(ids) > 0 {
+			return ids, nil
+		}
+		return nil, &CredentialError{
+			Code:    ErrCodeNoMachineID,
+			Message: "failed to get machine ID",
+			Cause:   err,
+		}
+	}
+	if len(ids) == 0 || ids[0] != hardware {
+		ids = append(ids, hardware)
 	}
+	return ids, nil
+}
 
-	key := deriveKey(machineID)
+// LoadCredentials reads slackdump's cached credentials for the current
+// workspace (workspace.txt). Returns credentials needed for Slack Edge API calls.
+func LoadCredentials() (*Credentials, error) {
+	return LoadWorkspaceCredentials("")
+}
 
-	credFile := filepath.Clean(filepath.Join(cacheDir, workspace+".bin"))
+// loadCredentialsFile decrypts and parses one workspace's .bin file.
+func loadCredentialsFile(cacheDir, workspace string) (*Credentials, error) {
+	credFile := workspaceCredentialPath(cacheDir, workspace)
 	ciphertext, err := os.ReadFile(credFile) //nolint:gosec // path validated by getCacheDir
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -214,26 +274,43 @@ func LoadCredentials() (*Credentials, error) {
 		}
 		return nil, fmt.Errorf("failed to read credentials file: %w", err)
 	}
+	creds, _, err := decryptCredentials(ciphertext, workspace)
+	return creds, err
+}
 
-	plaintext, err := decrypt(ciphertext, key)
+// decryptCredentials decrypts and parses a credential file with each of
+// this machine's IDs in turn, returning the credentials and plaintext from
+// the first that parses.
+func decryptCredentials(ciphertext []byte, workspace string) (*Credentials, []byte, error) {
+	ids, err := machineIDs()
 	if err != nil {
-		return nil, &CredentialError{
-			Code:    ErrCodeDecryptFailed,
-			Message: "failed to decrypt credentials",
-			Cause:   err,
-		}
+		return nil, nil, err
 	}
-
-	creds, err := parseCredentials(plaintext, workspace)
-	if err != nil {
-		return nil, &CredentialError{
-			Code:    ErrCodeParseFailed,
-			Message: "failed to parse credentials",
-			Cause:   err,
+	var lastErr error
+	for _, id := range ids {
+		plaintext, err := decrypt(ciphertext, deriveKey(id))
+		if err != nil {
+			lastErr = &CredentialError{
+				Code:    ErrCodeDecryptFailed,
+				Message: "failed to decrypt credentials",
+				Cause:   err,
+			}
+			continue
+		}
+		creds, err := parseCredentials(plaintext, workspace)
+		if err != nil {
+			// AES-CFB decrypts with any key, so a wrong machine ID shows
+			// up as unparseable plaintext.
+			lastErr = &CredentialError{
+				Code:    ErrCodeParseFailed,
+				Message: "failed to parse credentials",
+				Cause:   err,
+			}
+			continue
 		}
+		return creds, plaintext, nil
 	}
-
-	return creds, nil
+	return nil, nil, lastErr
 }
 
 // slackdumpCredentials matches the JSON format saved by slackdump.
@@ -269,12 +346,10 @@ func parseCredentials(data []byte, workspace string) (*Credentials, error) {
 // getCacheDir returns the path to slackdump's cache directory.
 // On macOS, this is ~/Library/Caches/slackdump/.
 func getCacheDir() (string, error) {
-	home, err := os.UserHomeDir()
+	cacheDir, err := cacheDirPath()
 	if err != nil {
-		return "", fmt.Errorf("could not determine home directory: %w", err)
+		return "", err
 	}
-
-	cacheDir := filepath.Join(home, "Library", "Caches", "slackdump")
 	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
 		return "", &CredentialError{
 			Code:    ErrCodeCacheNotFound,
@@ -285,6 +360,16 @@ func getCacheDir() (string, error) {
 	return cacheDir, nil
 }
 
+// cacheDirPath returns where slackdump's cache directory belongs, whether
+// or not it exists yet.
+func cacheDirPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory: %w", err)
+	}
+	return filepath.Join(home, "Library", "Caches", "slackdump"), nil
+}
+
 // getWorkspace reads the current workspace name from slackdump's cache.
 // The workspace name is stored in workspace.txt in the cache directory.
 func getWorkspace(cacheDir string) (string, error) {
@@ -343,6 +428,23 @@ func (c *Credentials) Validate() error {
 	return nil
 }
 
+// encrypt encrypts data with AES-256-CFB under a random IV, which it
+// prepends, matching slackdump's credential files.
+func encrypt(plaintext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCFBEncrypter(block, iv) //nolint:staticcheck // required for slackdump compatibility
+	ciphertext := make([]byte, len(plaintext))
+	stream.XORKeyStream(ciphertext, plaintext)
+	return append(iv, ciphertext...), nil
+}
+
 // decrypt decrypts AES-256-CFB encrypted data using the provided key.
 // The first 16 bytes of ciphertext must be the initialization vector (IV).
 func decrypt(ciphertext, key []byte) ([]byte, error) {
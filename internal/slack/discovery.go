@@ -0,0 +1,268 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// Channel discovery modes (channel_discovery).
+const (
+	// ChannelDiscoveryEdge uses only the client.userBoot and client.counts
+	// Edge endpoints.
+	ChannelDiscoveryEdge = "edge"
+	// ChannelDiscoveryWebAPI uses conversations.list and one
+	// conversations.history call per conversation instead.
+	ChannelDiscoveryWebAPI = "webapi"
+	// ChannelDiscoveryAuto tries the Edge endpoints and switches to the Web
+	// API for the rest of the run once they fail for a reason other than
+	// credentials or rate limiting, e.g. a proxy blocking them.
+	ChannelDiscoveryAuto = "auto"
+)
+
+// conversationsPageSize is the conversations.list page size Slack recommends.
+const conversationsPageSize = 200
+
+// channelDiscovery is shared by copies of an EdgeClient so an auto-mode
+// fallback and the conversations.list result hold for the whole run.
+type channelDiscovery struct {
+	mode       string
+	onFallback func(error)
+	cache      *discoveryCache
+
+	mu       sync.Mutex
+	fellBack bool
+
+	listMu        sync.Mutex
+	listed        bool
+	conversations []listedConversation
+}
+
+// WithChannelDiscovery returns a new EdgeClient whose ClientUserBoot and
+// ClientCounts use mode; an empty mode is ChannelDiscoveryAuto. onFallback,
+// if set, is called once with the Edge error when auto mode falls back.
+// Clients without a discovery mode use the Edge endpoints only.
+func (c *EdgeClient) WithChannelDiscovery(mode string, onFallback func(error)) *EdgeClient {
+	if mode == "" {
+		mode = ChannelDiscoveryAuto
+	}
+	client := c.WithHTTPClient(c.httpClient)
+	client.discovery = &channelDiscovery{mode: mode, onFallback: onFallback}
+	return client
+}
+
+// WithDiscoveryCache returns a new EdgeClient whose Web API discovery keeps
+// the newest message of each DM and group DM in dir, one file per
+// workspace, so later runs only call conversations.history for those whose
+// updated time moved. onWarn, if set, reports a cache that could not be
+// saved. It has no effect without WithChannelDiscovery.
+func (c *EdgeClient) WithDiscoveryCache(dir string, onWarn func(error)) *EdgeClient {
+	client := c.WithHTTPClient(c.httpClient)
+	if d := c.discovery; d != nil {
+		client.discovery = &channelDiscovery{
+			mode:       d.mode,
+			onFallback: d.onFallback,
+			cache:      &discoveryCache{dir: dir, onWarn: onWarn},
+		}
+	}
+	return client
+}
+
+// useWebAPI reports whether discovery should skip the Edge endpoints.
+func (c *EdgeClient) useWebAPI() bool {
+	d := c.discovery
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.mode == ChannelDiscoveryWebAPI || d.fellBack
+}
+
+// fallBack reports whether an Edge failure should be retried through the
+// Web API, recording the switch the first time.
+func (c *EdgeClient) fallBack(ctx context.Context, edgeErr error) bool {
+	d := c.discovery
+	if d == nil || d.mode != ChannelDiscoveryAuto || !edgeBlocked(ctx, edgeErr) {
+		return false
+	}
+	d.mu.Lock()
+	first := !d.fellBack
+	d.fellBack = true
+	d.mu.Unlock()
+	if first && d.onFallback != nil {
+		d.onFallback(edgeErr)
+	}
+	return true
+}
+
+// edgeBlocked reports whether an Edge failure looks like the endpoints are
+// unreachable rather than a problem the Web API would share.
+func edgeBlocked(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return !IsAuthFailure(err) && !IsRateLimited(err)
+}
+
+// listedConversation is a conversations.list entry: the userBoot channel
+// fields plus the other user of a DM.
+type listedConversation struct {
+	UserBootChannel
+	User string `json:"user,omitempty"`
+}
+
+type conversationsListResponse struct {
+	OK               bool                 `json:"ok"`
+	Error            string               `json:"error,omitempty"`
+	Channels         []listedConversation `json:"channels"`
+	ResponseMetadata struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+// listConversations pages conversations.list for every conversation type,
+// archived ones included as userBoot does.
+func (c *EdgeClient) listConversations(ctx context.Context) ([]listedConversation, error) {
+	var conversations []listedConversation
+	cursor := ""
+	for {
+		form := url.Values{}
+		form.Set("types", "public_channel,private_channel,mpim,im")
+		form.Set("limit", strconv.Itoa(conversationsPageSize))
+		if cursor != "" {
+			form.Set("cursor", cursor)
+		}
+		result, err := callWebAPI[conversationsListResponse](ctx, c, "conversations.list", form)
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, result.Channels...)
+		cursor = result.ResponseMetadata.NextCursor
+		if cursor == "" {
+			return conversations, nil
+		}
+	}
+}
+
+// discoveredConversations returns the run's conversations.list result,
+// paging it only on first use so userBoot and counts share one listing.
+func (c *EdgeClient) discoveredConversations(ctx context.Context) ([]listedConversation, error) {
+	d := c.discovery
+	if d == nil {
+		return c.listConversations(ctx)
+	}
+	d.listMu.Lock()
+	defer d.listMu.Unlock()
+	if !d.listed {
+		conversations, err := c.listConversations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d.conversations, d.listed = conversations, true
+	}
+	return d.conversations, nil
+}
+
+// webAPIUserBoot builds a userBoot response from conversations.list. Starred
+// conversations are not available from the Web API.
+func (c *EdgeClient) webAPIUserBoot(ctx context.Context) (*UserBootResponse, error) {
+	conversations, err := c.discoveredConversations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	boot := &UserBootResponse{OK: true}
+	for _, conv := range conversations {
+		if conv.IsIM {
+			boot.IMs = append(boot.IMs, IM{ID: conv.ID, User: conv.User, IsIM: true})
+			continue
+		}
+		boot.Channels = append(boot.Channels, conv.UserBootChannel)
+	}
+	return boot, nil
+}
+
+// webAPICounts builds a counts response from the newest message of each
+// conversation the user can read, one conversations.history call apiece.
+// DMs and group DMs whose updated time has not moved since the last run
+// reuse the cached newest message instead. Read positions, mentions, and
+// thread activity are not available.
+func (c *EdgeClient) webAPICounts(ctx context.Context) (*CountsResponse, error) {
+	conversations, err := c.discoveredConversations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cache *discoveryCache
+	if c.discovery != nil {
+		cache = c.discovery.cache
+	}
+	teamID := c.creds.TeamID
+	previous := cache.load(teamID)
+	directs := make(map[string]probedConversation)
+	counts := &CountsResponse{OK: true}
+	for _, conv := range conversations {
+		if !conv.IsIM && !conv.IsMpim && !conv.IsMember {
+			continue
+		}
+		direct := conv.IsIM || conv.IsMpim
+		var latest string
+		if direct {
+			latest, err = c.directLatestTS(ctx, conv, previous)
+		} else {
+			latest, err = c.latestMessageTS(ctx, conv.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if direct {
+			directs[conv.ID] = probedConversation{Updated: conv.Updated, Latest: latest}
+		}
+		snapshot := ChannelSnapshot{ID: conv.ID, Latest: latest}
+		switch {
+		case conv.IsIM:
+			counts.IMs = append(counts.IMs, snapshot)
+		case conv.IsMpim:
+			counts.MPIMs = append(counts.MPIMs, snapshot)
+		default:
+			counts.Channels = append(counts.Channels, snapshot)
+		}
+	}
+	cache.save(teamID, directs)
+	return counts, nil
+}
+
+// directLatestTS returns a DM or group DM's newest message timestamp: from
+// conversations.list when it carries one, from the last run when the
+// conversation's updated time has not moved, and otherwise from
+// conversations.history.
+func (c *EdgeClient) directLatestTS(ctx context.Context, conv listedConversation, previous map[string]probedConversation) (string, error) {
+	if conv.Latest != "" {
+		return conv.Latest, nil
+	}
+	if last, ok := previous[conv.ID]; ok && conv.Updated != 0 && last.Updated == conv.Updated {
+		return last.Latest, nil
+	}
+	return c.latestMessageTS(ctx, conv.ID)
+}
+
+// latestMessageTS returns the timestamp of a conversation's newest message,
+// or "" when it has none or can no longer be read.
+func (c *EdgeClient) latestMessageTS(ctx context.Context, channelID string) (string, error) {
+	form := url.Values{}
+	form.Set("channel", channelID)
+	form.Set("limit", "1")
+	result, err := c.fetchHistory(ctx, form)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == "channel_not_found" || apiErr.Code == "not_in_channel") {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(result.Messages) == 0 {
+		return "", nil
+	}
+	return result.Messages[0].TS, nil
+}
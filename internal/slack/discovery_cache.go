@@ -0,0 +1,85 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// discoveryCacheVersion is bumped when the cache file format changes; files
+// from other versions are ignored.
+const discoveryCacheVersion = 1
+
+// discoveryCache keeps, per workspace, what Web API discovery learned about
+// each DM and group DM so the next run can skip conversations.history for
+// those that have not moved. A nil *discoveryCache keeps nothing.
+type discoveryCache struct {
+	dir    string
+	onWarn func(error)
+}
+
+// probedConversation is a DM's updated time from conversations.list and the
+// newest message found for it.
+type probedConversation struct {
+	Updated int64  `json:"updated"`
+	Latest  string `json:"latest"`
+}
+
+// discoveryCacheData is the cache file for one workspace.
+type discoveryCacheData struct {
+	Version       int                           `json:"version"`
+	TeamID        string                        `json:"team_id"`
+	Conversations map[string]probedConversation `json:"conversations"`
+}
+
+func (c *discoveryCache) path(teamID string) string {
+	return filepath.Join(c.dir, "conversations-latest-"+teamID+".json")
+}
+
+// load returns the conversations saved for a workspace. A missing,
+// unreadable, or foreign file returns nil.
+func (c *discoveryCache) load(teamID string) map[string]probedConversation {
+	if c == nil || teamID == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.path(teamID))
+	if err != nil {
+		return nil
+	}
+	var cached discoveryCacheData
+	if json.Unmarshal(data, &cached) != nil || cached.Version != discoveryCacheVersion || cached.TeamID != teamID {
+		return nil
+	}
+	return cached.Conversations
+}
+
+// save replaces a workspace's saved conversations, reporting failures to
+// onWarn.
+func (c *discoveryCache) save(teamID string, conversations map[string]probedConversation) {
+	if c == nil || teamID == "" {
+		return
+	}
+	if err := c.write(teamID, conversations); err != nil && c.onWarn != nil {
+		c.onWarn(fmt.Errorf("saving conversation discovery cache: %w", err))
+	}
+}
+
+func (c *discoveryCache) write(teamID string, conversations map[string]probedConversation) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(discoveryCacheData{
+		Version:       discoveryCacheVersion,
+		TeamID:        teamID,
+		Conversations: conversations,
+	})
+	if err != nil {
+		return err
+	}
+	tmp := c.path(teamID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path(teamID))
+}
@@ -0,0 +1,184 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// discoveryServer serves blocked Edge endpoints (HTTP 403) and Web API
+// conversations.list and conversations.history responses.
+func discoveryServer(t *testing.T, edgeStatus int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/client.userBoot"), strings.HasSuffix(r.URL.Path, "/client.counts"):
+			if edgeStatus != http.StatusOK {
+				w.WriteHeader(edgeStatus)
+				_, _ = w.Write([]byte("blocked by proxy"))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+		case strings.HasSuffix(r.URL.Path, "/conversations.list"):
+			_, _ = w.Write([]byte(`{"ok": true, "channels": [
+				{"id": "C001", "name": "general", "is_channel": true, "is_member": true},
+				{"id": "C002", "name": "lobby", "is_channel": true, "is_member": false},
+				{"id": "D001", "is_im": true, "user": "U123"}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/conversations.history"):
+			ts := map[string]string{"C001": "1737676900.000000", "D001": "1737676000.000000"}[r.Form.Get("channel")]
+			if ts == "" {
+				_, _ = w.Write([]byte(`{"ok": false, "error": "not_in_channel"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok": true, "messages": [{"ts": "` + ts + `"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func discoveryClient(server *httptest.Server) *EdgeClient {
+	return NewEdgeClient(&Credentials{Token: "xoxc-test-token", TeamID: "T12345"}).
+		WithWorkspaceURL(server.URL + "/").
+		WithSlackAPIURL(server.URL)
+}
+
+func TestGetActiveChannels_WebAPIDiscovery(t *testing.T) {
+	client := discoveryClient(discoveryServer(t, http.StatusForbidden)).
+		WithChannelDiscovery(ChannelDiscoveryWebAPI, nil)
+
+	channels, err := client.GetActiveChannels(context.Background(), time.Unix(1737676500, 0))
+	if err != nil {
+		t.Fatalf("GetActiveChannels() error = %v", err)
+	}
+	if len(channels) != 1 || channels[0].ID != "C001" || channels[0].Name != "general" {
+		t.Fatalf("channels = %+v, want only general", channels)
+	}
+	if !channels[0].LastMessage.Equal(time.Unix(1737676900, 0)) {
+		t.Errorf("LastMessage = %v, want 1737676900", channels[0].LastMessage)
+	}
+
+	all, err := client.GetActiveChannels(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatalf("GetActiveChannels(all) error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("GetActiveChannels(all) = %d channels, want 3", len(all))
+	}
+}
+
+func TestGetActiveChannels_AutoDiscoveryFallsBack(t *testing.T) {
+	var fallbacks int
+	client := discoveryClient(discoveryServer(t, http.StatusForbidden)).
+		WithChannelDiscovery(ChannelDiscoveryAuto, func(error) { fallbacks++ })
+
+	channels, err := client.GetActiveChannels(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatalf("GetActiveChannels() error = %v", err)
+	}
+	if len(channels) != 3 {
+		t.Errorf("got %d channels, want 3", len(channels))
+	}
+	if _, err := client.ClientCounts(context.Background()); err != nil {
+		t.Fatalf("ClientCounts() error = %v", err)
+	}
+	if fallbacks != 1 {
+		t.Errorf("onFallback called %d times, want 1", fallbacks)
+	}
+}
+
+func TestGetActiveChannels_DiscoveryKeepsEdgeErrors(t *testing.T) {
+	// Edge-only clients report a blocked endpoint.
+	client := discoveryClient(discoveryServer(t, http.StatusForbidden)).
+		WithChannelDiscovery(ChannelDiscoveryEdge, nil)
+	if _, err := client.GetActiveChannels(context.Background(), time.Time{}); err == nil {
+		t.Error("edge discovery should fail when the Edge API is blocked")
+	}
+
+	// Rejected credentials are not a reason to fall back.
+	client = discoveryClient(discoveryServer(t, http.StatusOK)).
+		WithChannelDiscovery(ChannelDiscoveryAuto, func(err error) { t.Errorf("unexpected fallback: %v", err) })
+	if _, err := client.GetActiveChannels(context.Background(), time.Time{}); !IsAuthFailure(err) {
+		t.Errorf("GetActiveChannels() error = %v, want auth failure", err)
+	}
+}
+
+// directsServer serves a conversations.list of one channel, one DM, and one
+// group DM whose updated times come from updated, counting calls by method
+// and conversations.history calls by channel.
+func directsServer(t *testing.T, updated map[string]int64) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/conversations.list"):
+			calls["conversations.list"]++
+			_, _ = fmt.Fprintf(w, `{"ok": true, "channels": [
+				{"id": "C001", "name": "general", "is_channel": true, "is_member": true},
+				{"id": "D001", "is_im": true, "user": "U123", "updated": %d},
+				{"id": "G001", "name": "mpdm-a--b", "is_mpim": true, "updated": %d}
+			]}`, updated["D001"], updated["G001"])
+		case strings.HasSuffix(r.URL.Path, "/conversations.history"):
+			calls[r.Form.Get("channel")]++
+			_, _ = w.Write([]byte(`{"ok": true, "messages": [{"ts": "1737676900.000000"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+func TestGetActiveChannels_WebAPIListsConversationsOnce(t *testing.T) {
+	server, calls := directsServer(t, map[string]int64{"D001": 1, "G001": 1})
+	client := discoveryClient(server).WithChannelDiscovery(ChannelDiscoveryWebAPI, nil)
+
+	for range 2 {
+		if _, err := client.GetActiveChannels(context.Background(), time.Time{}); err != nil {
+			t.Fatalf("GetActiveChannels() error = %v", err)
+		}
+	}
+	if calls["conversations.list"] != 1 {
+		t.Errorf("conversations.list called %d times, want 1", calls["conversations.list"])
+	}
+}
+
+func TestClientCounts_WebAPIProbesOnlyMovedDirects(t *testing.T) {
+	dir := t.TempDir()
+	run := func(updated map[string]int64) (map[string]int, *CountsResponse) {
+		t.Helper()
+		server, calls := directsServer(t, updated)
+		client := discoveryClient(server).
+			WithChannelDiscovery(ChannelDiscoveryWebAPI, nil).
+			WithDiscoveryCache(dir, func(err error) { t.Errorf("unexpected warning: %v", err) })
+		counts, err := client.ClientCounts(context.Background())
+		if err != nil {
+			t.Fatalf("ClientCounts() error = %v", err)
+		}
+		return calls, counts
+	}
+
+	first, _ := run(map[string]int64{"D001": 100, "G001": 100})
+	if first["C001"] != 1 || first["D001"] != 1 || first["G001"] != 1 {
+		t.Fatalf("first run history calls = %v, want one per conversation", first)
+	}
+
+	second, counts := run(map[string]int64{"D001": 100, "G001": 200})
+	if second["C001"] != 1 || second["D001"] != 0 || second["G001"] != 1 {
+		t.Errorf("second run history calls = %v, want C001 and the moved G001 only", second)
+	}
+	if len(counts.IMs) != 1 || counts.IMs[0].Latest != "1737676900.000000" {
+		t.Errorf("IMs = %+v, want D001's cached latest", counts.IMs)
+	}
+}
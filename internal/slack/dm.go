@@ -0,0 +1,93 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ConversationsOpenResponse is the response from the Slack conversations.open API.
+type ConversationsOpenResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+}
+
+// FindByName looks up a user by username, display name, or real name.
+// Matching is case-insensitive and ignores a leading "@". Usernames win over
+// display names, which win over real names. Deleted users match too, so
+// departed colleagues can still be looked up, but an active user wins a tie
+// on the same field.
+func (idx UserIndex) FindByName(name string) (*User, bool) {
+	want := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+	if want == "" {
+		return nil, false
+	}
+	fields := []func(*User) string{
+		func(u *User) string { return u.Name },
+		func(u *User) string { return u.Profile.DisplayName },
+		func(u *User) string { return u.RealName },
+	}
+	ids := make([]string, 0, len(idx))
+	for id := range idx {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, field := range fields {
+		var deleted *User
+		for _, id := range ids {
+			user := idx[id]
+			if strings.ToLower(field(user)) != want {
+				continue
+			}
+			if !user.Deleted {
+				return user, true
+			}
+			if deleted == nil {
+				deleted = user
+			}
+		}
+		if deleted != nil {
+			return deleted, true
+		}
+	}
+	return nil, false
+}
+
+// FindDMChannel returns the DM channel ID for a user. Existing DMs come from
+// client.userBoot; only when the user has none does it fall back to
+// conversations.open, which creates the DM as a side effect. Callers that
+// can find the DM elsewhere, such as in the archive, should look there first.
+func (c *EdgeClient) FindDMChannel(ctx context.Context, userID string) (string, error) {
+	boot, err := c.ClientUserBoot(ctx)
+	if err != nil {
+		return "", fmt.Errorf("userBoot: %w", err)
+	}
+	for _, im := range boot.IMs {
+		if im.User == userID {
+			return im.ID, nil
+		}
+	}
+	return c.OpenDM(ctx, userID)
+}
+
+// OpenDM calls the Slack conversations.open API for a single user and returns
+// the DM channel ID.
+func (c *EdgeClient) OpenDM(ctx context.Context, userID string) (string, error) {
+	form := url.Values{}
+	form.Set("users", userID)
+	form.Set("return_im", "true")
+
+	result, err := callWebAPI[ConversationsOpenResponse](ctx, c, "conversations.open", form)
+	if err != nil {
+		return "", err
+	}
+	if result.Channel.ID == "" {
+		return "", fmt.Errorf("conversations.open: no channel returned for %s", userID)
+	}
+	return result.Channel.ID, nil
+}
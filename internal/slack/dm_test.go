@@ -0,0 +1,127 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUserIndex_FindByName(t *testing.T) {
+	idx := NewUserIndex([]User{
+		{ID: "U1", Name: "alice", RealName: "Alice Smith", Profile: UserProfile{DisplayName: "Ally"}},
+		{ID: "U2", Name: "bob", RealName: "ally"},
+		{ID: "U3", Name: "carol", Deleted: true},
+		{ID: "U4", Name: "dave", Deleted: true},
+		{ID: "U5", Name: "dave2", RealName: "Dave", Profile: UserProfile{DisplayName: "dave"}},
+		{ID: "U6", Name: "erin", Deleted: true, Profile: UserProfile{DisplayName: "Ed"}},
+		{ID: "U7", Name: "ed-old", Deleted: true},
+		{ID: "U8", Name: "frank", Profile: UserProfile{DisplayName: "ed-old"}},
+		{ID: "UA1", Name: "gina", Deleted: true, Profile: UserProfile{DisplayName: "Gee"}},
+		{ID: "UB1", Name: "gina2", Profile: UserProfile{DisplayName: "gee"}},
+	})
+
+	tests := []struct {
+		name   string
+		input  string
+		wantID string
+	}{
+		{"username", "alice", "U1"},
+		{"at prefix and case", "@BOB", "U2"},
+		{"display name beats real name", "ally", "U1"},
+		{"real name", "alice smith", "U1"},
+		{"deleted user", "carol", "U3"},
+		{"deleted username beats active display name", "dave", "U4"},
+		{"deleted display name", "ed", "U6"},
+		{"deleted username on tie with active display name", "ed-old", "U7"},
+		{"active user wins tie with deleted user", "gee", "UB1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, ok := idx.FindByName(tt.input)
+			if !ok {
+				t.Fatalf("FindByName(%q) found nothing", tt.input)
+			}
+			if user.ID != tt.wantID {
+				t.Errorf("FindByName(%q) = %s, want %s", tt.input, user.ID, tt.wantID)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "nobody"} {
+		if user, ok := idx.FindByName(input); ok {
+			t.Errorf("FindByName(%q) = %s, want no match", input, user.ID)
+		}
+	}
+}
+
+func TestEdgeClient_FindDMChannel_UsesUserBootIMs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/client.userBoot" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": true, "ims": [{"id": "D111", "user": "U1"}, {"id": "D222", "user": "U2"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithWorkspaceURL(server.URL + "/")
+
+	id, err := client.FindDMChannel(context.Background(), "U2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "D222" {
+		t.Errorf("FindDMChannel() = %q, want D222", id)
+	}
+}
+
+func TestEdgeClient_FindDMChannel_FallsBackToConversationsOpen(t *testing.T) {
+	var openBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/api/client.userBoot":
+			_, _ = w.Write([]byte(`{"ok": true, "ims": []}`))
+		case "/conversations.open":
+			bodyBytes, _ := io.ReadAll(r.Body)
+			openBody = string(bodyBytes)
+			_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "D999"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).
+		WithWorkspaceURL(server.URL + "/").
+		WithSlackAPIURL(server.URL)
+
+	id, err := client.FindDMChannel(context.Background(), "U9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "D999" {
+		t.Errorf("FindDMChannel() = %q, want D999", id)
+	}
+	if !strings.Contains(openBody, "users=U9") {
+		t.Errorf("expected users=U9 in conversations.open body, got: %s", openBody)
+	}
+}
+
+func TestEdgeClient_OpenDM_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": false, "error": "user_not_found"}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.OpenDM(context.Background(), "U_MISSING")
+	if err == nil || !strings.Contains(err.Error(), "user_not_found") {
+		t.Fatalf("OpenDM() error = %v, want user_not_found", err)
+	}
+}
@@ -2,14 +2,15 @@ package slack
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/chrisedwards/slack-export/pkg/slackts"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -21,21 +22,35 @@ const (
 
 	// DefaultHTTPTimeout is the default timeout for HTTP requests.
 	DefaultHTTPTimeout = 30 * time.Second
+
+	// dmResolveWorkers bounds concurrent DM name lookups, each of which may
+	// call users.info and team.info.
+	dmResolveWorkers = 8
 )
 
 // EdgeClient provides access to Slack's Edge API for fast channel detection.
 type EdgeClient struct {
+	// creds is the caller's Credentials, which AuthTest completes with the
+	// team and user IDs. session holds the token and cookies requests send;
+	// a reauthTransport swaps in a new Credentials rather than modifying it,
+	// so concurrent requests can read it without a lock.
 	creds        *Credentials
+	session      *atomic.Pointer[Credentials]
 	httpClient   *http.Client
 	baseURL      string
 	slackAPIURL  string
 	workspaceURL string // Set by AuthTest, e.g., "https://myteam.slack.com/"
+	discovery    *channelDiscovery
+	userList     *userListCaching
 }
 
 // NewEdgeClient creates a new Edge API client with the given credentials.
 func NewEdgeClient(creds *Credentials) *EdgeClient {
+	session := new(atomic.Pointer[Credentials])
+	session.Store(creds)
 	return &EdgeClient{
 		creds:       creds,
+		session:     session,
 		httpClient:  &http.Client{Timeout: DefaultHTTPTimeout},
 		baseURL:     DefaultEdgeBaseURL,
 		slackAPIURL: DefaultSlackAPIURL,
@@ -47,10 +62,13 @@ func NewEdgeClient(creds *Credentials) *EdgeClient {
 func (c *EdgeClient) WithBaseURL(baseURL string) *EdgeClient {
 	return &EdgeClient{
 		creds:        c.creds,
+		session:      c.session,
 		httpClient:   c.httpClient,
 		baseURL:      baseURL,
 		slackAPIURL:  c.slackAPIURL,
 		workspaceURL: c.workspaceURL,
+		discovery:    c.discovery,
+		userList:     c.userList,
 	}
 }
 
@@ -59,10 +77,13 @@ func (c *EdgeClient) WithBaseURL(baseURL string) *EdgeClient {
 func (c *EdgeClient) WithSlackAPIURL(slackAPIURL string) *EdgeClient {
 	return &EdgeClient{
 		creds:        c.creds,
+		session:      c.session,
 		httpClient:   c.httpClient,
 		baseURL:      c.baseURL,
 		slackAPIURL:  slackAPIURL,
 		workspaceURL: c.workspaceURL,
+		discovery:    c.discovery,
+		userList:     c.userList,
 	}
 }
 
@@ -71,10 +92,13 @@ func (c *EdgeClient) WithSlackAPIURL(slackAPIURL string) *EdgeClient {
 func (c *EdgeClient) WithWorkspaceURL(workspaceURL string) *EdgeClient {
 	return &EdgeClient{
 		creds:        c.creds,
+		session:      c.session,
 		httpClient:   c.httpClient,
 		baseURL:      c.baseURL,
 		slackAPIURL:  c.slackAPIURL,
 		workspaceURL: workspaceURL,
+		discovery:    c.discovery,
+		userList:     c.userList,
 	}
 }
 
@@ -83,10 +107,13 @@ func (c *EdgeClient) WithWorkspaceURL(workspaceURL string) *EdgeClient {
 func (c *EdgeClient) WithHTTPClient(client *http.Client) *EdgeClient {
 	return &EdgeClient{
 		creds:        c.creds,
+		session:      c.session,
 		httpClient:   client,
 		baseURL:      c.baseURL,
 		slackAPIURL:  c.slackAPIURL,
 		workspaceURL: c.workspaceURL,
+		discovery:    c.discovery,
+		userList:     c.userList,
 	}
 }
 
@@ -98,43 +125,11 @@ func (c *EdgeClient) post(ctx context.Context, endpoint string, body map[string]
 	if c.workspaceURL == "" {
 		return nil, fmt.Errorf("workspaceURL not set - call AuthTest first")
 	}
-	requestURL := fmt.Sprintf("%sapi/%s", c.workspaceURL, endpoint)
-
-	// Build form data with token
 	form := url.Values{}
-	form.Set("token", c.creds.Token)
-
 	for key, val := range body {
 		form.Set(key, formatValue(val))
 	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	formEncoded := form.Encode()
-	req.Body = io.NopCloser(strings.NewReader(formEncoded))
-	req.ContentLength = int64(len(formEncoded))
-
-	// Set cookies from credentials
-	for _, cookie := range c.creds.Cookies {
-		req.AddCookie(cookie)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("edge API error %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return io.ReadAll(resp.Body)
+	return c.send(ctx, c.workspaceURL+"api/"+endpoint, endpoint, form)
 }
 
 // formatValue converts a value to string for form encoding.
@@ -160,120 +155,75 @@ func formatValue(v any) string {
 
 // AuthTest calls the Slack auth.test API to verify credentials and get workspace info.
 // This must be called before using Edge API methods to obtain the TeamID.
-// On success, it sets creds.TeamID to the workspace's team ID.
+// On success, it sets creds.TeamID to the workspace's team ID, and a client on
+// the default API URLs switches to the workspace's deployment when it is not
+// hosted on slack.com.
 func (c *EdgeClient) AuthTest(ctx context.Context) (*AuthTestResponse, error) {
-	requestURL := fmt.Sprintf("%s/auth.test", c.slackAPIURL)
-
-	form := url.Values{}
-	form.Set("token", c.creds.Token)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
+	authResp, err := callWebAPI[AuthTestResponse](ctx, c, "auth.test", nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	formEncoded := form.Encode()
-	req.Body = io.NopCloser(strings.NewReader(formEncoded))
-	req.ContentLength = int64(len(formEncoded))
-
-	for _, cookie := range c.creds.Cookies {
-		req.AddCookie(cookie)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("auth.test API error %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var authResp AuthTestResponse
-	if err := json.Unmarshal(bodyBytes, &authResp); err != nil {
-		return nil, fmt.Errorf("parsing auth.test response: %w", err)
-	}
-
-	if !authResp.OK {
-		return nil, fmt.Errorf("auth.test failed: %s", authResp.Error)
+		return nil, err
 	}
 
 	c.creds.TeamID = authResp.TeamID
+	c.creds.UserID = authResp.UserID
 	c.workspaceURL = authResp.URL
-	return &authResp, nil
+	c.adoptWorkspaceHost(authResp.URL)
+	return authResp, nil
 }
 
 // ClientUserBoot calls the client.userBoot Edge API endpoint.
 // Returns all channels, DMs, and groups the user has access to with metadata.
+// With a webapi or auto channel discovery mode the response may instead be
+// built from conversations.list; see WithChannelDiscovery.
 func (c *EdgeClient) ClientUserBoot(ctx context.Context) (*UserBootResponse, error) {
-	data, err := c.post(ctx, "client.userBoot", map[string]any{
-		"include_permissions": true,
-		"only_self_subteams":  true,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	var resp UserBootResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("parsing userBoot response: %w", err)
+	if c.useWebAPI() {
+		return c.webAPIUserBoot(ctx)
 	}
-
-	if !resp.OK {
-		return nil, fmt.Errorf("userBoot API error: %s", resp.Error)
+	resp, err := c.edgeUserBoot(ctx)
+	if err != nil && c.fallBack(ctx, err) {
+		return c.webAPIUserBoot(ctx)
 	}
+	return resp, err
+}
 
-	return &resp, nil
+func (c *EdgeClient) edgeUserBoot(ctx context.Context) (*UserBootResponse, error) {
+	return callWebClientAPI[UserBootResponse](ctx, c, "client.userBoot", map[string]any{
+		"include_permissions": true,
+		"only_self_subteams":  true,
+	})
 }
 
 // ParseSlackTS parses a Slack timestamp string into a time.Time.
 // Slack timestamps are in the format "1737676800.123456" where the integer part
 // is Unix seconds and the decimal part is microseconds.
-// Returns zero time for empty string.
+// Returns zero time for empty string. Compare timestamps with
+// slackts.CompareTS instead of through time.Time to keep their order.
 func ParseSlackTS(ts string) (time.Time, error) {
-	if ts == "" {
-		return time.Time{}, nil
-	}
+	return slackts.ParseSlackTS(ts)
+}
 
-	parts := strings.Split(ts, ".")
-	secs, err := strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("parsing seconds: %w", err)
+// FetchUsers retrieves all users in the workspace using the Slack users.list API.
+// This uses the standard Slack API (not Edge API) with Tier 2 rate limiting.
+// Returns a UserIndex for O(1) lookups by user ID. With WithUserListCache a
+// recently fetched list is reused instead.
+func (c *EdgeClient) FetchUsers(ctx context.Context) (UserIndex, error) {
+	if c.userList != nil {
+		return c.userList.fetch(ctx, c)
 	}
+	return c.fetchAllUsers(ctx)
+}
 
-	var nsecs int64
-	if len(parts) > 1 && parts[1] != "" {
-		// Pad to 6 digits for microseconds
-		micro := parts[1]
-		for len(micro) < 6 {
-			micro += "0"
-		}
-		// Truncate if longer than 6 digits
-		if len(micro) > 6 {
-			micro = micro[:6]
-		}
-		microVal, err := strconv.ParseInt(micro, 10, 64)
-		if err != nil {
-			return time.Time{}, fmt.Errorf("parsing microseconds: %w", err)
-		}
-		// Convert microseconds to nanoseconds
-		nsecs = microVal * 1000
+// fetchAllUsers fetches the user index from Slack, bypassing any cache.
+func (c *EdgeClient) fetchAllUsers(ctx context.Context) (UserIndex, error) {
+	users, err := c.fetchUserList(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	return time.Unix(secs, nsecs), nil
+	return NewUserIndex(users), nil
 }
 
-// FetchUsers retrieves all users in the workspace using the Slack users.list API.
-// This uses the standard Slack API (not Edge API) with Tier 2 rate limiting.
-// Returns a UserIndex for O(1) lookups by user ID.
-func (c *EdgeClient) FetchUsers(ctx context.Context) (UserIndex, error) {
+// fetchUserList pages through users.list.
+func (c *EdgeClient) fetchUserList(ctx context.Context) ([]User, error) {
 	var allUsers []User
 	cursor := ""
 
@@ -285,130 +235,64 @@ func (c *EdgeClient) FetchUsers(ctx context.Context) (UserIndex, error) {
 		allUsers = append(allUsers, users...)
 
 		if nextCursor == "" {
-			break
+			return allUsers, nil
 		}
 		cursor = nextCursor
 	}
-
-	return NewUserIndex(allUsers), nil
 }
 
 // fetchUsersPage fetches a single page of users from the users.list API.
+// Deactivated members are included, marked Deleted; people who have left
+// the list entirely are looked up with FetchUserInfo instead.
 func (c *EdgeClient) fetchUsersPage(ctx context.Context, cursor string) ([]User, string, error) {
-	requestURL := fmt.Sprintf("%s/users.list", c.slackAPIURL)
-
 	form := url.Values{}
-	form.Set("token", c.creds.Token)
 	form.Set("limit", "200")
 	form.Set("include_locale", "false")
 	if cursor != "" {
 		form.Set("cursor", cursor)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	formEncoded := form.Encode()
-	req.Body = io.NopCloser(strings.NewReader(formEncoded))
-	req.ContentLength = int64(len(formEncoded))
-
-	for _, cookie := range c.creds.Cookies {
-		req.AddCookie(cookie)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	usersResp, err := callWebAPI[UsersListResponse](ctx, c, "users.list", form)
 	if err != nil {
-		return nil, "", fmt.Errorf("sending request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, "", fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("users.list API error %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, "", err
 	}
-
-	var usersResp UsersListResponse
-	if err := json.Unmarshal(bodyBytes, &usersResp); err != nil {
-		return nil, "", fmt.Errorf("parsing users.list response: %w", err)
-	}
-
-	if !usersResp.OK {
-		return nil, "", fmt.Errorf("users.list failed: %s", usersResp.Error)
-	}
-
 	return usersResp.Members, usersResp.ResponseMetadata.NextCursor, nil
 }
 
 // FetchUserInfo fetches a single user's info via the Slack users.info API.
 // This is used for external Slack Connect users not in the workspace user list.
 func (c *EdgeClient) FetchUserInfo(ctx context.Context, userID string) (*User, error) {
-	requestURL := fmt.Sprintf("%s/users.info", c.slackAPIURL)
-
 	form := url.Values{}
-	form.Set("token", c.creds.Token)
 	form.Set("user", userID)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, strings.NewReader(form.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	for _, cookie := range c.creds.Cookies {
-		req.AddCookie(cookie)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	result, err := callWebAPI[UserInfoResponse](ctx, c, "users.info", form)
 	if err != nil {
-		return nil, fmt.Errorf("users.info request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("users.info: HTTP %d", resp.StatusCode)
-	}
-
-	var result UserInfoResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding users.info response: %w", err)
-	}
-
-	if !result.OK {
-		return nil, fmt.Errorf("users.info: %s", result.Error)
+		return nil, err
 	}
-
 	return &result.User, nil
 }
 
 // ClientCounts calls the client.counts Edge API endpoint.
 // Returns activity timestamps showing when each channel last had a message.
+// With a webapi or auto channel discovery mode the response may instead be
+// built from conversations.history; see WithChannelDiscovery.
 func (c *EdgeClient) ClientCounts(ctx context.Context) (*CountsResponse, error) {
-	data, err := c.post(ctx, "client.counts", map[string]any{
+	if c.useWebAPI() {
+		return c.webAPICounts(ctx)
+	}
+	resp, err := c.edgeCounts(ctx)
+	if err != nil && c.fallBack(ctx, err) {
+		return c.webAPICounts(ctx)
+	}
+	return resp, err
+}
+
+func (c *EdgeClient) edgeCounts(ctx context.Context) (*CountsResponse, error) {
+	return callWebClientAPI[CountsResponse](ctx, c, "client.counts", map[string]any{
 		"thread_counts_by_channel": true,
 		"org_wide_aware":           true,
 		"include_file_channels":    true,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	var resp CountsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("parsing counts response: %w", err)
-	}
-
-	if !resp.OK {
-		return nil, fmt.Errorf("counts API error: %s", resp.Error)
-	}
-
-	return &resp, nil
 }
 
 // GetActiveChannels returns channels with activity since the given time.
@@ -439,6 +323,11 @@ func (c *EdgeClient) GetActiveChannelsWithUsers(
 	}
 
 	latestByID := buildTimestampLookup(counts)
+	snapshots := countsByID(counts)
+	starred := make(map[string]bool, len(boot.Starred))
+	for _, id := range boot.Starred {
+		starred[id] = true
+	}
 	includeAll := since.IsZero()
 
 	var active []Channel
@@ -449,16 +338,20 @@ func (c *EdgeClient) GetActiveChannelsWithUsers(
 			continue
 		}
 		active = append(active, Channel{
-			ID:          ch.ID,
-			Name:        ch.Name,
-			IsChannel:   ch.IsChannel,
-			IsGroup:     ch.IsGroup,
-			IsPrivate:   ch.IsPrivate,
-			IsArchived:  ch.IsArchived,
-			IsMember:    ch.IsMember,
-			IsMPIM:      ch.IsMpim,
-			Created:     time.Unix(ch.Created, 0),
-			LastMessage: latest,
+			ID:           ch.ID,
+			Name:         ch.Name,
+			IsChannel:    ch.IsChannel,
+			IsGroup:      ch.IsGroup,
+			IsPrivate:    ch.IsPrivate,
+			IsArchived:   ch.IsArchived,
+			IsMember:     ch.IsMember,
+			IsMPIM:       ch.IsMpim,
+			Created:      time.Unix(ch.Created, 0),
+			LastRead:     snapshots[ch.ID].lastRead(),
+			LastMessage:  latest,
+			MentionCount: snapshots[ch.ID].MentionCount,
+			HasUnreads:   snapshots[ch.ID].HasUnreads,
+			IsStarred:    starred[ch.ID],
 		})
 	}
 
@@ -468,10 +361,14 @@ func (c *EdgeClient) GetActiveChannelsWithUsers(
 			continue
 		}
 		active = append(active, Channel{
-			ID:          im.ID,
-			Name:        resolveDMName(im.User, userIndex),
-			IsIM:        true,
-			LastMessage: latest,
+			ID:           im.ID,
+			Name:         resolveDMName(im.User, userIndex),
+			IsIM:         true,
+			LastRead:     snapshots[im.ID].lastRead(),
+			LastMessage:  latest,
+			MentionCount: snapshots[im.ID].MentionCount,
+			HasUnreads:   snapshots[im.ID].HasUnreads,
+			IsStarred:    starred[im.ID],
 		})
 	}
 
@@ -506,6 +403,11 @@ func (c *EdgeClient) GetActiveChannelsWithResolver(
 	}
 
 	latestByID := buildTimestampLookup(counts)
+	snapshots := countsByID(counts)
+	starred := make(map[string]bool, len(boot.Starred))
+	for _, id := range boot.Starred {
+		starred[id] = true
+	}
 	includeAll := since.IsZero()
 
 	var active []Channel
@@ -517,55 +419,120 @@ func (c *EdgeClient) GetActiveChannelsWithResolver(
 			continue
 		}
 		active = append(active, Channel{
-			ID:          ch.ID,
-			Name:        ch.Name,
-			IsChannel:   ch.IsChannel,
-			IsGroup:     ch.IsGroup,
-			IsPrivate:   ch.IsPrivate,
-			IsArchived:  ch.IsArchived,
-			IsMember:    ch.IsMember,
-			IsMPIM:      ch.IsMpim,
-			Created:     time.Unix(ch.Created, 0),
-			LastMessage: latest,
+			ID:           ch.ID,
+			Name:         ch.Name,
+			IsChannel:    ch.IsChannel,
+			IsGroup:      ch.IsGroup,
+			IsPrivate:    ch.IsPrivate,
+			IsArchived:   ch.IsArchived,
+			IsMember:     ch.IsMember,
+			IsMPIM:       ch.IsMpim,
+			Created:      time.Unix(ch.Created, 0),
+			LastRead:     snapshots[ch.ID].lastRead(),
+			LastMessage:  latest,
+			MentionCount: snapshots[ch.ID].MentionCount,
+			HasUnreads:   snapshots[ch.ID].HasUnreads,
+			IsStarred:    starred[ch.ID],
 		})
 	}
 
 	// Process DMs with resolver
+	var ims []IM
 	for _, im := range boot.IMs {
 		latest := latestByID[im.ID]
-		if !includeAll && (latest.IsZero() || latest.Before(since)) {
-			continue
-		}
-
-		name, err := resolveDMNameWithResolver(ctx, im.User, resolver)
-		if err != nil {
-			return nil, fmt.Errorf("resolving DM user %s: %w", im.User, err)
+		if includeAll || (!latest.IsZero() && !latest.Before(since)) {
+			ims = append(ims, im)
 		}
-
+	}
+	names, err := resolveDMNames(ctx, ims, resolver)
+	if err != nil {
+		return nil, err
+	}
+	for i, im := range ims {
+		latest := latestByID[im.ID]
 		active = append(active, Channel{
-			ID:          im.ID,
-			Name:        name,
-			IsIM:        true,
-			LastMessage: latest,
+			ID:           im.ID,
+			Name:         names[i],
+			IsIM:         true,
+			LastRead:     snapshots[im.ID].lastRead(),
+			LastMessage:  latest,
+			MentionCount: snapshots[im.ID].MentionCount,
+			HasUnreads:   snapshots[im.ID].HasUnreads,
+			IsStarred:    starred[im.ID],
 		})
 	}
 
 	return active, nil
 }
 
+// resolveDMNames names the DMs' users with a bounded pool of workers. A user
+// whose lookup fails is named by ID (dm_<ID>) and reported to the resolver's
+// warnings, so one bad user doesn't fail the listing; rejected credentials
+// and cancellation still do.
+func resolveDMNames(ctx context.Context, ims []IM, resolver *UserResolver) ([]string, error) {
+	names := make([]string, len(ims))
+	failures := make([]error, len(ims))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(dmResolveWorkers)
+	for i, im := range ims {
+		g.Go(func() error {
+			name, err := resolveDMNameWithResolver(gctx, im.User, resolver)
+			if err != nil {
+				if IsAuthFailure(err) || gctx.Err() != nil {
+					return fmt.Errorf("resolving DM user %s: %w", im.User, err)
+				}
+				failures[i] = fmt.Errorf("resolving DM user %s, naming the DM by ID: %w", im.User, err)
+				name = fmt.Sprintf("dm_%s", im.User)
+			}
+			names[i] = name
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for _, err := range failures {
+		if err != nil {
+			resolver.warn(err)
+		}
+	}
+	return names, nil
+}
+
 // resolveDMNameWithResolver generates a DM channel name using the UserResolver.
 func resolveDMNameWithResolver(ctx context.Context, userID string, resolver *UserResolver) (string, error) {
 	if resolver == nil {
 		return fmt.Sprintf("dm_%s", userID), nil
 	}
-	username, err := resolver.Username(ctx, userID)
+	username, err := resolver.DMName(ctx, userID)
 	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("dm_%s", username), nil
 }
 
+// countsByID indexes the counts snapshots of every conversation type by ID.
+func countsByID(counts *CountsResponse) map[string]ChannelSnapshot {
+	snapshots := make(map[string]ChannelSnapshot, len(counts.Channels)+len(counts.MPIMs)+len(counts.IMs))
+	for _, group := range [][]ChannelSnapshot{counts.Channels, counts.MPIMs, counts.IMs} {
+		for _, snapshot := range group {
+			snapshots[snapshot.ID] = snapshot
+		}
+	}
+	return snapshots
+}
+
 // buildTimestampLookup creates a map from channel ID to latest message time.
+// lastRead parses the snapshot's last_read mark, returning the zero time
+// when it is missing, malformed, or "0000000000.000000" (never read).
+func (s ChannelSnapshot) lastRead() time.Time {
+	t, err := ParseSlackTS(s.LastRead)
+	if err != nil || t.Unix() == 0 {
+		return time.Time{}
+	}
+	return t
+}
+
 func buildTimestampLookup(counts *CountsResponse) map[string]time.Time {
 	lookup := make(map[string]time.Time)
 
@@ -2,6 +2,7 @@ package slack
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -252,9 +253,8 @@ func TestEdgeClient_Post_ErrorStatus(t *testing.T) {
 		t.Errorf("expected error to contain response body: %v", err)
 	}
 
-	// Verify error message follows Go conventions (lowercase)
-	if !strings.Contains(err.Error(), "edge API error") {
-		t.Errorf("expected lowercase error message 'edge API error': %v", err)
+	if !strings.HasPrefix(err.Error(), "client.userBoot: HTTP 401") {
+		t.Errorf("expected error to name the method and status: %v", err)
 	}
 }
 
@@ -273,8 +273,8 @@ func TestEdgeClient_Post_NetworkError(t *testing.T) {
 		t.Fatal("expected network error")
 	}
 
-	if !strings.Contains(err.Error(), "sending request") {
-		t.Errorf("expected 'sending request' error prefix: %v", err)
+	if !strings.Contains(err.Error(), "client.userBoot request") {
+		t.Errorf("expected 'client.userBoot request' error prefix: %v", err)
 	}
 }
 
@@ -516,7 +516,7 @@ func TestEdgeClient_ClientUserBoot_ParseError(t *testing.T) {
 		t.Fatal("expected error for invalid JSON")
 	}
 
-	if !strings.Contains(err.Error(), "parsing userBoot response") {
+	if !strings.Contains(err.Error(), "decoding client.userBoot response") {
 		t.Errorf("expected parsing error message: %v", err)
 	}
 }
@@ -627,7 +627,7 @@ func TestEdgeClient_ClientCounts_ParseError(t *testing.T) {
 		t.Fatal("expected error for invalid JSON")
 	}
 
-	if !strings.Contains(err.Error(), "parsing counts response") {
+	if !strings.Contains(err.Error(), "decoding client.counts response") {
 		t.Errorf("expected parsing error message: %v", err)
 	}
 }
@@ -1043,6 +1043,54 @@ func TestEdgeClient_GetActiveChannels_ZeroSince(t *testing.T) {
 	}
 }
 
+func TestEdgeClient_GetActiveChannels_Attention(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/client.userBoot") {
+			_, _ = w.Write([]byte(`{
+				"ok": true,
+				"ims": [{"id": "D123", "user": "U456", "is_im": true}],
+				"channels": [
+					{"id": "C001", "name": "channel-1", "is_channel": true},
+					{"id": "C002", "name": "channel-2", "is_channel": true}
+				],
+				"starred": ["C002"]
+			}`))
+		} else if strings.HasSuffix(r.URL.Path, "/client.counts") {
+			_, _ = w.Write([]byte(`{
+				"ok": true,
+				"channels": [{"id": "C001", "last_read": "1737676000.000000", "latest": "1737676900.123456", "has_unreads": true}],
+				"ims": [{"id": "D123", "last_read": "0000000000.000000", "latest": "1737676800.000000", "mention_count": 2, "has_unreads": true}]
+			}`))
+		}
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token", TeamID: "T12345"}).WithWorkspaceURL(server.URL + "/")
+
+	channels, err := client.GetActiveChannels(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[string]Channel, len(channels))
+	for _, ch := range channels {
+		got[ch.ID] = ch
+	}
+	if ch := got["C001"]; !ch.HasUnreads || ch.MentionCount != 0 || ch.IsStarred {
+		t.Errorf("C001 = %+v, want unread only", ch)
+	}
+	if ch := got["C002"]; ch.HasUnreads || !ch.IsStarred {
+		t.Errorf("C002 = %+v, want starred only", ch)
+	}
+	if ch := got["D123"]; ch.MentionCount != 2 || !ch.HasUnreads {
+		t.Errorf("D123 = %+v, want 2 mentions and unread", ch)
+	}
+	if !got["C001"].LastRead.Equal(time.Unix(1737676000, 0)) {
+		t.Errorf("C001 LastRead = %v, want 1737676000", got["C001"].LastRead)
+	}
+	if !got["D123"].LastRead.IsZero() {
+		t.Errorf("D123 LastRead = %v, want zero for a never-read DM", got["D123"].LastRead)
+	}
+}
+
 func TestEdgeClient_GetActiveChannels_UserBootError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasSuffix(r.URL.Path, "/client.userBoot") {
@@ -1323,7 +1371,7 @@ func TestEdgeClient_AuthTest_APIError(t *testing.T) {
 		t.Fatal("expected error for HTTP 401")
 	}
 
-	if !strings.Contains(err.Error(), "auth.test API error 401") {
+	if !strings.Contains(err.Error(), "auth.test: HTTP 401") {
 		t.Errorf("expected auth.test API error, got: %v", err)
 	}
 }
@@ -1350,7 +1398,7 @@ func TestEdgeClient_AuthTest_SlackError(t *testing.T) {
 		t.Fatal("expected error for Slack API error")
 	}
 
-	if !strings.Contains(err.Error(), "auth.test failed: invalid_auth") {
+	if !strings.Contains(err.Error(), "auth.test: invalid_auth") {
 		t.Errorf("expected auth.test failed error, got: %v", err)
 	}
 }
@@ -1941,3 +1989,62 @@ func TestEdgeClient_GetActiveChannelsWithResolver_NilResolver(t *testing.T) {
 		t.Errorf("expected dm_U456, got %s", channels[0].Name)
 	}
 }
+
+// dmListingServer serves a userBoot with one active DM per user ID.
+func dmListingServer(t *testing.T, userIDs ...string) *httptest.Server {
+	t.Helper()
+	var ims, counts []string
+	for i, id := range userIDs {
+		dm := fmt.Sprintf("D%03d", i)
+		ims = append(ims, fmt.Sprintf(`{"id": %q, "user": %q, "is_im": true}`, dm, id))
+		counts = append(counts, fmt.Sprintf(`{"id": %q, "latest": "1700000000.000000"}`, dm))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/client.userBoot"):
+			_, _ = fmt.Fprintf(w, `{"ok": true, "self": {}, "team": {}, "channels": [], "ims": [%s]}`, strings.Join(ims, ","))
+		case strings.HasSuffix(r.URL.Path, "/client.counts"):
+			_, _ = fmt.Fprintf(w, `{"ok": true, "ims": [%s]}`, strings.Join(counts, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEdgeClient_GetActiveChannelsWithResolver_FallsBackPerUser(t *testing.T) {
+	server := dmListingServer(t, "U_LOCAL", "U_GONE", "U_GUEST")
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test"}).WithWorkspaceURL(server.URL + "/")
+	fetcher := &mockFetcher{users: map[string]*User{"U_GUEST": {ID: "U_GUEST", Name: "guest"}}}
+	var warnings []string
+	resolver := NewUserResolver(NewUserIndex([]User{{ID: "U_LOCAL", Name: "local"}}), NewUserCache(""), fetcher).
+		WithWarnings(func(err error) { warnings = append(warnings, err.Error()) })
+
+	channels, err := client.GetActiveChannelsWithResolver(context.Background(), time.Time{}, resolver)
+	if err != nil {
+		t.Fatalf("GetActiveChannelsWithResolver() error = %v", err)
+	}
+	var names []string
+	for _, ch := range channels {
+		names = append(names, ch.Name)
+	}
+	if got, want := strings.Join(names, ","), "dm_local,dm_U_GONE,dm_guest"; got != want {
+		t.Errorf("DM names = %s, want %s", got, want)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "U_GONE") {
+		t.Errorf("warnings = %q, want one about U_GONE", warnings)
+	}
+}
+
+func TestEdgeClient_GetActiveChannelsWithResolver_AuthFailureAborts(t *testing.T) {
+	server := dmListingServer(t, "U_ONE", "U_TWO")
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test"}).WithWorkspaceURL(server.URL + "/")
+	fetcher := &mockFetcher{err: newAPIError("users.info", "invalid_auth", "users.info: invalid_auth")}
+	resolver := NewUserResolver(NewUserIndex(nil), nil, fetcher)
+
+	_, err := client.GetActiveChannelsWithResolver(context.Background(), time.Time{}, resolver)
+	if !IsAuthFailure(err) {
+		t.Errorf("GetActiveChannelsWithResolver() error = %v, want the auth failure", err)
+	}
+}
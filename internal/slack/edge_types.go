@@ -3,6 +3,8 @@ package slack
 import (
 	"context"
 	"strings"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // UserBootResponse is the response from the client.userBoot Edge API endpoint.
@@ -14,6 +16,8 @@ type UserBootResponse struct {
 	Team     Team              `json:"team"`
 	IMs      []IM              `json:"ims"`
 	Channels []UserBootChannel `json:"channels"`
+	// Starred lists the IDs of conversations the user starred.
+	Starred []string `json:"starred,omitempty"`
 }
 
 // UserBootChannel represents a channel from the userBoot response.
@@ -56,6 +60,9 @@ type Team struct {
 	ID     string `json:"id"`
 	Name   string `json:"name"`
 	Domain string `json:"domain"`
+	// Plan is the workspace's billing plan, e.g. "free", "std", "plus", or
+	// "enterprise", when Slack reports it.
+	Plan string `json:"plan,omitempty"`
 }
 
 // CountsResponse is the response from the client.counts Edge API endpoint.
@@ -97,7 +104,9 @@ type User struct {
 	ID       string      `json:"id"`
 	Name     string      `json:"name"`
 	RealName string      `json:"real_name"`
+	TeamID   string      `json:"team_id,omitempty"`
 	Deleted  bool        `json:"deleted"`
+	IsBot    bool        `json:"is_bot,omitempty"`
 	Profile  UserProfile `json:"profile"`
 }
 
@@ -105,6 +114,8 @@ type User struct {
 type UserProfile struct {
 	DisplayName string `json:"display_name"`
 	RealName    string `json:"real_name"`
+	StatusText  string `json:"status_text,omitempty"`
+	StatusEmoji string `json:"status_emoji,omitempty"`
 }
 
 // UsersListResponse is the response from the Slack users.list API.
@@ -184,10 +195,21 @@ type UserFetcher interface {
 
 // UserResolver provides unified user lookup across multiple sources.
 // Lookup order: UserIndex (workspace) → UserCache (disk) → UserFetcher (API).
+// A resolver is safe for concurrent use; concurrent lookups of the same user
+// share one API call.
 type UserResolver struct {
 	index   UserIndex
 	cache   *UserCache
 	fetcher UserFetcher
+	// fetches shares in-flight users.info calls by user ID.
+	fetches *singleflight.Group
+
+	homeTeam string
+	teams    TeamFetcher
+	labels   *teamLabels
+
+	style  NameStyle
+	onWarn func(error)
 }
 
 // NewUserResolver creates a resolver with the given sources.
@@ -197,10 +219,52 @@ func NewUserResolver(index UserIndex, cache *UserCache, fetcher UserFetcher) *Us
 		index:   index,
 		cache:   cache,
 		fetcher: fetcher,
+		fetches: &singleflight.Group{},
+	}
+}
+
+// WithWarnings makes onWarn receive lookups that failed and fell back to the
+// user ID, such as DM names during channel listing.
+func (r *UserResolver) WithWarnings(onWarn func(error)) *UserResolver {
+	resolver := *r
+	resolver.onWarn = onWarn
+	return &resolver
+}
+
+func (r *UserResolver) warn(err error) {
+	if r.onWarn != nil {
+		r.onWarn(err)
 	}
 }
 
-// Username returns the username for a user ID, checking sources in order.
+// fetchUser calls users.info once for concurrent lookups of id and caches
+// the result.
+func (r *UserResolver) fetchUser(ctx context.Context, id string) (*User, error) {
+	user, err, _ := r.fetches.Do(id, func() (any, error) {
+		user, err := r.fetcher.FetchUserInfo(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if r.cache != nil {
+			r.cache.Set(user)
+		}
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user.(*User), nil
+}
+
+// WithNameStyle makes Username return names in the given style.
+func (r *UserResolver) WithNameStyle(style NameStyle) *UserResolver {
+	resolver := *r
+	resolver.style = style
+	return &resolver
+}
+
+// Username returns the name used in file names for a user ID (the lowercase
+// username unless WithNameStyle says otherwise), checking sources in order.
 // Returns the raw ID if user cannot be found and fetcher is nil.
 func (r *UserResolver) Username(ctx context.Context, id string) (string, error) {
 	if id == "" {
@@ -209,29 +273,30 @@ func (r *UserResolver) Username(ctx context.Context, id string) (string, error)
 
 	// 1. Check workspace index
 	if r.index != nil {
-		if user, ok := r.index[id]; ok && user.Name != "" {
-			return strings.ToLower(user.Name), nil
+		if user, ok := r.index[id]; ok {
+			if name := r.style.FileName(user.NameFields()); name != "" {
+				return name, nil
+			}
 		}
 	}
 
 	// 2. Check disk cache
 	if r.cache != nil {
-		if user := r.cache.Get(id); user != nil && user.Name != "" {
-			return strings.ToLower(user.Name), nil
+		if user := r.cache.Get(id); user != nil {
+			if name := r.style.FileName(user.NameFields()); name != "" {
+				return name, nil
+			}
 		}
 	}
 
 	// 3. Fetch from API
 	if r.fetcher != nil {
-		user, err := r.fetcher.FetchUserInfo(ctx, id)
+		user, err := r.fetchUser(ctx, id)
 		if err != nil {
 			return "", err
 		}
-		if r.cache != nil {
-			r.cache.Set(user)
-		}
-		if user.Name != "" {
-			return strings.ToLower(user.Name), nil
+		if name := r.style.FileName(user.NameFields()); name != "" {
+			return name, nil
 		}
 	}
 
@@ -0,0 +1,25 @@
+package slack
+
+import "context"
+
+// EmojiListResponse is the response from emoji.list. Values are image URLs,
+// or "alias:<name>" for aliases of another emoji.
+type EmojiListResponse struct {
+	OK    bool              `json:"ok"`
+	Error string            `json:"error,omitempty"`
+	Emoji map[string]string `json:"emoji"`
+}
+
+// ListCustomEmoji returns the names of the workspace's custom emoji,
+// including aliases.
+func (c *EdgeClient) ListCustomEmoji(ctx context.Context) (map[string]bool, error) {
+	result, err := callWebAPI[EmojiListResponse](ctx, c, "emoji.list", nil)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(result.Emoji))
+	for name := range result.Emoji {
+		names[name] = true
+	}
+	return names, nil
+}
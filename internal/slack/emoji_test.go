@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestEdgeClient_ListCustomEmoji(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emoji.list" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "emoji": {
+			"partyparrot": "https://emoji.slack-edge.com/T1/partyparrot/abc.gif",
+			"parrot": "alias:partyparrot"}}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	names, err := client.ListCustomEmoji(context.Background())
+	if err != nil {
+		t.Fatalf("ListCustomEmoji() error = %v", err)
+	}
+	if want := map[string]bool{"partyparrot": true, "parrot": true}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListCustomEmoji() = %v, want %v", names, want)
+	}
+}
+
+func TestEdgeClient_ListCustomEmoji_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "missing_scope"}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	if _, err := client.ListCustomEmoji(context.Background()); err == nil {
+		t.Error("ListCustomEmoji() should fail on an API error")
+	}
+}
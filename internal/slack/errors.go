@@ -0,0 +1,65 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// authErrorCodes are Slack error codes meaning the token or session no
+// longer works and the user must re-authenticate.
+var authErrorCodes = map[string]bool{
+	"invalid_auth":     true,
+	"not_authed":       true,
+	"token_expired":    true,
+	"token_revoked":    true,
+	"account_inactive": true,
+}
+
+// APIError is a failed Slack API call: either a non-200 HTTP status or a
+// 200 response with "ok": false. Web API calls word it "method: HTTP 429"
+// or "method: error_code".
+type APIError struct {
+	// Method is the API method, e.g. "auth.test".
+	Method string
+	// Status is the HTTP status, or 0 when Slack answered ok:false.
+	Status int
+	// Code is Slack's error code, e.g. "invalid_auth".
+	Code string
+
+	msg string
+}
+
+func (e *APIError) Error() string {
+	return e.msg
+}
+
+// RateLimited reports whether Slack rejected the call for rate limiting.
+func (e *APIError) RateLimited() bool {
+	return e.Status == http.StatusTooManyRequests || e.Code == "ratelimited" || e.Code == "rate_limited"
+}
+
+// AuthFailed reports whether the credentials were rejected.
+func (e *APIError) AuthFailed() bool {
+	return e.Status == http.StatusUnauthorized || authErrorCodes[e.Code]
+}
+
+func newAPIError(method, code, format string, args ...any) *APIError {
+	return &APIError{Method: method, Code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+func newHTTPError(method string, status int, format string, args ...any) *APIError {
+	return &APIError{Method: method, Status: status, msg: fmt.Sprintf(format, args...)}
+}
+
+// IsRateLimited reports whether err is a Slack API rate-limit failure.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.RateLimited()
+}
+
+// IsAuthFailure reports whether err means the Slack credentials were rejected.
+func IsAuthFailure(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.AuthFailed()
+}
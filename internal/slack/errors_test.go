@@ -0,0 +1,50 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_Classification(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		rateLimited bool
+		authFailure bool
+	}{
+		{"http 429", newHTTPError("users.list", http.StatusTooManyRequests, "HTTP 429"), true, false},
+		{"ratelimited code", newAPIError("files.list", "ratelimited", "files.list: ratelimited"), true, false},
+		{"http 401", newHTTPError("auth.test", http.StatusUnauthorized, "HTTP 401"), false, true},
+		{"token revoked", fmt.Errorf("wrapped: %w", newAPIError("auth.test", "token_revoked", "x")), false, true},
+		{"other", newAPIError("files.info", "file_not_found", "x"), false, false},
+		{"not an API error", fmt.Errorf("ratelimited"), false, false},
+	}
+	for _, tt := range tests {
+		if got := IsRateLimited(tt.err); got != tt.rateLimited {
+			t.Errorf("%s: IsRateLimited() = %v, want %v", tt.name, got, tt.rateLimited)
+		}
+		if got := IsAuthFailure(tt.err); got != tt.authFailure {
+			t.Errorf("%s: IsAuthFailure() = %v, want %v", tt.name, got, tt.authFailure)
+		}
+	}
+}
+
+func TestEdgeClient_APIErrorsAreTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": false, "error": "not_authed"}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test"}).WithSlackAPIURL(server.URL)
+	_, err := client.FetchUsergroups(context.Background())
+	if !IsAuthFailure(err) {
+		t.Errorf("FetchUsergroups() error = %v, want auth failure", err)
+	}
+	if err.Error() != "usergroups.list: not_authed" {
+		t.Errorf("error message changed: %q", err.Error())
+	}
+}
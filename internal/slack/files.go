@@ -0,0 +1,143 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filesListPageSize is how many files are requested per files.list page.
+const filesListPageSize = 200
+
+// File is the subset of Slack file metadata shown by the files command.
+type File struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Title              string `json:"title"`
+	Filetype           string `json:"filetype"`
+	Mimetype           string `json:"mimetype"`
+	Subtype            string `json:"subtype"`
+	PrettyType         string `json:"pretty_type"`
+	Size               int64  `json:"size"`
+	User               string `json:"user"`
+	Created            int64  `json:"created"`
+	Permalink          string `json:"permalink"`
+	URLPrivateDownload string `json:"url_private_download"`
+	URLPrivate         string `json:"url_private"`
+}
+
+// DownloadURL returns the URL to fetch the file's content from.
+func (f File) DownloadURL() string {
+	if f.URLPrivateDownload != "" {
+		return f.URLPrivateDownload
+	}
+	return f.URLPrivate
+}
+
+// File kinds returned by Kind.
+const (
+	FileKindAudio = "audio"
+	FileKindImage = "image"
+)
+
+// Kind classifies the file as FileKindAudio (audio files and Slack clips),
+// FileKindImage, or "" for anything else.
+func (f File) Kind() string {
+	switch {
+	case strings.HasPrefix(f.Mimetype, "audio/"), f.Subtype == "slack_audio", f.Subtype == "slack_video":
+		return FileKindAudio
+	case strings.HasPrefix(f.Mimetype, "image/"):
+		return FileKindImage
+	}
+	return ""
+}
+
+// FilesListResponse is the response from the Slack files.list API.
+type FilesListResponse struct {
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+	Files  []File `json:"files"`
+	Paging struct {
+		Page  int `json:"page"`
+		Pages int `json:"pages"`
+	} `json:"paging"`
+}
+
+// ListFiles returns the files shared in a channel, newest first. A zero since
+// lists every file.
+func (c *EdgeClient) ListFiles(ctx context.Context, channelID string, since time.Time) ([]File, error) {
+	var files []File
+	for page := 1; ; page++ {
+		resp, err := c.fetchFilesPage(ctx, channelID, since, page)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, resp.Files...)
+		if resp.Paging.Page >= resp.Paging.Pages || len(resp.Files) == 0 {
+			return files, nil
+		}
+	}
+}
+
+func (c *EdgeClient) fetchFilesPage(
+	ctx context.Context,
+	channelID string,
+	since time.Time,
+	page int,
+) (*FilesListResponse, error) {
+	form := url.Values{}
+	form.Set("channel", channelID)
+	form.Set("count", strconv.Itoa(filesListPageSize))
+	form.Set("page", strconv.Itoa(page))
+	if !since.IsZero() {
+		form.Set("ts_from", strconv.FormatInt(since.Unix(), 10))
+	}
+	return callWebAPI[FilesListResponse](ctx, c, "files.list", form)
+}
+
+// DownloadFile streams a file's content to w and returns the bytes written.
+func (c *EdgeClient) DownloadFile(ctx context.Context, file File, w io.Writer) (int64, error) {
+	if file.DownloadURL() == "" {
+		return 0, fmt.Errorf("no download URL for %s", file.ID)
+	}
+	resp, err := c.openPrivateFile(ctx, file.DownloadURL())
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("reading file: %w", err)
+	}
+	return n, nil
+}
+
+// openPrivateFile requests a url_private* URL, which needs the token as a
+// Bearer header rather than a form field.
+func (c *EdgeClient) openPrivateFile(ctx context.Context, fileURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	creds := c.session.Load()
+	req.Header.Set("Authorization", "Bearer "+creds.Token)
+	for _, cookie := range creds.Cookies {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading file: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, newHTTPError("files.download", resp.StatusCode, "downloading file: HTTP %d", resp.StatusCode)
+	}
+	return resp, nil
+}
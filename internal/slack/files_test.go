@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEdgeClient_ListFiles_Paginates(t *testing.T) {
+	var forms []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files.list" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = r.ParseForm()
+		forms = append(forms, r.Form.Encode())
+		w.WriteHeader(http.StatusOK)
+		if r.Form.Get("page") == "1" {
+			_, _ = w.Write([]byte(`{"ok": true, "files": [{"id": "F1", "name": "a.png", "size": 10}],
+				"paging": {"page": 1, "pages": 2}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "files": [{"id": "F2", "name": "b.pdf"}], "paging": {"page": 2, "pages": 2}}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	files, err := client.ListFiles(context.Background(), "C123", since)
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	if len(files) != 2 || files[0].ID != "F1" || files[1].ID != "F2" {
+		t.Errorf("ListFiles() = %+v", files)
+	}
+	if len(forms) != 2 || !strings.Contains(forms[0], "channel=C123") ||
+		!strings.Contains(forms[0], "ts_from=1767225600") {
+		t.Errorf("requests = %v", forms)
+	}
+}
+
+func TestEdgeClient_ListFiles_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": false, "error": "missing_scope"}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.ListFiles(context.Background(), "C123", time.Time{})
+	if err == nil || !strings.Contains(err.Error(), "missing_scope") {
+		t.Fatalf("ListFiles() error = %v, want missing_scope", err)
+	}
+}
+
+func TestEdgeClient_DownloadFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxc-test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("file body"))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"})
+	var out bytes.Buffer
+	n, err := client.DownloadFile(context.Background(), File{ID: "F1", URLPrivate: server.URL + "/F1"}, &out)
+	if err != nil {
+		t.Fatalf("DownloadFile() error = %v", err)
+	}
+	if n != 9 || out.String() != "file body" {
+		t.Errorf("DownloadFile() = %d, %q", n, out.String())
+	}
+
+	if _, err := client.DownloadFile(context.Background(), File{ID: "F2"}, &out); err == nil {
+		t.Error("DownloadFile() without URL should fail")
+	}
+}
+
+func TestFile_Kind(t *testing.T) {
+	tests := []struct {
+		file File
+		want string
+	}{
+		{File{Mimetype: "audio/mpeg"}, FileKindAudio},
+		{File{Mimetype: "video/mp4", Subtype: "slack_video"}, FileKindAudio},
+		{File{Mimetype: "audio/webm", Subtype: "slack_audio"}, FileKindAudio},
+		{File{Mimetype: "image/png"}, FileKindImage},
+		{File{Mimetype: "application/pdf"}, ""},
+		{File{Mimetype: "video/mp4"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.file.Kind(); got != tt.want {
+			t.Errorf("Kind(%+v) = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// HistorySample summarizes the newest page of a channel's history.
+type HistorySample struct {
+	// Messages is the number of top-level messages in the page.
+	Messages int
+	// Threads counts messages with replies and Replies sums their replies.
+	Threads int
+	Replies int
+	// HasMore reports that older messages exist beyond the page.
+	HasMore bool
+	// Oldest is the timestamp of the oldest message in the page.
+	Oldest time.Time
+}
+
+// historyResponse is the subset of conversations.history used for sampling
+// and counting.
+type historyResponse struct {
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+	HasMore  bool   `json:"has_more"`
+	Messages []struct {
+		TS         string `json:"ts"`
+		ReplyCount int    `json:"reply_count"`
+	} `json:"messages"`
+	ResponseMetadata struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+// SampleHistory fetches one page of up to limit messages posted in a channel
+// since oldest, newest first, without paging further.
+func (c *EdgeClient) SampleHistory(
+	ctx context.Context,
+	channelID string,
+	oldest time.Time,
+	limit int,
+) (HistorySample, error) {
+	form := url.Values{}
+	form.Set("channel", channelID)
+	form.Set("limit", strconv.Itoa(limit))
+	form.Set("oldest", strconv.FormatInt(oldest.Unix(), 10))
+
+	result, err := c.fetchHistory(ctx, form)
+	if err != nil {
+		return HistorySample{}, err
+	}
+	return summarizeHistory(result), nil
+}
+
+// fetchHistory posts one conversations.history request.
+func (c *EdgeClient) fetchHistory(ctx context.Context, form url.Values) (historyResponse, error) {
+	result, err := callWebAPI[historyResponse](ctx, c, "conversations.history", form)
+	if err != nil {
+		return historyResponse{}, err
+	}
+	return *result, nil
+}
+
+// rawHistoryResponse is conversations.history with each message left
+// undecoded, for callers that render full messages.
+type rawHistoryResponse struct {
+	OK               bool              `json:"ok"`
+	Error            string            `json:"error,omitempty"`
+	HasMore          bool              `json:"has_more"`
+	Messages         []json.RawMessage `json:"messages"`
+	ResponseMetadata struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+// RecentMessages returns up to limit of a channel's newest top-level
+// messages as raw JSON, oldest first.
+func (c *EdgeClient) RecentMessages(ctx context.Context, channelID string, limit int) ([]json.RawMessage, error) {
+	form := url.Values{}
+	form.Set("channel", channelID)
+	form.Set("limit", strconv.Itoa(limit))
+	result, err := c.fetchRawHistory(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(result.Messages)
+	return result.Messages, nil
+}
+
+// MessagesAfter returns every top-level message posted in a channel after
+// the Slack timestamp ts as raw JSON, oldest first.
+func (c *EdgeClient) MessagesAfter(ctx context.Context, channelID, ts string) ([]json.RawMessage, error) {
+	var messages []json.RawMessage
+	cursor := ""
+	for {
+		form := url.Values{}
+		form.Set("channel", channelID)
+		form.Set("limit", strconv.Itoa(countPageSize))
+		form.Set("oldest", ts)
+		if cursor != "" {
+			form.Set("cursor", cursor)
+		}
+		result, err := c.fetchRawHistory(ctx, form)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, result.Messages...)
+		cursor = result.ResponseMetadata.NextCursor
+		if !result.HasMore || cursor == "" {
+			break
+		}
+	}
+	slices.Reverse(messages)
+	return messages, nil
+}
+
+func (c *EdgeClient) fetchRawHistory(ctx context.Context, form url.Values) (rawHistoryResponse, error) {
+	result, err := callWebAPI[rawHistoryResponse](ctx, c, "conversations.history", form)
+	if err != nil {
+		return rawHistoryResponse{}, err
+	}
+	return *result, nil
+}
+
+func summarizeHistory(result historyResponse) HistorySample {
+	sample := HistorySample{Messages: len(result.Messages), HasMore: result.HasMore}
+	for _, msg := range result.Messages {
+		if msg.ReplyCount > 0 {
+			sample.Threads++
+			sample.Replies += msg.ReplyCount
+		}
+		if ts, err := ParseSlackTS(msg.TS); err == nil && (sample.Oldest.IsZero() || ts.Before(sample.Oldest)) {
+			sample.Oldest = ts
+		}
+	}
+	return sample
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEdgeClient_SampleHistory(t *testing.T) {
+	var form string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations.history" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = r.ParseForm()
+		form = r.Form.Encode()
+		_, _ = w.Write([]byte(`{"ok": true, "has_more": true, "messages": [
+			{"ts": "1767312000.000200", "reply_count": 3},
+			{"ts": "1767225600.000100"},
+			{"ts": "1767268800.000100", "reply_count": 1}]}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+	oldest := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sample, err := client.SampleHistory(context.Background(), "C123", oldest, 100)
+	if err != nil {
+		t.Fatalf("SampleHistory() error = %v", err)
+	}
+	if sample.Messages != 3 || sample.Threads != 2 || sample.Replies != 4 || !sample.HasMore {
+		t.Errorf("SampleHistory() = %+v", sample)
+	}
+	if want := time.Unix(1767225600, 100000).UTC(); !sample.Oldest.Equal(want) {
+		t.Errorf("Oldest = %v, want %v", sample.Oldest, want)
+	}
+	if !strings.Contains(form, "channel=C123") || !strings.Contains(form, "limit=100") ||
+		!strings.Contains(form, "oldest=1735689600") {
+		t.Errorf("request form = %s", form)
+	}
+}
+
+func TestEdgeClient_SampleHistory_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.SampleHistory(context.Background(), "C404", time.Time{}, 100)
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Fatalf("SampleHistory() error = %v, want channel_not_found", err)
+	}
+}
+
+func TestEdgeClient_MessagesAfter_PagesOldestFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("oldest") != "1767225600.000100" {
+			t.Errorf("oldest = %q", r.Form.Get("oldest"))
+		}
+		if r.Form.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok": true, "has_more": true, "response_metadata": {"next_cursor": "page2"},
+				"messages": [{"ts": "1767225600.000400", "text": "fourth"}, {"ts": "1767225600.000300"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "messages": [{"ts": "1767225600.000200"}]}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	messages, err := client.MessagesAfter(context.Background(), "C123", "1767225600.000100")
+	if err != nil {
+		t.Fatalf("MessagesAfter() error = %v", err)
+	}
+	if len(messages) != 3 || !strings.Contains(string(messages[0]), "000200") ||
+		!strings.Contains(string(messages[2]), `"fourth"`) {
+		t.Errorf("MessagesAfter() = %s", messages)
+	}
+}
@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// countPageSize is the largest conversations.history page Slack serves.
+const countPageSize = 999
+
+// CountMessages counts the top-level messages, including thread broadcasts,
+// posted in a channel in [oldest, latest). Only timestamps are decoded, so
+// each page is cheap even in busy channels.
+func (c *EdgeClient) CountMessages(ctx context.Context, channelID string, oldest, latest time.Time) (int, error) {
+	count := 0
+	cursor := ""
+	for {
+		form := url.Values{}
+		form.Set("channel", channelID)
+		form.Set("limit", strconv.Itoa(countPageSize))
+		form.Set("oldest", formatSlackTS(oldest))
+		form.Set("latest", formatSlackTS(latest))
+		form.Set("inclusive", "true")
+		if cursor != "" {
+			form.Set("cursor", cursor)
+		}
+		result, err := c.fetchHistory(ctx, form)
+		if err != nil {
+			return count, err
+		}
+		for _, msg := range result.Messages {
+			if ts, err := ParseSlackTS(msg.TS); err == nil && !ts.Before(oldest) && ts.Before(latest) {
+				count++
+			}
+		}
+		cursor = result.ResponseMetadata.NextCursor
+		if !result.HasMore || cursor == "" {
+			return count, nil
+		}
+	}
+}
+
+func formatSlackTS(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEdgeClient_CountMessages_Pages(t *testing.T) {
+	oldest := time.Unix(1700000000, 0)
+	latest := oldest.Add(24 * time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("channel") != "C1" || r.Form.Get("oldest") != "1700000000.000000" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		if r.Form.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok": true, "has_more": true, "response_metadata": {"next_cursor": "page2"},
+				"messages": [{"ts": "1700000100.000100"}, {"ts": "1700000050.000000"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "messages": [{"ts": "1700000000.000000"}, {"ts": "1700086400.000000"}]}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	count, err := client.CountMessages(context.Background(), "C1", oldest, latest)
+	if err != nil {
+		t.Fatalf("CountMessages() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("CountMessages() = %d, want 3 (latest is exclusive)", count)
+	}
+}
+
+func TestEdgeClient_CountMessages_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	if _, err := client.CountMessages(context.Background(), "C1", time.Unix(0, 0), time.Unix(1, 0)); err == nil {
+		t.Error("CountMessages() error = nil, want channel_not_found")
+	}
+}
@@ -0,0 +1,35 @@
+package slack
+
+import "net/http"
+
+// Middleware wraps the transport under every request an EdgeClient sends,
+// for logging, metrics, caching, or request signing.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper, for writing
+// Middleware inline.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware returns a new EdgeClient whose requests pass through mw,
+// the first listed outermost, before reaching the current transport. The
+// client timeout is kept. Middleware added before WithRequestsPerSecond or
+// WithCredentialRefresher sees each retried attempt and refreshed
+// credentials; middleware added after them sees each call once.
+func (c *EdgeClient) WithMiddleware(mw ...Middleware) *EdgeClient {
+	if len(mw) == 0 {
+		return c.WithHTTPClient(c.httpClient)
+	}
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(mw) - 1; i >= 0; i-- {
+		transport = mw[i](transport)
+	}
+	return c.WithHTTPClient(&http.Client{Timeout: c.httpClient.Timeout, Transport: transport})
+}
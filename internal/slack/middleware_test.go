@@ -0,0 +1,53 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestEdgeClient_WithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-Signature") != "signed" {
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "presence": "active"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	named := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	sign := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Signature", "signed")
+			return next.RoundTrip(req)
+		})
+	}
+
+	base := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+	client := base.WithMiddleware(named("outer"), named("inner"), sign)
+	if _, err := client.FetchPresence(context.Background(), "U1"); err != nil {
+		t.Fatalf("FetchPresence() error = %v", err)
+	}
+	if want := []string{"outer", "inner"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("middleware ran in order %v, want %v", order, want)
+	}
+	if client.httpClient.Timeout != DefaultHTTPTimeout {
+		t.Errorf("Timeout = %v, want the original client's", client.httpClient.Timeout)
+	}
+	if _, err := base.FetchPresence(context.Background(), "U1"); err == nil {
+		t.Error("WithMiddleware changed the original client")
+	}
+}
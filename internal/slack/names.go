@@ -0,0 +1,114 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Built-in name styles for NameStyle.
+const (
+	NameStyleUsername = "username"
+	NameStyleDisplay  = "display"
+	NameStyleReal     = "real"
+)
+
+// NameFields are the names a NameStyle chooses from, and the fields a
+// custom name template may use.
+type NameFields struct {
+	ID          string
+	Name        string
+	DisplayName string
+	RealName    string
+}
+
+// NameFields returns the user's names.
+func (u *User) NameFields() NameFields {
+	return NameFields{ID: u.ID, Name: u.Name, DisplayName: u.Profile.DisplayName, RealName: u.RealName}
+}
+
+// NameStyle chooses which of a user's names is shown in messages and used
+// in DM file names. The zero value keeps the defaults: display names in
+// messages and lowercase usernames in file names.
+type NameStyle struct {
+	style string
+	tmpl  *template.Template
+}
+
+// ParseNameStyle parses a name_style value: "username", "display", "real",
+// or a template over NameFields such as "{{.RealName}} ({{.Name}})". An
+// empty value is the zero NameStyle.
+func ParseNameStyle(value string) (NameStyle, error) {
+	switch value {
+	case "", NameStyleUsername, NameStyleDisplay, NameStyleReal:
+		return NameStyle{style: value}, nil
+	}
+	if !strings.Contains(value, "{{") {
+		return NameStyle{}, fmt.Errorf("name_style must be username, display, real, or a template, got %q", value)
+	}
+	tmpl, err := template.New("name_style").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return NameStyle{}, fmt.Errorf("invalid name_style template: %w", err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, NameFields{}); err != nil {
+		return NameStyle{}, fmt.Errorf("invalid name_style template: %w", err)
+	}
+	return NameStyle{tmpl: tmpl}, nil
+}
+
+// Name returns the name shown for a user, falling back through the other
+// names when the chosen one is empty. It returns "" only when the user has
+// no name at all.
+func (s NameStyle) Name(f NameFields) string {
+	switch {
+	case s.tmpl != nil:
+		var out strings.Builder
+		if err := s.tmpl.Execute(&out, f); err == nil {
+			if name := strings.TrimSpace(out.String()); name != "" {
+				return name
+			}
+		}
+	case s.style == NameStyleUsername:
+		return firstName(f.Name, f.DisplayName, f.RealName)
+	case s.style == NameStyleReal:
+		return firstName(f.RealName, f.DisplayName, f.Name)
+	}
+	return firstName(f.DisplayName, f.RealName, f.Name)
+}
+
+// FileName returns the name used for the user in DM file names. The
+// default and username styles use the lowercase username; other styles
+// use Name made safe for file names, with spaces turned into dots.
+func (s NameStyle) FileName(f NameFields) string {
+	if s.tmpl == nil && (s.style == "" || s.style == NameStyleUsername) {
+		return strings.ToLower(f.Name)
+	}
+	var out strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s.Name(f)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			if space && out.Len() > 0 {
+				out.WriteByte('.')
+			}
+			space = false
+			out.WriteRune(r)
+		case unicode.IsSpace(r), r == '.':
+			space = true
+		}
+	}
+	if name := out.String(); name != "" {
+		return name
+	}
+	return strings.ToLower(f.Name)
+}
+
+func firstName(names ...string) string {
+	for _, name := range names {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package slack
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNameStyle(t *testing.T) {
+	alice := NameFields{ID: "U1", Name: "alice.w", DisplayName: "Ali", RealName: "Alice Wong"}
+	noDisplay := NameFields{ID: "U2", Name: "bob", RealName: "Bob O'Brien"}
+	tests := []struct {
+		style    string
+		fields   NameFields
+		name     string
+		fileName string
+	}{
+		{"", alice, "Ali", "alice.w"},
+		{NameStyleUsername, alice, "alice.w", "alice.w"},
+		{NameStyleDisplay, alice, "Ali", "ali"},
+		{NameStyleReal, alice, "Alice Wong", "alice.wong"},
+		{NameStyleDisplay, noDisplay, "Bob O'Brien", "bob.obrien"},
+		{"{{.RealName}} ({{.Name}})", alice, "Alice Wong (alice.w)", "alice.wong.alice.w"},
+		{"{{.DisplayName}}", noDisplay, "Bob O'Brien", "bob.obrien"},
+	}
+	for _, tt := range tests {
+		style, err := ParseNameStyle(tt.style)
+		if err != nil {
+			t.Fatalf("ParseNameStyle(%q) error = %v", tt.style, err)
+		}
+		if got := style.Name(tt.fields); got != tt.name {
+			t.Errorf("%q: Name() = %q, want %q", tt.style, got, tt.name)
+		}
+		if got := style.FileName(tt.fields); got != tt.fileName {
+			t.Errorf("%q: FileName() = %q, want %q", tt.style, got, tt.fileName)
+		}
+	}
+}
+
+func TestParseNameStyle_Invalid(t *testing.T) {
+	for _, value := range []string{"nickname", "{{.Nickname}}", "{{.Name"} {
+		if _, err := ParseNameStyle(value); err == nil {
+			t.Errorf("ParseNameStyle(%q) accepted an invalid style", value)
+		}
+	}
+}
+
+func TestUserResolver_WithNameStyle(t *testing.T) {
+	index := NewUserIndex([]User{{ID: "U1", Name: "alice.w", RealName: "Alice Wong"}})
+	style, _ := ParseNameStyle(NameStyleReal)
+	resolver := NewUserResolver(index, nil, nil).WithNameStyle(style).WithTeams("T1", nil)
+
+	if got, _ := resolver.DMName(context.Background(), "U1"); got != "alice.wong" {
+		t.Errorf("DMName() = %q, want alice.wong", got)
+	}
+	if got, _ := NewUserResolver(index, nil, nil).Username(context.Background(), "U1"); got != "alice.w" {
+		t.Errorf("default Username() = %q, want alice.w", got)
+	}
+}
@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ProfileField is a custom profile field defined by the workspace, such as
+// Team or Location.
+type ProfileField struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	Ordering int    `json:"ordering"`
+	Hidden   bool   `json:"is_hidden,omitempty"`
+}
+
+// TeamProfileResponse is the response from the Slack team.profile.get API.
+type TeamProfileResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Profile struct {
+		Fields []ProfileField `json:"fields"`
+	} `json:"profile"`
+}
+
+// ProfileFieldValue is a user's value for one custom profile field. Alt
+// is the display text Slack shows for link and user fields.
+type ProfileFieldValue struct {
+	Value string `json:"value"`
+	Alt   string `json:"alt,omitempty"`
+}
+
+// UserProfileResponse is the response from the Slack users.profile.get API.
+type UserProfileResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Profile struct {
+		Title  string                       `json:"title"`
+		Fields map[string]ProfileFieldValue `json:"fields"`
+	} `json:"profile"`
+}
+
+// UserProfileSnapshot is a user's title and custom profile fields, keyed by
+// field label.
+type UserProfileSnapshot struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	RealName    string            `json:"real_name,omitempty"`
+	DisplayName string            `json:"display_name,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Fields      map[string]string `json:"fields,omitempty"`
+}
+
+// FetchProfileFields returns the workspace's custom profile fields in
+// display order.
+func (c *EdgeClient) FetchProfileFields(ctx context.Context) ([]ProfileField, error) {
+	result, err := callWebAPI[TeamProfileResponse](ctx, c, "team.profile.get", nil)
+	if err != nil {
+		return nil, err
+	}
+	fields := result.Profile.Fields
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Ordering < fields[j].Ordering })
+	return fields, nil
+}
+
+// FetchUserProfile returns one user's profile with custom field values.
+func (c *EdgeClient) FetchUserProfile(ctx context.Context, userID string) (*UserProfileResponse, error) {
+	form := url.Values{}
+	form.Set("user", userID)
+	result, err := callWebAPI[UserProfileResponse](ctx, c, "users.profile.get", form)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CollectProfiles snapshots the title and custom profile fields of every
+// active member, one users.profile.get call per user. Hidden fields and
+// empty values are left out.
+func (c *EdgeClient) CollectProfiles(ctx context.Context) ([]UserProfileSnapshot, error) {
+	fields, err := c.FetchProfileFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if !field.Hidden {
+			labels[field.ID] = field.Label
+		}
+	}
+	users, err := c.FetchUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]UserProfileSnapshot, 0, len(users))
+	for _, user := range users {
+		if user.Deleted || user.IsBot {
+			continue
+		}
+		profile, err := c.FetchUserProfile(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, profileSnapshot(user, profile, labels))
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID < snapshots[j].ID })
+	return snapshots, nil
+}
+
+func profileSnapshot(user *User, profile *UserProfileResponse, labels map[string]string) UserProfileSnapshot {
+	snapshot := UserProfileSnapshot{
+		ID:          user.ID,
+		Name:        user.Name,
+		RealName:    user.RealName,
+		DisplayName: user.Profile.DisplayName,
+		Title:       strings.TrimSpace(profile.Profile.Title),
+	}
+	for id, field := range profile.Profile.Fields {
+		label, ok := labels[id]
+		value := strings.TrimSpace(field.Alt)
+		if value == "" {
+			value = strings.TrimSpace(field.Value)
+		}
+		if !ok || value == "" {
+			continue
+		}
+		if snapshot.Fields == nil {
+			snapshot.Fields = make(map[string]string)
+		}
+		snapshot.Fields[label] = value
+	}
+	return snapshot
+}
@@ -0,0 +1,51 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestEdgeClient_CollectProfiles(t *testing.T) {
+	profileCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		switch r.URL.Path {
+		case "/team.profile.get":
+			_, _ = w.Write([]byte(`{"ok": true, "profile": {"fields": [
+				{"id": "Xf2", "label": "Location", "ordering": 1},
+				{"id": "Xf1", "label": "Team", "ordering": 0},
+				{"id": "Xf3", "label": "Badge", "ordering": 2, "is_hidden": true}]}}`))
+		case "/users.list":
+			_, _ = w.Write([]byte(`{"ok": true, "members": [
+				{"id": "U1", "name": "alice", "real_name": "Alice"},
+				{"id": "U2", "name": "gone", "deleted": true},
+				{"id": "B1", "name": "deploybot", "is_bot": true}]}`))
+		case "/users.profile.get":
+			profileCalls++
+			if r.Form.Get("user") != "U1" {
+				t.Errorf("users.profile.get for %s", r.Form.Get("user"))
+			}
+			_, _ = w.Write([]byte(`{"ok": true, "profile": {"title": "Staff Engineer ", "fields": {
+				"Xf1": {"value": "Platform"}, "Xf2": {"value": "", "alt": ""}, "Xf3": {"value": "42"}}}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	profiles, err := client.CollectProfiles(context.Background())
+	if err != nil {
+		t.Fatalf("CollectProfiles() error = %v", err)
+	}
+	want := []UserProfileSnapshot{{
+		ID: "U1", Name: "alice", RealName: "Alice", Title: "Staff Engineer",
+		Fields: map[string]string{"Team": "Platform"},
+	}}
+	if !reflect.DeepEqual(profiles, want) || profileCalls != 1 {
+		t.Errorf("CollectProfiles() = %+v after %d profile call(s), want %+v", profiles, profileCalls, want)
+	}
+}
@@ -0,0 +1,212 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateLimitRetries is how many times a rate-limited request is retried.
+	maxRateLimitRetries = 6
+	// maxRateLimitBackoff caps the wait between retries when Slack sends no Retry-After.
+	maxRateLimitBackoff = 30 * time.Second
+)
+
+// requestPacer is a token bucket (burst 1) that spaces requests evenly.
+// A zero interval lets every request through immediately.
+type requestPacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRequestPacer(rps float64) *requestPacer {
+	pacer := &requestPacer{}
+	if rps > 0 {
+		pacer.interval = time.Duration(float64(time.Second) / rps)
+	}
+	return pacer
+}
+
+// wait blocks until the caller's turn in the queue or ctx is done.
+func (p *requestPacer) wait(ctx context.Context) error {
+	if p.interval <= 0 {
+		return nil
+	}
+	p.mu.Lock()
+	now := time.Now()
+	slot := p.next
+	if slot.Before(now) {
+		slot = now
+	}
+	p.next = slot.Add(p.interval)
+	p.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, delay)
+}
+
+// rateLimitTransport paces requests and transparently retries responses that
+// Slack rate limited, either with HTTP 429 or {"ok":false,"error":"ratelimited"}.
+// Each attempt gets its own timeout so waiting out a rate limit does not eat
+// into the time allowed for the retried request.
+type rateLimitTransport struct {
+	base           http.RoundTripper
+	pacer          *requestPacer
+	attemptTimeout time.Duration
+	sleep          func(context.Context, time.Duration) error
+}
+
+func newRateLimitTransport(base http.RoundTripper, rps float64, attemptTimeout time.Duration) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		base:           base,
+		pacer:          newRequestPacer(rps),
+		attemptTimeout: attemptTimeout,
+		sleep:          sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	body, err := replayableBody(req)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		if err := t.pacer.wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := t.attempt(ctx, req, body)
+		if err != nil {
+			return nil, err
+		}
+		limited, err := isRateLimited(resp)
+		if err != nil || !limited || attempt >= maxRateLimitRetries {
+			return resp, err
+		}
+		delay := retryDelay(resp, attempt)
+		_ = resp.Body.Close()
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends one copy of req. The attempt's timeout stays armed until the
+// response body is closed.
+func (t *rateLimitTransport) attempt(ctx context.Context, req *http.Request, body []byte) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if t.attemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.attemptTimeout)
+	}
+	attemptReq := req.Clone(ctx)
+	if body != nil {
+		attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := t.base.RoundTrip(attemptReq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+func replayableBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer func() { _ = req.Body.Close() }()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("buffering request body: %w", err)
+	}
+	return body, nil
+}
+
+// isRateLimited reports whether Slack rejected the request for rate limiting.
+// JSON bodies are buffered and restored so callers can still read them.
+func isRateLimited(resp *http.Response) (bool, error) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, nil
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return false, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("reading response: %w", err)
+	}
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &result) != nil {
+		return false, nil
+	}
+	return !result.OK && (result.Error == "ratelimited" || result.Error == "rate_limited"), nil
+}
+
+// retryDelay honors Retry-After and otherwise backs off exponentially.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	delay := time.Second << attempt
+	if delay > maxRateLimitBackoff {
+		delay = maxRateLimitBackoff
+	}
+	return delay
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRequestsPerSecond returns a new EdgeClient whose requests share one
+// queue paced to rps and whose rate-limited responses are retried
+// transparently. Zero or negative rps disables pacing but keeps the retries.
+// The client timeout becomes a per-attempt timeout.
+func (c *EdgeClient) WithRequestsPerSecond(rps float64) *EdgeClient {
+	base := c.httpClient.Transport
+	timeout := c.httpClient.Timeout
+	if existing, ok := base.(*rateLimitTransport); ok {
+		base, timeout = existing.base, existing.attemptTimeout
+	}
+	return c.WithHTTPClient(&http.Client{Transport: newRateLimitTransport(base, rps, timeout)})
+}
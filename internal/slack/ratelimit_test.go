@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func noSleep(context.Context, time.Duration) error { return nil }
+
+func TestRateLimitTransport_RetriesRatelimitedBody(t *testing.T) {
+	var calls int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		w.Header().Set("Content-Type", "application/json")
+		if calls < 3 {
+			_, _ = w.Write([]byte(`{"ok": false, "error": "ratelimited"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "channels": []}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).
+		WithWorkspaceURL(server.URL + "/").
+		WithRequestsPerSecond(0)
+	client.httpClient.Transport.(*rateLimitTransport).sleep = noSleep
+
+	if _, err := client.ClientCounts(context.Background()); err != nil {
+		t.Fatalf("ClientCounts() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("made %d requests, want 3", calls)
+	}
+	for i, body := range bodies {
+		if !strings.Contains(body, "token=xoxc-test-token") {
+			t.Errorf("attempt %d lost its form body: %q", i+1, body)
+		}
+	}
+}
+
+func TestRateLimitTransport_HonorsRetryAfter(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	var waited []time.Duration
+	transport := newRateLimitTransport(nil, 0, time.Second)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		waited = append(waited, d)
+		return nil
+	}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(data) != `{"ok": true}` {
+		t.Errorf("final response = %d %q", resp.StatusCode, data)
+	}
+	if len(waited) != 1 || waited[0] != 7*time.Second {
+		t.Errorf("waited %v, want [7s]", waited)
+	}
+}
+
+func TestRateLimitTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport := newRateLimitTransport(nil, 0, 0)
+	transport.sleep = noSleep
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || calls != maxRateLimitRetries+1 {
+		t.Errorf("status %d after %d calls, want 429 after %d", resp.StatusCode, calls, maxRateLimitRetries+1)
+	}
+}
+
+func TestRequestPacer_SpacesRequests(t *testing.T) {
+	pacer := newRequestPacer(50)
+	start := time.Now()
+	for range 3 {
+		if err := pacer.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("3 requests at 50 rps took %v, want at least ~40ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := newRequestPacer(0.001).wait(ctx); err != nil {
+		t.Errorf("first request should not wait, got %v", err)
+	}
+}
+
+func TestRetryDelay_BacksOffWithCap(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if got := retryDelay(resp, 0); got != time.Second {
+		t.Errorf("retryDelay(0) = %v, want 1s", got)
+	}
+	if got := retryDelay(resp, 3); got != 8*time.Second {
+		t.Errorf("retryDelay(3) = %v, want 8s", got)
+	}
+	if got := retryDelay(resp, 10); got != maxRateLimitBackoff {
+		t.Errorf("retryDelay(10) = %v, want cap %v", got, maxRateLimitBackoff)
+	}
+}
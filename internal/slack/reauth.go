@@ -0,0 +1,196 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CredentialRefresher supplies fresh credentials after Slack rejects the
+// current ones mid-run.
+type CredentialRefresher interface {
+	RefreshCredentials(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialRefresherFunc adapts a function to CredentialRefresher.
+type CredentialRefresherFunc func(ctx context.Context) (*Credentials, error)
+
+// RefreshCredentials calls f.
+func (f CredentialRefresherFunc) RefreshCredentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// ReloadWorkspaceCredentials re-reads slackdump's credential cache for
+// workspace, which another slackdump process may have refreshed since the
+// run started.
+func ReloadWorkspaceCredentials(workspace string) CredentialRefresher {
+	return CredentialRefresherFunc(func(context.Context) (*Credentials, error) {
+		return LoadWorkspaceCredentials(workspace)
+	})
+}
+
+// reauthTransport retries a request once with refreshed credentials when
+// Slack answers it with an authentication error. The new token and cookies
+// replace the client's session, so later requests use them too.
+type reauthTransport struct {
+	base      http.RoundTripper
+	session   *atomic.Pointer[Credentials]
+	refresher CredentialRefresher
+	mu        sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := replayableBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(withBody(req, body))
+	if err != nil {
+		return nil, err
+	}
+	rejected, err := isAuthRejected(resp)
+	if err != nil || !rejected {
+		return resp, err
+	}
+	token, cookies, ok := t.refresh(req.Context(), sentToken(req, body))
+	if !ok {
+		return resp, nil
+	}
+	retry, err := withCredentials(req, body, token, cookies)
+	if err != nil {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	return t.base.RoundTrip(retry)
+}
+
+// refresh reloads the credentials and reports whether they differ from the
+// ones the rejected request used. When a concurrent request has already
+// refreshed them since sent was used, the session is returned as is.
+func (t *reauthTransport) refresh(ctx context.Context, sent string) (string, []*http.Cookie, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current := t.session.Load()
+	if sent != "" && current.Token != sent {
+		return current.Token, current.Cookies, true
+	}
+	fresh, err := t.refresher.RefreshCredentials(ctx)
+	if err != nil || fresh == nil || fresh.Token == "" || fresh.Token == current.Token && sameCookies(fresh.Cookies, current.Cookies) {
+		return "", nil, false
+	}
+	next := *current
+	next.Token, next.Cookies = fresh.Token, fresh.Cookies
+	t.session.Store(&next)
+	return fresh.Token, fresh.Cookies, true
+}
+
+// sentToken returns the token req carried as a form field or Bearer
+// header, or "" when it carried none.
+func sentToken(req *http.Request, body []byte) string {
+	if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	if body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			return form.Get("token")
+		}
+	}
+	return ""
+}
+
+func sameCookies(a, b []*http.Cookie) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Value != b[i].Value {
+			return false
+		}
+	}
+	return true
+}
+
+func withBody(req *http.Request, body []byte) *http.Request {
+	if body == nil {
+		return req
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.ContentLength = int64(len(body))
+	return clone
+}
+
+// withCredentials copies req with token in place of the old one, whether
+// it was sent as a form field or a Bearer header, and with cookies in place
+// of the old cookies.
+func withCredentials(req *http.Request, body []byte, token string, cookies []*http.Cookie) (*http.Request, error) {
+	if body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("parsing request form: %w", err)
+		}
+		if form.Has("token") {
+			form.Set("token", token)
+			body = []byte(form.Encode())
+		}
+	}
+	retry := withBody(req, body)
+	if retry == req {
+		retry = req.Clone(req.Context())
+	}
+	if strings.HasPrefix(retry.Header.Get("Authorization"), "Bearer ") {
+		retry.Header.Set("Authorization", "Bearer "+token)
+	}
+	retry.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		retry.AddCookie(cookie)
+	}
+	return retry, nil
+}
+
+// isAuthRejected reports whether Slack rejected the request's credentials.
+// JSON bodies are buffered and restored so callers can still read them.
+func isAuthRejected(resp *http.Response) (bool, error) {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true, nil
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return false, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("reading response: %w", err)
+	}
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &result) != nil {
+		return false, nil
+	}
+	return !result.OK && authErrorCodes[result.Error], nil
+}
+
+// WithCredentialRefresher returns a new EdgeClient that, when Slack rejects
+// its credentials, asks refresher for new ones and retries the request once
+// before reporting the failure.
+func (c *EdgeClient) WithCredentialRefresher(refresher CredentialRefresher) *EdgeClient {
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return c.WithHTTPClient(&http.Client{
+		Timeout:   c.httpClient.Timeout,
+		Transport: &reauthTransport{base: base, session: c.session, refresher: refresher},
+	})
+}
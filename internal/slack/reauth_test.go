@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// authServer accepts only the token "xoxc-fresh" with cookie d=fresh.
+func authServer(t *testing.T, calls *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		cookie, err := r.Cookie("d")
+		if r.Form.Get("token") != "xoxc-fresh" || err != nil || cookie.Value != "fresh" {
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "presence": "active"}`))
+	}))
+}
+
+func TestEdgeClient_CredentialRefresherRetriesOnce(t *testing.T) {
+	var calls, refreshes int
+	server := authServer(t, &calls)
+	defer server.Close()
+
+	creds := &Credentials{Token: "xoxc-stale", Cookies: []*http.Cookie{{Name: "d", Value: "stale"}}}
+	client := NewEdgeClient(creds).WithSlackAPIURL(server.URL).
+		WithCredentialRefresher(CredentialRefresherFunc(func(context.Context) (*Credentials, error) {
+			refreshes++
+			return &Credentials{Token: "xoxc-fresh", Cookies: []*http.Cookie{{Name: "d", Value: "fresh"}}}, nil
+		}))
+
+	presence, err := client.FetchPresence(context.Background(), "U1")
+	if err != nil || presence != "active" {
+		t.Fatalf("FetchPresence() = %q, %v", presence, err)
+	}
+	if calls != 2 || refreshes != 1 {
+		t.Errorf("calls = %d, refreshes = %d, want 2 and 1", calls, refreshes)
+	}
+	if token := client.session.Load().Token; token != "xoxc-fresh" {
+		t.Errorf("session credentials not updated: token %q", token)
+	}
+	if creds.Token != "xoxc-stale" {
+		t.Errorf("caller's credentials modified: token %q", creds.Token)
+	}
+
+	if _, err := client.FetchPresence(context.Background(), "U1"); err != nil {
+		t.Fatalf("second FetchPresence() error = %v", err)
+	}
+	if calls != 3 || refreshes != 1 {
+		t.Errorf("after second call: calls = %d, refreshes = %d, want 3 and 1", calls, refreshes)
+	}
+}
+
+func TestEdgeClient_CredentialRefresherUnchangedCredentialsFail(t *testing.T) {
+	var calls int
+	server := authServer(t, &calls)
+	defer server.Close()
+
+	creds := &Credentials{Token: "xoxc-stale", Cookies: []*http.Cookie{{Name: "d", Value: "stale"}}}
+	client := NewEdgeClient(creds).WithSlackAPIURL(server.URL).
+		WithCredentialRefresher(CredentialRefresherFunc(func(context.Context) (*Credentials, error) {
+			return &Credentials{Token: "xoxc-stale", Cookies: []*http.Cookie{{Name: "d", Value: "stale"}}}, nil
+		}))
+
+	_, err := client.FetchPresence(context.Background(), "U1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.AuthFailed() {
+		t.Fatalf("FetchPresence() error = %v, want auth failure", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want no retry with unchanged credentials", calls)
+	}
+}
+
+func TestEdgeClient_CredentialRefreshDuringConcurrentRequests(t *testing.T) {
+	var calls, refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = r.ParseForm()
+		token := r.Form.Get("token")
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			token = bearer
+		}
+		if token != "xoxc-fresh" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+			return
+		}
+		if r.URL.Path == "/file" {
+			_, _ = w.Write([]byte("content"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "presence": "active"}`))
+	}))
+	defer server.Close()
+
+	creds := &Credentials{Token: "xoxc-stale", Cookies: []*http.Cookie{{Name: "d", Value: "stale"}}}
+	client := NewEdgeClient(creds).WithSlackAPIURL(server.URL).
+		WithCredentialRefresher(CredentialRefresherFunc(func(context.Context) (*Credentials, error) {
+			refreshes.Add(1)
+			return &Credentials{Token: "xoxc-fresh", Cookies: []*http.Cookie{{Name: "d", Value: "fresh"}}}, nil
+		}))
+
+	var g errgroup.Group
+	for range 8 {
+		g.Go(func() error {
+			if _, err := client.FetchPresence(context.Background(), "U1"); err != nil {
+				return err
+			}
+			resp, err := client.openPrivateFile(context.Background(), server.URL+"/file")
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("concurrent requests error = %v", err)
+	}
+	if refreshes.Load() != 1 {
+		t.Errorf("refreshes = %d, want 1 shared by all requests", refreshes.Load())
+	}
+}
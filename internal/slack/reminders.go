@@ -0,0 +1,83 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// scheduledMessagesPageSize is how many scheduled messages are requested per
+// chat.scheduledMessages.list page.
+const scheduledMessagesPageSize = 100
+
+// Reminder is one of the authenticated user's reminders. Time is zero for
+// recurring reminders and CompleteTS is set once a reminder is done.
+type Reminder struct {
+	ID         string `json:"id"`
+	Creator    string `json:"creator"`
+	User       string `json:"user"`
+	Text       string `json:"text"`
+	Recurring  bool   `json:"recurring"`
+	Time       int64  `json:"time"`
+	CompleteTS int64  `json:"complete_ts"`
+}
+
+// ScheduledMessage is a message the authenticated user scheduled to post.
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channel_id"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text"`
+}
+
+// RemindersListResponse is the response from the Slack reminders.list API.
+type RemindersListResponse struct {
+	OK        bool       `json:"ok"`
+	Error     string     `json:"error,omitempty"`
+	Reminders []Reminder `json:"reminders"`
+}
+
+// ScheduledMessagesListResponse is the response from the Slack
+// chat.scheduledMessages.list API.
+type ScheduledMessagesListResponse struct {
+	OK                bool               `json:"ok"`
+	Error             string             `json:"error,omitempty"`
+	ScheduledMessages []ScheduledMessage `json:"scheduled_messages"`
+	ResponseMetadata  struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+// ListReminders returns the authenticated user's reminders, including
+// completed ones.
+func (c *EdgeClient) ListReminders(ctx context.Context) ([]Reminder, error) {
+	result, err := callWebAPI[RemindersListResponse](ctx, c, "reminders.list", nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.Reminders, nil
+}
+
+// ListScheduledMessages returns every message the authenticated user has
+// scheduled and not yet posted, across all channels.
+func (c *EdgeClient) ListScheduledMessages(ctx context.Context) ([]ScheduledMessage, error) {
+	var messages []ScheduledMessage
+	cursor := ""
+	for {
+		form := url.Values{}
+		form.Set("limit", strconv.Itoa(scheduledMessagesPageSize))
+		if cursor != "" {
+			form.Set("cursor", cursor)
+		}
+		result, err := callWebAPI[ScheduledMessagesListResponse](ctx, c, "chat.scheduledMessages.list", form)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, result.ScheduledMessages...)
+		cursor = result.ResponseMetadata.NextCursor
+		if cursor == "" {
+			return messages, nil
+		}
+	}
+}
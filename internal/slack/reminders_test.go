@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEdgeClient_ListReminders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reminders.list" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "reminders": [
+			{"id": "Rm1", "text": "Review PR", "time": 1768467600},
+			{"id": "Rm2", "text": "Standup", "recurring": true}]}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	reminders, err := client.ListReminders(context.Background())
+	if err != nil {
+		t.Fatalf("ListReminders() error = %v", err)
+	}
+	if len(reminders) != 2 || reminders[0].Time != 1768467600 || !reminders[1].Recurring {
+		t.Errorf("ListReminders() = %+v", reminders)
+	}
+}
+
+func TestEdgeClient_ListScheduledMessages_Pages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/chat.scheduledMessages.list" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if r.Form.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok": true, "scheduled_messages": [
+				{"id": "Q1", "channel_id": "C1", "post_at": 1768467600, "text": "first"}],
+				"response_metadata": {"next_cursor": "page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "scheduled_messages": [
+			{"id": "Q2", "channel_id": "C2", "post_at": 1768471200, "text": "second"}]}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	messages, err := client.ListScheduledMessages(context.Background())
+	if err != nil {
+		t.Fatalf("ListScheduledMessages() error = %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != "Q1" || messages[1].ChannelID != "C2" {
+		t.Errorf("ListScheduledMessages() = %+v", messages)
+	}
+}
+
+func TestEdgeClient_ListReminders_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "missing_scope"}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.ListReminders(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "missing_scope" {
+		t.Errorf("ListReminders() error = %v, want missing_scope APIError", err)
+	}
+}
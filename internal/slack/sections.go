@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"strings"
+)
+
+// ChannelSection is a sidebar section of the Slack client. Custom sections
+// have a name; the built-in ones are identified by Type.
+type ChannelSection struct {
+	ID             string `json:"channel_section_id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	ChannelIDsPage struct {
+		ChannelIDs []string `json:"channel_ids"`
+	} `json:"channel_ids_page"`
+}
+
+// ChannelSectionsResponse is the response from the Slack
+// users.channelSections.list API.
+type ChannelSectionsResponse struct {
+	OK              bool             `json:"ok"`
+	Error           string           `json:"error,omitempty"`
+	ChannelSections []ChannelSection `json:"channel_sections"`
+}
+
+// Default section labels for conversations the user has not filed into a
+// section, matching the Slack sidebar.
+const (
+	SectionChannels       = "Channels"
+	SectionDirectMessages = "Direct messages"
+)
+
+// Label returns the section's name as the sidebar shows it.
+func (s ChannelSection) Label() string {
+	if name := strings.TrimSpace(s.Name); name != "" {
+		return name
+	}
+	switch s.Type {
+	case "stars":
+		return "Starred"
+	case "direct_messages":
+		return SectionDirectMessages
+	case "recent_apps":
+		return "Apps"
+	case "slack_connect":
+		return "External connections"
+	}
+	return SectionChannels
+}
+
+// FetchChannelSections returns the user's sidebar sections in sidebar
+// order.
+func (c *EdgeClient) FetchChannelSections(ctx context.Context) ([]ChannelSection, error) {
+	result, err := callWebAPI[ChannelSectionsResponse](ctx, c, "users.channelSections.list", nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.ChannelSections, nil
+}
+
+// ChannelSectionLabels maps each given channel to the label of the section
+// it is filed in. Channels outside every section get the sidebar's default:
+// SectionDirectMessages for DMs and group DMs, SectionChannels otherwise.
+func ChannelSectionLabels(sections []ChannelSection, chans []Channel) map[string]string {
+	filed := make(map[string]string)
+	for _, section := range sections {
+		for _, id := range section.ChannelIDsPage.ChannelIDs {
+			if _, ok := filed[id]; !ok {
+				filed[id] = section.Label()
+			}
+		}
+	}
+	labels := make(map[string]string, len(chans))
+	for _, ch := range chans {
+		switch label, ok := filed[ch.ID]; {
+		case ok:
+			labels[ch.ID] = label
+		case ch.IsIM || ch.IsMPIM:
+			labels[ch.ID] = SectionDirectMessages
+		default:
+			labels[ch.ID] = SectionChannels
+		}
+	}
+	return labels
+}
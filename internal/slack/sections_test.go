@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestEdgeClient_FetchChannelSections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users.channelSections.list" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "channel_sections": [
+			{"channel_section_id": "L1", "name": "Engineering", "type": "standard",
+			 "channel_ids_page": {"channel_ids": ["C1", "C2"]}},
+			{"channel_section_id": "L2", "name": "", "type": "stars",
+			 "channel_ids_page": {"channel_ids": ["D1"]}},
+			{"channel_section_id": "L3", "name": "", "type": "channels",
+			 "channel_ids_page": {"channel_ids": []}}]}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	sections, err := client.FetchChannelSections(context.Background())
+	if err != nil {
+		t.Fatalf("FetchChannelSections() error = %v", err)
+	}
+	chans := []Channel{{ID: "C1"}, {ID: "D1", IsIM: true}, {ID: "D2", IsIM: true}, {ID: "G1", IsMPIM: true}, {ID: "C3"}}
+	got := ChannelSectionLabels(sections, chans)
+	want := map[string]string{
+		"C1": "Engineering",
+		"D1": "Starred",
+		"D2": SectionDirectMessages,
+		"G1": SectionDirectMessages,
+		"C3": SectionChannels,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChannelSectionLabels() = %v, want %v", got, want)
+	}
+}
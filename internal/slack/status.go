@@ -0,0 +1,85 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+)
+
+// UserStatus is a snapshot of a user's custom status and, for the
+// authenticated user, presence ("active" or "away").
+type UserStatus struct {
+	UserID   string `json:"user_id"`
+	Name     string `json:"name"`
+	Presence string `json:"presence,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Emoji    string `json:"emoji,omitempty"`
+}
+
+// PresenceResponse is the response from the Slack users.getPresence API.
+type PresenceResponse struct {
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+	Presence string `json:"presence"`
+}
+
+// CollectStatuses snapshots the authenticated user's status and presence.
+// With all set, every active member's custom status is included as well;
+// presence stays limited to the authenticated user because Slack only
+// reports it one user per call.
+func (c *EdgeClient) CollectStatuses(ctx context.Context, all bool) ([]UserStatus, error) {
+	auth, err := c.AuthTest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	presence, err := c.FetchPresence(ctx, auth.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !all {
+		self, err := c.FetchUserInfo(ctx, auth.UserID)
+		if err != nil {
+			return nil, err
+		}
+		status := userStatus(self)
+		status.Presence = presence
+		return []UserStatus{status}, nil
+	}
+	// Statuses change between runs, so a cached user list would not do.
+	users, err := c.fetchAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []UserStatus
+	for _, user := range users {
+		if user.Deleted {
+			continue
+		}
+		status := userStatus(user)
+		if user.ID == auth.UserID {
+			status.Presence = presence
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func userStatus(user *User) UserStatus {
+	return UserStatus{
+		UserID: user.ID,
+		Name:   user.Name,
+		Text:   user.Profile.StatusText,
+		Emoji:  user.Profile.StatusEmoji,
+	}
+}
+
+// FetchPresence returns a user's presence, "active" or "away".
+func (c *EdgeClient) FetchPresence(ctx context.Context, userID string) (string, error) {
+	form := url.Values{}
+	form.Set("user", userID)
+
+	result, err := callWebAPI[PresenceResponse](ctx, c, "users.getPresence", form)
+	if err != nil {
+		return "", err
+	}
+	return result.Presence, nil
+}
@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func statusServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_, _ = w.Write([]byte(`{"ok": true, "user_id": "U1", "team_id": "T1"}`))
+		case "/users.getPresence":
+			_ = r.ParseForm()
+			if r.Form.Get("user") != "U1" {
+				t.Errorf("presence requested for %q", r.Form.Get("user"))
+			}
+			_, _ = w.Write([]byte(`{"ok": true, "presence": "away"}`))
+		case "/users.info":
+			_, _ = w.Write([]byte(`{"ok": true, "user": {"id": "U1", "name": "alice",
+				"profile": {"status_text": "Lunch", "status_emoji": ":taco:"}}}`))
+		case "/users.list":
+			_, _ = w.Write([]byte(`{"ok": true, "members": [
+				{"id": "U1", "name": "alice", "profile": {"status_text": "Lunch"}},
+				{"id": "U2", "name": "bob", "profile": {"status_text": "Vacation", "status_emoji": ":palm_tree:"}},
+				{"id": "U3", "name": "gone", "deleted": true}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestEdgeClient_CollectStatuses_Self(t *testing.T) {
+	server := statusServer(t)
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	statuses, err := client.CollectStatuses(context.Background(), false)
+	if err != nil {
+		t.Fatalf("CollectStatuses() error = %v", err)
+	}
+	want := UserStatus{UserID: "U1", Name: "alice", Presence: "away", Text: "Lunch", Emoji: ":taco:"}
+	if len(statuses) != 1 || statuses[0] != want {
+		t.Errorf("CollectStatuses() = %+v", statuses)
+	}
+}
+
+func TestEdgeClient_CollectStatuses_All(t *testing.T) {
+	server := statusServer(t)
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	statuses, err := client.CollectStatuses(context.Background(), true)
+	if err != nil {
+		t.Fatalf("CollectStatuses() error = %v", err)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].UserID < statuses[j].UserID })
+	if len(statuses) != 2 {
+		t.Fatalf("CollectStatuses() = %+v, want deleted users skipped", statuses)
+	}
+	if statuses[0].Presence != "away" || statuses[1].Presence != "" || statuses[1].Emoji != ":palm_tree:" {
+		t.Errorf("CollectStatuses() = %+v", statuses)
+	}
+}
@@ -0,0 +1,158 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// TeamInfoResponse is the response from the Slack team.info API.
+type TeamInfoResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	Team  Team   `json:"team"`
+}
+
+// TeamFetcher fetches a workspace's details, e.g. the home team of a
+// Slack Connect user.
+type TeamFetcher interface {
+	FetchTeamInfo(ctx context.Context, teamID string) (*Team, error)
+}
+
+// FetchTeamInfo fetches a workspace's name and domain via the Slack
+// team.info API. External Slack Connect teams are visible to their partners.
+func (c *EdgeClient) FetchTeamInfo(ctx context.Context, teamID string) (*Team, error) {
+	form := url.Values{}
+	form.Set("team", teamID)
+
+	result, err := callWebAPI[TeamInfoResponse](ctx, c, "team.info", form)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Team, nil
+}
+
+// TeamID returns the workspace's team ID, known once AuthTest succeeds.
+func (c *EdgeClient) TeamID() string {
+	return c.creds.TeamID
+}
+
+// WithTeams makes DMName label users from other workspaces with their team:
+// users not in the index whose team differs from homeTeam are external
+// Slack Connect users.
+func (r *UserResolver) WithTeams(homeTeam string, teams TeamFetcher) *UserResolver {
+	resolver := *r
+	resolver.homeTeam = homeTeam
+	resolver.teams = teams
+	resolver.labels = &teamLabels{byID: make(map[string]string)}
+	return &resolver
+}
+
+// teamLabels remembers each team's DM name label, fetching each team once
+// even when several DMs resolve at the same time.
+type teamLabels struct {
+	mu      sync.Mutex
+	byID    map[string]string
+	fetches singleflight.Group
+}
+
+func (l *teamLabels) get(teamID string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	label, ok := l.byID[teamID]
+	return label, ok
+}
+
+func (l *teamLabels) set(teamID, label string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.byID[teamID] = label
+}
+
+// DMName returns the name part of a DM with the user: the username, plus
+// "_<team>" for external users when WithTeams is set, so identically named
+// users from different organizations don't collide.
+func (r *UserResolver) DMName(ctx context.Context, id string) (string, error) {
+	username, err := r.Username(ctx, id)
+	if err != nil || r.teams == nil || r.homeTeam == "" {
+		return username, err
+	}
+	if _, member := r.index[id]; member {
+		return username, nil
+	}
+	user, err := r.externalUser(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if user == nil || user.TeamID == "" || user.TeamID == r.homeTeam {
+		return username, nil
+	}
+	label, err := r.teamLabel(ctx, user.TeamID)
+	if err != nil {
+		return "", err
+	}
+	return username + "_" + label, nil
+}
+
+// externalUser returns the user's details with their team, re-fetching
+// cache entries written before teams were recorded.
+func (r *UserResolver) externalUser(ctx context.Context, id string) (*User, error) {
+	var user *User
+	if r.cache != nil {
+		user = r.cache.Get(id)
+	}
+	if (user == nil || user.TeamID == "") && r.fetcher != nil {
+		return r.fetchUser(ctx, id)
+	}
+	return user, nil
+}
+
+// teamLabel returns the team's domain, or its name, for use in file names.
+// Teams Slack won't describe fall back to their ID so names stay unique.
+func (r *UserResolver) teamLabel(ctx context.Context, teamID string) (string, error) {
+	if label, ok := r.labels.get(teamID); ok {
+		return label, nil
+	}
+	label, err, _ := r.labels.fetches.Do(teamID, func() (any, error) {
+		label := strings.ToLower(teamID)
+		team, err := r.teams.FetchTeamInfo(ctx, teamID)
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			if name := fileLabel(team.Domain, team.Name); name != "" {
+				label = name
+			}
+		case !errors.As(err, &apiErr) || apiErr.RateLimited() || apiErr.AuthFailed():
+			return "", err
+		}
+		r.labels.set(teamID, label)
+		return label, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return label.(string), nil
+}
+
+// fileLabel lowercases the first non-empty candidate and replaces anything
+// but letters, digits, dots, and hyphens with hyphens.
+func fileLabel(candidates ...string) string {
+	for _, candidate := range candidates {
+		label := strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-':
+				return r
+			default:
+				return '-'
+			}
+		}, strings.ToLower(strings.TrimSpace(candidate)))
+		if label = strings.Trim(label, "-."); label != "" {
+			return label
+		}
+	}
+	return ""
+}
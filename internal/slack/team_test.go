@@ -0,0 +1,106 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mockTeams struct {
+	teams map[string]*Team
+	err   error
+	calls int
+}
+
+func (m *mockTeams) FetchTeamInfo(_ context.Context, id string) (*Team, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	if team, ok := m.teams[id]; ok {
+		return team, nil
+	}
+	return nil, newAPIError("team.info", "team_not_found", "team.info: team_not_found")
+}
+
+func TestEdgeClient_FetchTeamInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/team.info" || r.Form.Get("team") != "T2" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Form)
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "team": {"id": "T2", "name": "Acme Corp", "domain": "acme"}}`))
+	}))
+	defer server.Close()
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	team, err := client.FetchTeamInfo(context.Background(), "T2")
+	if err != nil {
+		t.Fatalf("FetchTeamInfo() error = %v", err)
+	}
+	if team.Domain != "acme" || team.Name != "Acme Corp" {
+		t.Errorf("FetchTeamInfo() = %+v", team)
+	}
+}
+
+func TestUserResolver_DMNameLabelsExternalUsers(t *testing.T) {
+	idx := NewUserIndex([]User{{ID: "U1", Name: "jane.doe", TeamID: "T1"}})
+	fetcher := &mockFetcher{users: map[string]*User{
+		"U2": {ID: "U2", Name: "jane.doe", TeamID: "T2"},
+		"U3": {ID: "U3", Name: "jane.doe", TeamID: "T3"},
+		"U4": {ID: "U4", Name: "guest", TeamID: "T1"},
+	}}
+	teams := &mockTeams{teams: map[string]*Team{"T2": {ID: "T2", Name: "Acme Corp", Domain: "acme"}}}
+	resolver := NewUserResolver(idx, NewUserCache(""), fetcher).WithTeams("T1", teams)
+
+	tests := map[string]string{"U1": "jane.doe", "U2": "jane.doe_acme", "U3": "jane.doe_t3", "U4": "guest"}
+	for id, want := range tests {
+		got, err := resolver.DMName(context.Background(), id)
+		if err != nil {
+			t.Fatalf("DMName(%s) error = %v", id, err)
+		}
+		if got != want {
+			t.Errorf("DMName(%s) = %q, want %q", id, got, want)
+		}
+	}
+	if _, err := resolver.DMName(context.Background(), "U2"); err != nil || teams.calls != 2 {
+		t.Errorf("team lookups = %d, want each team fetched once", teams.calls)
+	}
+}
+
+func TestUserResolver_DMNameRefetchesCachedUsersWithoutTeam(t *testing.T) {
+	cache := NewUserCache("")
+	cache.Set(&User{ID: "U2", Name: "jane.doe"})
+	fetcher := &mockFetcher{users: map[string]*User{"U2": {ID: "U2", Name: "jane.doe", TeamID: "T2"}}}
+	teams := &mockTeams{teams: map[string]*Team{"T2": {ID: "T2", Name: "Acme Corp"}}}
+	resolver := NewUserResolver(nil, cache, fetcher).WithTeams("T1", teams)
+
+	got, err := resolver.DMName(context.Background(), "U2")
+	if err != nil || got != "jane.doe_acme-corp" {
+		t.Errorf("DMName() = %q, %v; want jane.doe_acme-corp", got, err)
+	}
+	if cache.Get("U2").TeamID != "T2" {
+		t.Error("refetched user not cached")
+	}
+}
+
+func TestUserResolver_DMNameWithoutTeams(t *testing.T) {
+	fetcher := &mockFetcher{users: map[string]*User{"U2": {ID: "U2", Name: "jane.doe", TeamID: "T2"}}}
+	resolver := NewUserResolver(nil, nil, fetcher)
+
+	if got, err := resolver.DMName(context.Background(), "U2"); err != nil || got != "jane.doe" {
+		t.Errorf("DMName() = %q, %v; want jane.doe", got, err)
+	}
+}
+
+func TestUserResolver_DMNameTeamLookupFailure(t *testing.T) {
+	fetcher := &mockFetcher{users: map[string]*User{"U2": {ID: "U2", Name: "jane.doe", TeamID: "T2"}}}
+	teams := &mockTeams{err: errors.New("connection reset")}
+	resolver := NewUserResolver(nil, NewUserCache(""), fetcher).WithTeams("T1", teams)
+
+	if _, err := resolver.DMName(context.Background(), "U2"); err == nil {
+		t.Error("DMName() error = nil, want network failure returned so names stay stable")
+	}
+}
@@ -20,6 +20,11 @@ type Channel struct {
 	Created     time.Time // Channel creation timestamp
 	LastRead    time.Time // Last read timestamp
 	LastMessage time.Time // Most recent message timestamp
+	// MentionCount, HasUnreads, and IsStarred come from client.counts and
+	// userBoot; they order exports so channels needing attention go first.
+	MentionCount int
+	HasUnreads   bool
+	IsStarred    bool
 }
 
 // Credentials holds authentication data for Slack API access.
@@ -27,5 +32,6 @@ type Credentials struct {
 	Token     string         // xoxc-... token
 	Cookies   []*http.Cookie // Session cookies including 'd' cookie
 	TeamID    string         // Workspace ID (T...)
+	UserID    string         // Authenticated user's ID (U...), set by AuthTest
 	Workspace string         // Workspace name (from workspace.txt)
 }
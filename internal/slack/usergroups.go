@@ -0,0 +1,141 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Usergroup is a Slack user group (subteam) referenced by <!subteam^ID> mentions.
+type Usergroup struct {
+	ID     string `json:"id"`
+	Handle string `json:"handle"`
+	Name   string `json:"name"`
+}
+
+// UsergroupsListResponse is the response from usergroups.list.
+type UsergroupsListResponse struct {
+	OK         bool        `json:"ok"`
+	Error      string      `json:"error,omitempty"`
+	Usergroups []Usergroup `json:"usergroups"`
+}
+
+// FetchUsergroups retrieves all user groups, including disabled ones, via the
+// Slack usergroups.list API so historical subteam mentions still resolve.
+func (c *EdgeClient) FetchUsergroups(ctx context.Context) ([]Usergroup, error) {
+	form := url.Values{}
+	form.Set("include_disabled", "true")
+
+	result, err := callWebAPI[UsergroupsListResponse](ctx, c, "usergroups.list", form)
+	if err != nil {
+		return nil, err
+	}
+	return result.Usergroups, nil
+}
+
+// UsergroupCacheData is the top-level structure for the usergroup cache file.
+type UsergroupCacheData struct {
+	Version    int                  `json:"version"`
+	FetchedAt  int64                `json:"fetched_at"`
+	Usergroups map[string]Usergroup `json:"usergroups"`
+}
+
+// UsergroupCache persists the workspace user group index between runs so
+// offline renders can resolve subteam mentions.
+// Thread-safe for concurrent access.
+type UsergroupCache struct {
+	path   string
+	mu     sync.RWMutex
+	groups map[string]Usergroup
+}
+
+// NewUsergroupCache creates a new UsergroupCache that persists to the given path.
+func NewUsergroupCache(path string) *UsergroupCache {
+	return &UsergroupCache{
+		path:   path,
+		groups: make(map[string]Usergroup),
+	}
+}
+
+// Replace swaps the cached index for the given groups.
+func (c *UsergroupCache) Replace(groups []Usergroup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.groups = make(map[string]Usergroup, len(groups))
+	for _, group := range groups {
+		c.groups[group.ID] = group
+	}
+}
+
+// Handles returns a map of user group ID to handle (without the leading @).
+func (c *UsergroupCache) Handles() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	handles := make(map[string]string, len(c.groups))
+	for id, group := range c.groups {
+		handle := group.Handle
+		if handle == "" {
+			handle = group.Name
+		}
+		if handle != "" {
+			handles[id] = handle
+		}
+	}
+	return handles
+}
+
+// Load reads the cache from disk. Returns nil if file doesn't exist.
+func (c *UsergroupCache) Load() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var cacheData UsergroupCacheData
+	if err := json.Unmarshal(data, &cacheData); err != nil {
+		return err
+	}
+
+	c.groups = make(map[string]Usergroup, len(cacheData.Usergroups))
+	for id, group := range cacheData.Usergroups {
+		c.groups[id] = group
+	}
+	return nil
+}
+
+// Save writes the cache to disk.
+func (c *UsergroupCache) Save() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(UsergroupCacheData{
+		Version:    1,
+		FetchedAt:  time.Now().Unix(),
+		Usergroups: c.groups,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, data, 0600)
+}
+
+// DefaultUsergroupCachePath returns the default path for the usergroup cache:
+// ~/.cache/slack-export/usergroups.json
+func DefaultUsergroupCachePath() string {
+	return filepath.Join(filepath.Dir(DefaultCachePath()), "usergroups.json")
+}
@@ -0,0 +1,111 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEdgeClient_FetchUsergroups_Success(t *testing.T) {
+	var capturedPath, capturedBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		bodyBytes, _ := io.ReadAll(r.Body)
+		capturedBody = string(bodyBytes)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"ok": true,
+			"usergroups": [
+				{"id": "S123", "handle": "eng-team", "name": "Engineering"},
+				{"id": "S456", "handle": "oncall", "name": "On Call"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	groups, err := client.FetchUsergroups(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Handle != "eng-team" {
+		t.Fatalf("groups = %+v, want eng-team and oncall", groups)
+	}
+	if capturedPath != "/usergroups.list" {
+		t.Errorf("path = %q, want /usergroups.list", capturedPath)
+	}
+	for _, want := range []string{"token=xoxc-test-token", "include_disabled=true"} {
+		if !strings.Contains(capturedBody, want) {
+			t.Errorf("expected %q in request body, got: %s", want, capturedBody)
+		}
+	}
+}
+
+func TestEdgeClient_FetchUsergroups_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok": false, "error": "missing_scope"}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+
+	_, err := client.FetchUsergroups(context.Background())
+	if err == nil {
+		t.Fatal("expected error for API error response")
+	}
+	if !strings.Contains(err.Error(), "missing_scope") {
+		t.Errorf("error should contain API error, got: %v", err)
+	}
+}
+
+func TestUsergroupCache_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usergroups.json")
+
+	cache1 := NewUsergroupCache(path)
+	cache1.Replace([]Usergroup{
+		{ID: "S123", Handle: "eng-team"},
+		{ID: "S456", Name: "No Handle"},
+	})
+	if err := cache1.Save(); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	cache2 := NewUsergroupCache(path)
+	if err := cache2.Load(); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	handles := cache2.Handles()
+	if handles["S123"] != "eng-team" {
+		t.Errorf("handle for S123 = %q, want eng-team", handles["S123"])
+	}
+	if handles["S456"] != "No Handle" {
+		t.Errorf("handle for S456 = %q, want name fallback", handles["S456"])
+	}
+}
+
+func TestUsergroupCache_LoadMissingFile(t *testing.T) {
+	cache := NewUsergroupCache(filepath.Join(t.TempDir(), "missing.json"))
+	if err := cache.Load(); err != nil {
+		t.Fatalf("Load error for missing file: %v", err)
+	}
+	if len(cache.Handles()) != 0 {
+		t.Error("expected empty handles for missing cache file")
+	}
+}
+
+func TestDefaultUsergroupCachePath(t *testing.T) {
+	path := DefaultUsergroupCachePath()
+	if filepath.Base(path) != "usergroups.json" {
+		t.Errorf("expected usergroups.json, got %s", path)
+	}
+	if filepath.Dir(path) != filepath.Dir(DefaultCachePath()) {
+		t.Errorf("usergroup cache should live next to the user cache, got %s", path)
+	}
+}
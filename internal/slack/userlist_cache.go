@@ -0,0 +1,148 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// userListCacheVersion is bumped when the cache file format changes; files
+// from other versions are ignored and refetched.
+const userListCacheVersion = 1
+
+// UserListCache keeps each workspace's users.list result on disk so runs
+// within ttl skip the paged, Tier-2 limited calls.
+type UserListCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// userListCacheData is the cache file for one workspace. FetchedAt is the
+// watermark compared against the TTL.
+type userListCacheData struct {
+	Version   int    `json:"version"`
+	TeamID    string `json:"team_id"`
+	FetchedAt int64  `json:"fetched_at"`
+	Users     []User `json:"users"`
+}
+
+// NewUserListCache creates a UserListCache writing one file per workspace
+// into dir. A list fetched less than ttl ago is reused.
+func NewUserListCache(dir string, ttl time.Duration) *UserListCache {
+	return &UserListCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// DefaultUserListCacheDir returns the directory of the default user cache,
+// ~/.cache/slack-export.
+func DefaultUserListCacheDir() string {
+	return filepath.Dir(DefaultCachePath())
+}
+
+// Path returns the cache file for a workspace.
+func (c *UserListCache) Path(teamID string) string {
+	return filepath.Join(c.dir, "users-list-"+teamID+".json")
+}
+
+// load returns the cached users of a workspace and when they were fetched.
+// A missing, unreadable, or foreign file reports ok false.
+func (c *UserListCache) load(teamID string) (users []User, fetchedAt time.Time, ok bool) {
+	data, err := os.ReadFile(c.Path(teamID))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	var cached userListCacheData
+	if json.Unmarshal(data, &cached) != nil || cached.Version != userListCacheVersion || cached.TeamID != teamID {
+		return nil, time.Time{}, false
+	}
+	return cached.Users, time.Unix(cached.FetchedAt, 0), true
+}
+
+// save writes a freshly fetched user list.
+func (c *UserListCache) save(teamID string, users []User) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(userListCacheData{
+		Version:   userListCacheVersion,
+		TeamID:    teamID,
+		FetchedAt: c.now().Unix(),
+		Users:     users,
+	})
+	if err != nil {
+		return err
+	}
+	tmp := c.Path(teamID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.Path(teamID))
+}
+
+// userListCaching is an EdgeClient's cache configuration.
+type userListCaching struct {
+	cache  *UserListCache
+	onWarn func(error)
+}
+
+// WithUserListCache returns a new EdgeClient whose FetchUsers reuses the
+// workspace's cached list while it is younger than the cache's TTL. When a
+// refetch fails for any reason other than rejected credentials or
+// cancellation, such as rate limiting that outlasted the retries, the stale
+// list is used instead and onWarn, if set, is called with the error. onWarn
+// also reports a list that could not be saved.
+func (c *EdgeClient) WithUserListCache(cache *UserListCache, onWarn func(error)) *EdgeClient {
+	client := c.WithHTTPClient(c.httpClient)
+	client.userList = &userListCaching{cache: cache, onWarn: onWarn}
+	return client
+}
+
+// RefreshUsers fetches the user index from Slack even when a cached list
+// is fresh, saving it for later runs, e.g. after a lookup missed someone
+// who joined since the list was cached.
+func (c *EdgeClient) RefreshUsers(ctx context.Context) (UserIndex, error) {
+	users, err := c.fetchUserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u := c.userList; u != nil && c.creds.TeamID != "" {
+		if err := u.cache.save(c.creds.TeamID, users); err != nil {
+			u.warn(fmt.Errorf("saving user list cache: %w", err))
+		}
+	}
+	return NewUserIndex(users), nil
+}
+
+// fetch returns the cached index when fresh, otherwise fetches and saves it.
+func (u *userListCaching) fetch(ctx context.Context, c *EdgeClient) (UserIndex, error) {
+	teamID := c.creds.TeamID
+	if teamID == "" {
+		return c.fetchAllUsers(ctx)
+	}
+	cached, fetchedAt, ok := u.cache.load(teamID)
+	if ok && u.cache.now().Sub(fetchedAt) < u.cache.ttl {
+		return NewUserIndex(cached), nil
+	}
+	users, err := c.fetchUserList(ctx)
+	if err != nil {
+		if !ok || IsAuthFailure(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		u.warn(fmt.Errorf("users.list failed, using the user list cached %s: %w",
+			fetchedAt.Format("2006-01-02 15:04"), err))
+		return NewUserIndex(cached), nil
+	}
+	if err := u.cache.save(teamID, users); err != nil {
+		u.warn(fmt.Errorf("saving user list cache: %w", err))
+	}
+	return NewUserIndex(users), nil
+}
+
+func (u *userListCaching) warn(err error) {
+	if u.onWarn != nil {
+		u.onWarn(err)
+	}
+}
@@ -0,0 +1,122 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// userListServer answers users.list with one user, or with status when it
+// is set.
+func userListServer(t *testing.T, calls *int, status *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		*calls++
+		if *status != http.StatusOK {
+			w.WriteHeader(*status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "members": [{"id": "U1", "name": "alice"}]}`))
+	}))
+}
+
+func TestEdgeClient_UserListCache(t *testing.T) {
+	var calls int
+	status := http.StatusOK
+	server := userListServer(t, &calls, &status)
+	defer server.Close()
+
+	now := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	cache := NewUserListCache(t.TempDir(), 12*time.Hour)
+	cache.now = func() time.Time { return now }
+	var warnings []error
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token", TeamID: "T1"}).
+		WithSlackAPIURL(server.URL).
+		WithUserListCache(cache, func(err error) { warnings = append(warnings, err) })
+
+	for range 2 {
+		users, err := client.FetchUsers(context.Background())
+		if err != nil || users["U1"].Name != "alice" {
+			t.Fatalf("FetchUsers() = %v, %v", users, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fresh cache: made %d users.list calls, want 1", calls)
+	}
+
+	now = now.Add(13 * time.Hour)
+	if _, err := client.FetchUsers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("stale cache: made %d users.list calls, want 2", calls)
+	}
+
+	now = now.Add(13 * time.Hour)
+	status = http.StatusTooManyRequests
+	users, err := client.FetchUsers(context.Background())
+	if err != nil || users["U1"].Name != "alice" {
+		t.Fatalf("rate-limited FetchUsers() = %v, %v; want the stale list", users, err)
+	}
+	if len(warnings) != 1 || !IsRateLimited(warnings[0]) {
+		t.Errorf("warnings = %v, want one rate-limit warning", warnings)
+	}
+
+	status = http.StatusUnauthorized
+	if _, err := client.FetchUsers(context.Background()); !IsAuthFailure(err) {
+		t.Errorf("FetchUsers() with rejected credentials error = %v, want the auth failure", err)
+	}
+}
+
+func TestUserListCache_IgnoresOtherWorkspaces(t *testing.T) {
+	cache := NewUserListCache(t.TempDir(), time.Hour)
+	if err := cache.save("T1", []User{{ID: "U1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := cache.load("T2"); ok {
+		t.Error("load() returned another workspace's users")
+	}
+	if users, _, ok := cache.load("T1"); !ok || len(users) != 1 {
+		t.Errorf("load(T1) = %v, %v", users, ok)
+	}
+}
+
+func TestEdgeClient_UserListCacheNeedsUsersOnFirstRun(t *testing.T) {
+	var calls int
+	status := http.StatusTooManyRequests
+	server := userListServer(t, &calls, &status)
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token", TeamID: "T1"}).
+		WithSlackAPIURL(server.URL).
+		WithUserListCache(NewUserListCache(t.TempDir(), time.Hour), nil)
+	var apiErr *APIError
+	if _, err := client.FetchUsers(context.Background()); !errors.As(err, &apiErr) {
+		t.Errorf("FetchUsers() without a cached list error = %v, want the API error", err)
+	}
+}
+
+func TestEdgeClient_RefreshUsersUpdatesCache(t *testing.T) {
+	var calls int
+	status := http.StatusOK
+	server := userListServer(t, &calls, &status)
+	defer server.Close()
+
+	cache := NewUserListCache(t.TempDir(), time.Hour)
+	if err := cache.save("T1", nil); err != nil {
+		t.Fatal(err)
+	}
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token", TeamID: "T1"}).
+		WithSlackAPIURL(server.URL).
+		WithUserListCache(cache, nil)
+	if users, err := client.RefreshUsers(context.Background()); err != nil || len(users) != 1 {
+		t.Fatalf("RefreshUsers() = %v, %v", users, err)
+	}
+	if users, err := client.FetchUsers(context.Background()); err != nil || len(users) != 1 || calls != 1 {
+		t.Errorf("FetchUsers() after refresh = %v, %v with %d calls; want the refreshed list from cache", users, err, calls)
+	}
+}
@@ -3,7 +3,10 @@ package slack
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // mockFetcher implements UserFetcher for testing.
@@ -11,9 +14,12 @@ type mockFetcher struct {
 	users map[string]*User
 	err   error
 	calls []string
+	mu    sync.Mutex
 }
 
 func (m *mockFetcher) FetchUserInfo(_ context.Context, id string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.calls = append(m.calls, id)
 	if m.err != nil {
 		return nil, m.err
@@ -129,3 +135,46 @@ func TestUserResolver_EmptyID(t *testing.T) {
 		t.Errorf("expected unknown for empty ID, got %s", name)
 	}
 }
+
+// blockingFetcher holds every users.info call until release is closed.
+type blockingFetcher struct {
+	started chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (f *blockingFetcher) FetchUserInfo(_ context.Context, id string) (*User, error) {
+	if f.calls.Add(1) == 1 {
+		close(f.started)
+	}
+	<-f.release
+	return &User{ID: id, Name: "shared.user"}, nil
+}
+
+func TestUserResolver_ConcurrentLookupsShareOneFetch(t *testing.T) {
+	fetcher := &blockingFetcher{started: make(chan struct{}), release: make(chan struct{})}
+	resolver := NewUserResolver(nil, nil, fetcher)
+
+	var wg sync.WaitGroup
+	names := make([]string, 5)
+	for i := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			names[i], _ = resolver.Username(context.Background(), "U_SHARED")
+		}()
+	}
+	<-fetcher.started
+	time.Sleep(20 * time.Millisecond) // let the other lookups join the call
+	close(fetcher.release)
+	wg.Wait()
+
+	if got := fetcher.calls.Load(); got != 1 {
+		t.Errorf("users.info calls = %d, want 1", got)
+	}
+	for i, name := range names {
+		if name != "shared.user" {
+			t.Errorf("lookup %d = %q, want shared.user", i, name)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrorBody caps how much of a failed response's body an error quotes.
+const maxErrorBody = 512
+
+// apiStatus is the envelope every Slack API response shares.
+type apiStatus struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// callWebAPI posts form to a Slack Web API method, {slackAPIURL}/{method},
+// and decodes the response into a T. Failures are reported the same way for
+// every method: an *APIError for a non-200 status or an ok:false answer,
+// otherwise a wrapped transport or decoding error. Rate-limited calls are
+// retried by the client's transport, not here.
+func callWebAPI[T any](ctx context.Context, c *EdgeClient, method string, form url.Values) (*T, error) {
+	data, err := c.send(ctx, c.slackAPIURL+"/"+method, method, form)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIResponse[T](method, data)
+}
+
+// callWebClientAPI is callWebAPI for the webclient endpoints under the
+// workspace URL, such as client.userBoot. AuthTest must be called first.
+func callWebClientAPI[T any](ctx context.Context, c *EdgeClient, endpoint string, body map[string]any) (*T, error) {
+	data, err := c.post(ctx, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIResponse[T](endpoint, data)
+}
+
+// send posts form, with the token added, to requestURL with the session
+// cookies and returns the body of a 200 response. form is not modified.
+func (c *EdgeClient) send(ctx context.Context, requestURL, method string, form url.Values) ([]byte, error) {
+	values := url.Values{}
+	for key, vals := range form {
+		values[key] = vals
+	}
+	creds := c.session.Load()
+	values.Set("token", creds.Token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range creds.Cookies {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		if body = bytes.TrimSpace(body); len(body) > 0 {
+			return nil, newHTTPError(method, resp.StatusCode, "%s: HTTP %d: %s", method, resp.StatusCode, body)
+		}
+		return nil, newHTTPError(method, resp.StatusCode, "%s: HTTP %d", method, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s response: %w", method, err)
+	}
+	return data, nil
+}
+
+// decodeAPIResponse checks a response's ok field and decodes it into a T.
+func decodeAPIResponse[T any](method string, data []byte) (*T, error) {
+	var status apiStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("decoding %s response: %w", method, err)
+	}
+	if !status.OK {
+		return nil, newAPIError(method, status.Error, "%s: %s", method, status.Error)
+	}
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("decoding %s response: %w", method, err)
+	}
+	return &result, nil
+}
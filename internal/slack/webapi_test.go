@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCallWebAPI(t *testing.T) {
+	var gotPath string
+	var gotForm url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = r.ParseForm()
+		gotForm = r.PostForm
+		_, _ = w.Write([]byte(`{"ok": true, "team": {"id": "T1", "name": "Acme"}}`))
+	}))
+	defer server.Close()
+
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+	form := url.Values{"team": {"T1"}}
+	result, err := callWebAPI[TeamInfoResponse](context.Background(), client, "team.info", form)
+	if err != nil {
+		t.Fatalf("callWebAPI() error = %v", err)
+	}
+	if result.Team.Name != "Acme" {
+		t.Errorf("Team = %+v, want Acme", result.Team)
+	}
+	if gotPath != "/team.info" || gotForm.Get("token") != "xoxc-test-token" || gotForm.Get("team") != "T1" {
+		t.Errorf("request = %s %v", gotPath, gotForm)
+	}
+	if form.Has("token") {
+		t.Error("callWebAPI() added the token to the caller's form")
+	}
+}
+
+func TestCallWebAPI_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMsg     string
+		wantStatus  int
+		wantCode    string
+		wantAPIErr  bool
+		rateLimited bool
+	}{
+		{"ok false", http.StatusOK, `{"ok": false, "error": "missing_scope"}`, "emoji.list: missing_scope", 0, "missing_scope", true, false},
+		{"http status", http.StatusBadGateway, "", "emoji.list: HTTP 502", http.StatusBadGateway, "", true, false},
+		{"http status with body", http.StatusTooManyRequests, "slow down\n", "emoji.list: HTTP 429: slow down", http.StatusTooManyRequests, "", true, true},
+		{"undecodable", http.StatusOK, `<html>`, "decoding emoji.list response", 0, "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"}).WithSlackAPIURL(server.URL)
+			_, err := callWebAPI[EmojiListResponse](context.Background(), client, "emoji.list", nil)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantMsg) {
+				t.Fatalf("error = %v, want prefix %q", err, tt.wantMsg)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) != tt.wantAPIErr {
+				t.Fatalf("errors.As(*APIError) = %v, want %v", !tt.wantAPIErr, tt.wantAPIErr)
+			}
+			if !tt.wantAPIErr {
+				return
+			}
+			if apiErr.Method != "emoji.list" || apiErr.Status != tt.wantStatus || apiErr.Code != tt.wantCode {
+				t.Errorf("APIError = %+v", apiErr)
+			}
+			if IsRateLimited(err) != tt.rateLimited {
+				t.Errorf("IsRateLimited() = %v, want %v", !tt.rateLimited, tt.rateLimited)
+			}
+		})
+	}
+}
+
+func TestCallWebClientAPI_RequiresAuthTest(t *testing.T) {
+	client := NewEdgeClient(&Credentials{Token: "xoxc-test-token"})
+	if _, err := callWebClientAPI[CountsResponse](context.Background(), client, "client.counts", nil); err == nil {
+		t.Error("callWebClientAPI() without a workspace URL should fail")
+	}
+}
@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// credentialFileExt is the extension slackdump uses for encrypted workspace
+// credential files in its cache directory.
+const credentialFileExt = ".bin"
+
+// WorkspaceStatus describes one workspace found in slackdump's cache.
+type WorkspaceStatus struct {
+	Name string
+	// Current is true for the workspace selected in workspace.txt.
+	Current bool
+	// Err is nil when the credentials decrypt, parse and validate.
+	Err error
+}
+
+// ListWorkspaces returns the names of all workspaces with cached credentials,
+// sorted by name.
+func ListWorkspaces() ([]string, error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return listWorkspaces(cacheDir)
+}
+
+func listWorkspaces(cacheDir string) ([]string, error) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading slackdump cache: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, credentialFileExt) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(name, credentialFileExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadWorkspaceCredentials reads the cached credentials for the named
+// workspace. An empty name selects the current workspace from workspace.txt.
+func LoadWorkspaceCredentials(workspace string) (*Credentials, error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if workspace == "" {
+		if workspace, err = getWorkspace(cacheDir); err != nil {
+			return nil, err
+		}
+		return loadCredentialsFile(cacheDir, workspace)
+	}
+	if err := checkWorkspaceName(cacheDir, workspace); err != nil {
+		return nil, err
+	}
+	return loadCredentialsFile(cacheDir, workspace)
+}
+
+// checkWorkspaceName rejects names that are not a cached workspace, which
+// also keeps path separators out of the credential file path.
+func checkWorkspaceName(cacheDir, workspace string) error {
+	names, err := listWorkspaces(cacheDir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == workspace {
+			return nil
+		}
+	}
+	available := "none"
+	if len(names) > 0 {
+		available = strings.Join(names, ", ")
+	}
+	return &CredentialError{
+		Code:    ErrCodeUnknownWorkspace,
+		Message: fmt.Sprintf("unknown workspace %q (available: %s)", workspace, available),
+	}
+}
+
+// CheckWorkspaces loads and validates the credentials of every cached
+// workspace, so one broken workspace does not hide the others.
+func CheckWorkspaces() ([]WorkspaceStatus, error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	names, err := listWorkspaces(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	current, _ := getWorkspace(cacheDir)
+	statuses := make([]WorkspaceStatus, 0, len(names))
+	for _, name := range names {
+		status := WorkspaceStatus{Name: name, Current: name == current}
+		creds, err := loadCredentialsFile(cacheDir, name)
+		if err == nil {
+			err = creds.Validate()
+		}
+		status.Err = err
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// workspaceCredentialPath returns the path of a workspace's credential file.
+func workspaceCredentialPath(cacheDir, workspace string) string {
+	return filepath.Clean(filepath.Join(cacheDir, workspace+credentialFileExt))
+}
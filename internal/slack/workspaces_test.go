@@ -0,0 +1,114 @@
+package slack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupWorkspaceCache writes a slackdump cache under a temporary HOME with
+// the given workspaces' credential JSON, encrypted for this machine.
+func setupWorkspaceCache(t *testing.T, current string, workspaces map[string]string) string {
+	t.Helper()
+	home := t.TempDir()
+	cacheDir := filepath.Join(home, "Library", "Caches", "slackdump")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, "workspace.txt"), []byte(current+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	machineID, err := GetMachineID()
+	if err != nil {
+		t.Skipf("machine ID unavailable: %v", err)
+	}
+	key := deriveKey(machineID)
+	for name, jsonData := range workspaces {
+		ciphertext, err := encryptTestData([]byte(jsonData), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(cacheDir, name+".bin"), ciphertext, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("HOME", home)
+	return cacheDir
+}
+
+func TestListWorkspaces(t *testing.T) {
+	cacheDir := setupWorkspaceCache(t, "acme", map[string]string{
+		"acme":   `{"Token":"xoxc-acme"}`,
+		"globex": `{"Token":"xoxc-globex"}`,
+	})
+	if err := os.WriteFile(filepath.Join(cacheDir, "notes.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ListWorkspaces()
+	if err != nil {
+		t.Fatalf("ListWorkspaces() error = %v", err)
+	}
+	if strings.Join(names, ",") != "acme,globex" {
+		t.Errorf("ListWorkspaces() = %v, want [acme globex]", names)
+	}
+}
+
+func TestLoadWorkspaceCredentials_ByName(t *testing.T) {
+	setupWorkspaceCache(t, "acme", map[string]string{
+		"acme":   `{"Token":"xoxc-acme"}`,
+		"globex": `{"Token":"xoxc-globex"}`,
+	})
+
+	creds, err := LoadWorkspaceCredentials("globex")
+	if err != nil {
+		t.Fatalf("LoadWorkspaceCredentials() error = %v", err)
+	}
+	if creds.Token != "xoxc-globex" || creds.Workspace != "globex" {
+		t.Errorf("creds = %+v, want globex", creds)
+	}
+
+	current, err := LoadWorkspaceCredentials("")
+	if err != nil || current.Workspace != "acme" {
+		t.Errorf("LoadWorkspaceCredentials(\"\") = %+v, %v; want current workspace acme", current, err)
+	}
+}
+
+func TestLoadWorkspaceCredentials_Unknown(t *testing.T) {
+	setupWorkspaceCache(t, "acme", map[string]string{"acme": `{"Token":"xoxc-acme"}`})
+
+	for _, name := range []string{"initech", "../acme"} {
+		_, err := LoadWorkspaceCredentials(name)
+		credErr := GetCredentialError(err)
+		if credErr == nil || credErr.Code != ErrCodeUnknownWorkspace {
+			t.Errorf("LoadWorkspaceCredentials(%q) error = %v, want unknown workspace", name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), "available: acme") {
+			t.Errorf("error should list available workspaces: %v", err)
+		}
+	}
+}
+
+func TestCheckWorkspaces(t *testing.T) {
+	setupWorkspaceCache(t, "acme", map[string]string{
+		"acme":   `{"Token":"xoxc-acme"}`,
+		"broken": `not json`,
+		"legacy": `{"Token":"xoxp-legacy"}`,
+	})
+
+	statuses, err := CheckWorkspaces()
+	if err != nil {
+		t.Fatalf("CheckWorkspaces() error = %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("CheckWorkspaces() returned %d workspaces, want 3", len(statuses))
+	}
+	if statuses[0].Name != "acme" || !statuses[0].Current || statuses[0].Err != nil {
+		t.Errorf("acme status = %+v, want current and valid", statuses[0])
+	}
+	if statuses[1].Err == nil || statuses[2].Err == nil {
+		t.Errorf("broken and legacy should fail validation: %+v", statuses[1:])
+	}
+}
@@ -0,0 +1,341 @@
+// Package tracing records spans around export stages and sends them to an
+// OpenTelemetry collector with OTLP over HTTP, using the JSON encoding so no
+// OpenTelemetry SDK is needed.
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config says where spans are sent.
+type Config struct {
+	// Endpoint is the collector's OTLP/HTTP base URL, e.g.
+	// http://localhost:4318. Spans are posted to {Endpoint}/v1/traces
+	// unless the URL already ends in that path.
+	Endpoint string
+	// Headers are sent with every export, e.g. an API key.
+	Headers map[string]string
+	// ServiceName and ServiceVersion describe this process in the trace.
+	ServiceName    string
+	ServiceVersion string
+}
+
+// ConfigFromEnv fills unset fields of cfg from the standard OpenTelemetry
+// variables: OTEL_EXPORTER_OTLP_TRACES_ENDPOINT (used as-is),
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS
+// (key=value,key=value), and OTEL_SERVICE_NAME.
+func ConfigFromEnv(cfg Config) Config {
+	if cfg.Endpoint == "" {
+		if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+			cfg.Endpoint = endpoint
+		} else {
+			cfg.Endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		}
+	}
+	if len(cfg.Headers) == 0 {
+		cfg.Headers = parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	}
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		cfg.ServiceName = name
+	}
+	return cfg
+}
+
+// parseHeaders reads the key=value,key=value list of OTEL_EXPORTER_OTLP_HEADERS,
+// whose values may be URL-encoded.
+func parseHeaders(list string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(list, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = decoded
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// Tracer collects the spans of one run and exports them on Flush.
+type Tracer struct {
+	endpoint string
+	cfg      Config
+	client   *http.Client
+	now      func() time.Time
+
+	// traceID and parentID continue a trace handed down in TRACEPARENT.
+	traceID  string
+	parentID string
+
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// New returns a Tracer for cfg, or nil when no endpoint is configured. A nil
+// Tracer records nothing.
+func New(cfg Config) *Tracer {
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "slack-export"
+	}
+	t := &Tracer{
+		endpoint: tracesURL(cfg.Endpoint),
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+	}
+	t.traceID, t.parentID = parseTraceparent(os.Getenv("TRACEPARENT"))
+	return t
+}
+
+// tracesURL appends the OTLP traces path to a base endpoint.
+func tracesURL(endpoint string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if strings.HasSuffix(endpoint, "/v1/traces") {
+		return endpoint
+	}
+	return endpoint + "/v1/traces"
+}
+
+// parseTraceparent reads a W3C traceparent (00-traceid-spanid-flags) so a
+// CI pipeline's trace can include this run. Malformed values are ignored.
+func parseTraceparent(value string) (traceID, spanID string) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", ""
+	}
+	if _, err := hex.DecodeString(parts[1] + parts[2]); err != nil {
+		return "", ""
+	}
+	return strings.ToLower(parts[1]), strings.ToLower(parts[2])
+}
+
+type tracerKey struct{}
+type spanKey struct{}
+
+// WithTracer returns ctx carrying t, so Start records spans under it.
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	if t == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// Attr is one span attribute.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr { return Attr{Key: key, Value: value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attr { return Attr{Key: key, Value: value} }
+
+// Span is one timed operation. All methods are safe on a nil Span, which
+// Start returns when ctx carries no Tracer.
+type Span struct {
+	tracer   *Tracer
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+	attrs    []Attr
+	err      error
+}
+
+// OTLP span kinds.
+const (
+	kindInternal = 1
+	kindClient   = 3
+)
+
+// Start begins a span named name, a child of the span in ctx if any, and
+// returns ctx carrying it. Call End when the operation finishes.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return start(ctx, name, kindInternal, attrs)
+}
+
+func start(ctx context.Context, name string, kind int, attrs []Attr) (context.Context, *Span) {
+	t, _ := ctx.Value(tracerKey{}).(*Tracer)
+	if t == nil {
+		return ctx, nil
+	}
+	span := &Span{tracer: t, spanID: newID(8), name: name, kind: kind, start: t.now(), attrs: attrs}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok {
+		span.traceID, span.parentID = parent.traceID, parent.spanID
+	} else if t.traceID != "" {
+		span.traceID, span.parentID = t.traceID, t.parentID
+	} else {
+		span.traceID = newID(16)
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func newID(size int) string {
+	b := make([]byte, size)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SetAttributes adds attributes, e.g. counts known only at the end.
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// End finishes the span, marking it failed when err is not nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.end = s.tracer.now()
+	s.err = err
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, s)
+	s.tracer.mu.Unlock()
+}
+
+// Flush sends the finished spans to the collector and forgets them.
+func (t *Tracer) Flush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(t.payload(spans))
+	if err != nil {
+		return fmt.Errorf("encoding spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating trace export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("exporting spans: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("exporting spans: HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// payload builds an OTLP ExportTraceServiceRequest in its JSON encoding.
+func (t *Tracer) payload(spans []*Span) map[string]any {
+	encoded := make([]map[string]any, 0, len(spans))
+	for _, s := range spans {
+		span := map[string]any{
+			"traceId":           s.traceID,
+			"spanId":            s.spanID,
+			"name":              s.name,
+			"kind":              s.kind,
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        encodeAttrs(s.attrs),
+		}
+		if s.parentID != "" {
+			span["parentSpanId"] = s.parentID
+		}
+		if s.err != nil {
+			span["status"] = map[string]any{"code": 2, "message": s.err.Error()}
+		}
+		encoded = append(encoded, span)
+	}
+	resource := []Attr{String("service.name", t.cfg.ServiceName)}
+	if t.cfg.ServiceVersion != "" {
+		resource = append(resource, String("service.version", t.cfg.ServiceVersion))
+	}
+	return map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{"attributes": encodeAttrs(resource)},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "slack-export"},
+				"spans": encoded,
+			}},
+		}},
+	}
+}
+
+func encodeAttrs(attrs []Attr) []any {
+	encoded := make([]any, 0, len(attrs))
+	for _, a := range attrs {
+		var value map[string]any
+		switch v := a.Value.(type) {
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		encoded = append(encoded, map[string]any{"key": a.Key, "value": value})
+	}
+	return encoded
+}
+
+// Middleware records a client span for each HTTP request made under a
+// traced context, so slow or failing Slack API calls show up under the
+// stage that made them. It has the shape of slack.Middleware.
+func Middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		_, span := start(req.Context(), "HTTP "+req.Method+" "+req.URL.Path, kindClient, []Attr{
+			String("http.request.method", req.Method),
+			String("server.address", req.URL.Host),
+			String("url.path", req.URL.Path),
+		})
+		resp, err := next.RoundTrip(req)
+		if err == nil {
+			span.SetAttributes(Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.End(fmt.Errorf("HTTP %d", resp.StatusCode))
+				return resp, nil
+			}
+		}
+		span.End(err)
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,171 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	} `json:"attributes"`
+	Status *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+type exportRequest struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []struct {
+				Key   string         `json:"key"`
+				Value map[string]any `json:"value"`
+			} `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []exportedSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+// collector records OTLP exports.
+func collector(t *testing.T) (*httptest.Server, *[]exportRequest, *http.Header) {
+	t.Helper()
+	var exports []exportRequest
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("export to %s with Content-Type %q", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		headers = r.Header
+		var req exportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding export: %v", err)
+		}
+		exports = append(exports, req)
+	}))
+	t.Cleanup(server.Close)
+	return server, &exports, &headers
+}
+
+func TestNew_DisabledWithoutEndpoint(t *testing.T) {
+	if tracer := New(Config{}); tracer != nil {
+		t.Fatalf("New() without endpoint = %v, want nil", tracer)
+	}
+	ctx, span := Start(WithTracer(context.Background(), nil), "stage")
+	span.SetAttributes(Int("n", 1))
+	span.End(errors.New("ignored"))
+	if span != nil || ctx.Value(spanKey{}) != nil {
+		t.Error("Start() without a tracer should record nothing")
+	}
+	var tracer *Tracer
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() on nil tracer = %v", err)
+	}
+}
+
+func TestTracer_ExportsSpanTree(t *testing.T) {
+	t.Setenv("TRACEPARENT", "")
+	server, exports, headers := collector(t)
+	slackAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer slackAPI.Close()
+
+	tracer := New(Config{Endpoint: server.URL, Headers: map[string]string{"X-Api-Key": "secret"}, ServiceVersion: "1.2.3"})
+	ctx, root := Start(WithTracer(context.Background(), tracer), "slack-export sync")
+	stageCtx, stage := Start(ctx, "discovery", String("slack_export.stage", "discovery"))
+	client := &http.Client{Transport: Middleware(http.DefaultTransport)}
+	req, _ := http.NewRequestWithContext(stageCtx, http.MethodPost, slackAPI.URL+"/api/users.list", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	stage.SetAttributes(Int("slack_export.channels", 3))
+	stage.End(errors.New("rate limited"))
+	root.End(nil)
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(*exports) != 1 {
+		t.Fatalf("got %d exports, want 1", len(*exports))
+	}
+	if got := headers.Get("X-Api-Key"); got != "secret" {
+		t.Errorf("X-Api-Key header = %q", got)
+	}
+	resource := (*exports)[0].ResourceSpans[0]
+	if attrs := resource.Resource.Attributes; len(attrs) != 2 || attrs[0].Value["stringValue"] != "slack-export" || attrs[1].Value["stringValue"] != "1.2.3" {
+		t.Errorf("resource attributes = %+v", attrs)
+	}
+	spans := map[string]exportedSpan{}
+	for _, s := range resource.ScopeSpans[0].Spans {
+		spans[s.Name] = s
+	}
+	rootSpan, stageSpan, httpSpan := spans["slack-export sync"], spans["discovery"], spans["HTTP POST /api/users.list"]
+	if len(rootSpan.TraceID) != 32 || len(rootSpan.SpanID) != 16 || rootSpan.ParentSpanID != "" || rootSpan.Status != nil {
+		t.Errorf("root span = %+v", rootSpan)
+	}
+	if stageSpan.TraceID != rootSpan.TraceID || stageSpan.ParentSpanID != rootSpan.SpanID {
+		t.Errorf("stage span %+v is not a child of root %+v", stageSpan, rootSpan)
+	}
+	if stageSpan.Status == nil || stageSpan.Status.Code != 2 || stageSpan.Status.Message != "rate limited" {
+		t.Errorf("stage span status = %+v, want error", stageSpan.Status)
+	}
+	if len(stageSpan.Attributes) != 2 || stageSpan.Attributes[1].Value["intValue"] != "3" {
+		t.Errorf("stage span attributes = %+v", stageSpan.Attributes)
+	}
+	if httpSpan.ParentSpanID != stageSpan.SpanID || httpSpan.Kind != kindClient || httpSpan.Status == nil {
+		t.Errorf("HTTP span = %+v, want failed client child of the stage", httpSpan)
+	}
+
+	if err := tracer.Flush(context.Background()); err != nil || len(*exports) != 1 {
+		t.Errorf("second Flush() = %v with %d exports; want nothing sent", err, len(*exports))
+	}
+}
+
+func TestTracer_ContinuesTraceparent(t *testing.T) {
+	t.Setenv("TRACEPARENT", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01")
+	server, exports, _ := collector(t)
+
+	tracer := New(Config{Endpoint: server.URL + "/v1/traces"})
+	_, span := Start(WithTracer(context.Background(), tracer), "slack-export export")
+	span.End(nil)
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := (*exports)[0].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if got.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || got.ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("span = %+v, want it under the TRACEPARENT span", got)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "api-key=a%20b, tenant=ops,broken")
+	t.Setenv("OTEL_SERVICE_NAME", "nightly-export")
+
+	cfg := ConfigFromEnv(Config{})
+	if cfg.Endpoint != "http://collector:4318" || cfg.ServiceName != "nightly-export" {
+		t.Errorf("ConfigFromEnv() = %+v", cfg)
+	}
+	if len(cfg.Headers) != 2 || cfg.Headers["api-key"] != "a b" || cfg.Headers["tenant"] != "ops" {
+		t.Errorf("headers = %v", cfg.Headers)
+	}
+	if cfg := ConfigFromEnv(Config{Endpoint: "http://configured:4318"}); cfg.Endpoint != "http://configured:4318" {
+		t.Errorf("configured endpoint replaced by environment: %q", cfg.Endpoint)
+	}
+}
@@ -0,0 +1,24 @@
+package slackexport
+
+import "github.com/chrisedwards/slack-export/internal/export"
+
+// progressEvents forwards the internal exporter events to a ProgressCallback.
+type progressEvents struct {
+	e *Exporter
+}
+
+func (p progressEvents) OnStage(message string) {
+	p.e.emit(Event{Kind: Info, Message: message})
+}
+
+func (p progressEvents) OnChannelStart(ch export.ChannelProgress) {
+	p.e.emit(Event{Kind: ChannelStarted, Channel: ch.Name, ChannelID: ch.ID})
+}
+
+func (p progressEvents) OnChannelDone(ch export.ChannelProgress) {
+	p.e.emit(Event{Kind: ChannelDone, Channel: ch.Name, ChannelID: ch.ID, Files: ch.Files})
+}
+
+func (p progressEvents) OnError(err error) {
+	p.e.emit(Event{Kind: Warning, Err: err})
+}
@@ -0,0 +1,222 @@
+// Package slackexport lets Go programs embed slack-export without invoking
+// the CLI. It wraps the internal packages behind a small API that follows
+// semantic versioning: exported names here only change in a major release.
+//
+// Credentials come from slackdump's credential cache, exactly as for the CLI,
+// so run "slackdump workspace wiz" once before using the library.
+//
+//	exp, err := slackexport.New(slackexport.Options{
+//		OutputDir: "/data/slack",
+//		Channels:  slackexport.ChannelFilter{Include: []string{"eng-*"}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	return exp.Sync(ctx)
+package slackexport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chrisedwards/slack-export/internal/config"
+	"github.com/chrisedwards/slack-export/internal/export"
+	"github.com/chrisedwards/slack-export/internal/slack"
+)
+
+// Failure kinds returned by Exporter methods. Match them with errors.Is.
+var (
+	ErrSlackdumpFailed = export.ErrSlackdumpFailed
+	ErrChannelSkipped  = export.ErrChannelSkipped
+	ErrRateLimited     = export.ErrRateLimited
+	ErrAuthExpired     = export.ErrAuthExpired
+)
+
+// ChannelFilter selects channels by name or ID using glob patterns, with the
+// same semantics as the include/exclude config keys. An empty Include
+// selects every channel not excluded.
+type ChannelFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// EventKind identifies what a progress Event reports.
+type EventKind int
+
+const (
+	// StageStarted reports that an operation or one of its stages began.
+	StageStarted EventKind = iota + 1
+	// StageDone reports that an operation finished successfully.
+	StageDone
+	// Failed reports that an operation failed; Event.Err holds the error.
+	Failed
+	// Info carries a progress line in Event.Message, such as
+	// "Archive already current".
+	Info
+	// ChannelStarted reports that a channel is about to be rendered.
+	ChannelStarted
+	// ChannelDone reports that a channel finished rendering; Event.Files
+	// holds the number of changed files written.
+	ChannelDone
+	// Warning reports a non-fatal problem in Event.Err; the operation
+	// continues.
+	Warning
+)
+
+// Event is one progress notification. Stage names the running operation;
+// the remaining fields are set only for the kinds that document them.
+type Event struct {
+	Kind      EventKind
+	Stage     string
+	Message   string
+	Channel   string
+	ChannelID string
+	Files     int
+	Err       error
+}
+
+// ProgressCallback receives progress events. It is called synchronously, so
+// it must return quickly.
+type ProgressCallback func(Event)
+
+// Middleware wraps the transport under every Slack API request, for
+// logging, metrics, caching, or request signing. It sees each attempt,
+// including rate-limit retries, with the credentials already applied.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper, for writing
+// Middleware inline.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Options configures an Exporter. Zero values keep the setting from
+// ConfigFile, or the built-in default when ConfigFile is empty.
+type Options struct {
+	// ConfigFile is an optional slack-export YAML config to start from.
+	ConfigFile string
+	// OutputDir is where rendered markdown is written.
+	OutputDir string
+	// ArchiveDir holds the per-workspace slackdump archives.
+	ArchiveDir string
+	// Timezone is the IANA zone used for day boundaries.
+	Timezone string
+	// Workspace selects a slackdump workspace; empty uses the current one.
+	Workspace string
+	// Channels restricts which channels are exported.
+	Channels ChannelFilter
+	// Progress, when set, receives progress events instead of the console
+	// output the CLI prints. When nil, progress is printed to stdout and
+	// warnings to stderr.
+	Progress ProgressCallback
+	// Middleware wraps Slack API requests, the first listed outermost.
+	// Slackdump's own requests do not pass through it.
+	Middleware []Middleware
+}
+
+// Exporter archives and renders Slack conversations.
+type Exporter struct {
+	inner    *export.Exporter
+	progress ProgressCallback
+	stage    string
+}
+
+// New loads credentials, verifies them with Slack, and returns an Exporter.
+func New(opts Options) (*Exporter, error) {
+	cfg, err := buildConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	middleware := make([]slack.Middleware, len(opts.Middleware))
+	for i, mw := range opts.Middleware {
+		middleware[i] = slack.Middleware(mw)
+	}
+	inner, err := export.NewExporter(context.Background(), cfg, middleware...)
+	if err != nil {
+		return nil, err
+	}
+	e := &Exporter{inner: inner, progress: opts.Progress}
+	if opts.Progress != nil {
+		inner.SetEvents(progressEvents{e})
+	}
+	return e, nil
+}
+
+func buildConfig(opts Options) (*config.Config, error) {
+	cfg := config.Default()
+	if opts.ConfigFile != "" {
+		loaded, err := config.Load(opts.ConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading config: %w", err)
+		}
+		cfg = loaded
+	}
+	setIfNotEmpty(&cfg.OutputDir, opts.OutputDir)
+	setIfNotEmpty(&cfg.ArchiveDir, opts.ArchiveDir)
+	setIfNotEmpty(&cfg.Timezone, opts.Timezone)
+	setIfNotEmpty(&cfg.Workspace, opts.Workspace)
+	if len(opts.Channels.Include) > 0 || len(opts.Channels.Exclude) > 0 {
+		cfg.Include = opts.Channels.Include
+		cfg.Exclude = opts.Channels.Exclude
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func setIfNotEmpty(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
+
+// Sync refreshes the archive for recent activity and renders changed days.
+func (e *Exporter) Sync(ctx context.Context) error {
+	return e.run("sync", func() error {
+		return e.inner.Sync(ctx, time.Now(), export.SyncOptions{})
+	})
+}
+
+// ExportDate renders one date (YYYY-MM-DD) from the archive.
+func (e *Exporter) ExportDate(ctx context.Context, date string) error {
+	return e.ExportRange(ctx, date, date)
+}
+
+// ExportRange renders an inclusive date range (YYYY-MM-DD) from the archive.
+func (e *Exporter) ExportRange(ctx context.Context, from, to string) error {
+	return e.run("export", func() error {
+		return e.inner.ExportRange(ctx, from, to, export.ExportOptions{})
+	})
+}
+
+// ExportDM refreshes and renders the direct messages with one user, found by
+// username, display name, or real name.
+func (e *Exporter) ExportDM(ctx context.Context, username, from, to string) error {
+	return e.run("dm", func() error {
+		return e.inner.ExportDM(ctx, username, from, to)
+	})
+}
+
+func (e *Exporter) run(stage string, fn func() error) error {
+	e.stage = stage
+	e.emit(Event{Kind: StageStarted})
+	if err := fn(); err != nil {
+		e.emit(Event{Kind: Failed, Err: err})
+		return err
+	}
+	e.emit(Event{Kind: StageDone})
+	return nil
+}
+
+func (e *Exporter) emit(event Event) {
+	event.Stage = e.stage
+	if e.progress != nil {
+		e.progress(event)
+	}
+}
@@ -0,0 +1,129 @@
+package slackexport
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrisedwards/slack-export/internal/export"
+)
+
+func TestBuildConfig_OptionsOverrideDefaults(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "out")
+	cfg, err := buildConfig(Options{
+		OutputDir: outputDir,
+		Timezone:  "UTC",
+		Workspace: "acme",
+		Channels:  ChannelFilter{Include: []string{"eng-*"}, Exclude: []string{"eng-random"}},
+	})
+	if err != nil {
+		t.Fatalf("buildConfig() error = %v", err)
+	}
+	if cfg.OutputDir != outputDir || cfg.Timezone != "UTC" || cfg.Workspace != "acme" {
+		t.Errorf("options not applied: %+v", cfg)
+	}
+	if len(cfg.Include) != 1 || len(cfg.Exclude) != 1 {
+		t.Errorf("channel filter not applied: include=%v exclude=%v", cfg.Include, cfg.Exclude)
+	}
+	if cfg.Lookback != "7d" {
+		t.Errorf("Lookback = %q, want built-in default", cfg.Lookback)
+	}
+}
+
+func TestBuildConfig_StartsFromConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "slack-export.yaml")
+	content := "output_dir: " + filepath.Join(dir, "from-file") + "\ntimezone: Europe/London\ninclude: [general]\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := buildConfig(Options{ConfigFile: path, Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("buildConfig() error = %v", err)
+	}
+	if cfg.OutputDir != filepath.Join(dir, "from-file") || cfg.Timezone != "UTC" {
+		t.Errorf("config = %+v, want file output_dir with UTC override", cfg)
+	}
+	if len(cfg.Include) != 1 || cfg.Include[0] != "general" {
+		t.Errorf("Include = %v, want file patterns kept", cfg.Include)
+	}
+}
+
+func TestBuildConfig_Invalid(t *testing.T) {
+	_, err := buildConfig(Options{OutputDir: t.TempDir(), Timezone: "Mars/Olympus"})
+	if err == nil {
+		t.Error("buildConfig() should reject an unknown timezone")
+	}
+}
+
+func TestExporterRun_EmitsEvents(t *testing.T) {
+	var events []Event
+	exp := &Exporter{progress: func(e Event) { events = append(events, e) }}
+	boom := errors.New("boom")
+
+	if err := exp.run("sync", func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := exp.run("export", func() error { return boom }); !errors.Is(err, boom) {
+		t.Fatalf("run() error = %v, want boom", err)
+	}
+
+	want := []EventKind{StageStarted, StageDone, StageStarted, Failed}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i, kind := range want {
+		if events[i].Kind != kind {
+			t.Errorf("event %d kind = %v, want %v", i, events[i].Kind, kind)
+		}
+	}
+	if events[3].Err != boom || events[3].Stage != "export" {
+		t.Errorf("failure event = %+v", events[3])
+	}
+}
+
+func TestProgressEvents_ForwardsExporterEvents(t *testing.T) {
+	var events []Event
+	exp := &Exporter{progress: func(e Event) { events = append(events, e) }, stage: "sync"}
+	adapter := progressEvents{exp}
+
+	adapter.OnStage("Archive already current")
+	adapter.OnChannelDone(export.ChannelProgress{ID: "C1", Name: "general", Files: 2})
+	adapter.OnError(errors.New("disk full"))
+
+	if len(events) != 3 {
+		t.Fatalf("events = %+v", events)
+	}
+	if events[0].Kind != Info || events[0].Message != "Archive already current" || events[0].Stage != "sync" {
+		t.Errorf("info event = %+v", events[0])
+	}
+	if events[1].Kind != ChannelDone || events[1].Channel != "general" || events[1].Files != 2 {
+		t.Errorf("channel event = %+v", events[1])
+	}
+	if events[2].Kind != Warning || events[2].Err == nil {
+		t.Errorf("warning event = %+v", events[2])
+	}
+}
+
+func TestRoundTripperFunc(t *testing.T) {
+	want := &http.Response{StatusCode: http.StatusTeapot}
+	var mw Middleware = func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Test", "1")
+			return next.RoundTrip(req)
+		})
+	}
+	transport := mw(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("X-Test") != "1" {
+			t.Error("middleware did not run before the next transport")
+		}
+		return want, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "https://slack.com/api/auth.test", nil)
+	if resp, err := transport.RoundTrip(req); err != nil || resp != want {
+		t.Errorf("RoundTrip() = %v, %v", resp, err)
+	}
+}
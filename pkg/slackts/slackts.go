@@ -0,0 +1,103 @@
+// Package slackts parses, formats, and orders Slack message timestamps such
+// as "1737676800.123456": Unix seconds, a dot, and a fraction that also
+// serves as the message's unique sort key within its channel.
+//
+// Converting a timestamp to time.Time and back is lossy for fractions
+// beyond microseconds, so ordering and equality should use CompareTS on the
+// strings themselves. Like the rest of slack-export's public API, exported
+// names here only change in a major release.
+package slackts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSlackTS parses a Slack timestamp into a time.Time, keeping
+// microseconds; longer fractions are truncated. An empty string returns the
+// zero time.
+func ParseSlackTS(ts string) (time.Time, error) {
+	if ts == "" {
+		return time.Time{}, nil
+	}
+	secs, frac, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing seconds: %w", err)
+	}
+	var micro int64
+	if frac != "" {
+		frac = padFraction(frac, 6)[:6]
+		if micro, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("parsing microseconds: %w", err)
+		}
+	}
+	return time.Unix(sec, micro*1000), nil
+}
+
+// FormatSlackTS formats t as a Slack timestamp with six fraction digits.
+// Time below a microsecond is dropped.
+func FormatSlackTS(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+// CompareTS returns -1, 0, or +1 as a sorts before, equal to, or after b.
+// The fractions are compared digit by digit at any length, so timestamps in
+// the same second keep their order however finely Slack distinguishes
+// them, and "1737676800.1" equals "1737676800.100000". Malformed timestamps
+// sort before valid ones and by their text among themselves.
+func CompareTS(a, b string) int {
+	aSec, aFrac, aOK := splitTS(a)
+	bSec, bFrac, bOK := splitTS(b)
+	switch {
+	case !aOK || !bOK:
+		if aOK != bOK {
+			if aOK {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a, b)
+	case aSec != bSec:
+		if aSec < bSec {
+			return -1
+		}
+		return 1
+	}
+	width := max(len(aFrac), len(bFrac))
+	return strings.Compare(padFraction(aFrac, width), padFraction(bFrac, width))
+}
+
+// Less reports whether a sorts before b; see CompareTS.
+func Less(a, b string) bool {
+	return CompareTS(a, b) < 0
+}
+
+// Valid reports whether ts is a well-formed Slack timestamp.
+func Valid(ts string) bool {
+	_, _, ok := splitTS(ts)
+	return ok
+}
+
+func splitTS(ts string) (int64, string, bool) {
+	secs, frac, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil || strings.HasPrefix(secs, "+") {
+		return 0, "", false
+	}
+	for _, r := range frac {
+		if r < '0' || r > '9' {
+			return 0, "", false
+		}
+	}
+	return sec, frac, true
+}
+
+func padFraction(frac string, width int) string {
+	if len(frac) >= width {
+		return frac
+	}
+	return frac + strings.Repeat("0", width-len(frac))
+}
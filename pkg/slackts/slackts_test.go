@@ -0,0 +1,56 @@
+package slackts
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParseAndFormatSlackTS(t *testing.T) {
+	got, err := ParseSlackTS("1737676800.123")
+	if err != nil || !got.Equal(time.Unix(1737676800, 123000000)) {
+		t.Fatalf("ParseSlackTS() = %v, %v", got, err)
+	}
+	if ts := FormatSlackTS(got); ts != "1737676800.123000" {
+		t.Errorf("FormatSlackTS() = %q", ts)
+	}
+	if zero, err := ParseSlackTS(""); err != nil || !zero.IsZero() {
+		t.Errorf("ParseSlackTS(\"\") = %v, %v", zero, err)
+	}
+	if _, err := ParseSlackTS("1737676800.abc"); err == nil {
+		t.Error("ParseSlackTS accepted a malformed fraction")
+	}
+}
+
+func TestCompareTS(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1737676800.000100", "1737676800.000200", -1},
+		{"1737676800.1", "1737676800.100000", 0},
+		{"1737676800.1234567", "1737676800.1234566", 1},
+		{"999999999.900000", "1000000000.000000", -1},
+		{"1737676800", "1737676800.000000", 0},
+		{"garbage", "1737676800.000000", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareTS(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareTS(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareTS(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareTS(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestLess_SortsSameSecond(t *testing.T) {
+	ts := []string{"1737676800.0000011", "1737676800.000001", "1737676799.999999"}
+	sort.Slice(ts, func(i, j int) bool { return Less(ts[i], ts[j]) })
+	want := []string{"1737676799.999999", "1737676800.000001", "1737676800.0000011"}
+	for i := range want {
+		if ts[i] != want[i] {
+			t.Fatalf("sorted = %v, want %v", ts, want)
+		}
+	}
+}